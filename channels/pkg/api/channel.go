@@ -18,6 +18,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/values"
@@ -57,6 +58,10 @@ type AddonSpec struct {
 	// ManifestHash is the sha256 hash of our manifest
 	ManifestHash string `json:"manifestHash,omitempty"`
 
+	// ChartDigest is the digest of the Helm chart the manifest was rendered from, for addons rendered from charts.
+	// It is included in ManifestHash, so the addon is reapplied when the chart changes.
+	ChartDigest string `json:"chartDigest,omitempty"`
+
 	// KubernetesVersion is a semver version range on which this version of the addon can be applied
 	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
 
@@ -76,6 +81,14 @@ type AddonSpec struct {
 	// NeedsPKI determines if channels should provision a CA and a cert-manager issuer for the addon.
 	NeedsPKI bool `json:"needsPKI,omitempty"`
 
+	// DependsOn lists the names of addons that must be applied and ready before this addon is applied.
+	// An addon is ready once its objects are healthy: its CRDs are established, and the services of its webhooks have ready endpoints.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// RequiredCRDs lists the names of CustomResourceDefinitions, such as certificates.cert-manager.io,
+	// that must be established before this addon is applied.
+	RequiredCRDs []string `json:"requiredCRDs,omitempty"`
+
 	Version string `json:"version,omitempty"`
 
 	// PruneSpec specifies how old objects should be removed (pruned).
@@ -83,6 +96,8 @@ type AddonSpec struct {
 }
 
 // PruneSpec specifies how old objects should be removed (pruned).
+// Addons are now pruned as ApplySets; the PruneSpec selects the objects applied by older versions of channels,
+// which are adopted into the ApplySet the first time it is applied.
 type PruneSpec struct {
 	// Kinds specifies the objects to be pruned, by Kind.
 	Kinds []PruneKindSpec `json:"kinds,omitempty"`
@@ -114,7 +129,89 @@ func (a *Addons) Verify() error {
 		if addon.KubernetesVersion != "" {
 			return fmt.Errorf("bootstrap addon %q has a KubernetesVersion", values.StringValue(addon.Name))
 		}
+		for _, crd := range addon.RequiredCRDs {
+			if !strings.Contains(crd, ".") {
+				return fmt.Errorf("bootstrap addon %q requires CRD %q, which is not of the form <plural>.<group>", values.StringValue(addon.Name), crd)
+			}
+		}
+	}
+
+	var names []string
+	dependsOn := make(map[string][]string)
+	for _, addon := range a.Spec.Addons {
+		if addon == nil {
+			continue
+		}
+		name := values.StringValue(addon.Name)
+		if _, found := dependsOn[name]; !found {
+			names = append(names, name)
+		}
+		dependsOn[name] = append(dependsOn[name], addon.DependsOn...)
+	}
+	if _, err := DependencyOrder(names, dependsOn); err != nil {
+		return err
 	}
 
 	return nil
 }
+
+// DependencyOrder sorts the names of addons so that every addon comes after the addons it depends on.
+// An addon that others depend on is moved to just before the first addon that needs it; otherwise the order is kept.
+// It returns an error if an addon depends on an addon that is not in names, or if the dependencies form a cycle.
+func DependencyOrder(names []string, dependsOn map[string][]string) ([]string, error) {
+	known := make(map[string]bool)
+	for _, name := range names {
+		known[name] = true
+	}
+	for _, name := range names {
+		for _, dependency := range dependsOn[name] {
+			if !known[dependency] {
+				return nil, fmt.Errorf("addon %q depends on addon %q, which is not in the channel", name, dependency)
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+
+	var ordered []string
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			// The cycle is the part of the path starting from the first visit of name.
+			for i := range path {
+				if path[i] == name {
+					cycle := append(append([]string{}, path[i:]...), name)
+					return fmt.Errorf("addon dependencies form a cycle: %s", strings.Join(cycle, " -> "))
+				}
+			}
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dependency := range dependsOn[name] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+
+		ordered = append(ordered, name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
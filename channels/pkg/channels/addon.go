@@ -20,8 +20,10 @@ import (
 	"context"
 	"crypto/x509/pkix"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"go.uber.org/multierr"
 	"k8s.io/kops/pkg/pki"
@@ -42,7 +44,25 @@ import (
 )
 
 type Applier interface {
-	Apply(ctx context.Context, data []byte) error
+	// Apply applies the manifest as the ApplySet of the channel, pruning objects that were applied previously but are no longer in the manifest.
+	// The objects selected by prune are adopted into the ApplySet the first time it is applied,
+	// so that objects applied by older versions of channels are also pruned.
+	Apply(ctx context.Context, channel *Channel, data []byte, prune *api.PruneSpec) error
+}
+
+// HealthChecker is implemented by appliers that can check the health of the objects they applied.
+type HealthChecker interface {
+	// WaitForHealthy waits until all the objects in the manifest are healthy, returning an error on timeout.
+	WaitForHealthy(ctx context.Context, data []byte, timeout time.Duration) error
+}
+
+// UpdateOptions holds the options for applying an update to an addon.
+type UpdateOptions struct {
+	// HealthTimeout is how long to wait for the applied objects to become healthy.
+	// If zero, health is not checked and the update is recorded as soon as it is applied.
+	HealthTimeout time.Duration
+	// Rollback applies the previously applied manifest again if the objects do not become healthy.
+	Rollback bool
 }
 
 // Addon is a wrapper around a single version of an addon
@@ -157,7 +177,7 @@ func (a *Addon) GetManifestFullUrl() (*url.URL, error) {
 	return manifestURL, nil
 }
 
-func (a *Addon) EnsureUpdated(ctx context.Context, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient certmanager.Interface, pruner *Pruner, applier Applier, existingVersion *ChannelVersion) (*AddonUpdate, error) {
+func (a *Addon) EnsureUpdated(ctx context.Context, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient certmanager.Interface, applier Applier, existingVersion *ChannelVersion, options UpdateOptions) (*AddonUpdate, error) {
 	required, err := a.GetRequiredUpdates(ctx, k8sClient, cmClient, existingVersion)
 	if err != nil {
 		return nil, err
@@ -169,7 +189,7 @@ func (a *Addon) EnsureUpdated(ctx context.Context, vfsContext *vfs.VFSContext, k
 	var merr error
 
 	if required.NewVersion != nil {
-		err := a.updateAddon(ctx, k8sClient, vfsContext, pruner, applier, required)
+		err := a.updateAddon(ctx, k8sClient, vfsContext, applier, required, options)
 		if err != nil {
 			merr = multierr.Append(merr, err)
 		}
@@ -183,7 +203,7 @@ func (a *Addon) EnsureUpdated(ctx context.Context, vfsContext *vfs.VFSContext, k
 	return required, merr
 }
 
-func (a *Addon) updateAddon(ctx context.Context, k8sClient kubernetes.Interface, vfsContext *vfs.VFSContext, pruner *Pruner, applier Applier, required *AddonUpdate) error {
+func (a *Addon) updateAddon(ctx context.Context, k8sClient kubernetes.Interface, vfsContext *vfs.VFSContext, applier Applier, required *AddonUpdate, options UpdateOptions) error {
 	manifestURL, err := a.GetManifestFullUrl()
 	if err != nil {
 		return err
@@ -197,43 +217,81 @@ func (a *Addon) updateAddon(ctx context.Context, k8sClient kubernetes.Interface,
 		return fmt.Errorf("error reading manifest: %w", err)
 	}
 
-	var merr error
-	var applyError, pruneError error
+	channel := a.buildChannel()
+	version := a.ChannelVersion()
 
-	if applyError = applier.Apply(ctx, data); applyError != nil {
-		merr = multierr.Append(merr, fmt.Errorf("error applying update: %w", applyError))
+	// Without a health timeout, the update is only recorded once the objects are healthy when they are applied,
+	// so an update that has not converged yet is applied again the next time the channel is applied.
+	if err := applier.Apply(ctx, channel, data, a.Spec.Prune); err != nil && !(options.HealthTimeout != 0 && goerrors.Is(err, ErrNotHealthy)) {
+		return fmt.Errorf("error updating addon from %q: %w", manifestURL, err)
 	}
 
-	if pruneError = pruner.Prune(ctx, data, a.Spec.Prune); pruneError != nil {
-		merr = multierr.Append(merr, fmt.Errorf("error pruning manifest: %w", pruneError))
-	}
+	if options.HealthTimeout != 0 {
+		healthChecker, ok := applier.(HealthChecker)
+		if !ok {
+			return fmt.Errorf("applier %T does not support health checks", applier)
+		}
 
-	if applyError != nil && pruneError == nil {
-		// If we failed to apply, but not prune, we should try to apply again
-		if err := applier.Apply(ctx, data); err != nil {
-			merr = multierr.Append(merr, fmt.Errorf("error applying update after prune: %w", err))
-		} else {
-			// If we succeeded to apply after prune, clear the errors
-			merr = nil
+		klog.Infof("Waiting up to %v for addon %q to become healthy", options.HealthTimeout, a.Name)
+		if err := healthChecker.WaitForHealthy(ctx, data, options.HealthTimeout); err != nil {
+			return a.handleUnhealthy(ctx, k8sClient, applier, required, options, err)
 		}
+		version.Health = AddonHealthHealthy
 	}
 
-	if merr != nil {
-		return fmt.Errorf("error updating addon from %q: %w", manifestURL, merr)
+	if options.Rollback {
+		if err := channel.SetAppliedManifest(ctx, k8sClient, a.Spec.ManifestHash, data); err != nil {
+			return fmt.Errorf("error recording applied manifest: %w", err)
+		}
 	}
 
 	if err := a.AddNeedsUpdateLabel(ctx, k8sClient, required); err != nil {
 		return fmt.Errorf("error adding needs-update label: %v", err)
 	}
 
-	channel := a.buildChannel()
-	err = channel.SetInstalledVersion(ctx, k8sClient, a.ChannelVersion())
+	err = channel.SetInstalledVersion(ctx, k8sClient, version)
 	if err != nil {
 		return fmt.Errorf("error applying annotation to record addon installation: %v", err)
 	}
 	return nil
 }
 
+// handleUnhealthy records that an update did not become healthy, rolling back to the previously applied manifest if requested.
+func (a *Addon) handleUnhealthy(ctx context.Context, k8sClient kubernetes.Interface, applier Applier, required *AddonUpdate, options UpdateOptions, healthErr error) error {
+	channel := a.buildChannel()
+
+	if options.Rollback && required.ExistingVersion != nil {
+		previous, previousHash, err := channel.GetAppliedManifest(ctx, k8sClient)
+		if err != nil {
+			return fmt.Errorf("addon %q did not become healthy (%v), and reading the previous manifest failed: %w", a.Name, healthErr, err)
+		}
+
+		if previous != nil && previousHash == required.ExistingVersion.ManifestHash {
+			klog.Warningf("addon %q did not become healthy, rolling back to ManifestHash %q: %v", a.Name, previousHash, healthErr)
+			if err := applier.Apply(ctx, channel, previous, a.Spec.Prune); err != nil && !goerrors.Is(err, ErrNotHealthy) {
+				return fmt.Errorf("addon %q did not become healthy (%v), and rolling back failed: %w", a.Name, healthErr, err)
+			}
+
+			rolledBack := *required.ExistingVersion
+			rolledBack.Health = AddonHealthRolledBack
+			rolledBack.FailedManifestHash = a.Spec.ManifestHash
+			if err := channel.SetInstalledVersion(ctx, k8sClient, &rolledBack); err != nil {
+				return fmt.Errorf("error applying annotation to record addon rollback: %v", err)
+			}
+			return fmt.Errorf("addon %q did not become healthy and was rolled back: %w", a.Name, healthErr)
+		}
+
+		klog.Warningf("addon %q did not become healthy, and the previously applied manifest was not recorded; cannot roll back", a.Name)
+	}
+
+	failed := a.ChannelVersion()
+	failed.Health = AddonHealthFailed
+	if err := channel.SetInstalledVersion(ctx, k8sClient, failed); err != nil {
+		return fmt.Errorf("error applying annotation to record addon failure: %v", err)
+	}
+	return fmt.Errorf("addon %q did not become healthy: %w", a.Name, healthErr)
+}
+
 func (a *Addon) AddNeedsUpdateLabel(ctx context.Context, k8sClient kubernetes.Interface, required *AddonUpdate) error {
 	if required.ExistingVersion != nil {
 		if a.Spec.NeedsRollingUpdate != "" {
@@ -18,7 +18,11 @@ package channels
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/blang/semver/v4"
 	fakecertmanager "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
@@ -28,6 +32,7 @@ import (
 	fakekubernetes "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/kops/channels/pkg/api"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 func Test_Filtering(t *testing.T) {
@@ -124,6 +129,27 @@ func Test_Replacement(t *testing.T) {
 			New:      &ChannelVersion{Id: "a", ManifestHash: hash1, SystemGeneration: 1},
 			Replaces: false,
 		},
+		// Test health outcomes
+		{
+			Old:      &ChannelVersion{Id: "a", ManifestHash: hash1, SystemGeneration: 1, Health: AddonHealthHealthy},
+			New:      &ChannelVersion{Id: "a", ManifestHash: hash1, SystemGeneration: 1},
+			Replaces: false,
+		},
+		{
+			Old:      &ChannelVersion{Id: "a", ManifestHash: hash1, SystemGeneration: 1, Health: AddonHealthFailed},
+			New:      &ChannelVersion{Id: "a", ManifestHash: hash1, SystemGeneration: 1},
+			Replaces: true,
+		},
+		{
+			Old:      &ChannelVersion{Id: "a", ManifestHash: hash1, SystemGeneration: 1, Health: AddonHealthRolledBack, FailedManifestHash: hash2},
+			New:      &ChannelVersion{Id: "a", ManifestHash: hash2, SystemGeneration: 1},
+			Replaces: false,
+		},
+		{
+			Old:      &ChannelVersion{Id: "a", ManifestHash: hash1, SystemGeneration: 1, Health: AddonHealthRolledBack, FailedManifestHash: hash2},
+			New:      &ChannelVersion{Id: "a", ManifestHash: "", SystemGeneration: 1},
+			Replaces: true,
+		},
 	}
 	for _, g := range grid {
 		actual := g.New.replaces(t.Name(), g.Old)
@@ -363,3 +389,216 @@ func Test_InstallPKI(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+// fakeApplier records the manifests it applies, and reports them as healthy if healthy is set.
+// Like ClientApplier, Apply returns ErrNotHealthy for objects that are not healthy.
+type fakeApplier struct {
+	applied []string
+	healthy bool
+}
+
+func (f *fakeApplier) Apply(ctx context.Context, channel *Channel, data []byte, prune *api.PruneSpec) error {
+	f.applied = append(f.applied, string(data))
+	if !f.healthy {
+		return ErrNotHealthy
+	}
+	return nil
+}
+
+func (f *fakeApplier) WaitForHealthy(ctx context.Context, data []byte, timeout time.Duration) error {
+	if !f.healthy {
+		return fmt.Errorf("deployment not ready")
+	}
+	return nil
+}
+
+func Test_EnsureUpdatedHealth(t *testing.T) {
+	ctx := context.Background()
+	vfs.Context.ResetMemfsContext(true)
+
+	channelLocation, err := url.Parse("memfs://tests/addons/bootstrap-channel.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, data := range map[string]string{"v1.yaml": "manifest-v1", "v2.yaml": "manifest-v2"} {
+		p, err := vfs.Context.BuildVfsPath("memfs://tests/addons/" + name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := p.WriteFile(ctx, strings.NewReader(data), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	newAddon := func(manifest string, hash string) *Addon {
+		return &Addon{
+			Name:            "test",
+			ChannelName:     "bootstrap",
+			ChannelLocation: *channelLocation,
+			Spec: &api.AddonSpec{
+				Name:         fi.PtrTo("test"),
+				Manifest:     fi.PtrTo(manifest),
+				ManifestHash: hash,
+			},
+		}
+	}
+
+	kubeSystem := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kube-system",
+		},
+	}
+	fakek8s := fakekubernetes.NewSimpleClientset(kubeSystem)
+	fakecm := fakecertmanager.NewSimpleClientset()
+	options := UpdateOptions{HealthTimeout: time.Minute, Rollback: true}
+
+	installedVersion := func() *ChannelVersion {
+		version, err := newAddon("", "").buildChannel().GetInstalledVersion(ctx, fakek8s)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return version
+	}
+
+	// A healthy install is recorded as healthy, and its manifest kept for rollback.
+	applier := &fakeApplier{healthy: true}
+	if _, err := newAddon("v1.yaml", "hash1").EnsureUpdated(ctx, vfs.Context, fakek8s, fakecm, applier, nil, options); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v1 := installedVersion()
+	if v1.ManifestHash != "hash1" || v1.Health != AddonHealthHealthy {
+		t.Fatalf("unexpected installed version after healthy install: %v", v1)
+	}
+
+	// An update that does not become healthy is rolled back.
+	applier = &fakeApplier{healthy: false}
+	if _, err := newAddon("v2.yaml", "hash2").EnsureUpdated(ctx, vfs.Context, fakek8s, fakecm, applier, v1, options); err == nil {
+		t.Fatalf("expected error from unhealthy update")
+	}
+	if len(applier.applied) != 2 || applier.applied[0] != "manifest-v2" || applier.applied[1] != "manifest-v1" {
+		t.Errorf("expected manifest-v2 to be applied and then rolled back to manifest-v1, got %v", applier.applied)
+	}
+	rolledBack := installedVersion()
+	if rolledBack.ManifestHash != "hash1" || rolledBack.Health != AddonHealthRolledBack || rolledBack.FailedManifestHash != "hash2" {
+		t.Fatalf("unexpected installed version after rollback: %v", rolledBack)
+	}
+
+	// The rolled back version is not retried.
+	required, err := newAddon("v2.yaml", "hash2").GetRequiredUpdates(ctx, fakek8s, fakecm, rolledBack)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if required != nil {
+		t.Errorf("expected rolled back version not to be reapplied")
+	}
+
+	// Without rollback, an update that does not become healthy is recorded as failed.
+	applier = &fakeApplier{healthy: false}
+	if _, err := newAddon("v2.yaml", "hash2").EnsureUpdated(ctx, vfs.Context, fakek8s, fakecm, applier, v1, UpdateOptions{HealthTimeout: time.Minute}); err == nil {
+		t.Fatalf("expected error from unhealthy update")
+	}
+	failed := installedVersion()
+	if failed.ManifestHash != "hash2" || failed.Health != AddonHealthFailed {
+		t.Fatalf("unexpected installed version after failed update: %v", failed)
+	}
+
+	// Without a health timeout, an update that is not healthy when it is applied is not recorded.
+	applier = &fakeApplier{healthy: false}
+	if _, err := newAddon("v1.yaml", "hash1").EnsureUpdated(ctx, vfs.Context, fakek8s, fakecm, applier, failed, UpdateOptions{}); err == nil {
+		t.Fatalf("expected error from unhealthy update")
+	}
+	if notRecorded := installedVersion(); notRecorded.ManifestHash != "hash2" {
+		t.Fatalf("unexpected installed version after unhealthy update: %v", notRecorded)
+	}
+}
+
+func Test_DependencyOrder(t *testing.T) {
+	grid := []struct {
+		Name          string
+		DependsOn     map[string][]string
+		Expected      []string
+		ExpectedError string
+	}{
+		{
+			Name:      "no dependencies",
+			DependsOn: map[string][]string{"c": nil, "a": nil, "b": nil},
+			Expected:  []string{"a", "b", "c"},
+		},
+		{
+			Name: "dependencies are applied first",
+			DependsOn: map[string][]string{
+				"a": {"certmanager.io"},
+				"b": nil,
+				"c": {"a"},
+
+				"certmanager.io": nil,
+			},
+			Expected: []string{"certmanager.io", "a", "b", "c"},
+		},
+		{
+			Name: "transitive dependencies",
+			DependsOn: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": nil,
+			},
+			Expected: []string{"c", "b", "a"},
+		},
+		{
+			Name: "unknown dependency",
+			DependsOn: map[string][]string{
+				"a": {"missing"},
+			},
+			ExpectedError: `addon "a" depends on addon "missing", which is not in the channel`,
+		},
+		{
+			Name: "cycle",
+			DependsOn: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"a"},
+			},
+			ExpectedError: "addon dependencies form a cycle: a -> b -> c -> a",
+		},
+		{
+			Name: "self dependency",
+			DependsOn: map[string][]string{
+				"a": {"a"},
+			},
+			ExpectedError: "addon dependencies form a cycle: a -> a",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			menu := NewAddonMenu()
+			for name, dependsOn := range g.DependsOn {
+				menu.Addons[name] = &Addon{
+					Name: name,
+					Spec: &api.AddonSpec{
+						Name:      fi.PtrTo(name),
+						DependsOn: dependsOn,
+					},
+				}
+			}
+
+			addons, err := menu.DependencyOrder()
+			if g.ExpectedError != "" {
+				if err == nil || err.Error() != g.ExpectedError {
+					t.Fatalf("expected error %q, got %v", g.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var actual []string
+			for _, addon := range addons {
+				actual = append(actual, addon.Name)
+			}
+			if strings.Join(actual, ",") != strings.Join(g.Expected, ",") {
+				t.Errorf("unexpected order %v, expected %v", actual, g.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// appliedManifestKey is the key of the gzipped manifest in the applied manifest ConfigMap.
+	appliedManifestKey = "manifest.yaml.gz"
+	// appliedManifestHashAnnotation records the ManifestHash of the manifest in the applied manifest ConfigMap.
+	appliedManifestHashAnnotation = AnnotationPrefix + "manifest-hash"
+)
+
+// AppliedManifestConfigMapName returns the name of the ConfigMap that holds the last manifest applied for the addon,
+// which is kept so that a later update can be rolled back.
+func (c *Channel) AppliedManifestConfigMapName() string {
+	return "kops-addon-manifest." + c.Name
+}
+
+// SetAppliedManifest records the manifest that was applied for the addon.
+func (c *Channel) SetAppliedManifest(ctx context.Context, k8sClient kubernetes.Interface, manifestHash string, data []byte) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return fmt.Errorf("error compressing manifest: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("error compressing manifest: %w", err)
+	}
+
+	configMaps := k8sClient.CoreV1().ConfigMaps(c.Namespace)
+	name := c.AppliedManifestConfigMapName()
+
+	configMap, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("error getting configmap %s/%s: %w", c.Namespace, name, err)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: c.Namespace,
+				Name:      name,
+			},
+		}
+	}
+
+	if configMap.Annotations == nil {
+		configMap.Annotations = make(map[string]string)
+	}
+	configMap.Annotations[appliedManifestHashAnnotation] = manifestHash
+	configMap.BinaryData = map[string][]byte{appliedManifestKey: buf.Bytes()}
+
+	if configMap.ResourceVersion == "" {
+		_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	} else {
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("error writing configmap %s/%s: %w", c.Namespace, name, err)
+	}
+	return nil
+}
+
+// GetAppliedManifest returns the last manifest that was applied for the addon and its ManifestHash.
+// It returns nil if no manifest was recorded.
+func (c *Channel) GetAppliedManifest(ctx context.Context, k8sClient kubernetes.Interface) ([]byte, string, error) {
+	name := c.AppliedManifestConfigMapName()
+	configMap, err := k8sClient.CoreV1().ConfigMaps(c.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("error getting configmap %s/%s: %w", c.Namespace, name, err)
+	}
+
+	compressed, found := configMap.BinaryData[appliedManifestKey]
+	if !found {
+		return nil, "", nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, "", fmt.Errorf("error decompressing manifest in configmap %s/%s: %w", c.Namespace, name, err)
+	}
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, "", fmt.Errorf("error decompressing manifest in configmap %s/%s: %w", c.Namespace, name, err)
+	}
+
+	return data, configMap.Annotations[appliedManifestHashAnnotation], nil
+}
@@ -46,6 +46,19 @@ type Channel struct {
 //	1  Prune functionality introduced.
 const CurrentSystemGeneration = 1
 
+// AddonHealth is the outcome of the health checks run after applying an addon.
+type AddonHealth string
+
+const (
+	// AddonHealthHealthy means the applied objects became healthy.
+	AddonHealthHealthy AddonHealth = "Healthy"
+	// AddonHealthFailed means the applied objects did not become healthy in time.
+	AddonHealthFailed AddonHealth = "Failed"
+	// AddonHealthRolledBack means the applied objects did not become healthy in time,
+	// and the previously applied manifest was applied again.
+	AddonHealthRolledBack AddonHealth = "RolledBack"
+)
+
 type ChannelVersion struct {
 	Channel      *string `json:"channel,omitempty"`
 	Id           string  `json:"id,omitempty"`
@@ -54,6 +67,12 @@ type ChannelVersion struct {
 	// SystemGeneration holds the generation of the channels functionality.
 	// It is used so that we reapply when we introduce new features, such as prune.
 	SystemGeneration int `json:"systemGeneration,omitempty"`
+
+	// Health is the outcome of the health checks run after applying the version.
+	// It is empty if health checks were not run.
+	Health AddonHealth `json:"health,omitempty"`
+	// FailedManifestHash is the hash of the manifest that was rolled back, if Health is RolledBack.
+	FailedManifestHash string `json:"failedManifestHash,omitempty"`
 }
 
 func stringValue(s *string) string {
@@ -72,6 +91,12 @@ func (c *ChannelVersion) String() string {
 		s += " ManifestHash=" + c.ManifestHash
 	}
 	s += " SystemGeneration=" + strconv.Itoa(c.SystemGeneration)
+	if c.Health != "" {
+		s += " Health=" + string(c.Health)
+	}
+	if c.FailedManifestHash != "" {
+		s += " FailedManifestHash=" + c.FailedManifestHash
+	}
 	return s
 }
 
@@ -123,6 +148,16 @@ func (c *ChannelVersion) replaces(name string, existing *ChannelVersion) bool {
 		return true
 	}
 
+	if existing.Health == AddonHealthRolledBack && c.ManifestHash == existing.FailedManifestHash {
+		klog.V(4).Infof("cluster rolled back ManifestHash %q for %q; will not replace", c.ManifestHash, name)
+		return false
+	}
+
+	if existing.Health == AddonHealthFailed && c.ManifestHash == existing.ManifestHash {
+		klog.V(4).Infof("cluster has unhealthy ManifestHash %q for %q; will replace", c.ManifestHash, name)
+		return true
+	}
+
 	if c.ManifestHash != existing.ManifestHash {
 		klog.V(4).Infof("cluster has different ManifestHash for %q (%q vs %q); will replace", name, c.ManifestHash, existing.ManifestHash)
 		return true
@@ -18,22 +18,40 @@ package channels
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/restmapper"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/channels/pkg/api"
 	"k8s.io/kops/pkg/applylib/applyset"
 	"k8s.io/kops/pkg/kubemanifest"
 )
 
 type ClientApplier struct {
 	Client     dynamic.Interface
-	RESTMapper *restmapper.DeferredDiscoveryRESTMapper
+	RESTMapper meta.RESTMapper
+
+	// HealthCheckInterval is how often WaitForHealthy checks the objects; it defaults to 5 seconds.
+	HealthCheckInterval time.Duration
 }
 
-// Apply applies the manifest to the cluster.
-func (p *ClientApplier) Apply(ctx context.Context, manifest []byte) error {
+var _ HealthChecker = &ClientApplier{}
+var _ DependencyWaiter = &ClientApplier{}
+
+// Apply applies the manifest to the cluster with server-side apply, as the ApplySet of the channel.
+// Objects that were in the ApplySet but are no longer in the manifest are pruned.
+func (p *ClientApplier) Apply(ctx context.Context, channel *Channel, manifest []byte, prune *api.PruneSpec) error {
 	objects, err := kubemanifest.LoadObjectsFrom(manifest)
 	if err != nil {
 		return fmt.Errorf("failed to parse objects: %w", err)
@@ -45,10 +63,8 @@ func (p *ClientApplier) Apply(ctx context.Context, manifest []byte) error {
 	}
 
 	// We force to overcome errors like: Apply failed with 1 conflict: conflict with "kubectl-client-side-apply" using apps/v1: .spec.template.spec.containers[name="foo"].image
-	// TODO: How to handle this better?   In a controller we don't have a choice and have to force eventually.
-	// But we could do something like try first without forcing, log the conflict if there is one, and then force.
-	// This would mean that if there was a loop we could log/detect it.
-	// We could even do things like back-off on the force apply.
+	// In a controller we don't have a choice and have to force eventually, but the applyset first tries without forcing,
+	// and reports any conflicts, so that we can detect fights with other field managers.
 	force := true
 	patchOptions.Force = &force
 
@@ -56,6 +72,7 @@ func (p *ClientApplier) Apply(ctx context.Context, manifest []byte) error {
 		RESTMapper:   p.RESTMapper,
 		Client:       p.Client,
 		PatchOptions: patchOptions,
+		Parent:       channel.ApplySetParent(),
 	})
 	if err != nil {
 		return err
@@ -69,21 +86,205 @@ func (p *ClientApplier) Apply(ctx context.Context, manifest []byte) error {
 		return err
 	}
 
+	// The first time we apply an addon as an ApplySet, we adopt the objects that older versions of channels
+	// would have pruned, so they are pruned if they are not in the manifest.
+	// Adopting also moves the fields that older versions of channels set with kubectl replace (an Update)
+	// to our server-side apply field manager, so that fields dropped from the manifest are removed.
+	// We retry until the adoption has been recorded on the parent, in case we were interrupted.
+	adopted, err := s.IsAdopted(ctx)
+	if err != nil {
+		return err
+	}
+	if !adopted {
+		var adopt []*unstructured.Unstructured
+		if prune != nil {
+			adopt, err = findPrunableObjects(ctx, p.Client, p.RESTMapper, prune)
+			if err != nil {
+				return fmt.Errorf("failed to find objects to adopt: %w", err)
+			}
+		}
+		if err := s.Adopt(ctx, adopt); err != nil {
+			return fmt.Errorf("failed to adopt objects: %w", err)
+		}
+	}
+
 	results, err := s.ApplyOnce(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to apply objects: %w", err)
 	}
 
-	// TODO: Implement pruning
-
 	if !results.AllApplied() {
 		return fmt.Errorf("not all objects were applied")
 	}
 
-	// TODO: Check object health status
+	// We only prune once everything has been applied, so a failed update does not remove objects that are still needed.
+	if _, err := s.Prune(ctx); err != nil {
+		return fmt.Errorf("failed to prune objects: %w", err)
+	}
+
 	if !results.AllHealthy() {
-		return fmt.Errorf("not all objects were healthy")
+		return ErrNotHealthy
 	}
 
 	return nil
 }
+
+// ErrNotHealthy is returned by Apply when all the objects were applied, but not all of them were healthy yet.
+// Callers that wait for the objects to converge with WaitForHealthy can ignore it.
+var ErrNotHealthy = errors.New("not all objects were healthy")
+
+// WaitForHealthy waits until all the objects in the manifest are healthy, returning an error on timeout.
+func (p *ClientApplier) WaitForHealthy(ctx context.Context, manifest []byte, timeout time.Duration) error {
+	objects, err := kubemanifest.LoadObjectsFrom(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to parse objects: %w", err)
+	}
+
+	return p.waitForHealthy(ctx, objects, nil, timeout)
+}
+
+// WaitForDependency waits until all the objects in the manifest are healthy, and the services backing any webhooks
+// in the manifest have ready endpoints, so that other addons can create objects that use them.
+func (p *ClientApplier) WaitForDependency(ctx context.Context, manifest []byte, timeout time.Duration) error {
+	objects, err := kubemanifest.LoadObjectsFrom(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to parse objects: %w", err)
+	}
+
+	return p.waitForHealthy(ctx, objects, webhookServices(objects), timeout)
+}
+
+// WaitForCRDs waits until the named CustomResourceDefinitions exist and are established, returning an error on timeout.
+func (p *ClientApplier) WaitForCRDs(ctx context.Context, names []string, timeout time.Duration) error {
+	// We only need the identity of the CRDs to read them back and check their health.
+	var objects kubemanifest.ObjectList
+	for _, name := range names {
+		objects = append(objects, kubemanifest.NewObject(map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+		}))
+	}
+
+	return p.waitForHealthy(ctx, objects, nil, timeout)
+}
+
+// waitForHealthy waits until all the objects are healthy and all the services have ready endpoints, returning an error on timeout.
+func (p *ClientApplier) waitForHealthy(ctx context.Context, objects kubemanifest.ObjectList, services []types.NamespacedName, timeout time.Duration) error {
+	s, err := applyset.New(applyset.Options{
+		RESTMapper: p.RESTMapper,
+		Client:     p.Client,
+	})
+	if err != nil {
+		return err
+	}
+
+	var applyableObjects []applyset.ApplyableObject
+	for _, object := range objects {
+		applyableObjects = append(applyableObjects, object)
+	}
+	if err := s.SetDesiredObjects(applyableObjects); err != nil {
+		return err
+	}
+
+	interval := p.HealthCheckInterval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+
+	var unhealthy []string
+	err = wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		results, err := s.CheckHealth(ctx)
+		if err != nil {
+			klog.Warningf("error checking health: %v", err)
+			return false, nil
+		}
+		unhealthy = results.Unhealthy()
+
+		for _, service := range services {
+			ready, err := p.hasReadyEndpoints(ctx, service)
+			if err != nil {
+				klog.Warningf("error checking endpoints of service %v: %v", service, err)
+				return false, nil
+			}
+			if !ready {
+				unhealthy = append(unhealthy, "Service:"+service.String())
+			}
+		}
+		return len(unhealthy) == 0, nil
+	})
+	if err != nil {
+		if len(unhealthy) != 0 {
+			return fmt.Errorf("objects not healthy after %v: %s", timeout, strings.Join(unhealthy, ", "))
+		}
+		return fmt.Errorf("objects not healthy after %v: %w", timeout, err)
+	}
+	return nil
+}
+
+// hasReadyEndpoints returns true if the service has at least one ready endpoint.
+func (p *ClientApplier) hasReadyEndpoints(ctx context.Context, service types.NamespacedName) (bool, error) {
+	gvr := schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}
+	endpointSlices, err := p.Client.Resource(gvr).Namespace(service.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service.Name,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, endpointSlice := range endpointSlices.Items {
+		endpoints, _, _ := unstructured.NestedSlice(endpointSlice.Object, "endpoints")
+		for _, endpoint := range endpoints {
+			endpointMap, ok := endpoint.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			// A missing ready condition should be interpreted as ready.
+			ready, found, _ := unstructured.NestedBool(endpointMap, "conditions", "ready")
+			if ready || !found {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// webhookServices returns the services that back the admission and conversion webhooks defined by the objects.
+func webhookServices(objects kubemanifest.ObjectList) []types.NamespacedName {
+	var services []types.NamespacedName
+	seen := sets.New[types.NamespacedName]()
+	addService := func(clientConfig map[string]interface{}) {
+		namespace, _, _ := unstructured.NestedString(clientConfig, "service", "namespace")
+		name, _, _ := unstructured.NestedString(clientConfig, "service", "name")
+		service := types.NamespacedName{Namespace: namespace, Name: name}
+		if name != "" && !seen.Has(service) {
+			seen.Insert(service)
+			services = append(services, service)
+		}
+	}
+
+	for _, object := range objects {
+		u := object.ToUnstructured()
+		switch object.GroupVersionKind().GroupKind() {
+		case schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"},
+			schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}:
+			webhooks, _, _ := unstructured.NestedSlice(u.Object, "webhooks")
+			for _, webhook := range webhooks {
+				webhookMap, ok := webhook.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				clientConfig, _, _ := unstructured.NestedMap(webhookMap, "clientConfig")
+				addService(clientConfig)
+			}
+
+		case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+			clientConfig, _, _ := unstructured.NestedMap(u.Object, "spec", "conversion", "webhook", "clientConfig")
+			addService(clientConfig)
+		}
+	}
+
+	return services
+}
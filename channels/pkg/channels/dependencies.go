@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// DependencyWaiter is implemented by appliers that can wait for the dependencies of an addon to be ready.
+type DependencyWaiter interface {
+	// WaitForDependency waits until the objects in the manifest of an addon are ready to be used by other addons,
+	// returning an error on timeout.
+	WaitForDependency(ctx context.Context, data []byte, timeout time.Duration) error
+	// WaitForCRDs waits until the named CustomResourceDefinitions are established, returning an error on timeout.
+	WaitForCRDs(ctx context.Context, names []string, timeout time.Duration) error
+}
+
+// DependencyOrder returns the addons of the menu in the order they should be applied,
+// so that every addon comes after the addons it depends on.
+func (m *AddonMenu) DependencyOrder() ([]*Addon, error) {
+	var names []string
+	dependsOn := make(map[string][]string)
+	for name, addon := range m.Addons {
+		names = append(names, name)
+		dependsOn[name] = addon.Spec.DependsOn
+	}
+	// Addons without dependencies between them are applied in order of name, so the order is predictable.
+	sort.Strings(names)
+
+	ordered, err := api.DependencyOrder(names, dependsOn)
+	if err != nil {
+		return nil, err
+	}
+
+	var addons []*Addon
+	for _, name := range ordered {
+		addons = append(addons, m.Addons[name])
+	}
+	return addons, nil
+}
+
+// WaitForDependencies waits until the addons and CRDs that the addon depends on are ready, returning an error on timeout.
+func (a *Addon) WaitForDependencies(ctx context.Context, vfsContext *vfs.VFSContext, menu *AddonMenu, waiter DependencyWaiter, timeout time.Duration) error {
+	for _, name := range a.Spec.DependsOn {
+		dependency := menu.Addons[name]
+		if dependency == nil {
+			return fmt.Errorf("addon %q depends on addon %q, which is not in the channel", a.Name, name)
+		}
+
+		manifestURL, err := dependency.GetManifestFullUrl()
+		if err != nil {
+			return err
+		}
+		data, err := vfsContext.ReadFile(manifestURL.String())
+		if err != nil {
+			return fmt.Errorf("error reading manifest of addon %q: %w", name, err)
+		}
+
+		klog.Infof("Waiting up to %v for addon %q to be ready before applying %q", timeout, name, a.Name)
+		if err := waiter.WaitForDependency(ctx, data, timeout); err != nil {
+			return fmt.Errorf("addon %q depends on addon %q, which is not ready: %w", a.Name, name, err)
+		}
+	}
+
+	if len(a.Spec.RequiredCRDs) != 0 {
+		klog.Infof("Waiting up to %v for CRDs %v to be established before applying %q", timeout, a.Spec.RequiredCRDs, a.Name)
+		if err := waiter.WaitForCRDs(ctx, a.Spec.RequiredCRDs, timeout); err != nil {
+			return fmt.Errorf("addon %q requires CRDs that are not established: %w", a.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -20,130 +20,81 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/restmapper"
 	"k8s.io/klog/v2"
+	"k8s.io/kops"
 	"k8s.io/kops/channels/pkg/api"
-	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/pkg/applylib/applyset"
 )
 
-type Pruner struct {
-	Client     dynamic.Interface
-	RESTMapper *restmapper.DeferredDiscoveryRESTMapper
+// ApplySetParent returns the parent object of the ApplySet that holds the objects of the addon.
+func (c *Channel) ApplySetParent() *applyset.Parent {
+	return &applyset.Parent{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Secret"},
+		Namespace:        c.Namespace,
+		Name:             "kops-addon-applyset." + c.Name,
+		Tooling:          "kops/v" + kops.Version,
+	}
 }
 
-// Prune prunes objects not in the manifest, according to PruneSpec.
-func (p *Pruner) Prune(ctx context.Context, manifest []byte, spec *api.PruneSpec) error {
-	klog.Infof("Prune spec: %v", spec)
-
+// findPrunableObjects returns the objects selected by a PruneSpec.
+// Before addons were applied as ApplySets, these objects were pruned if they were not in the manifest,
+// so we adopt them into the ApplySet of the addon, to continue pruning them.
+func findPrunableObjects(ctx context.Context, client dynamic.Interface, restMapper meta.RESTMapper, spec *api.PruneSpec) ([]*unstructured.Unstructured, error) {
 	if spec == nil {
-		return nil
-	}
-
-	objects, err := kubemanifest.LoadObjectsFrom(manifest)
-	if err != nil {
-		return fmt.Errorf("failed to parse objects: %w", err)
-	}
-
-	objectsByKind := make(map[schema.GroupKind][]*kubemanifest.Object)
-	for _, object := range objects {
-		gv, err := schema.ParseGroupVersion(object.APIVersion())
-		if err != nil || gv.Version == "" {
-			return fmt.Errorf("failed to parse apiVersion %q", object.APIVersion())
-		}
-		kind := object.Kind()
-		if kind == "" {
-			return fmt.Errorf("failed to find kind in object")
-		}
-
-		gvk := gv.WithKind(kind)
-		gk := gvk.GroupKind()
-		objectsByKind[gk] = append(objectsByKind[gk], object)
+		return nil, nil
 	}
 
+	var objects []*unstructured.Unstructured
 	for i := range spec.Kinds {
 		pruneKind := &spec.Kinds[i]
 		gk := schema.GroupKind{Group: pruneKind.Group, Kind: pruneKind.Kind}
-		if err := p.pruneObjectsOfKind(ctx, gk, pruneKind, objectsByKind[gk]); err != nil {
-			return fmt.Errorf("failed to prune objects of kind %s: %w", gk, err)
-		}
-	}
-
-	return nil
-}
-
-func (p *Pruner) pruneObjectsOfKind(ctx context.Context, gk schema.GroupKind, spec *api.PruneKindSpec, keepObjects []*kubemanifest.Object) error {
-	klog.Infof("pruning objects of kind: %v", gk)
-
-	restMapping, err := p.RESTMapper.RESTMapping(gk)
-	if err != nil {
-		return fmt.Errorf("unable to find resource for %s: %w", gk, err)
-	}
-
-	gvr := restMapping.Resource
 
-	var listOptions v1.ListOptions
-	listOptions.LabelSelector = spec.LabelSelector
-	listOptions.FieldSelector = spec.FieldSelector
-
-	baseResource := p.Client.Resource(gvr)
-	if len(spec.Namespaces) == 0 {
-		objects, err := baseResource.List(ctx, listOptions)
+		restMapping, err := restMapper.RESTMapping(gk)
 		if err != nil {
-			return fmt.Errorf("error listing objects: %w", err)
-		}
-		if err := p.pruneObjects(ctx, gvr, objects, keepObjects); err != nil {
-			return err
-		}
-	} else {
-		for _, namespace := range spec.Namespaces {
-			resource := baseResource.Namespace(namespace)
-			actualObjects, err := resource.List(ctx, listOptions)
-			if err != nil {
-				return fmt.Errorf("error listing objects in namespace %s: %w", namespace, err)
+			if meta.IsNoMatchError(err) {
+				klog.V(2).Infof("skipping objects of kind %v, which is not known to the server", gk)
+				continue
 			}
-			if err := p.pruneObjects(ctx, gvr, actualObjects, keepObjects); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-func (p *Pruner) pruneObjects(ctx context.Context, gvr schema.GroupVersionResource, actualObjects *unstructured.UnstructuredList, keepObjects []*kubemanifest.Object) error {
-	keepMap := make(map[string]*kubemanifest.Object)
-	for _, keepObject := range keepObjects {
-		key := keepObject.GetNamespace() + "/" + keepObject.GetName()
-		keepMap[key] = keepObject
-	}
-
-	for _, actualObject := range actualObjects.Items {
-		name := actualObject.GetName()
-		namespace := actualObject.GetNamespace()
-		key := namespace + "/" + name
-		if _, found := keepMap[key]; found {
-			// Object is in manifest, don't delete
-			continue
+			return nil, fmt.Errorf("unable to find resource for %s: %w", gk, err)
 		}
+		gvr := restMapping.Resource
 
-		klog.Infof("pruning %s %s", gvr, key)
+		var listOptions v1.ListOptions
+		listOptions.LabelSelector = pruneKind.LabelSelector
+		listOptions.FieldSelector = pruneKind.FieldSelector
 
-		var resource dynamic.ResourceInterface
-		if namespace != "" {
-			resource = p.Client.Resource(gvr).Namespace(namespace)
+		baseResource := client.Resource(gvr)
+		var lists []*unstructured.UnstructuredList
+		if len(pruneKind.Namespaces) == 0 {
+			list, err := baseResource.List(ctx, listOptions)
+			if err != nil {
+				return nil, fmt.Errorf("error listing objects of kind %s: %w", gk, err)
+			}
+			lists = append(lists, list)
 		} else {
-			resource = p.Client.Resource(gvr)
+			for _, namespace := range pruneKind.Namespaces {
+				list, err := baseResource.Namespace(namespace).List(ctx, listOptions)
+				if err != nil {
+					return nil, fmt.Errorf("error listing objects of kind %s in namespace %s: %w", gk, namespace, err)
+				}
+				lists = append(lists, list)
+			}
 		}
 
-		var opts v1.DeleteOptions
-		if err := resource.Delete(ctx, name, opts); err != nil {
-			return fmt.Errorf("failed to delete %s: %w", key, err)
+		for _, list := range lists {
+			for j := range list.Items {
+				obj := &list.Items[j]
+				// Lists do not always populate the apiVersion and kind of the items.
+				obj.SetGroupVersionKind(restMapping.GroupVersionKind)
+				objects = append(objects, obj)
+			}
 		}
 	}
 
-	return nil
+	return objects, nil
 }
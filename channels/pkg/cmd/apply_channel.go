@@ -22,12 +22,14 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/spf13/cobra"
 	"go.uber.org/multierr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/restmapper"
@@ -38,6 +40,13 @@ import (
 
 type ApplyChannelOptions struct {
 	Yes bool
+
+	// HealthTimeout is how long to wait for the objects of each updated addon to become healthy.
+	HealthTimeout time.Duration
+	// Rollback applies the previous manifest of an addon again if its update does not become healthy.
+	Rollback bool
+	// DependencyTimeout is how long to wait for the addons and CRDs that an addon depends on to be ready.
+	DependencyTimeout time.Duration
 }
 
 func NewCmdApplyChannel(f Factory, out io.Writer) *cobra.Command {
@@ -53,6 +62,9 @@ func NewCmdApplyChannel(f Factory, out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&options.Yes, "yes", false, "Apply update")
+	cmd.Flags().DurationVar(&options.HealthTimeout, "health-timeout", 0, "Time to wait for the objects of each updated addon to become healthy; if zero, an update is only recorded once its objects are healthy when they are applied")
+	cmd.Flags().BoolVar(&options.Rollback, "rollback", false, "Apply the previous manifest of an addon again if its update does not become healthy (requires --health-timeout)")
+	cmd.Flags().DurationVar(&options.DependencyTimeout, "dependency-timeout", 5*time.Minute, "Time to wait for the addons and CRDs that an addon depends on to be ready")
 
 	return cmd
 }
@@ -95,6 +107,10 @@ func RunApplyChannel(ctx context.Context, f Factory, out io.Writer, options *App
 		return fmt.Errorf("unexpected number of arguments. Only one channel may be processed at the same time.")
 	}
 
+	if options.Rollback && options.HealthTimeout == 0 {
+		return fmt.Errorf("--rollback requires --health-timeout")
+	}
+
 	channelLocation := args[0]
 
 	// menu is the expected list of addons in the cluster and their configurations.
@@ -103,10 +119,10 @@ func RunApplyChannel(ctx context.Context, f Factory, out io.Writer, options *App
 		return fmt.Errorf("cannot build the addon menu from args: %w", err)
 	}
 
-	return applyMenu(ctx, menu, f.VFSContext(), k8sClient, cmClient, dynamicClient, restMapper, options.Yes)
+	return applyMenu(ctx, menu, f.VFSContext(), k8sClient, cmClient, dynamicClient, restMapper, options)
 }
 
-func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient versioned.Interface, dynamicClient dynamic.Interface, restMapper *restmapper.DeferredDiscoveryRESTMapper, apply bool) error {
+func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VFSContext, k8sClient kubernetes.Interface, cmClient versioned.Interface, dynamicClient dynamic.Interface, restMapper *restmapper.DeferredDiscoveryRESTMapper, options *ApplyChannelOptions) error {
 	// channelVersions is the list of installed addons in the cluster.
 	// It is keyed by <namespace>:<addon name>.
 	channelVersions, err := getChannelVersions(ctx, k8sClient)
@@ -114,7 +130,13 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VF
 		return fmt.Errorf("cannot fetch channel versions from namespaces: %w", err)
 	}
 
-	updates, needUpdates, err := getUpdates(ctx, menu, k8sClient, cmClient, channelVersions)
+	// Addons are applied after the addons they depend on.
+	ordered, err := menu.DependencyOrder()
+	if err != nil {
+		return fmt.Errorf("cannot order addons: %w", err)
+	}
+
+	updates, needUpdates, err := getUpdates(ctx, ordered, k8sClient, cmClient, channelVersions)
 	if err != nil {
 		return fmt.Errorf("failed to get updates: %w", err)
 	}
@@ -155,26 +177,48 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VF
 		}
 	}
 
-	if !apply {
+	if !options.Yes {
 		fmt.Printf("\nMust specify --yes to update\n")
 		return nil
 	}
 
-	pruner := &channels.Pruner{
+	applier := &channels.ClientApplier{
 		Client:     dynamicClient,
 		RESTMapper: restMapper,
 	}
 
-	applier := &channels.ClientApplier{
-		Client:     dynamicClient,
-		RESTMapper: restMapper,
+	updateOptions := channels.UpdateOptions{
+		HealthTimeout: options.HealthTimeout,
+		Rollback:      options.Rollback,
 	}
 
 	var merr error
 
+	// failed holds the addons that were not updated, so we don't apply the addons that depend on them.
+	failed := sets.New[string]()
+
 	for _, needUpdate := range needUpdates {
-		update, err := needUpdate.EnsureUpdated(ctx, vfsContext, k8sClient, cmClient, pruner, applier, channelVersions[needUpdate.GetNamespace()+":"+needUpdate.Name])
+		var failedDependencies []string
+		for _, dependency := range needUpdate.Spec.DependsOn {
+			if failed.Has(dependency) {
+				failedDependencies = append(failedDependencies, dependency)
+			}
+		}
+		if len(failedDependencies) != 0 {
+			failed.Insert(needUpdate.Name)
+			merr = multierr.Append(merr, fmt.Errorf("not updating %q because its dependencies %v were not updated", needUpdate.Name, failedDependencies))
+			continue
+		}
+
+		if err := needUpdate.WaitForDependencies(ctx, vfsContext, menu, applier, options.DependencyTimeout); err != nil {
+			failed.Insert(needUpdate.Name)
+			merr = multierr.Append(merr, fmt.Errorf("updating %q: %w", needUpdate.Name, err))
+			continue
+		}
+
+		update, err := needUpdate.EnsureUpdated(ctx, vfsContext, k8sClient, cmClient, applier, channelVersions[needUpdate.GetNamespace()+":"+needUpdate.Name], updateOptions)
 		if err != nil {
+			failed.Insert(needUpdate.Name)
 			merr = multierr.Append(merr, fmt.Errorf("updating %q: %w", needUpdate.Name, err))
 		} else if update != nil {
 			fmt.Printf("Updated %q\n", update.Name)
@@ -184,10 +228,10 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, vfsContext *vfs.VF
 	return merr
 }
 
-func getUpdates(ctx context.Context, menu *channels.AddonMenu, k8sClient kubernetes.Interface, cmClient versioned.Interface, channelVersions map[string]*channels.ChannelVersion) ([]*channels.AddonUpdate, []*channels.Addon, error) {
+func getUpdates(ctx context.Context, addons []*channels.Addon, k8sClient kubernetes.Interface, cmClient versioned.Interface, channelVersions map[string]*channels.ChannelVersion) ([]*channels.AddonUpdate, []*channels.Addon, error) {
 	var updates []*channels.AddonUpdate
 	var needUpdates []*channels.Addon
-	for _, addon := range menu.Addons {
+	for _, addon := range addons {
 		update, err := addon.GetRequiredUpdates(ctx, k8sClient, cmClient, channelVersions[addon.GetNamespace()+":"+addon.Name])
 		if err != nil {
 			return nil, nil, fmt.Errorf("error checking for required update: %v", err)
@@ -65,7 +65,11 @@ func TestGetUpdates(t *testing.T) {
 			},
 		},
 	}
-	_, needUpdates, err := getUpdates(ctx, menu, k8sClient, cmfake.NewSimpleClientset(), channelVersions)
+	addons, err := menu.DependencyOrder()
+	if err != nil {
+		t.Fatalf("failed to order addons: %v", err)
+	}
+	_, needUpdates, err := getUpdates(ctx, addons, k8sClient, cmfake.NewSimpleClientset(), channelVersions)
 	if err != nil {
 		t.Errorf("failed to get updates: %v", err)
 	}
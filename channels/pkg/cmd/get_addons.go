@@ -125,7 +125,14 @@ func RunGetAddons(ctx context.Context, f Factory, out io.Writer, options *GetAdd
 			return "?"
 		})
 
-		columns := []string{"NAMESPACE", "NAME", "HASH", "CHANNEL"}
+		t.AddColumn("STATUS", func(r *addonInfo) string {
+			if r.Version == nil || r.Version.Health == "" {
+				return "-"
+			}
+			return string(r.Version.Health)
+		})
+
+		columns := []string{"NAMESPACE", "NAME", "HASH", "CHANNEL", "STATUS"}
 		err := t.Render(info, os.Stdout, columns...)
 		if err != nil {
 			return err
@@ -97,10 +97,11 @@ func (r *HostsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, r.updateHosts(ctx, endpointsList)
+	return ctrl.Result{}, r.updateConfigMap(ctx, buildAddrToHosts(r.clusterName, endpointsList))
 }
 
-func (r *HostsReconciler) updateHosts(ctx context.Context, endpointsList *corev1.EndpointsList) error {
+// buildAddrToHosts maps the addresses of the discovery endpoints to their internal hostnames.
+func buildAddrToHosts(clusterName string, endpointsList *corev1.EndpointsList) map[string][]string {
 	addrToHosts := make(map[string][]string)
 
 	for i := range endpointsList.Items {
@@ -112,7 +113,7 @@ func (r *HostsReconciler) updateHosts(ctx context.Context, endpointsList *corev1
 			klog.Warningf("endpoints %s/%s found without discovery label %q; filtering is not working correctly", endpoints.Name, endpoints.Namespace, kops.DiscoveryLabelKey)
 			continue
 		}
-		suffix := ".internal." + r.clusterName
+		suffix := ".internal." + clusterName
 		if !strings.HasSuffix(hostname, suffix) {
 			hostname = hostname + suffix
 		} else {
@@ -133,7 +134,7 @@ func (r *HostsReconciler) updateHosts(ctx context.Context, endpointsList *corev1
 		}
 	}
 
-	return r.updateConfigMap(ctx, addrToHosts)
+	return addrToHosts
 }
 
 // managedConfigMap holds the fields we manage
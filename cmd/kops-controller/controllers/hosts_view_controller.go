@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/cmd/kops-controller/pkg/discovery"
+	"k8s.io/kops/pkg/apis/kops"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// HostsViewReconciler populates the HostsView that kops-controller serves to the nodes,
+// from the same endpoints as the HostsReconciler.
+type HostsViewReconciler struct {
+	// clusterName identifies the kOps cluster
+	clusterName string
+
+	// client is the controller-runtime client
+	client client.Client
+
+	// log is a logr
+	log logr.Logger
+
+	// view is the HostsView we populate
+	view *discovery.HostsView
+}
+
+// NewHostsViewReconciler is the constructor for a HostsViewReconciler
+func NewHostsViewReconciler(mgr manager.Manager, opt *config.Options, view *discovery.HostsView) (*HostsViewReconciler, error) {
+	r := &HostsViewReconciler{
+		clusterName: opt.ClusterName,
+		client:      mgr.GetClient(),
+		log:         ctrl.Log.WithName("controllers").WithName("HostsView"),
+		view:        view,
+	}
+
+	return r, nil
+}
+
+// Reconcile is the main reconciler function that observes endpoints changes.
+func (r *HostsViewReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.log.WithValues("endpoints", req.NamespacedName)
+
+	endpointsLabels := client.HasLabels([]string{kops.DiscoveryLabelKey})
+
+	endpointsList := &corev1.EndpointsList{}
+
+	// For security, we only process endpoints in kube-system
+	if err := r.client.List(ctx, endpointsList, endpointsLabels, client.InNamespace("kube-system")); err != nil {
+		klog.Warningf("unable to list endpoints: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.view.Update(buildAddrToHosts(r.clusterName, endpointsList))
+}
+
+func (r *HostsViewReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Every kops-controller serves the view, not just the leader
+	needLeaderElection := false
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("hostsview").
+		For(&corev1.Endpoints{}).
+		WithOptions(controller.Options{NeedLeaderElection: &needLeaderElection}).
+		Complete(r)
+}
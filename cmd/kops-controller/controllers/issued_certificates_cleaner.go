@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/bootstrap"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// issuedCertificatesCleanupInterval is how often we look for records of deleted nodes.
+const issuedCertificatesCleanupInterval = time.Hour
+
+// IssuedCertificatesCleaner deletes the record of the certificates issued to a node once the node has been deleted
+// and all of its certificates have expired.  The record is kept until then, so that the certificates of a deleted
+// (or compromised) node can still be revoked.
+type IssuedCertificatesCleaner struct {
+	// client is the controller-runtime client
+	client client.Client
+
+	// reader reads directly from the API server; we do not cache ConfigMaps
+	reader client.Reader
+
+	// log is a logr
+	log logr.Logger
+}
+
+var _ manager.Runnable = &IssuedCertificatesCleaner{}
+var _ manager.LeaderElectionRunnable = &IssuedCertificatesCleaner{}
+
+// NewIssuedCertificatesCleaner is the constructor for an IssuedCertificatesCleaner
+func NewIssuedCertificatesCleaner(mgr manager.Manager) (*IssuedCertificatesCleaner, error) {
+	c := &IssuedCertificatesCleaner{
+		client: mgr.GetClient(),
+		reader: mgr.GetAPIReader(),
+		log:    ctrl.Log.WithName("controllers").WithName("IssuedCertificates"),
+	}
+
+	return c, nil
+}
+
+// Start periodically cleans up the records, until the context is done.
+func (c *IssuedCertificatesCleaner) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.cleanup(ctx, time.Now()); err != nil {
+			c.log.Error(err, "error cleaning up issued certificate records")
+		}
+	}, issuedCertificatesCleanupInterval)
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (c *IssuedCertificatesCleaner) NeedLeaderElection() bool {
+	return true
+}
+
+// cleanup deletes the records of deleted nodes whose certificates have all expired.
+func (c *IssuedCertificatesCleaner) cleanup(ctx context.Context, now time.Time) error {
+	configMaps := &corev1.ConfigMapList{}
+	if err := c.reader.List(ctx, configMaps, client.InNamespace(bootstrap.IssuedCertificatesNamespace)); err != nil {
+		return fmt.Errorf("listing configmaps in %s: %w", bootstrap.IssuedCertificatesNamespace, err)
+	}
+
+	prefix := bootstrap.IssuedCertificatesConfigMapName("")
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		nodeName, ok := strings.CutPrefix(configMap.Name, prefix)
+		if !ok {
+			continue
+		}
+
+		issued, err := bootstrap.ParseIssuedCertificates(configMap.Data)
+		if err != nil {
+			klog.Warningf("skipping configmap %s/%s: %v", configMap.Namespace, configMap.Name, err)
+			continue
+		}
+		if !bootstrap.AllIssuedCertificatesExpired(issued, now) {
+			continue
+		}
+
+		// The node may have been re-registered, or never deleted.
+		node := &corev1.Node{}
+		if err := c.client.Get(ctx, types.NamespacedName{Name: nodeName}, node); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("getting node %q: %w", nodeName, err)
+		}
+
+		if err := c.client.Delete(ctx, configMap, client.Preconditions{ResourceVersion: &configMap.ResourceVersion}); err != nil {
+			if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+				continue
+			}
+			return fmt.Errorf("deleting configmap %s/%s: %w", configMap.Namespace, configMap.Name, err)
+		}
+		klog.Infof("deleted the record of the expired certificates issued to deleted node %q", nodeName)
+	}
+
+	return nil
+}
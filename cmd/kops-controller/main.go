@@ -31,10 +31,12 @@ import (
 	"k8s.io/klog/v2/klogr"
 	"k8s.io/kops/cmd/kops-controller/controllers"
 	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/cmd/kops-controller/pkg/discovery"
 	"k8s.io/kops/cmd/kops-controller/pkg/server"
 	"k8s.io/kops/pkg/apis/kops/v1alpha2"
 	"k8s.io/kops/pkg/bootstrap"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
+	"k8s.io/kops/pkg/bootstrap/tpmbootstrap"
 	"k8s.io/kops/pkg/nodeidentity"
 	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
 	nodeidentityazure "k8s.io/kops/pkg/nodeidentity/azure"
@@ -126,6 +128,12 @@ func main() {
 
 	vfsContext := vfs.NewVFSContext()
 
+	// hostsView holds the hosts that we serve for discovery, on clusters that don't publish DNS records
+	var hostsView *discovery.HostsView
+	if opt.Discovery != nil && opt.Discovery.Enabled {
+		hostsView = discovery.NewHostsView()
+	}
+
 	if opt.Server != nil {
 		var verifiers []bootstrap.Verifier
 		var err error
@@ -195,6 +203,15 @@ func main() {
 			verifiers = append(verifiers, verifier)
 		}
 
+		if opt.Server.TPM != nil {
+			verifier, err := tpmbootstrap.NewVerifier(opt.Server.TPM, mgr.GetClient())
+			if err != nil {
+				setupLog.Error(err, "unable to create verifier")
+				os.Exit(1)
+			}
+			verifiers = append(verifiers, verifier)
+		}
+
 		if len(verifiers) == 0 {
 			klog.Fatalf("server verifiers not provided")
 		}
@@ -210,12 +227,22 @@ func main() {
 
 		verifier := bootstrap.NewChainVerifier(verifiers...)
 
-		srv, err := server.NewServer(vfsContext, &opt, verifier, uncachedClient)
+		srv, err := server.NewServer(vfsContext, &opt, verifier, uncachedClient, hostsView, mgr.GetEventRecorderFor("kops-controller"))
 		if err != nil {
 			setupLog.Error(err, "unable to create server")
 			os.Exit(1)
 		}
 		mgr.Add(srv)
+
+		issuedCertificatesCleaner, err := controllers.NewIssuedCertificatesCleaner(mgr)
+		if err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "IssuedCertificatesCleaner")
+			os.Exit(1)
+		}
+		if err := mgr.Add(issuedCertificatesCleaner); err != nil {
+			setupLog.Error(err, "unable to add controller", "controller", "IssuedCertificatesCleaner")
+			os.Exit(1)
+		}
 	}
 
 	if opt.EnableCloudIPAM {
@@ -231,7 +258,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := addGossipController(mgr, &opt); err != nil {
+	if err := addGossipController(mgr, &opt, hostsView); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GossipController")
 		os.Exit(1)
 	}
@@ -342,7 +369,7 @@ func addNodeController(ctx context.Context, mgr manager.Manager, vfsContext *vfs
 	return nil
 }
 
-func addGossipController(mgr manager.Manager, opt *config.Options) error {
+func addGossipController(mgr manager.Manager, opt *config.Options, hostsView *discovery.HostsView) error {
 	if opt.Discovery == nil || !opt.Discovery.Enabled {
 		return nil
 	}
@@ -361,6 +388,15 @@ func addGossipController(mgr manager.Manager, opt *config.Options) error {
 		return err
 	}
 
+	viewController, err := controllers.NewHostsViewReconciler(mgr, opt, hostsView)
+	if err != nil {
+		return err
+	}
+
+	if err := viewController.SetupWithManager(mgr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
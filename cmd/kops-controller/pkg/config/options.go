@@ -18,6 +18,7 @@ package config
 
 import (
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
+	"k8s.io/kops/pkg/bootstrap/tpmbootstrap"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
 	"k8s.io/kops/upup/pkg/fi/cloudup/do"
@@ -55,6 +56,9 @@ type ServerOptions struct {
 	// PKI configures private/public key node authentication.
 	PKI *pkibootstrap.Options `json:"pki,omitempty"`
 
+	// TPM configures TPM attestation of bare-metal hosts enrolled with a TPM identity.
+	TPM *tpmbootstrap.Options `json:"tpm,omitempty"`
+
 	// ServerKeyPath is the path to our TLS serving private key.
 	ServerKeyPath string `json:"serverKeyPath,omitempty"`
 	// ServerCertificatePath is the path to our TLS serving certificate.
@@ -66,6 +70,21 @@ type ServerOptions struct {
 	SigningCAs []string `json:"signingCAs"`
 	// CertNames is the list of active certificate names.
 	CertNames []string `json:"certNames"`
+
+	// AuditLogPath is the path of the append-only log to which bootstrap requests are recorded.
+	AuditLogPath string `json:"auditLogPath,omitempty"`
+	// Admission configures the rules a node must satisfy before we issue it certificates.
+	Admission *AdmissionOptions `json:"admission,omitempty"`
+}
+
+// AdmissionOptions are the rules evaluated against a verified node before we issue it certificates.
+type AdmissionOptions struct {
+	// MaxNodesPerInstanceGroup limits the number of nodes that can be registered in an instance group, keyed by instance group name.
+	MaxNodesPerInstanceGroup map[string]int `json:"maxNodesPerInstanceGroup,omitempty"`
+	// AllowedInstanceTypes restricts bootstrapping to instances of the listed machine types.
+	AllowedInstanceTypes []string `json:"allowedInstanceTypes,omitempty"`
+	// DeniedInstanceIDs lists the instances that are never allowed to bootstrap.
+	DeniedInstanceIDs []string `json:"deniedInstanceIDs,omitempty"`
 }
 
 type ServerProviderOptions struct {
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/nodeup"
+)
+
+// MaxWatchTimeout is the longest time a client can wait for the hosts to change.
+const MaxWatchTimeout = 5 * time.Minute
+
+// HostsView holds the internal hostnames of the cluster and their addresses, as served to the nodes.
+// It is the source of truth for discovery on clusters that do not publish DNS records.
+type HostsView struct {
+	mutex sync.Mutex
+
+	// hosts is nil until the view has been populated
+	hosts           []nodeup.DiscoveryHost
+	resourceVersion string
+
+	// changed is closed when the hosts change, to wake up watchers
+	changed chan struct{}
+}
+
+// NewHostsView is the constructor for a HostsView
+func NewHostsView() *HostsView {
+	return &HostsView{
+		changed: make(chan struct{}),
+	}
+}
+
+// Update replaces the hosts in the view, notifying watchers if they have changed.
+func (v *HostsView) Update(addrToHosts map[string][]string) error {
+	hostToAddrs := make(map[string][]string)
+	for addr, hosts := range addrToHosts {
+		for _, host := range hosts {
+			hostToAddrs[host] = append(hostToAddrs[host], addr)
+		}
+	}
+
+	hosts := []nodeup.DiscoveryHost{}
+	for host, addrs := range hostToAddrs {
+		sort.Strings(addrs)
+		hosts = append(hosts, nodeup.DiscoveryHost{Name: host, Addresses: addrs})
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Name < hosts[j].Name
+	})
+
+	// The resource version is derived from the content, so that all the kops-controller instances agree on it
+	b, err := json.Marshal(hosts)
+	if err != nil {
+		return fmt.Errorf("error serializing hosts: %w", err)
+	}
+	hash := sha256.Sum256(b)
+	resourceVersion := hex.EncodeToString(hash[:8])
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.hosts != nil && v.resourceVersion == resourceVersion {
+		return nil
+	}
+
+	klog.V(2).Infof("discovery hosts changed to version %s: %v", resourceVersion, hosts)
+	v.hosts = hosts
+	v.resourceVersion = resourceVersion
+	close(v.changed)
+	v.changed = make(chan struct{})
+
+	return nil
+}
+
+// get returns the current hosts, or nil if the view has not been populated,
+// along with a channel that is closed when the hosts next change.
+func (v *HostsView) get() (*nodeup.DiscoveryHostsResponse, <-chan struct{}) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.hosts == nil {
+		return nil, v.changed
+	}
+
+	response := &nodeup.DiscoveryHostsResponse{
+		APIVersion:      nodeup.DiscoveryAPIVersion,
+		ResourceVersion: v.resourceVersion,
+		Hosts:           v.hosts,
+	}
+	return response, v.changed
+}
+
+// Wait returns the hosts once their resource version differs from resourceVersion,
+// or the current hosts when the context is done.  It returns nil if the view has not been populated.
+func (v *HostsView) Wait(ctx context.Context, resourceVersion string) *nodeup.DiscoveryHostsResponse {
+	for {
+		response, changed := v.get()
+		if response != nil && response.ResourceVersion != resourceVersion {
+			return response
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return response
+		}
+	}
+}
+
+// NewHostsHandler serves the hosts in the view.
+// If the resourceVersion query parameter is set, the request waits for up to timeoutSeconds for the hosts to change.
+func NewHostsHandler(view *HostsView) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		ctx := r.Context()
+
+		resourceVersion := r.URL.Query().Get("resourceVersion")
+		if resourceVersion != "" {
+			timeout := time.Duration(0)
+			if s := r.URL.Query().Get("timeoutSeconds"); s != "" {
+				seconds, err := strconv.Atoi(s)
+				if err != nil || seconds < 0 {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte("invalid timeoutSeconds"))
+					return
+				}
+				timeout = time.Duration(seconds) * time.Second
+			}
+			if timeout > MaxWatchTimeout {
+				timeout = MaxWatchTimeout
+			}
+
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		} else {
+			// Don't wait for the hosts to change
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			cancel()
+		}
+
+		response := view.Wait(ctx, resourceVersion)
+		if response == nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("discovery hosts not yet available"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
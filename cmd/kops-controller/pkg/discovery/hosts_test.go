@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kops/pkg/apis/nodeup"
+)
+
+func getHosts(t *testing.T, server *httptest.Server, query string) (int, *nodeup.DiscoveryHostsResponse) {
+	t.Helper()
+
+	resp, err := http.Get(server.URL + nodeup.DiscoveryHostsPath + query)
+	if err != nil {
+		t.Fatalf("error querying hosts: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	response := &nodeup.DiscoveryHostsResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		t.Fatalf("error decoding hosts: %v", err)
+	}
+	return resp.StatusCode, response
+}
+
+func TestHostsHandler(t *testing.T) {
+	view := NewHostsView()
+	server := httptest.NewServer(NewHostsHandler(view))
+	defer server.Close()
+
+	if code, _ := getHosts(t, server, ""); code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d before the view is populated, got %d", http.StatusServiceUnavailable, code)
+	}
+
+	if err := view.Update(map[string][]string{
+		"10.0.0.2": {"api.internal.example.k8s.local", "kops-controller.internal.example.k8s.local"},
+		"10.0.0.1": {"api.internal.example.k8s.local"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating view: %v", err)
+	}
+
+	code, first := getHosts(t, server, "")
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	expected := []nodeup.DiscoveryHost{
+		{Name: "api.internal.example.k8s.local", Addresses: []string{"10.0.0.1", "10.0.0.2"}},
+		{Name: "kops-controller.internal.example.k8s.local", Addresses: []string{"10.0.0.2"}},
+	}
+	if !reflect.DeepEqual(first.Hosts, expected) {
+		t.Errorf("unexpected hosts %v, expected %v", first.Hosts, expected)
+	}
+	if first.APIVersion != nodeup.DiscoveryAPIVersion {
+		t.Errorf("unexpected apiVersion %q", first.APIVersion)
+	}
+
+	// A watch that sees no change returns the same version when it times out
+	if _, unchanged := getHosts(t, server, "?resourceVersion="+first.ResourceVersion+"&timeoutSeconds=1"); unchanged.ResourceVersion != first.ResourceVersion {
+		t.Errorf("unexpected resourceVersion %q after timeout, expected %q", unchanged.ResourceVersion, first.ResourceVersion)
+	}
+
+	// Updating with the same hosts does not change the version
+	if err := view.Update(map[string][]string{
+		"10.0.0.1": {"api.internal.example.k8s.local"},
+		"10.0.0.2": {"kops-controller.internal.example.k8s.local", "api.internal.example.k8s.local"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating view: %v", err)
+	}
+	if _, same := getHosts(t, server, ""); same.ResourceVersion != first.ResourceVersion {
+		t.Errorf("unexpected resourceVersion %q for the same hosts, expected %q", same.ResourceVersion, first.ResourceVersion)
+	}
+
+	// A watch returns as soon as the hosts change
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		if err := view.Update(map[string][]string{
+			"10.0.0.3": {"api.internal.example.k8s.local", "kops-controller.internal.example.k8s.local"},
+		}); err != nil {
+			t.Errorf("unexpected error updating view: %v", err)
+		}
+	}()
+	start := time.Now()
+	_, changed := getHosts(t, server, "?resourceVersion="+first.ResourceVersion+"&timeoutSeconds=60")
+	if time.Since(start) > 30*time.Second {
+		t.Errorf("watch did not return when the hosts changed")
+	}
+	if changed.ResourceVersion == first.ResourceVersion {
+		t.Errorf("expected resourceVersion to change")
+	}
+	expected = []nodeup.DiscoveryHost{
+		{Name: "api.internal.example.k8s.local", Addresses: []string{"10.0.0.3"}},
+		{Name: "kops-controller.internal.example.k8s.local", Addresses: []string{"10.0.0.3"}},
+	}
+	if !reflect.DeepEqual(changed.Hosts, expected) {
+		t.Errorf("unexpected hosts %v, expected %v", changed.Hosts, expected)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/bootstrap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// admissionError is returned when a node is not allowed to bootstrap.
+type admissionError struct {
+	reason string
+}
+
+func (e *admissionError) Error() string {
+	return e.reason
+}
+
+func denyf(format string, args ...interface{}) error {
+	return &admissionError{reason: fmt.Sprintf(format, args...)}
+}
+
+// admit evaluates the admission rules against a verified node.
+// It returns an admissionError if the node is not allowed to bootstrap,
+// or another error if the rules could not be evaluated.
+func admit(ctx context.Context, kubeClient client.Client, rules *config.AdmissionOptions, id *bootstrap.VerifyResult) error {
+	if rules == nil {
+		return nil
+	}
+
+	if id.InstanceID != "" && slices.Contains(rules.DeniedInstanceIDs, id.InstanceID) {
+		return denyf("instance %q is denied", id.InstanceID)
+	}
+
+	if len(rules.AllowedInstanceTypes) != 0 {
+		if id.InstanceType == "" {
+			return denyf("instance type of %q is not known", id.NodeName)
+		}
+		if !slices.Contains(rules.AllowedInstanceTypes, id.InstanceType) {
+			return denyf("instance type %q is not allowed", id.InstanceType)
+		}
+	}
+
+	if max, found := rules.MaxNodesPerInstanceGroup[id.InstanceGroupName]; found {
+		nodes := &corev1.NodeList{}
+		if err := kubeClient.List(ctx, nodes, client.MatchingLabels{kops.NodeLabelInstanceGroup: id.InstanceGroupName}); err != nil {
+			return fmt.Errorf("listing nodes in instance group %q: %w", id.InstanceGroupName, err)
+		}
+
+		// A node that is re-bootstrapping does not count against the limit.
+		// Nodes only appear here once they register, after they were issued certificates,
+		// so concurrent bootstraps can exceed the limit; serializing the requests would not prevent that.
+		count := 0
+		for _, node := range nodes.Items {
+			if node.Name != id.NodeName {
+				count++
+			}
+		}
+		if count >= max {
+			return denyf("instance group %q already has %d nodes, the maximum is %d", id.InstanceGroupName, count, max)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/bootstrap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeNodeClient is a client that can only list nodes.
+type fakeNodeClient struct {
+	client.Client
+	nodes []corev1.Node
+}
+
+func (c *fakeNodeClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+
+	nodeList := list.(*corev1.NodeList)
+	for _, node := range c.nodes {
+		if listOptions.LabelSelector != nil && !listOptions.LabelSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		nodeList.Items = append(nodeList.Items, node)
+	}
+	return nil
+}
+
+func buildNode(name string, instanceGroup string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{kops.NodeLabelInstanceGroup: instanceGroup},
+		},
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	kubeClient := &fakeNodeClient{
+		nodes: []corev1.Node{
+			buildNode("node-a", "nodes"),
+			buildNode("node-b", "nodes"),
+			buildNode("node-c", "other"),
+		},
+	}
+
+	rules := &config.AdmissionOptions{
+		MaxNodesPerInstanceGroup: map[string]int{"nodes": 2},
+		AllowedInstanceTypes:     []string{"t3.medium", "t3.large"},
+		DeniedInstanceIDs:        []string{"i-denied"},
+	}
+
+	grid := []struct {
+		name     string
+		rules    *config.AdmissionOptions
+		id       bootstrap.VerifyResult
+		expected string
+	}{
+		{
+			name: "no rules",
+			id:   bootstrap.VerifyResult{NodeName: "node-d", InstanceGroupName: "nodes"},
+		},
+		{
+			name:  "admitted",
+			rules: rules,
+			id:    bootstrap.VerifyResult{NodeName: "node-d", InstanceGroupName: "other", InstanceID: "i-d", InstanceType: "t3.medium"},
+		},
+		{
+			name:     "denied instance",
+			rules:    rules,
+			id:       bootstrap.VerifyResult{NodeName: "node-d", InstanceGroupName: "other", InstanceID: "i-denied", InstanceType: "t3.medium"},
+			expected: `instance "i-denied" is denied`,
+		},
+		{
+			name:     "instance type not allowed",
+			rules:    rules,
+			id:       bootstrap.VerifyResult{NodeName: "node-d", InstanceGroupName: "other", InstanceID: "i-d", InstanceType: "m5.xlarge"},
+			expected: `instance type "m5.xlarge" is not allowed`,
+		},
+		{
+			name:     "instance type unknown",
+			rules:    rules,
+			id:       bootstrap.VerifyResult{NodeName: "node-d", InstanceGroupName: "other", InstanceID: "i-d"},
+			expected: `instance type of "node-d" is not known`,
+		},
+		{
+			name:     "instance group full",
+			rules:    rules,
+			id:       bootstrap.VerifyResult{NodeName: "node-d", InstanceGroupName: "nodes", InstanceID: "i-d", InstanceType: "t3.large"},
+			expected: `instance group "nodes" already has 2 nodes, the maximum is 2`,
+		},
+		{
+			name:  "existing node rebootstrapping",
+			rules: rules,
+			id:    bootstrap.VerifyResult{NodeName: "node-b", InstanceGroupName: "nodes", InstanceID: "i-b", InstanceType: "t3.large"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			err := admit(context.Background(), kubeClient, g.rules, &g.id)
+			if g.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var admissionErr *admissionError
+			if !errors.As(err, &admissionErr) {
+				t.Fatalf("expected admission error %q, got %v", g.expected, err)
+			}
+			if err.Error() != g.expected {
+				t.Errorf("unexpected admission error: got %q, want %q", err.Error(), g.expected)
+			}
+		})
+	}
+}
+
+func TestAuditLogAppends(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "audit", "bootstrap.log")
+
+	for i, result := range []BootstrapResult{BootstrapResultIssued, BootstrapResultDenied} {
+		l, err := openAuditLog(p)
+		if err != nil {
+			t.Fatalf("opening audit log: %v", err)
+		}
+		s := &Server{auditLog: l}
+
+		record := &BootstrapAuditRecord{
+			RemoteAddr: "10.0.0.1:1234",
+			NodeName:   "node-a",
+			Certs:      []string{"kubelet"},
+			Result:     result,
+		}
+		if result == BootstrapResultDenied {
+			record.Reason = "denied"
+		}
+		s.audit(record)
+
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatalf("reading audit log: %v", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+		if len(lines) != i+1 {
+			t.Fatalf("expected %d lines in audit log, got %d", i+1, len(lines))
+		}
+
+		got := &BootstrapAuditRecord{}
+		if err := json.Unmarshal([]byte(lines[i]), got); err != nil {
+			t.Fatalf("parsing audit record: %v", err)
+		}
+		if got.NodeName != "node-a" || got.Result != result || got.Reason != record.Reason || got.Timestamp.IsZero() {
+			t.Errorf("unexpected audit record %+v", got)
+		}
+	}
+}
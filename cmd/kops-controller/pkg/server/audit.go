@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/bootstrap"
+)
+
+// BootstrapResult is the outcome of a bootstrap request.
+type BootstrapResult string
+
+const (
+	// BootstrapResultIssued means that certificates were issued to the node.
+	BootstrapResultIssued BootstrapResult = "Issued"
+	// BootstrapResultDenied means that the node was not allowed to bootstrap.
+	BootstrapResultDenied BootstrapResult = "Denied"
+	// BootstrapResultFailed means that the request could not be processed.
+	BootstrapResultFailed BootstrapResult = "Failed"
+)
+
+// BootstrapAuditRecord is an entry in the bootstrap audit log.
+type BootstrapAuditRecord struct {
+	// Timestamp is the time the request was completed.
+	Timestamp time.Time `json:"timestamp"`
+	// RemoteAddr is the network address the request came from.
+	RemoteAddr string `json:"remoteAddr"`
+	// NodeName is the verified name of the node, empty if the node could not be identified.
+	NodeName string `json:"nodeName,omitempty"`
+	// InstanceGroup is the instance group of the node.
+	InstanceGroup string `json:"instanceGroup,omitempty"`
+	// InstanceID is the cloud identifier of the node.
+	InstanceID string `json:"instanceID,omitempty"`
+	// InstanceType is the machine type of the node.
+	InstanceType string `json:"instanceType,omitempty"`
+	// Certs is the names of the certificates the node requested.
+	Certs []string `json:"certs,omitempty"`
+	// Result is the outcome of the request.
+	Result BootstrapResult `json:"result"`
+	// Reason explains why the request was denied or failed.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (r *BootstrapAuditRecord) setIdentity(id *bootstrap.VerifyResult) {
+	r.NodeName = id.NodeName
+	r.InstanceGroup = id.InstanceGroupName
+	r.InstanceID = id.InstanceID
+	r.InstanceType = id.InstanceType
+}
+
+func (r *BootstrapAuditRecord) setCerts(certs map[string]string) {
+	r.Certs = nil
+	for name := range certs {
+		r.Certs = append(r.Certs, name)
+	}
+	sort.Strings(r.Certs)
+}
+
+// deny records that the request was refused.
+func (r *BootstrapAuditRecord) deny(reason string) {
+	r.Result = BootstrapResultDenied
+	r.Reason = reason
+}
+
+// fail records that the request could not be processed.
+func (r *BootstrapAuditRecord) fail(reason string) {
+	r.Result = BootstrapResultFailed
+	r.Reason = reason
+}
+
+// auditLog appends bootstrap records to a file, one JSON object per line.
+type auditLog struct {
+	mutex sync.Mutex
+	f     *os.File
+}
+
+// openAuditLog opens the audit log at p for appending, creating it if needed.
+func openAuditLog(p string) (*auditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return nil, fmt.Errorf("creating directory for audit log %q: %w", p, err)
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log %q: %w", p, err)
+	}
+	return &auditLog{f: f}, nil
+}
+
+func (l *auditLog) write(record *BootstrapAuditRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	_, err = l.f.Write(b)
+	return err
+}
+
+// audit writes the record to the audit log, and posts it as an event on the node if the node was identified.
+func (s *Server) audit(record *BootstrapAuditRecord) {
+	record.Timestamp = time.Now().UTC()
+
+	if s.auditLog != nil {
+		if err := s.auditLog.write(record); err != nil {
+			klog.Warningf("failed to write bootstrap audit record: %v", err)
+		}
+	}
+
+	if s.recorder == nil || record.NodeName == "" {
+		return
+	}
+
+	// Nodes are referenced by name, with the name used as the UID, matching the kubelet.
+	ref := &corev1.ObjectReference{
+		Kind: "Node",
+		Name: record.NodeName,
+		UID:  types.UID(record.NodeName),
+	}
+
+	message := fmt.Sprintf("instance group %q, instance %q (%s), certificates [%s]", record.InstanceGroup, record.InstanceID, record.InstanceType, strings.Join(record.Certs, ", "))
+	switch record.Result {
+	case BootstrapResultIssued:
+		s.recorder.Eventf(ref, corev1.EventTypeNormal, "BootstrapIssued", "Issued certificates to %s: %s", record.RemoteAddr, message)
+	case BootstrapResultDenied:
+		s.recorder.Eventf(ref, corev1.EventTypeWarning, "BootstrapDenied", "Denied bootstrap from %s: %s: %s", record.RemoteAddr, record.Reason, message)
+	default:
+		s.recorder.Eventf(ref, corev1.EventTypeWarning, "BootstrapFailed", "Failed bootstrap from %s: %s: %s", record.RemoteAddr, record.Reason, message)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// revocationListPathPrefix is the URL path under which kops-controller serves the certificate revocation lists.
+const revocationListPathPrefix = "/crl/"
+
+// recordIssuedCertificates records the serial numbers of the certificates issued to a node,
+// so that they can be revoked with "kops revoke certificate".
+func recordIssuedCertificates(ctx context.Context, kubeClient client.Client, nodeName string, issued map[string]*bootstrap.IssuedCertificate, now time.Time) error {
+	key := types.NamespacedName{
+		Namespace: bootstrap.IssuedCertificatesNamespace,
+		Name:      bootstrap.IssuedCertificatesConfigMapName(nodeName),
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		create := false
+		if err := kubeClient.Get(ctx, key, configMap); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("getting configmap %v: %w", key, err)
+			}
+			create = true
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: key.Namespace,
+					Name:      key.Name,
+				},
+			}
+		}
+
+		data := configMap.Data
+		for serial, cert := range issued {
+			var err error
+			data, err = bootstrap.AddIssuedCertificate(data, serial, cert, now)
+			if err != nil {
+				return fmt.Errorf("updating configmap %v: %w", key, err)
+			}
+		}
+		configMap.Data = data
+
+		if create {
+			if err := kubeClient.Create(ctx, configMap); err != nil {
+				if apierrors.IsAlreadyExists(err) {
+					// Raced with another request for the same node; retry as an update.
+					return apierrors.NewConflict(corev1.Resource("configmaps"), key.Name, err)
+				}
+				return fmt.Errorf("creating configmap %v: %w", key, err)
+			}
+			return nil
+		}
+		if err := kubeClient.Update(ctx, configMap); err != nil {
+			if apierrors.IsConflict(err) {
+				return err
+			}
+			return fmt.Errorf("updating configmap %v: %w", key, err)
+		}
+		return nil
+	})
+}
+
+// revocationListHandler serves the certificate revocation lists published in the state store, in DER form.
+type revocationListHandler struct {
+	configBase vfs.Path
+	// signers is the set of keysets for which revocation lists may be served.
+	signers sets.Set[string]
+}
+
+func (h *revocationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	signer, keypairID, found := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, revocationListPathPrefix), ".crl"), "/")
+	if !found || !h.signers.Has(signer) || !isKeypairID(keypairID) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	data, err := h.configBase.Join(pki.RevocationListPath(signer, keypairID)).ReadFile(r.Context())
+	if err != nil {
+		if os.IsNotExist(err) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		klog.Warningf("reading revocation list for %q: %v", signer, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	crl, err := pki.ParsePEMRevocationList(data)
+	if err != nil {
+		klog.Warningf("parsing revocation list for %q: %v", signer, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pkix-crl")
+	_, _ = w.Write(crl.Raw)
+}
+
+// isKeypairID returns true if the string could be the ID of a keypair, which is usually a decimal number.
+func isKeypairID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeConfigMapClient is a client that stores configmaps in memory.
+type fakeConfigMapClient struct {
+	client.Client
+	configMaps map[client.ObjectKey]*corev1.ConfigMap
+}
+
+func (c *fakeConfigMapClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	configMap, found := c.configMaps[key]
+	if !found {
+		return apierrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+	}
+	configMap.DeepCopyInto(obj.(*corev1.ConfigMap))
+	return nil
+}
+
+func (c *fakeConfigMapClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	key := client.ObjectKeyFromObject(obj)
+	if _, found := c.configMaps[key]; found {
+		return apierrors.NewAlreadyExists(corev1.Resource("configmaps"), key.Name)
+	}
+	c.configMaps[key] = obj.(*corev1.ConfigMap).DeepCopy()
+	return nil
+}
+
+func (c *fakeConfigMapClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	key := client.ObjectKeyFromObject(obj)
+	if _, found := c.configMaps[key]; !found {
+		return apierrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+	}
+	c.configMaps[key] = obj.(*corev1.ConfigMap).DeepCopy()
+	return nil
+}
+
+func TestRecordIssuedCertificates(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := &fakeConfigMapClient{configMaps: map[client.ObjectKey]*corev1.ConfigMap{}}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	err := recordIssuedCertificates(ctx, kubeClient, "node-a", map[string]*bootstrap.IssuedCertificate{
+		"1": {Name: "kubelet", Signer: "kubernetes-ca", NotAfter: now.Add(time.Hour)},
+		"2": {Name: "kube-proxy", Signer: "kubernetes-ca", NotAfter: now.Add(time.Hour)},
+	}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	later := now.Add(2 * time.Hour)
+	err = recordIssuedCertificates(ctx, kubeClient, "node-a", map[string]*bootstrap.IssuedCertificate{
+		"3": {Name: "kubelet", Signer: "kubernetes-ca", NotAfter: later.Add(time.Hour)},
+	}, later)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key := client.ObjectKey{Namespace: "kops-system", Name: "kops-issued-certificates.node-a"}
+	configMap, found := kubeClient.configMaps[key]
+	if !found {
+		t.Fatalf("configmap %v was not created", key)
+	}
+
+	issued, err := bootstrap.ParseIssuedCertificates(configMap.Data)
+	if err != nil {
+		t.Fatalf("unexpected error parsing configmap: %v", err)
+	}
+	if len(issued) != 1 {
+		t.Fatalf("expected expired certificates to be removed, got %v", configMap.Data)
+	}
+	if cert := issued["3"]; cert == nil || cert.Name != "kubelet" || cert.Signer != "kubernetes-ca" {
+		t.Errorf("unexpected record for serial 3: %+v", cert)
+	}
+}
+
+func TestRevocationListHandler(t *testing.T) {
+	ctx := context.Background()
+	configBase := vfs.NewMemFSPath(vfs.NewMemFSContext(), "cluster")
+	caKey, err := pki.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("generating private key: %v", err)
+	}
+	ca, _, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+		Signer:     "kubernetes-ca",
+		Type:       "ca",
+		Subject:    pkix.Name{CommonName: "kubernetes-ca"},
+		PrivateKey: caKey,
+	}, nil)
+	if err != nil {
+		t.Fatalf("issuing CA certificate: %v", err)
+	}
+	_, crl, err := pki.RevokeCertificates(ca, caKey, nil, []*pki.RevokedCertificate{{SerialNumber: big.NewInt(42)}}, time.Now())
+	if err != nil {
+		t.Fatalf("building revocation list: %v", err)
+	}
+	if err := configBase.Join("pki/crl/kubernetes-ca/1234.crl").WriteFile(ctx, bytes.NewReader(crl), nil); err != nil {
+		t.Fatalf("writing revocation list: %v", err)
+	}
+
+	handler := &revocationListHandler{
+		configBase: configBase,
+		signers:    sets.New("kubernetes-ca"),
+	}
+
+	for path, expected := range map[string]int{
+		"/crl/kubernetes-ca/1234.crl":       http.StatusOK,
+		"/crl/kubernetes-ca/5678.crl":       http.StatusNotFound,
+		"/crl/kubernetes-ca.crl":            http.StatusNotFound,
+		"/crl/other-ca/1234.crl":            http.StatusNotFound,
+		"/crl/kubernetes-ca/../../keys.crl": http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != expected {
+			t.Errorf("%s: expected status %d, got %d", path, expected, w.Code)
+		}
+	}
+}
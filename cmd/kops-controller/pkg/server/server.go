@@ -23,6 +23,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"io"
@@ -31,11 +32,13 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/cmd/kops-controller/pkg/discovery"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/nodeup"
@@ -66,11 +69,19 @@ type Server struct {
 
 	// challengeClient performs our callback-challenge into the node
 	challengeClient *bootstrap.ChallengeClient
+
+	// recorder posts events recording bootstrap requests on the nodes
+	recorder record.EventRecorder
+
+	// auditLog is the append-only log of bootstrap requests, if configured
+	auditLog *auditLog
 }
 
 var _ manager.LeaderElectionRunnable = &Server{}
 
-func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstrap.Verifier, uncachedClient client.Client) (*Server, error) {
+// NewServer builds the kops-controller server.  If hostsView is not nil, the server also serves the hosts for discovery.
+// Bootstrap requests are recorded as events using recorder, if not nil.
+func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstrap.Verifier, uncachedClient client.Client, hostsView *discovery.HostsView, recorder record.EventRecorder) (*Server, error) {
 	server := &http.Server{
 		Addr: opt.Server.Listen,
 		TLSConfig: &tls.Config{
@@ -84,6 +95,7 @@ func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstr
 		server:         server,
 		verifier:       verifier,
 		uncachedClient: uncachedClient,
+		recorder:       recorder,
 	}
 
 	configBase, err := vfsContext.BuildVfsPath(opt.ConfigBase)
@@ -109,8 +121,26 @@ func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstr
 	}
 	s.challengeClient = challengeClient
 
+	if opt.Server.AuditLogPath != "" {
+		// Control plane nodes provisioned by older versions of nodeup may not have a writable log directory;
+		// we still record bootstrap requests as events in that case.
+		auditLog, err := openAuditLog(opt.Server.AuditLogPath)
+		if err != nil {
+			klog.Errorf("bootstrap audit log disabled: %v", err)
+		} else {
+			s.auditLog = auditLog
+		}
+	}
+
 	r := http.NewServeMux()
 	r.Handle("/bootstrap", http.HandlerFunc(s.bootstrap))
+	r.Handle(revocationListPathPrefix, &revocationListHandler{
+		configBase: configBase,
+		signers:    sets.New(opt.Server.SigningCAs...),
+	})
+	if hostsView != nil {
+		r.Handle(nodeup.DiscoveryHostsPath, discovery.NewHostsHandler(hostsView))
+	}
 	server.Handler = recovery(r)
 
 	return s, nil
@@ -141,8 +171,15 @@ func (s *Server) Start(ctx context.Context) error {
 }
 
 func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
+	audit := &BootstrapAuditRecord{
+		RemoteAddr: r.RemoteAddr,
+		Result:     BootstrapResultIssued,
+	}
+	defer s.audit(audit)
+
 	if r.Body == nil {
 		klog.Infof("bootstrap %s no body", r.RemoteAddr)
+		audit.fail("no body")
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -150,6 +187,7 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		klog.Infof("bootstrap %s read err: %v", r.RemoteAddr, err)
+		audit.fail("failed to read body")
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(fmt.Sprintf("bootstrap %s failed to read body: %v", r.RemoteAddr, err)))
 		return
@@ -161,16 +199,19 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		// means that we should exit nodeup gracefully
 		if err == bootstrap.ErrAlreadyExists {
+			audit.deny("node already exists")
 			w.WriteHeader(http.StatusConflict)
 			klog.Infof("%s: %v", r.RemoteAddr, err)
 			return
 		}
 		klog.Infof("bootstrap %s verify err: %v", r.RemoteAddr, err)
+		audit.deny(fmt.Sprintf("failed to verify token: %v", err))
 		w.WriteHeader(http.StatusForbidden)
 		// don't return the error; this allows us to have richer errors without security implications
 		_, _ = w.Write([]byte("failed to verify token"))
 		return
 	}
+	audit.setIdentity(id)
 
 	// Once the node is registered, we don't allow further registrations, this protects against a pod or escaped workload attempting to impersonate the node.
 	{
@@ -180,14 +221,16 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 			for _, condition := range node.Status.Conditions {
 				if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
 					klog.Infof("bootstrap %s node %q already exists; denying to avoid node-impersonation attacks", r.RemoteAddr, id.NodeName)
+					audit.deny("node already registered")
 					w.WriteHeader(http.StatusConflict)
 					_, _ = w.Write([]byte("node already registered"))
 					return
 				}
 			}
 		}
-		if err != nil && !errors.IsNotFound(err) {
+		if err != nil && !apierrors.IsNotFound(err) {
 			klog.Infof("bootstrap %s error querying for node %q: %v", r.RemoteAddr, id.NodeName, err)
+			audit.fail(fmt.Sprintf("error querying for node: %v", err))
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte("internal error"))
 			return
@@ -197,6 +240,7 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 	req := &nodeup.BootstrapRequest{}
 	if err := json.Unmarshal(body, req); err != nil {
 		klog.Infof("bootstrap %s decode err: %v", r.RemoteAddr, err)
+		audit.fail(fmt.Sprintf("failed to decode: %v", err))
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(fmt.Sprintf("failed to decode: %v", err)))
 		return
@@ -204,14 +248,18 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 
 	if req.APIVersion != nodeup.BootstrapAPIVersion {
 		klog.Infof("bootstrap %s wrong APIVersion", r.RemoteAddr)
+		audit.fail(fmt.Sprintf("unexpected APIVersion %q", req.APIVersion))
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte("unexpected APIVersion"))
 		return
 	}
 
+	audit.setCerts(req.Certs)
+
 	if model.UseChallengeCallback(kops.CloudProviderID(s.opt.Cloud)) {
 		if err := s.challengeClient.DoCallbackChallenge(ctx, s.opt.ClusterName, id.ChallengeEndpoint, req); err != nil {
 			klog.Infof("bootstrap %s callback challenge failed: %v", r.RemoteAddr, err)
+			audit.deny(fmt.Sprintf("callback challenge failed: %v", err))
 			w.WriteHeader(http.StatusBadRequest)
 			_, _ = w.Write([]byte("callback failed"))
 			return
@@ -229,6 +277,7 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 		nodeConfig, err := s.getNodeConfig(r.Context(), req, id)
 		if err != nil {
 			klog.Infof("bootstrap failed to build node config: %v", err)
+			audit.fail(fmt.Sprintf("failed to build node config: %v", err))
 			w.WriteHeader(http.StatusBadRequest)
 			_, _ = w.Write([]byte("failed to build node config"))
 			return
@@ -243,15 +292,56 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 	_, _ = hash.Write([]byte(r.RemoteAddr))
 	validHours := (455 * 24) + (hash.Sum32() % (30 * 24))
 
+	if err := admit(ctx, s.uncachedClient, s.opt.Server.Admission, id); err != nil {
+		var admissionErr *admissionError
+		if errors.As(err, &admissionErr) {
+			klog.Infof("bootstrap %s node %q not admitted: %v", r.RemoteAddr, id.NodeName, err)
+			audit.deny(err.Error())
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("node not admitted"))
+			return
+		}
+		klog.Infof("bootstrap %s admission err: %v", r.RemoteAddr, err)
+		audit.fail(fmt.Sprintf("evaluating admission rules: %v", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("internal error"))
+		return
+	}
+
+	issued := make(map[string]*bootstrap.IssuedCertificate)
 	for name, pubKey := range req.Certs {
-		cert, err := s.issueCert(ctx, name, pubKey, id, validHours, req.KeypairIDs)
+		cert, signer, err := s.issueCert(ctx, name, pubKey, id, validHours, req.KeypairIDs)
 		if err != nil {
 			klog.Infof("bootstrap %s cert %q issue err: %v", r.RemoteAddr, name, err)
+			audit.fail(fmt.Sprintf("failed to issue %q: %v", name, err))
 			w.WriteHeader(http.StatusBadRequest)
 			_, _ = w.Write([]byte(fmt.Sprintf("failed to issue %q: %v", name, err)))
 			return
 		}
-		resp.Certs[name] = cert
+		certString, err := cert.AsString()
+		if err != nil {
+			klog.Infof("bootstrap %s cert %q encode err: %v", r.RemoteAddr, name, err)
+			audit.fail(fmt.Sprintf("failed to encode %q: %v", name, err))
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("internal error"))
+			return
+		}
+		resp.Certs[name] = certString
+		issued[cert.Certificate.SerialNumber.String()] = &bootstrap.IssuedCertificate{
+			Name:      name,
+			Signer:    signer,
+			KeypairID: s.keypairIDs[signer],
+			NotAfter:  cert.Certificate.NotAfter,
+		}
+	}
+
+	// We don't hand out certificates we can't later revoke.
+	if err := recordIssuedCertificates(ctx, s.uncachedClient, id.NodeName, issued, time.Now()); err != nil {
+		klog.Infof("bootstrap %s failed to record issued certificates: %v", r.RemoteAddr, err)
+		audit.fail(fmt.Sprintf("failed to record issued certificates: %v", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("internal error"))
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -259,14 +349,15 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 	klog.Infof("bootstrap %s %s success", r.RemoteAddr, id.NodeName)
 }
 
-func (s *Server) issueCert(ctx context.Context, name string, pubKey string, id *bootstrap.VerifyResult, validHours uint32, keypairIDs map[string]string) (string, error) {
+// issueCert issues the named certificate for the node, returning it along with the name of the keyset that signed it.
+func (s *Server) issueCert(ctx context.Context, name string, pubKey string, id *bootstrap.VerifyResult, validHours uint32, keypairIDs map[string]string) (*pki.Certificate, string, error) {
 	block, _ := pem.Decode([]byte(pubKey))
 	if block.Type != "RSA PUBLIC KEY" {
-		return "", fmt.Errorf("unexpected key type %q", block.Type)
+		return nil, "", fmt.Errorf("unexpected key type %q", block.Type)
 	}
 	key, err := x509.ParsePKIXPublicKey(block.Bytes)
 	if err != nil {
-		return "", fmt.Errorf("parsing key: %v", err)
+		return nil, "", fmt.Errorf("parsing key: %v", err)
 	}
 
 	issueReq := &pki.IssueCertRequest{
@@ -277,7 +368,7 @@ func (s *Server) issueCert(ctx context.Context, name string, pubKey string, id *
 	}
 
 	if !s.certNames.Has(name) {
-		return "", fmt.Errorf("key name not enabled")
+		return nil, "", fmt.Errorf("key name not enabled")
 	}
 	switch name {
 	case "etcd-client-cilium":
@@ -305,22 +396,22 @@ func (s *Server) issueCert(ctx context.Context, name string, pubKey string, id *
 			CommonName: rbac.KubeRouter,
 		}
 	default:
-		return "", fmt.Errorf("unexpected key name")
+		return nil, "", fmt.Errorf("unexpected key name")
 	}
 
 	// This field was added to the protocol in kOps 1.22.
 	if len(keypairIDs) > 0 {
 		if keypairIDs[issueReq.Signer] != s.keypairIDs[issueReq.Signer] {
-			return "", fmt.Errorf("request's keypair ID %q for %s didn't match server's %q", keypairIDs[issueReq.Signer], issueReq.Signer, s.keypairIDs[issueReq.Signer])
+			return nil, "", fmt.Errorf("request's keypair ID %q for %s didn't match server's %q", keypairIDs[issueReq.Signer], issueReq.Signer, s.keypairIDs[issueReq.Signer])
 		}
 	}
 
 	cert, _, _, err := pki.IssueCert(ctx, issueReq, s.keystore)
 	if err != nil {
-		return "", fmt.Errorf("issuing certificate: %v", err)
+		return nil, "", fmt.Errorf("issuing certificate: %v", err)
 	}
 
-	return cert.AsString()
+	return cert, issueReq.Signer, nil
 }
 
 // recovery is responsible for ensuring we don't exit on a panic.
@@ -319,6 +319,10 @@ func NewCmdCreateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 
 	cmd.Flags().StringVar(&options.DNSZone, "dns-zone", options.DNSZone, "DNS hosted zone (defaults to longest matching zone)")
 	cmd.RegisterFlagCompletionFunc("dns-zone", completeDNSZone(options))
+	cmd.Flags().StringVar(&options.DNSProvider, "dns-provider", options.DNSProvider, "DNS provider that manages the DNS hosted zone, if not the DNS service of the cloud provider: rfc2136, powerdns")
+	cmd.RegisterFlagCompletionFunc("dns-provider", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"rfc2136", "powerdns"}, cobra.ShellCompDirectiveNoFileComp
+	})
 	cmd.Flags().StringVar(&options.OutDir, "out", options.OutDir, "Path to write any local output")
 	cmd.MarkFlagDirname("out")
 	cmd.Flags().StringSliceVar(&options.AdminAccess, "admin-access", options.AdminAccess, "Restrict API access to this CIDR.  If not set, access will not be restricted by IP.")
@@ -612,6 +616,10 @@ func RunCreateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Cr
 		cluster.Spec.DNSZone = c.DNSZone
 	}
 
+	if c.DNSProvider != "" {
+		cluster.Spec.DNSProvider = c.DNSProvider
+	}
+
 	for i, cidr := range c.NetworkCIDRs {
 		if i == 0 {
 			cluster.Spec.Networking.NetworkCIDR = cidr
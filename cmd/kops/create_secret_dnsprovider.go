@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	createSecretDNSProviderLong = templates.LongDesc(i18n.T(`
+	Create the credentials of the DNS provider of the cluster and store them in the state store.
+	kOps uses them to manage the DNS records it creates, and passes them to dns-controller as environment variables.`))
+
+	createSecretDNSProviderExample = templates.Examples(i18n.T(`
+	# Create the credentials of an RFC 2136 DNS server.
+	kops create secret dnsprovider -f /path/to/credentials.yaml \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Create the credentials of a PowerDNS server via stdin.
+	cat <<EOF | kops create secret dnsprovider --name k8s-cluster.example.com --state s3://my-state-store -f -
+	PDNS_API_URL: http://pdns.example.com:8081
+	PDNS_API_KEY: secret
+	EOF
+
+	# Replace the existing credentials of the DNS provider
+	kops create secret dnsprovider -f /path/to/credentials.yaml --force \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	`))
+
+	createSecretDNSProviderShort = i18n.T(`Create the credentials of the DNS provider.`)
+)
+
+type CreateSecretDNSProviderOptions struct {
+	ClusterName         string
+	CredentialsFilePath string
+	Force               bool
+}
+
+func NewCmdCreateSecretDNSProvider(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateSecretDNSProviderOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "dnsprovider [CLUSTER] -f FILENAME",
+		Short:             createSecretDNSProviderShort,
+		Long:              createSecretDNSProviderLong,
+		Example:           createSecretDNSProviderExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateSecretDNSProvider(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.CredentialsFilePath, "filename", "f", "", "Path to the file with the environment variables configuring the DNS provider")
+	cmd.MarkFlagRequired("filename")
+	cmd.RegisterFlagCompletionFunc("filename", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"yaml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force replace the secret if it already exists")
+
+	return cmd
+}
+
+func RunCreateSecretDNSProvider(ctx context.Context, f commandutils.Factory, out io.Writer, options *CreateSecretDNSProviderOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if options.CredentialsFilePath == "-" {
+		data, err = ConsumeStdin()
+		if err != nil {
+			return fmt.Errorf("reading DNS provider credentials from stdin: %v", err)
+		}
+	} else {
+		data, err = os.ReadFile(options.CredentialsFilePath)
+		if err != nil {
+			return fmt.Errorf("reading DNS provider credentials %v: %v", options.CredentialsFilePath, err)
+		}
+	}
+
+	var parsedData map[string]string
+	err = kops.ParseRawYaml(data, &parsedData)
+	if err != nil {
+		return fmt.Errorf("unable to parse YAML %v: %v", options.CredentialsFilePath, err)
+	}
+	if len(parsedData) == 0 {
+		return fmt.Errorf("no DNS provider credentials found in %v", options.CredentialsFilePath)
+	}
+
+	secret := &fi.Secret{
+		Data: data,
+	}
+
+	if !options.Force {
+		_, created, err := secretStore.GetOrCreateSecret(ctx, cloudup.DNSProviderSecretName, secret)
+		if err != nil {
+			return fmt.Errorf("error adding DNS provider secret: %v", err)
+		}
+		if !created {
+			return fmt.Errorf("failed to create the DNS provider secret as it already exists. Pass the `--force` flag to replace an existing secret")
+		}
+	} else {
+		_, err := secretStore.ReplaceSecret(cloudup.DNSProviderSecretName, secret)
+		if err != nil {
+			return fmt.Errorf("updating DNS provider secret: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -70,6 +70,9 @@ type DeleteInstanceOptions struct {
 	InstanceID string
 
 	Surge bool
+
+	// RevokeCertificates revokes the certificates kops-controller issued to the node before deleting it.
+	RevokeCertificates bool
 }
 
 func (o *DeleteInstanceOptions) initDefaults() {
@@ -85,6 +88,7 @@ func (o *DeleteInstanceOptions) initDefaults() {
 	o.ValidateCount = d.ValidateCount
 
 	o.Surge = true
+	o.RevokeCertificates = true
 }
 
 func NewCmdDeleteInstance(f *util.Factory, out io.Writer) *cobra.Command {
@@ -139,6 +143,7 @@ func NewCmdDeleteInstance(f *util.Factory, out io.Writer) *cobra.Command {
 
 	cmd.Flags().BoolVar(&options.CloudOnly, "cloudonly", options.CloudOnly, "Perform deletion update without confirming progress with Kubernetes")
 	cmd.Flags().BoolVar(&options.Surge, "surge", options.Surge, "Surge by detaching the node from the ASG before deletion")
+	cmd.Flags().BoolVar(&options.RevokeCertificates, "revoke-certificates", options.RevokeCertificates, "Revoke the certificates kops-controller issued to the node before deleting it")
 
 	cmd.Flags().DurationVar(&options.ValidationTimeout, "validation-timeout", options.ValidationTimeout, "Maximum time to wait for a cluster to validate")
 	cmd.Flags().Int32Var(&options.ValidateCount, "validate-count", options.ValidateCount, "Number of times that a cluster needs to be validated after single node update")
@@ -216,6 +221,17 @@ func RunDeleteInstance(ctx context.Context, f *util.Factory, out io.Writer, opti
 		return nil
 	}
 
+	// Revocation needs the kube API to find the certificates issued to the node, so it is skipped with --cloudonly.
+	if options.RevokeCertificates && !options.CloudOnly {
+		revoked, err := revokeNodeCertificates(ctx, out, cluster, clientSet, k8sClient, cloudMember.Node.Name)
+		if err != nil {
+			return fmt.Errorf("revoking certificates of node %q (use --revoke-certificates=false to skip): %v", cloudMember.Node.Name, err)
+		}
+		if !revoked {
+			fmt.Fprintf(out, "No certificates issued by kops-controller were recorded for node %s\n", cloudMember.Node.Name)
+		}
+	}
+
 	d := &instancegroups.RollingUpdateCluster{
 		Clientset:         clientSet,
 		Cluster:           cluster,
@@ -251,22 +267,31 @@ func RunDeleteInstance(ctx context.Context, f *util.Factory, out io.Writer, opti
 	return d.UpdateSingleInstance(cloudMember, options.Surge)
 }
 
-func getNodes(ctx context.Context, cluster *kopsapi.Cluster, verbose bool) (kubernetes.Interface, string, []v1.Node, error) {
-	var nodes []v1.Node
-	var k8sClient kubernetes.Interface
-
+// getKubeClient builds a kube client for the cluster from the kubecfg context named after the cluster.
+func getKubeClient(cluster *kopsapi.Cluster) (kubernetes.Interface, string, error) {
 	contextName := cluster.ObjectMeta.Name
 	clientGetter := genericclioptions.NewConfigFlags(true)
 	clientGetter.Context = &contextName
 
 	config, err := clientGetter.ToRESTConfig()
 	if err != nil {
-		return nil, "", nil, fmt.Errorf("cannot load kubecfg settings for %q: %v", contextName, err)
+		return nil, "", fmt.Errorf("cannot load kubecfg settings for %q: %v", contextName, err)
 	}
 
-	k8sClient, err = kubernetes.NewForConfig(config)
+	k8sClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot build kube client for %q: %v", contextName, err)
+	}
+
+	return k8sClient, config.Host, nil
+}
+
+func getNodes(ctx context.Context, cluster *kopsapi.Cluster, verbose bool) (kubernetes.Interface, string, []v1.Node, error) {
+	var nodes []v1.Node
+
+	k8sClient, host, err := getKubeClient(cluster)
 	if err != nil {
-		return nil, "", nil, fmt.Errorf("cannot build kube client for %q: %v", contextName, err)
+		return nil, "", nil, err
 	}
 
 	nodeList, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
@@ -281,7 +306,7 @@ func getNodes(ctx context.Context, cluster *kopsapi.Cluster, verbose bool) (kube
 	if nodeList != nil {
 		nodes = nodeList.Items
 	}
-	return k8sClient, config.Host, nodes, nil
+	return k8sClient, host, nodes, nil
 }
 
 func deleteNodeMatch(cloudMember *cloudinstances.CloudInstance, options *DeleteInstanceOptions) bool {
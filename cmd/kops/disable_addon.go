@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type DisableAddonOptions struct {
+	ClusterName string
+	AddonName   string
+}
+
+var (
+	disableAddonLong = pretty.LongDesc(i18n.T(`Disable an optional addon.
+
+	This command disables the addon in the desired cluster configuration in the registry,
+	keeping the rest of its configuration, and validates the result.
+
+	kops disable does not update the cloud resources; to apply the changes use ` + pretty.Bash("kops update cluster") + `.`))
+
+	disableAddonExample = templates.Examples(i18n.T(`
+	# Disable metrics-server.
+	kops disable addon metrics-server.addons.k8s.io --name k8s-cluster.example.com
+	`))
+
+	disableAddonShort = i18n.T(`Disable an optional addon.`)
+)
+
+func NewCmdDisableAddon(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &DisableAddonOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "addon ADDON",
+		Short:   disableAddonShort,
+		Long:    disableAddonLong,
+		Example: disableAddonExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 1 {
+				return fmt.Errorf("must specify the name of one addon to disable")
+			}
+			options.AddonName = args[0]
+
+			return nil
+		},
+		ValidArgsFunction: completeOptionalAddon,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunDisableAddon(cmd.Context(), f, out, options)
+		},
+	}
+
+	return cmd
+}
+
+func RunDisableAddon(ctx context.Context, f *util.Factory, out io.Writer, options *DisableAddonOptions) error {
+	return updateAddon(ctx, f, out, options.ClusterName, func(cluster *api.Cluster) error {
+		return commands.DisableAddon(cluster, options.AddonName)
+	})
+}
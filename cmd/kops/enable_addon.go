@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type EnableAddonOptions struct {
+	ClusterName string
+	AddonName   string
+
+	// Sets allows setting values in the configuration of the addon.
+	Sets []string
+	// Unsets allows unsetting values in the configuration of the addon.
+	Unsets []string
+}
+
+var (
+	enableAddonLong = pretty.LongDesc(i18n.T(`Enable an optional addon.
+
+	This command enables the addon in the desired cluster configuration in the registry,
+	optionally setting values in its configuration, and validates the result.
+	Use ` + pretty.Bash("kops get addon-catalog") + ` to list the optional addons.
+
+	kops enable does not update the cloud resources; to apply the changes use ` + pretty.Bash("kops update cluster") + `.`))
+
+	enableAddonExample = templates.Examples(i18n.T(`
+	# Enable metrics-server.
+	kops enable addon metrics-server.addons.k8s.io --name k8s-cluster.example.com
+
+	# Enable the cluster autoscaler, setting its expander.
+	kops enable addon cluster-autoscaler.addons.k8s.io --name k8s-cluster.example.com --set expander=priority
+	`))
+
+	enableAddonShort = i18n.T(`Enable an optional addon.`)
+)
+
+func NewCmdEnableAddon(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &EnableAddonOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "addon ADDON",
+		Short:   enableAddonShort,
+		Long:    enableAddonLong,
+		Example: enableAddonExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 1 {
+				return fmt.Errorf("must specify the name of one addon to enable")
+			}
+			options.AddonName = args[0]
+
+			return nil
+		},
+		ValidArgsFunction: completeOptionalAddon,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunEnableAddon(cmd.Context(), f, out, options)
+		},
+	}
+
+	LazyQuoteStringSliceVar(cmd.Flags(), &options.Sets, "set", options.Sets, "Set values in the configuration of the addon")
+	cmd.RegisterFlagCompletionFunc("set", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringSliceVar(&options.Unsets, "unset", options.Unsets, "Unset values in the configuration of the addon")
+	cmd.RegisterFlagCompletionFunc("unset", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func RunEnableAddon(ctx context.Context, f *util.Factory, out io.Writer, options *EnableAddonOptions) error {
+	return updateAddon(ctx, f, out, options.ClusterName, func(cluster *api.Cluster) error {
+		return commands.EnableAddon(cluster, options.AddonName, options.Sets, options.Unsets)
+	})
+}
+
+// updateAddon applies the change to the configuration of the cluster, validates it and writes it to the registry.
+func updateAddon(ctx context.Context, f *util.Factory, out io.Writer, clusterName string, change func(cluster *api.Cluster) error) error {
+	oldCluster, err := GetCluster(ctx, f, clusterName)
+	if err != nil {
+		return err
+	}
+
+	err = oldCluster.FillDefaults()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, oldCluster)
+	if err != nil {
+		return err
+	}
+
+	newCluster := oldCluster.DeepCopy()
+	if err := change(newCluster); err != nil {
+		return err
+	}
+
+	failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		return fmt.Errorf("%s", failure)
+	}
+
+	fmt.Fprintf(out, "To deploy these changes, run: kops update cluster --name %s --yes\n", clusterName)
+	return nil
+}
+
+func completeOptionalAddon(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, entry := range commands.AddonCatalog {
+		if entry.Optional() {
+			names = append(names, entry.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
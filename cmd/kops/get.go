@@ -62,6 +62,8 @@ func NewCmdGet(f *util.Factory, out io.Writer) *cobra.Command {
 	})
 
 	// create subcommands
+	cmd.AddCommand(NewCmdGetAddonCatalog(f, out, options))
+	cmd.AddCommand(NewCmdGetAddons(f, out, options))
 	cmd.AddCommand(NewCmdGetAll(f, out, options))
 	cmd.AddCommand(NewCmdGetAssets(f, out, options))
 	cmd.AddCommand(NewCmdGetCluster(f, out, options))
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	getAddonCatalogLong = templates.LongDesc(i18n.T(`
+	Display the addons that kOps can install, with the clouds and Kubernetes versions they support.
+
+	Optional addons can be enabled with "kops enable addon" and disabled with "kops disable addon";
+	the other addons are installed by kOps based on the cluster configuration.`))
+
+	getAddonCatalogExample = templates.Examples(i18n.T(`
+	# Display the addon catalog.
+	kops get addon-catalog
+
+	# Display the addon catalog in YAML format.
+	kops get addon-catalog -o yaml
+	`))
+
+	getAddonCatalogShort = i18n.T(`Display the addons that kOps can install.`)
+)
+
+func NewCmdGetAddonCatalog(f *util.Factory, out io.Writer, options *GetOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "addon-catalog",
+		Short:   getAddonCatalogShort,
+		Long:    getAddonCatalogLong,
+		Example: getAddonCatalogExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetAddonCatalog(out, options)
+		},
+	}
+
+	return cmd
+}
+
+func RunGetAddonCatalog(out io.Writer, options *GetOptions) error {
+	catalog := commands.AddonCatalog
+
+	switch options.Output {
+	case OutputTable:
+		return addonCatalogOutputTable(catalog, out)
+	case OutputYaml:
+		y, err := yaml.Marshal(catalog)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	case OutputJSON:
+		j, err := json.Marshal(catalog)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+}
+
+func addonCatalogOutputTable(catalog []*commands.AddonCatalogEntry, out io.Writer) error {
+	t := &tables.Table{}
+	t.AddColumn("NAME", func(e *commands.AddonCatalogEntry) string {
+		return e.Name
+	})
+	t.AddColumn("OPTIONAL", func(e *commands.AddonCatalogEntry) string {
+		if e.Optional() {
+			return "yes"
+		}
+		return "no"
+	})
+	t.AddColumn("CLOUDS", func(e *commands.AddonCatalogEntry) string {
+		if len(e.Clouds) == 0 {
+			return "all"
+		}
+		var clouds []string
+		for _, cloud := range e.Clouds {
+			clouds = append(clouds, string(cloud))
+		}
+		return strings.Join(clouds, ",")
+	})
+	t.AddColumn("KUBERNETES", func(e *commands.AddonCatalogEntry) string {
+		if e.KubernetesVersion == "" {
+			return "all"
+		}
+		return e.KubernetesVersion
+	})
+	t.AddColumn("DESCRIPTION", func(e *commands.AddonCatalogEntry) string {
+		return e.Description
+	})
+	return t.Render(catalog, out, "NAME", "OPTIONAL", "CLOUDS", "KUBERNETES", "DESCRIPTION")
+}
@@ -17,13 +17,170 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"sort"
 
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/cmd/kops/util"
 	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
 )
 
+var (
+	getAddonsLong = templates.LongDesc(i18n.T(`
+	Display the addons installed in the cluster by the channels tool,
+	including whether each addon became healthy after it was last applied.`))
+
+	getAddonsExample = templates.Examples(i18n.T(`
+	# Display the installed addons.
+	kops get addons
+
+	# Display the installed addons in YAML format.
+	kops get addons -o yaml
+	`))
+
+	getAddonsShort = i18n.T(`Display installed addons.`)
+)
+
+type renderableAddon struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	ManifestHash string `json:"manifestHash,omitempty"`
+	Channel      string `json:"channel,omitempty"`
+	Health       string `json:"health,omitempty"`
+}
+
+func NewCmdGetAddons(f *util.Factory, out io.Writer, options *GetOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "addons [CLUSTER]",
+		Aliases:           []string{"addon"},
+		Short:             getAddonsShort,
+		Long:              getAddonsLong,
+		Example:           getAddonsExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetAddons(cmd.Context(), f, out, options)
+		},
+	}
+
+	return cmd
+}
+
+func RunGetAddons(ctx context.Context, f *util.Factory, out io.Writer, options *GetOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	k8sClient, err := createK8sClient(cluster)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing namespaces: %v", err)
+	}
+
+	var addons []*renderableAddon
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		for name, version := range channels.FindChannelVersions(ns) {
+			addon := &renderableAddon{
+				Namespace:    ns.Name,
+				Name:         name,
+				ManifestHash: version.ManifestHash,
+				Health:       string(version.Health),
+			}
+			if version.Channel != nil {
+				addon.Channel = *version.Channel
+			}
+			addons = append(addons, addon)
+		}
+	}
+	sort.Slice(addons, func(i, j int) bool {
+		if addons[i].Namespace != addons[j].Namespace {
+			return addons[i].Namespace < addons[j].Namespace
+		}
+		return addons[i].Name < addons[j].Name
+	})
+
+	switch options.Output {
+	case OutputTable:
+		if len(addons) == 0 {
+			fmt.Fprintf(out, "No managed addons found\n")
+			return nil
+		}
+		return installedAddonsOutputTable(addons, out)
+	case OutputYaml:
+		y, err := yaml.Marshal(addons)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	case OutputJSON:
+		j, err := json.Marshal(addons)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+}
+
+func installedAddonsOutputTable(addons []*renderableAddon, out io.Writer) error {
+	t := &tables.Table{}
+	t.AddColumn("NAMESPACE", func(a *renderableAddon) string {
+		return a.Namespace
+	})
+	t.AddColumn("NAME", func(a *renderableAddon) string {
+		return a.Name
+	})
+	t.AddColumn("HASH", func(a *renderableAddon) string {
+		return a.ManifestHash
+	})
+	t.AddColumn("CHANNEL", func(a *renderableAddon) string {
+		if a.Channel == "" {
+			return "?"
+		}
+		return a.Channel
+	})
+	t.AddColumn("STATUS", func(a *renderableAddon) string {
+		if a.Health == "" {
+			return "-"
+		}
+		return a.Health
+	})
+	return t.Render(addons, out, "NAMESPACE", "NAME", "HASH", "CHANNEL", "STATUS")
+}
+
 func addonsOutputTable(cluster *api.Cluster, addons []*unstructured.Unstructured, out io.Writer) error {
 	t := &tables.Table{}
 	t.AddColumn("NAME", func(o *unstructured.Unstructured) string {
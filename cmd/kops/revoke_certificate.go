@@ -0,0 +1,307 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/acls"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	revokeCertificateLong = templates.LongDesc(i18n.T(`
+	Revoke certificates that kops-controller issued to a node.
+
+	Revoked certificates are added to the certificate revocation list (CRL)
+	of the keypair that signed them. The CRL is published to the state store
+	and served by kops-controller. Neither kube-apiserver nor the kubelet
+	checks the CRL, so revoked certificates are still accepted by them.
+
+	kops-controller records the serial numbers of the certificates it issues
+	to each node, so all of a node's certificates can be revoked by node name.
+	Individual certificates can be revoked by serial number, in decimal or in
+	hexadecimal prefixed with 0x.
+	`))
+
+	revokeCertificateExample = templates.Examples(i18n.T(`
+	# Revoke all the certificates issued to a node.
+	kops revoke certificate --node i-0a5ed581b862d3425 \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Revoke a certificate signed by the primary keypair of kubernetes-ca by serial number.
+	kops revoke certificate --serial 0x5a3c8e21f1d0b7a4 \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Revoke a certificate signed by another keypair of kubernetes-ca by serial number.
+	kops revoke certificate --serial 0x5a3c8e21f1d0b7a4 --keypair 6982820025135291416230495506 \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	`))
+
+	revokeCertificateShort = i18n.T(`Revoke certificates issued to a node.`)
+)
+
+type RevokeCertificateOptions struct {
+	ClusterName string
+	// Node is the name of the node whose certificates should be revoked.
+	Node string
+	// Serials is the serial numbers of the certificates to revoke.
+	Serials []string
+	// Keyset is the name of the keyset that signed the certificates named by Serials.
+	Keyset string
+	// Keypair is the ID of the keypair that signed the certificates named by Serials; defaults to the primary keypair.
+	Keypair string
+}
+
+// NewCmdRevokeCertificate returns a revoke certificate command.
+func NewCmdRevokeCertificate(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RevokeCertificateOptions{
+		Keyset: fi.CertificateIDCA,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "certificate {--node NODE | --serial SERIAL}",
+		Short:   revokeCertificateShort,
+		Long:    revokeCertificateLong,
+		Example: revokeCertificateExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 0 {
+				return fmt.Errorf("unexpected arguments; use --node or --serial")
+			}
+
+			if options.Node == "" && len(options.Serials) == 0 {
+				return fmt.Errorf("must specify --node or --serial")
+			}
+			if options.Node != "" && len(options.Serials) != 0 {
+				return fmt.Errorf("cannot specify both --node and --serial")
+			}
+			if options.Keypair != "" && len(options.Serials) == 0 {
+				return fmt.Errorf("--keypair can only be specified with --serial")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRevokeCertificate(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Node, "node", options.Node, "Name of the node whose certificates should be revoked")
+	cmd.Flags().StringSliceVar(&options.Serials, "serial", options.Serials, "Serial number of a certificate to revoke")
+	cmd.Flags().StringVar(&options.Keyset, "keyset", options.Keyset, "Name of the keyset that signed the certificates given by --serial")
+	cmd.Flags().StringVar(&options.Keypair, "keypair", options.Keypair, "ID of the keypair that signed the certificates given by --serial, if not the primary keypair of the keyset")
+
+	return cmd
+}
+
+// RunRevokeCertificate revokes certificates.
+func RunRevokeCertificate(ctx context.Context, f *util.Factory, out io.Writer, options *RevokeCertificateOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return fmt.Errorf("getting cluster: %q: %v", options.ClusterName, err)
+	}
+
+	clientSet, err := f.KopsClient()
+	if err != nil {
+		return fmt.Errorf("getting clientset: %v", err)
+	}
+
+	if options.Node != "" {
+		k8sClient, _, err := getKubeClient(cluster)
+		if err != nil {
+			return err
+		}
+		revoked, err := revokeNodeCertificates(ctx, out, cluster, clientSet, k8sClient, options.Node)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return fmt.Errorf("no certificates issued by kops-controller were recorded for node %q", options.Node)
+		}
+		return nil
+	}
+
+	var certificates []*pki.RevokedCertificate
+	for _, s := range options.Serials {
+		serial, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return fmt.Errorf("invalid serial number %q", s)
+		}
+		// The expiry time is not known, so the certificate is kept in the CRL until the keypair expires.
+		certificates = append(certificates, &pki.RevokedCertificate{SerialNumber: serial})
+	}
+
+	return revokeCertificates(ctx, out, cluster, clientSet, options.Keyset, options.Keypair, certificates)
+}
+
+// revokeNodeCertificates revokes the certificates that kops-controller recorded as issued to the node.
+// It returns false if no certificates were recorded for the node.
+func revokeNodeCertificates(ctx context.Context, out io.Writer, cluster *kopsapi.Cluster, clientSet simple.Clientset, k8sClient kubernetes.Interface, nodeName string) (bool, error) {
+	configMapName := bootstrap.IssuedCertificatesConfigMapName(nodeName)
+	configMap, err := k8sClient.CoreV1().ConfigMaps(bootstrap.IssuedCertificatesNamespace).Get(ctx, configMapName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting certificates issued to node %q: %v", nodeName, err)
+	}
+
+	issued, err := bootstrap.ParseIssuedCertificates(configMap.Data)
+	if err != nil {
+		return false, fmt.Errorf("reading certificates issued to node %q: %v", nodeName, err)
+	}
+	if len(issued) == 0 {
+		return false, nil
+	}
+
+	type issuer struct {
+		signer    string
+		keypairID string
+	}
+	certificatesByIssuer := make(map[issuer][]*pki.RevokedCertificate)
+	for s, cert := range issued {
+		serial, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return false, fmt.Errorf("invalid serial number %q recorded for node %q", s, nodeName)
+		}
+		key := issuer{signer: cert.Signer, keypairID: cert.KeypairID}
+		certificatesByIssuer[key] = append(certificatesByIssuer[key], &pki.RevokedCertificate{
+			SerialNumber: serial,
+			NotAfter:     cert.NotAfter,
+		})
+	}
+
+	var issuers []issuer
+	for key := range certificatesByIssuer {
+		issuers = append(issuers, key)
+	}
+	sort.Slice(issuers, func(i, j int) bool {
+		if issuers[i].signer != issuers[j].signer {
+			return issuers[i].signer < issuers[j].signer
+		}
+		return issuers[i].keypairID < issuers[j].keypairID
+	})
+
+	for _, key := range issuers {
+		certificates := certificatesByIssuer[key]
+		sort.Slice(certificates, func(i, j int) bool { return certificates[i].SerialNumber.Cmp(certificates[j].SerialNumber) < 0 })
+		if err := revokeCertificates(ctx, out, cluster, clientSet, key.signer, key.keypairID, certificates); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// revokeCertificates adds the certificates to the revocation list of the keypair that signed them,
+// which is the primary keypair of the keyset if keypairID is empty.
+func revokeCertificates(ctx context.Context, out io.Writer, cluster *kopsapi.Cluster, clientSet simple.Clientset, signer string, keypairID string, certificates []*pki.RevokedCertificate) error {
+	keyStore, err := clientSet.KeyStore(cluster)
+	if err != nil {
+		return fmt.Errorf("getting keystore: %v", err)
+	}
+
+	keyset, err := keyStore.FindKeyset(ctx, signer)
+	if err != nil {
+		return fmt.Errorf("reading keyset %q: %v", signer, err)
+	}
+	if keyset == nil || keyset.Primary == nil {
+		return fmt.Errorf("keyset %q not found", signer)
+	}
+	keypair := keyset.Primary
+	if keypairID != "" {
+		keypair = keyset.Items[keypairID]
+		if keypair == nil {
+			return fmt.Errorf("keypair %q not found in keyset %q", keypairID, signer)
+		}
+	}
+	if keypair.Certificate == nil || keypair.PrivateKey == nil {
+		return fmt.Errorf("keypair %q of keyset %q has no private key", keypair.Id, signer)
+	}
+
+	configBase, err := registry.ConfigBase(clientSet.VFSContext(), cluster)
+	if err != nil {
+		return err
+	}
+	recordPath := configBase.Join(pki.RevokedCertificatesPath(signer, keypair.Id))
+	crlPath := configBase.Join(pki.RevocationListPath(signer, keypair.Id))
+
+	var previous *pki.RevokedCertificates
+	data, err := recordPath.ReadFile(ctx)
+	if err == nil {
+		previous = &pki.RevokedCertificates{}
+		if err := json.Unmarshal(data, previous); err != nil {
+			return fmt.Errorf("reading %s: %v", recordPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %v", recordPath, err)
+	}
+
+	record, crl, err := pki.RevokeCertificates(keypair.Certificate, keypair.PrivateKey, previous, certificates, time.Now())
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding revoked certificates: %v", err)
+	}
+
+	// The record is written first, so that a failure to write the CRL is repaired by the next revocation.
+	for _, f := range []struct {
+		path vfs.Path
+		data []byte
+	}{{recordPath, data}, {crlPath, crl}} {
+		acl, err := acls.GetACL(ctx, f.path, cluster)
+		if err != nil {
+			return err
+		}
+		if err := f.path.WriteFile(ctx, bytes.NewReader(f.data), acl); err != nil {
+			return fmt.Errorf("writing %s: %v", f.path, err)
+		}
+	}
+
+	for _, cert := range certificates {
+		fmt.Fprintf(out, "Revoked %s certificate %s\n", signer, cert.SerialNumber)
+	}
+	return nil
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxBundleShort = i18n.T(`Manage bundles of cluster assets for air-gapped installation.`)
+
+	toolboxBundleCreateLong = pretty.LongDesc(i18n.T(`
+	Create a bundle holding the image and file assets of a cluster.
+
+	The bundle is an OCI image layout holding the images, with the files stored as blobs of the layout
+	and listed with the images in ` + pretty.Bash("kops-bundle.yaml") + `. It is written as a tarball if
+	the output ends in .tar, .tar.gz or .tgz, and as a directory otherwise.
+
+	The assets are those of the cluster in the state store, or of the cluster and instance groups in
+	the file given by ` + pretty.Bash("--filename") + `, optionally with another version of Kubernetes.
+	They are always those of the version of kops creating the bundle.
+
+	Images and files are verified against the verification policy of the cluster, and their signatures
+	are added to the bundle.`))
+
+	toolboxBundleCreateExample = templates.Examples(i18n.T(`
+	# Create a bundle of the assets of a cluster.
+	kops toolbox bundle create --name k8s-cluster.example.com --output bundle.tar.gz
+
+	# Create a bundle of the assets of a cluster spec, for another version of Kubernetes.
+	kops toolbox bundle create -f cluster.yaml --kubernetes-version 1.30.2 --output bundle.tar.gz
+	`))
+
+	toolboxBundleCreateShort = i18n.T(`Create a bundle of the assets of a cluster.`)
+
+	toolboxBundlePushLong = pretty.LongDesc(i18n.T(`
+	Push the images of a bundle to a container registry and its files to a file repository,
+	and set the cluster to use them.
+
+	The assets are pushed to the locations from which the cluster downloads them once
+	` + pretty.Bash("containerRegistry") + ` and ` + pretty.Bash("fileRepository") + ` are set in its assets spec.
+	The file repository is given as it is set in the assets spec, for example as the https URL of an S3 bucket.
+
+	kops toolbox bundle push does not update the cloud resources; to apply the changes use ` + pretty.Bash("kops update cluster") + `.`))
+
+	toolboxBundlePushExample = templates.Examples(i18n.T(`
+	# Push a bundle to a private registry and file repository.
+	kops toolbox bundle push bundle.tar.gz --name k8s-cluster.example.com --to registry.example.com/kops --files https://s3.amazonaws.com/example-assets/kops
+	`))
+
+	toolboxBundlePushShort = i18n.T(`Push a bundle to a private registry and file repository.`)
+)
+
+func NewCmdToolboxBundle(f *util.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: toolboxBundleShort,
+	}
+
+	cmd.AddCommand(NewCmdToolboxBundleCreate(f, out))
+	cmd.AddCommand(NewCmdToolboxBundlePush(f, out))
+
+	return cmd
+}
+
+type ToolboxBundleCreateOptions struct {
+	ClusterName string
+
+	// Filename is the file holding the cluster and instance groups, used instead of the cluster in the state store.
+	Filename string
+	// KubernetesVersion overrides the version of kubernetes of the cluster.
+	KubernetesVersion string
+	// Output is the tarball or directory to which the bundle is written.
+	Output string
+}
+
+func (o *ToolboxBundleCreateOptions) InitDefaults() {
+	o.Output = "kops-bundle.tar.gz"
+}
+
+func NewCmdToolboxBundleCreate(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxBundleCreateOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "create [CLUSTER]",
+		Short:             toolboxBundleCreateShort,
+		Long:              toolboxBundleCreateLong,
+		Example:           toolboxBundleCreateExample,
+		Args:              rootCommand.clusterNameArgsAllowNoCluster(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxBundleCreate(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Filename, "filename", "f", options.Filename, "File holding the cluster and instance groups to create the bundle for, instead of the cluster in the state store")
+	cmd.MarkFlagFilename("filename", "yaml", "json")
+	cmd.Flags().StringVar(&options.KubernetesVersion, "kubernetes-version", options.KubernetesVersion, "Version of Kubernetes to create the bundle for, instead of the version of the cluster")
+	cmd.RegisterFlagCompletionFunc("kubernetes-version", cobra.NoFileCompletions)
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Tarball or directory to write the bundle to")
+	cmd.MarkFlagFilename("output", "tar", "tar.gz", "tgz")
+
+	return cmd
+}
+
+func RunToolboxBundleCreate(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxBundleCreateOptions) error {
+	if options.ClusterName == "" && options.Filename == "" {
+		return fmt.Errorf("--name or --filename is required")
+	}
+
+	factory := f
+	clusterName := options.ClusterName
+	if options.Filename != "" || options.KubernetesVersion != "" {
+		stateDir, err := os.MkdirTemp("", "kops-bundle-state")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(stateDir)
+
+		factory, clusterName, err = stageBundleCluster(ctx, f, options, stateDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	updateClusterResults, err := RunUpdateCluster(ctx, factory, out, &UpdateClusterOptions{
+		Target:      cloudup.TargetDryRun,
+		GetAssets:   true,
+		ClusterName: clusterName,
+	})
+	if err != nil {
+		return err
+	}
+
+	dir := options.Output
+	if assets.IsBundleArchive(options.Output) {
+		dir, err = os.MkdirTemp("", "kops-bundle")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+	}
+
+	index, err := assets.CreateBundle(dir, updateClusterResults.ImageAssets, updateClusterResults.FileAssets, f.VFSContext(), updateClusterResults.Cluster)
+	if err != nil {
+		return err
+	}
+
+	if assets.IsBundleArchive(options.Output) {
+		if err := assets.WriteBundleArchive(dir, options.Output); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "Created bundle %s with %d images and %d files for Kubernetes %s\n", options.Output, len(index.Images), len(index.Files), index.KubernetesVersion)
+	return nil
+}
+
+// stageBundleCluster writes the cluster to a temporary state store in the directory,
+// so that its assets can be listed for a cluster spec from a file, or for another version of kubernetes.
+// It returns a factory for the temporary state store, and the name of the cluster.
+func stageBundleCluster(ctx context.Context, f *util.Factory, options *ToolboxBundleCreateOptions, stateDir string) (*util.Factory, string, error) {
+	staging := util.NewFactory(&util.FactoryOptions{
+		RegistryPath: "file://" + stateDir,
+	})
+	clientset, err := staging.KopsClient()
+	if err != nil {
+		return nil, "", err
+	}
+
+	clusterName := options.ClusterName
+	if options.Filename != "" {
+		if err := RunCreate(ctx, staging, io.Discard, &CreateOptions{Filenames: []string{options.Filename}}); err != nil {
+			return nil, "", err
+		}
+		if clusterName == "" {
+			clusters, err := clientset.ListClusters(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, "", err
+			}
+			if len(clusters.Items) != 1 {
+				return nil, "", fmt.Errorf("--name is required when %q holds %d clusters", options.Filename, len(clusters.Items))
+			}
+			clusterName = clusters.Items[0].Name
+		}
+	} else {
+		cluster, err := GetCluster(ctx, f, clusterName)
+		if err != nil {
+			return nil, "", err
+		}
+		sourceClientset, err := f.KopsClient()
+		if err != nil {
+			return nil, "", err
+		}
+		instanceGroups, err := commands.ReadAllInstanceGroups(ctx, sourceClientset, cluster)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// The cluster is stored in the temporary state store only.
+		cluster.Spec.ConfigStore = kopsapi.ConfigStoreSpec{}
+		cluster.ResourceVersion = ""
+		if _, err := clientset.CreateCluster(ctx, cluster); err != nil {
+			return nil, "", fmt.Errorf("error staging cluster: %v", err)
+		}
+		for _, ig := range instanceGroups {
+			ig.ResourceVersion = ""
+			if _, err := clientset.InstanceGroupsFor(cluster).Create(ctx, ig, metav1.CreateOptions{}); err != nil {
+				return nil, "", fmt.Errorf("error staging instanceGroup %q: %v", ig.Name, err)
+			}
+		}
+	}
+
+	if options.KubernetesVersion != "" {
+		cluster, err := GetCluster(ctx, staging, clusterName)
+		if err != nil {
+			return nil, "", err
+		}
+		cluster.Spec.KubernetesVersion = options.KubernetesVersion
+		if _, err := clientset.UpdateCluster(ctx, cluster, nil); err != nil {
+			return nil, "", fmt.Errorf("error staging cluster: %v", err)
+		}
+	}
+
+	return staging, clusterName, nil
+}
+
+type ToolboxBundlePushOptions struct {
+	ClusterName string
+
+	// Bundle is the tarball or directory holding the bundle.
+	Bundle string
+	// To is the container registry to which the images are pushed.
+	To string
+	// Files is the file repository to which the files are pushed.
+	Files string
+}
+
+func NewCmdToolboxBundlePush(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxBundlePushOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "push BUNDLE",
+		Short:   toolboxBundlePushShort,
+		Long:    toolboxBundlePushLong,
+		Example: toolboxBundlePushExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 1 {
+				return fmt.Errorf("must specify one bundle to push")
+			}
+			options.Bundle = args[0]
+
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return []string{"tar", "tar.gz", "tgz"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxBundlePush(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.To, "to", options.To, "Container registry to push the images to")
+	cmd.RegisterFlagCompletionFunc("to", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&options.Files, "files", options.Files, "File repository to push the files to")
+	cmd.RegisterFlagCompletionFunc("files", cobra.NoFileCompletions)
+
+	return cmd
+}
+
+func RunToolboxBundlePush(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxBundlePushOptions) error {
+	if options.To == "" && options.Files == "" {
+		return fmt.Errorf("--to or --files is required")
+	}
+
+	dir := options.Bundle
+	if assets.IsBundleArchive(options.Bundle) {
+		var err error
+		dir, err = os.MkdirTemp("", "kops-bundle")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		if err := assets.ExtractBundleArchive(options.Bundle, dir); err != nil {
+			return err
+		}
+	}
+
+	index, err := assets.ReadBundleIndex(dir)
+	if err != nil {
+		return err
+	}
+	if index.KopsVersion != kopsbase.Version {
+		klog.Warningf("bundle was created with kops %s, which uses different assets than kops %s", index.KopsVersion, kopsbase.Version)
+	}
+
+	oldCluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if oldCluster.Spec.KubernetesVersion != index.KubernetesVersion {
+		klog.Warningf("bundle was created for Kubernetes %s, but the cluster uses Kubernetes %s", index.KubernetesVersion, oldCluster.Spec.KubernetesVersion)
+	}
+
+	err = oldCluster.FillDefaults()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, oldCluster)
+	if err != nil {
+		return err
+	}
+
+	newCluster := oldCluster.DeepCopy()
+	if newCluster.Spec.Assets == nil {
+		newCluster.Spec.Assets = &kopsapi.AssetsSpec{}
+	}
+	if options.To != "" {
+		newCluster.Spec.Assets.ContainerRegistry = &options.To
+	}
+	if options.Files != "" {
+		newCluster.Spec.Assets.FileRepository = &options.Files
+	}
+
+	if err := assets.PushBundle(dir, index, newCluster.Spec.Assets, f.VFSContext(), newCluster); err != nil {
+		return err
+	}
+
+	failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		return fmt.Errorf("%s", failure)
+	}
+
+	fmt.Fprintf(out, "Pushed bundle %s\n", options.Bundle)
+	fmt.Fprintf(out, "To deploy these changes, run: kops update cluster --name %s --yes\n", options.ClusterName)
+	return nil
+}
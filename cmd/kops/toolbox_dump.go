@@ -142,24 +142,13 @@ func RunToolboxDump(ctx context.Context, f commandutils.Factory, out io.Writer,
 	}
 
 	if options.Dir != "" {
-		privateKeyPath := options.PrivateKey
-		if strings.HasPrefix(privateKeyPath, "~/") {
-			privateKeyPath = filepath.Join(os.Getenv("HOME"), privateKeyPath[2:])
-		}
-		key, err := os.ReadFile(privateKeyPath)
-		if err != nil {
-			return fmt.Errorf("reading private key %q: %v", privateKeyPath, err)
-		}
-
-		parsedKey, err := ssh.ParseRawPrivateKey(key)
-		if err != nil {
-			return fmt.Errorf("parsing private key %q: %v", privateKeyPath, err)
-		}
-
-		signer, err := ssh.NewSignerFromKey(parsedKey)
+		sshConfig, keyRing, err := buildSSHConfig(options.PrivateKey, options.SSHUser)
 		if err != nil {
-			return fmt.Errorf("creating signer for private key %q: %v", privateKeyPath, err)
+			return err
 		}
+		defer func(keyRing agent.Agent) {
+			_ = keyRing.RemoveAll()
+		}(keyRing)
 
 		contextName := cluster.ObjectMeta.Name
 		clientGetter := genericclioptions.NewConfigFlags(true)
@@ -190,33 +179,8 @@ func RunToolboxDump(ctx context.Context, f commandutils.Factory, out io.Writer,
 			klog.Warningf("not limiting number of nodes dumped: %v", err)
 		}
 
-		sshConfig := &ssh.ClientConfig{
-			Config: ssh.Config{},
-			User:   options.SSHUser,
-			Auth: []ssh.AuthMethod{
-				ssh.PublicKeys(signer),
-			},
-			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-		}
-
-		keyRing := agent.NewKeyring()
-		defer func(keyRing agent.Agent) {
-			_ = keyRing.RemoveAll()
-		}(keyRing)
-		err = keyRing.Add(agent.AddedKey{
-			PrivateKey: parsedKey,
-		})
-		if err != nil {
-			return fmt.Errorf("adding key to SSH agent: %w", err)
-		}
-
 		// look for a bastion instance and use it if exists
-		bastionAddress := ""
-		for _, instance := range d.Instances {
-			if strings.Contains(instance.Name, "bastion") {
-				bastionAddress = instance.PublicAddresses[0]
-			}
-		}
+		bastionAddress := findBastionAddress(d)
 		dumper := dump.NewLogDumper(bastionAddress, sshConfig, keyRing, options.Dir)
 
 		var additionalIPs []string
@@ -282,6 +246,48 @@ func RunToolboxDump(ctx context.Context, f commandutils.Factory, out io.Writer,
 	}
 }
 
+// buildSSHConfig builds the SSH configuration for connecting to instances with the private key,
+// and an agent holding the key for forwarding through a bastion
+func buildSSHConfig(privateKey string, sshUser string) (*ssh.ClientConfig, agent.Agent, error) {
+	privateKeyPath := privateKey
+	if strings.HasPrefix(privateKeyPath, "~/") {
+		privateKeyPath = filepath.Join(os.Getenv("HOME"), privateKeyPath[2:])
+	}
+	key, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading private key %q: %v", privateKeyPath, err)
+	}
+
+	parsedKey, err := ssh.ParseRawPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing private key %q: %v", privateKeyPath, err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(parsedKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating signer for private key %q: %v", privateKeyPath, err)
+	}
+
+	sshConfig := &ssh.ClientConfig{
+		Config: ssh.Config{},
+		User:   sshUser,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeys(signer),
+		},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	}
+
+	keyRing := agent.NewKeyring()
+	err = keyRing.Add(agent.AddedKey{
+		PrivateKey: parsedKey,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("adding key to SSH agent: %w", err)
+	}
+
+	return sshConfig, keyRing, nil
+}
+
 func truncateNodeList(nodes *corev1.NodeList, max int) error {
 	if max < 0 {
 		return errors.New("--max-nodes must be greater than zero")
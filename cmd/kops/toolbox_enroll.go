@@ -37,6 +37,9 @@ func NewCmdToolboxEnroll(f commandutils.Factory, out io.Writer) *cobra.Command {
 			Adds an individual machine to the cluster.`)),
 		Example: templates.Examples(i18n.T(`
 			kops toolbox enroll --name k8s-cluster.example.com
+
+			# Enroll a machine with a TPM, binding it to the secure boot state
+			kops toolbox enroll --cluster k8s-cluster.example.com --instance-group nodes --host 192.168.1.10 --tpm --tpm-pcrs 7
 		`)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return commands.RunToolboxEnroll(cmd.Context(), f, out, options)
@@ -50,5 +53,8 @@ func NewCmdToolboxEnroll(f commandutils.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.SSHUser, "ssh-user", options.SSHUser, "user for ssh")
 	cmd.Flags().IntVar(&options.SSHPort, "ssh-port", options.SSHPort, "port for ssh")
 
+	cmd.Flags().BoolVar(&options.TPM, "tpm", options.TPM, "Enroll the machine using an attestation key in its TPM (requires tpm2-tools on the machine)")
+	cmd.Flags().IntSliceVar(&options.TPMPCRs, "tpm-pcrs", options.TPMPCRs, "PCRs whose current values the machine must attest to when joining, with --tpm")
+
 	return cmd
 }
@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/agent"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/dump"
+	"k8s.io/kops/pkg/resources"
+	resourceops "k8s.io/kops/pkg/resources/ops"
+	"k8s.io/kops/protokube/pkg/gossip"
+	gossipdns "k8s.io/kops/protokube/pkg/gossip/dns"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxGossipLong = templates.LongDesc(i18n.T(`
+	Displays the gossip membership and DNS records of a cluster that uses gossip DNS.
+
+	Connects to a control plane instance over SSH and queries protokube for the members of the gossip cluster,
+	where the members were discovered from, and the DNS records held in the gossip state.`))
+
+	toolboxGossipExample = templates.Examples(i18n.T(`
+	# Display the gossip members of a cluster
+	kops toolbox gossip --name k8s-cluster.k8s.local
+
+	# Display the full gossip status, including DNS records, of a specific instance
+	kops toolbox gossip --name k8s-cluster.k8s.local --node 10.0.1.15 -o yaml
+	`))
+
+	toolboxGossipShort = i18n.T(`Display gossip membership and DNS records`)
+)
+
+// protokubeGossipStatusCommand is the command that prints the gossip status on an instance
+const protokubeGossipStatusCommand = "sudo /opt/kops/bin/protokube gossip status"
+
+type ToolboxGossipOptions struct {
+	Output string
+
+	ClusterName string
+
+	Node       string
+	PrivateKey string
+	SSHUser    string
+}
+
+func (o *ToolboxGossipOptions) InitDefaults() {
+	o.Output = OutputTable
+	o.PrivateKey = "~/.ssh/id_rsa"
+	o.SSHUser = "ubuntu"
+}
+
+func NewCmdToolboxGossip(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxGossipOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "gossip [CLUSTER]",
+		Short:             toolboxGossipShort,
+		Long:              toolboxGossipLong,
+		Example:           toolboxGossipExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxGossip(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format.  One of table, json or yaml")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputTable, OutputJSON, OutputYaml}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	cmd.Flags().StringVar(&options.Node, "node", options.Node, "Address of the instance to query; defaults to a control plane instance")
+	cmd.RegisterFlagCompletionFunc("node", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&options.PrivateKey, "private-key", options.PrivateKey, "File containing private key to use for SSH access to instances")
+	cmd.Flags().StringVar(&options.SSHUser, "ssh-user", options.SSHUser, "The remote user for SSH access to instances")
+	cmd.RegisterFlagCompletionFunc("ssh-user", cobra.NoFileCompletions)
+
+	return cmd
+}
+
+func RunToolboxGossip(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxGossipOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	if !cluster.UsesLegacyGossip() {
+		return fmt.Errorf("cluster %q does not use gossip DNS", cluster.ObjectMeta.Name)
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	resourceMap, err := resourceops.ListResources(cloud, cluster)
+	if err != nil {
+		return err
+	}
+	d, err := resources.BuildDump(ctx, cloud, resourceMap)
+	if err != nil {
+		return err
+	}
+
+	sshConfig, keyRing, err := buildSSHConfig(options.PrivateKey, options.SSHUser)
+	if err != nil {
+		return err
+	}
+	defer func(keyRing agent.Agent) {
+		_ = keyRing.RemoveAll()
+	}(keyRing)
+
+	bastionAddress := findBastionAddress(d)
+
+	host := options.Node
+	useBastion := bastionAddress != ""
+	if host == "" {
+		host, useBastion = findControlPlaneAddress(d, bastionAddress)
+		if host == "" {
+			return fmt.Errorf("cannot find the address of a control plane instance; specify an instance with --node")
+		}
+	}
+
+	var stdout bytes.Buffer
+	dumper := dump.NewLogDumper(bastionAddress, sshConfig, keyRing, "")
+	if err := dumper.RunCommand(ctx, host, useBastion, protokubeGossipStatusCommand, &stdout); err != nil {
+		return fmt.Errorf("querying gossip status: %w", err)
+	}
+
+	status := &gossipdns.Status{}
+	if err := json.Unmarshal(stdout.Bytes(), status); err != nil {
+		return fmt.Errorf("parsing gossip status from %q: %w", host, err)
+	}
+
+	switch options.Output {
+	case OutputTable:
+		return writeGossipStatusTable(out, status)
+
+	case OutputYaml:
+		b, err := kops.ToRawYaml(status)
+		if err != nil {
+			return fmt.Errorf("error marshaling yaml: %v", err)
+		}
+		_, err = out.Write(b)
+		if err != nil {
+			return fmt.Errorf("error writing to stdout: %v", err)
+		}
+		return nil
+
+	case OutputJSON:
+		b, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling json: %v", err)
+		}
+		_, err = out.Write(b)
+		if err != nil {
+			return fmt.Errorf("error writing to stdout: %v", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+}
+
+// gossipMemberRow is a member of a gossip cluster, for the table output
+type gossipMemberRow struct {
+	Protocol string
+	gossip.GossipMember
+}
+
+func writeGossipStatusTable(out io.Writer, status *gossipdns.Status) error {
+	var rows []*gossipMemberRow
+	for g := status.Gossip; g != nil; g = g.Secondary {
+		for _, member := range g.Members {
+			rows = append(rows, &gossipMemberRow{
+				Protocol:     g.Protocol,
+				GossipMember: member,
+			})
+		}
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("PROTOCOL", func(r *gossipMemberRow) string {
+		return r.Protocol
+	})
+	t.AddColumn("MEMBER", func(r *gossipMemberRow) string {
+		return r.Name
+	})
+	t.AddColumn("ADDRESS", func(r *gossipMemberRow) string {
+		return r.Address
+	})
+	t.AddColumn("STATE", func(r *gossipMemberRow) string {
+		return r.State
+	})
+	if err := t.Render(rows, out, "PROTOCOL", "MEMBER", "ADDRESS", "STATE"); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n")
+	for g := status.Gossip; g != nil; g = g.Secondary {
+		seeds := g.Seeds
+		fmt.Fprintf(out, "%s seeds from %s: %s\n", g.Protocol, seeds.Provider, strings.Join(seeds.Seeds, ", "))
+		if seeds.Error != "" {
+			fmt.Fprintf(out, "  last error: %s\n", seeds.Error)
+		}
+	}
+
+	recordCount := 0
+	for _, zone := range status.DNS.Zones {
+		recordCount += len(zone.Records)
+	}
+	fmt.Fprintf(out, "\n%d DNS records at version %d; use -o yaml to display them\n", recordCount, status.DNS.Version)
+	return nil
+}
+
+// findBastionAddress returns the public address of a bastion instance, or "" if there is none
+func findBastionAddress(d *resources.Dump) string {
+	bastionAddress := ""
+	for _, instance := range d.Instances {
+		if strings.Contains(instance.Name, "bastion") && len(instance.PublicAddresses) != 0 {
+			bastionAddress = instance.PublicAddresses[0]
+		}
+	}
+	return bastionAddress
+}
+
+// findControlPlaneAddress returns the address of a control plane instance, and whether it must be reached through the bastion
+func findControlPlaneAddress(d *resources.Dump, bastionAddress string) (string, bool) {
+	for _, instance := range d.Instances {
+		isControlPlane := false
+		for _, role := range instance.Roles {
+			switch strings.ToLower(role) {
+			case "control-plane", "controlplane", "master":
+				isControlPlane = true
+			}
+		}
+		if !isControlPlane {
+			continue
+		}
+
+		if len(instance.PublicAddresses) != 0 {
+			return instance.PublicAddresses[0], false
+		}
+		if len(instance.PrivateAddresses) != 0 && bastionAddress != "" {
+			return instance.PrivateAddresses[0], true
+		}
+	}
+	return "", false
+}
@@ -32,6 +32,7 @@ func NewCmdUpgrade(f *util.Factory, out io.Writer) *cobra.Command {
 	}
 
 	// create subcommands
+	cmd.AddCommand(NewCmdUpgradeAddons(f, out))
 	cmd.AddCommand(NewCmdUpgradeCluster(f, out))
 
 	return cmd
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	upgradeAddonsLong = pretty.LongDesc(i18n.T(`
+	Checks the channel for newer versions of the addons managed by kOps and pins the chosen ones in the
+	cluster spec, without upgrading Kubernetes. Only versions supported by this release of kOps are proposed.
+	After this command is run, use ` + pretty.Bash("kops update cluster") + ` to apply the new versions.
+	`))
+
+	upgradeAddonsExample = templates.Examples(i18n.T(`
+	# Display the addon upgrades available in the channel.
+	kops upgrade addons k8s-cluster.example.com --state=s3://my-state-store
+
+	# Upgrade CoreDNS only.
+	kops upgrade addons k8s-cluster.example.com --addon coredns.addons.k8s.io --yes --state=s3://my-state-store
+	`))
+
+	upgradeAddonsShort = i18n.T("Upgrade the addons of a kubernetes cluster.")
+)
+
+type UpgradeAddonsOptions struct {
+	ClusterName string
+	Yes         bool
+	Channel     string
+	// Addons restricts the upgrade to the named addons.
+	Addons []string
+}
+
+func NewCmdUpgradeAddons(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &UpgradeAddonsOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "addons [CLUSTER]",
+		Short:             upgradeAddonsShort,
+		Long:              upgradeAddonsLong,
+		Example:           upgradeAddonsExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			return RunUpgradeAddons(ctx, f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", false, "Apply update")
+	cmd.Flags().StringVar(&options.Channel, "channel", "", "Channel to use for upgrade")
+	cmd.RegisterFlagCompletionFunc("channel", completeChannel)
+	cmd.Flags().StringSliceVar(&options.Addons, "addon", options.Addons, "Upgrade only the named addons")
+	cmd.RegisterFlagCompletionFunc("addon", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func RunUpgradeAddons(ctx context.Context, f *util.Factory, out io.Writer, options *UpgradeAddonsOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+
+	if cluster.ObjectMeta.Annotations[kopsapi.AnnotationNameManagement] == kopsapi.AnnotationValueManagementImported {
+		return fmt.Errorf("upgrade is not for use with imported clusters")
+	}
+
+	channelLocation := options.Channel
+	if channelLocation == "" {
+		channelLocation = cluster.Spec.Channel
+	}
+	if channelLocation == "" {
+		channelLocation = kopsapi.DefaultChannel
+	}
+
+	channel, err := kopsapi.LoadAddonChannel(f.VFSContext(), channelLocation)
+	if err != nil {
+		return fmt.Errorf("error loading addon versions of channel %q: %v", channelLocation, err)
+	}
+
+	upgrades, err := commands.FindAddonUpgrades(cluster, channel)
+	if err != nil {
+		return err
+	}
+
+	chosen := sets.New(options.Addons...)
+	var actions []*upgradeAction
+	for _, upgrade := range upgrades {
+		if chosen.Len() != 0 && !chosen.Has(upgrade.Name) {
+			continue
+		}
+		chosen.Delete(upgrade.Name)
+
+		actions = append(actions, &upgradeAction{
+			Item:     "Addon/" + upgrade.Name,
+			Property: "Version",
+			Old:      upgrade.Current,
+			New:      upgrade.Available,
+			apply: func() {
+				commands.SetAddonVersion(cluster, upgrade.Name, upgrade.Available)
+			},
+		})
+	}
+	for _, name := range sets.List(chosen) {
+		fmt.Fprintf(os.Stderr, "No upgrade available for addon %q\n", name)
+	}
+
+	if len(actions) == 0 {
+		// Note stderr - we try not to print to stdout if no update is needed
+		fmt.Fprintf(os.Stderr, "\nNo upgrade required\n")
+		return nil
+	}
+
+	{
+		t := &tables.Table{}
+		t.AddColumn("ITEM", func(a *upgradeAction) string {
+			return a.Item
+		})
+		t.AddColumn("PROPERTY", func(a *upgradeAction) string {
+			return a.Property
+		})
+		t.AddColumn("OLD", func(a *upgradeAction) string {
+			return a.Old
+		})
+		t.AddColumn("NEW", func(a *upgradeAction) string {
+			return a.New
+		})
+
+		err := t.Render(actions, out, "ITEM", "PROPERTY", "OLD", "NEW")
+		if err != nil {
+			return err
+		}
+	}
+
+	if !options.Yes {
+		fmt.Printf("\nMust specify --yes to perform upgrade\n")
+		return nil
+	}
+	for _, action := range actions {
+		action.apply()
+	}
+
+	if err := commands.UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nUpdates applied to configuration.\n")
+	fmt.Printf("You can now apply these changes, using `kops update cluster %s`\n", cluster.ObjectMeta.Name)
+
+	return nil
+}
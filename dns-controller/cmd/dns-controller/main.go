@@ -23,9 +23,11 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/pflag"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	_ "k8s.io/component-base/metrics/prometheus/restclient" // for client metric registration
@@ -38,6 +40,8 @@ import (
 	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/do"
 	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/google/clouddns"
 	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/openstack/designate"
+	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/powerdns"
+	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/rfc2136"
 	_ "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/scaleway"
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/protokube/pkg/gossip"
@@ -54,11 +58,14 @@ var (
 
 func main() {
 	fmt.Printf("dns-controller version %s\n", BuildVersion)
-	var dnsServer, dnsProviderID, gossipListen, gossipSecret, watchNamespace, metricsListen, gossipProtocol, gossipSecretSecondary, gossipListenSecondary, gossipProtocolSecondary string
+	var dnsServer, dnsProviderID, gossipListen, gossipSecret, watchNamespace, metricsListen, gossipProtocol, gossipSecretSecondary, gossipListenSecondary, gossipProtocolSecondary, txtOwnerID string
 	var gossipSeeds, gossipSeedsSecondary, zones []string
+	var gossipAdditionalSecrets, gossipAdditionalSecretsSecondary []string
+	var gossipSealing, gossipSealingSecondary string
 	var internalIpv4, internalIpv6 bool
-	var watchIngress bool
+	var watchIngress, watchGateway bool
 	var updateInterval int
+	var syncStaleThreshold time.Duration
 
 	// Be sure to get the glog flags
 	klog.InitFlags(nil)
@@ -66,22 +73,29 @@ func main() {
 
 	flag.StringVar(&dnsServer, "dns-server", "", "DNS Server")
 	flags.BoolVar(&watchIngress, "watch-ingress", true, "Configure hostnames found in ingress resources")
+	flags.BoolVar(&watchGateway, "watch-gateway", false, "Configure hostnames found in Gateway API gateway, httproute and grpcroute resources")
 	flags.StringSliceVar(&gossipSeeds, "gossip-seed", gossipSeeds, "If set, will enable gossip zones and seed using the provided addresses")
 	flags.StringSliceVarP(&zones, "zone", "z", []string{}, "Configure permitted zones and their mappings")
-	flags.StringVar(&dnsProviderID, "dns", "aws-route53", "DNS provider we should use (aws-route53, google-clouddns, digitalocean, gossip, openstack-designate, scaleway)")
+	flags.StringVar(&dnsProviderID, "dns", "aws-route53", "DNS provider we should use (aws-route53, google-clouddns, digitalocean, gossip, openstack-designate, powerdns, rfc2136, scaleway)")
 	flag.StringVar(&gossipProtocol, "gossip-protocol", "mesh", "mesh/memberlist")
 	flags.StringVar(&gossipListen, "gossip-listen", fmt.Sprintf("0.0.0.0:%d", wellknownports.DNSControllerGossipWeaveMesh), "The address on which to listen if gossip is enabled")
 	flags.StringVar(&gossipSecret, "gossip-secret", gossipSecret, "Secret to use to secure gossip")
+	flags.StringArrayVar(&gossipAdditionalSecrets, "gossip-additional-secrets", nil, "Additional secrets to accept on gossip, so that the gossip secret can be rotated")
+	flags.StringVar(&gossipSealing, "gossip-sealing", "", "Sealing of the memberlist gossip state with the secret: Enforced, Permissive or Disabled")
 	flag.StringVar(&gossipProtocolSecondary, "gossip-protocol-secondary", "", "mesh/memberlist")
 	flag.StringVar(&gossipListenSecondary, "gossip-listen-secondary", fmt.Sprintf("0.0.0.0:%d", wellknownports.DNSControllerGossipMemberlist), "address:port on which to bind for gossip")
 	flags.StringVar(&gossipSecretSecondary, "gossip-secret-secondary", gossipSecret, "Secret to use to secure gossip")
+	flags.StringArrayVar(&gossipAdditionalSecretsSecondary, "gossip-additional-secrets-secondary", nil, "Additional secrets to accept on the secondary gossip, so that the gossip secret can be rotated")
+	flags.StringVar(&gossipSealingSecondary, "gossip-sealing-secondary", "", "Sealing of the secondary memberlist gossip state with the secret: Enforced, Permissive or Disabled")
 	flags.StringSliceVar(&gossipSeedsSecondary, "gossip-seed-secondary", gossipSeedsSecondary, "If set, will enable gossip zones and seed using the provided addresses")
 	flags.BoolVar(&internalIpv4, "internal-ipv4", internalIpv4, "Internal network has IPv4")
 	flags.BoolVar(&internalIpv6, "internal-ipv6", internalIpv6, "Internal network has IPv6")
 	flags.StringVar(&watchNamespace, "watch-namespace", "", "Limits the functionality for pods, services and ingress to specific namespace, by default all")
 	flag.IntVar(&route53.MaxBatchSize, "route53-batch-size", route53.MaxBatchSize, "Maximum number of operations performed per changeset batch")
-	flag.StringVar(&metricsListen, "metrics-listen", "", "The address on which to listen for Prometheus metrics.")
+	flag.StringVar(&metricsListen, "metrics-listen", "", "The address on which to listen for Prometheus metrics and health checks.")
+	flags.DurationVar(&syncStaleThreshold, "sync-stale-threshold", 10*time.Minute, "The readiness check fails when DNS records have not been synchronized for longer than this.")
 	flags.IntVar(&updateInterval, "update-interval", 5, "Configure interval at which to update DNS records.")
+	flags.StringVar(&txtOwnerID, "txt-owner-id", "", "If set, record ownership of DNS records in TXT records with this owner id, and only update or delete owned records")
 
 	// Trick to avoid 'logging before flag.Parse' warning
 	flag.CommandLine.Parse([]string{})
@@ -102,13 +116,6 @@ func main() {
 		os.Exit(1)
 	}
 
-	if metricsListen != "" {
-		go func() {
-			http.Handle("/metrics", promhttp.Handler())
-			log.Fatal(http.ListenAndServe(metricsListen, nil))
-		}()
-	}
-
 	zoneRules, err := dns.ParseZoneRules(zones)
 	if err != nil {
 		klog.Errorf("unexpected zone flags: %q", err)
@@ -126,6 +133,11 @@ func main() {
 		klog.Fatalf("error building REST client: %v", err)
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		klog.Fatalf("error building dynamic client: %v", err)
+	}
+
 	var dnsProviders []dnsprovider.Interface
 	if dnsProviderID != "gossip" {
 		var file io.Reader
@@ -154,7 +166,7 @@ func main() {
 		channelName := "dns"
 		var gossipState gossip.GossipState
 
-		gossipState, err = gossip.GetGossipState(gossipProtocol, gossipListen, channelName, gossipName, []byte(gossipSecret), gossipSeeds)
+		gossipState, err = gossip.GetGossipState(gossipProtocol, gossipListen, channelName, gossipName, gossip.NewKeyring(gossipSecret, gossipAdditionalSecrets, gossipSealing), gossipSeeds)
 		if err != nil {
 			klog.Errorf("Error initializing gossip: %v", err)
 			os.Exit(1)
@@ -162,7 +174,7 @@ func main() {
 
 		if gossipProtocolSecondary != "" {
 
-			secondaryGossipState, err := gossip.GetGossipState(gossipProtocolSecondary, gossipListenSecondary, channelName, gossipName, []byte(gossipSecretSecondary), gossip.NewStaticSeedProvider(gossipSeedsSecondary))
+			secondaryGossipState, err := gossip.GetGossipState(gossipProtocolSecondary, gossipListenSecondary, channelName, gossipName, gossip.NewKeyring(gossipSecretSecondary, gossipAdditionalSecretsSecondary, gossipSealingSecondary), gossip.NewStaticSeedProvider(gossipSeedsSecondary))
 			if err != nil {
 				klog.Errorf("Error initializing secondary gossip: %v", err)
 				os.Exit(1)
@@ -195,14 +207,33 @@ func main() {
 		dnsProviders = append(dnsProviders, dnsProvider)
 	}
 
-	dnsController, err := dns.NewDNSController(dnsProviders, zoneRules, updateInterval)
+	dnsController, err := dns.NewDNSController(dnsProviders, zoneRules, updateInterval, txtOwnerID)
 	if err != nil {
 		klog.Errorf("Error building DNS controller: %v", err)
 		os.Exit(1)
 	}
 
+	dnsController.SetEventRecorder(watchers.NewEventRecorder(client))
+
+	if metricsListen != "" {
+		go func() {
+			http.Handle("/metrics", promhttp.Handler())
+			http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("ok"))
+			})
+			http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+				if err := dnsController.CheckSynced(syncStaleThreshold); err != nil {
+					http.Error(w, err.Error(), http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte("ok"))
+			})
+			log.Fatal(http.ListenAndServe(metricsListen, nil))
+		}()
+	}
+
 	// @step: initialize the watchers
-	if err := initializeWatchers(client, dnsController, watchNamespace, watchIngress, internalRecordTypes); err != nil {
+	if err := initializeWatchers(client, dynamicClient, dnsController, watchNamespace, watchIngress, watchGateway, internalRecordTypes); err != nil {
 		klog.Errorf("%s", err)
 		os.Exit(1)
 	}
@@ -212,7 +243,7 @@ func main() {
 }
 
 // initializeWatchers is responsible for creating the watchers
-func initializeWatchers(client kubernetes.Interface, dnsctl *dns.DNSController, namespace string, watchIngress bool, internalRecordTypes []dns.RecordType) error {
+func initializeWatchers(client kubernetes.Interface, dynamicClient dynamic.Interface, dnsctl *dns.DNSController, namespace string, watchIngress, watchGateway bool, internalRecordTypes []dns.RecordType) error {
 	klog.V(1).Infof("initializing the watch controllers, namespace: %q", namespace)
 
 	nodeController, err := watchers.NewNodeController(client, dnsctl, internalRecordTypes)
@@ -240,6 +271,25 @@ func initializeWatchers(client kubernetes.Interface, dnsctl *dns.DNSController,
 		klog.Infof("Ingress controller disabled")
 	}
 
+	var gatewayController *watchers.GatewayController
+	var httpRouteController, grpcRouteController *watchers.RouteController
+	if watchGateway {
+		gatewayController, err = watchers.NewGatewayController(dynamicClient, dnsctl, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the gateway controller, error: %v", err)
+		}
+		httpRouteController, err = watchers.NewHTTPRouteController(dynamicClient, dnsctl, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the httproute controller, error: %v", err)
+		}
+		grpcRouteController, err = watchers.NewGRPCRouteController(dynamicClient, dnsctl, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the grpcroute controller, error: %v", err)
+		}
+	} else {
+		klog.Infof("Gateway controller disabled")
+	}
+
 	go nodeController.Run()
 	go podController.Run()
 	go serviceController.Run()
@@ -248,5 +298,11 @@ func initializeWatchers(client kubernetes.Interface, dnsctl *dns.DNSController,
 		go ingressController.Run()
 	}
 
+	if watchGateway {
+		go gatewayController.Run()
+		go httpRouteController.Run()
+		go grpcRouteController.Run()
+	}
+
 	return nil
 }
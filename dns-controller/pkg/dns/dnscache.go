@@ -29,26 +29,33 @@ import (
 type dnsCache struct {
 	// zonesProviders is a slice of configured DNS providers
 	zonesProviders []dnsprovider.Zones
+	// capabilities are the capabilities of the DNS providers, in the same order as zonesProviders
+	capabilities []dnsprovider.Capabilities
 
 	// mutex protects the following mutable state
 	mutex sync.Mutex
 
 	cachedZones          []dnsprovider.Zone
 	cachedZonesTimestamp int64
+	// cachedZoneCapabilities are the capabilities of the DNS provider of each of the cachedZones
+	cachedZoneCapabilities map[string]dnsprovider.Capabilities
 }
 
 func newDNSCache(providers []dnsprovider.Interface) (*dnsCache, error) {
 	var zonesProviders []dnsprovider.Zones
+	var capabilities []dnsprovider.Capabilities
 	for _, provider := range providers {
 		zonesProvider, ok := provider.Zones()
 		if !ok {
 			return nil, fmt.Errorf("DNS provider does not support zones")
 		}
 		zonesProviders = append(zonesProviders, zonesProvider)
+		capabilities = append(capabilities, dnsprovider.GetCapabilities(provider))
 	}
 
 	return &dnsCache{
 		zonesProviders: zonesProviders,
+		capabilities:   capabilities,
 	}, nil
 }
 
@@ -75,16 +82,34 @@ func (d *dnsCache) ListZones(validity time.Duration) ([]dnsprovider.Zone, error)
 	}
 
 	var allZones []dnsprovider.Zone
-	for _, zonesProvider := range d.zonesProviders {
+	zoneCapabilities := make(map[string]dnsprovider.Capabilities)
+	for i, zonesProvider := range d.zonesProviders {
+		start := time.Now()
 		zones, err := zonesProvider.List()
+		observeProviderRequest(operationListZones, start, err)
 		if err != nil {
 			return nil, fmt.Errorf("error querying for DNS zones: %v", err)
 		}
 
+		for _, zone := range zones {
+			zoneCapabilities[zone.Name()+"::"+zone.ID()] = d.capabilities[i]
+		}
 		allZones = append(allZones, zones...)
 	}
 	d.cachedZones = allZones
 	d.cachedZonesTimestamp = now
+	d.cachedZoneCapabilities = zoneCapabilities
 
 	return allZones, nil
 }
+
+// Capabilities returns the capabilities of the DNS provider of a zone returned by ListZones
+func (d *dnsCache) Capabilities(zone dnsprovider.Zone) dnsprovider.Capabilities {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if capabilities, found := d.cachedZoneCapabilities[zone.Name()+"::"+zone.ID()]; found {
+		return capabilities
+	}
+	return dnsprovider.DefaultCapabilities
+}
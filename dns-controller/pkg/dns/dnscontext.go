@@ -36,4 +36,13 @@ type Scope interface {
 
 	// AllKeys gets the set of all keys currently in the scope
 	AllKeys() []string
+
+	// Warning reports a problem with the records for recordName to the object they were configured on
+	Warning(recordName string, reason string, message string)
+}
+
+// EventRecorder reports problems with records to the objects they were configured on
+type EventRecorder interface {
+	// Warning reports a problem with the records set for recordName in the named scope
+	Warning(scope string, recordName string, reason string, message string)
 }
@@ -19,6 +19,7 @@ package dns
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -58,10 +59,22 @@ type DNSController struct {
 	// changeCount is a change-counter, which helps us avoid computation when nothing has changed
 	changeCount uint64
 
+	// pendingSince is when we first saw changes that have not yet been applied to DNS, or zero if there are none
+	pendingSince time.Time
+	// lastSyncTime is when DNS was last known to match the desired records, or zero if it never was
+	lastSyncTime time.Time
+
 	// failCount is a fail-counter for exponential backoff, reset on success
 	failCount uint64
 	// update loop frequency (seconds)
 	updateInterval time.Duration
+
+	// ownerID identifies this dns-controller in the TXT records recording ownership of DNS records.
+	// If empty, ownership is not recorded and all records are considered to be owned.
+	ownerID string
+
+	// eventRecorder reports problems with records to the objects they were configured on, if set
+	eventRecorder EventRecorder
 }
 
 // DNSController is a Context
@@ -88,7 +101,7 @@ type DNSControllerScope struct {
 var _ Scope = &DNSControllerScope{}
 
 // NewDNSController creates a DnsController
-func NewDNSController(dnsProviders []dnsprovider.Interface, zoneRules *ZoneRules, updateInterval int) (*DNSController, error) {
+func NewDNSController(dnsProviders []dnsprovider.Interface, zoneRules *ZoneRules, updateInterval int, ownerID string) (*DNSController, error) {
 	dnsCache, err := newDNSCache(dnsProviders)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing DNS cache: %v", err)
@@ -99,11 +112,17 @@ func NewDNSController(dnsProviders []dnsprovider.Interface, zoneRules *ZoneRules
 		zoneRules:      zoneRules,
 		dnsCache:       dnsCache,
 		updateInterval: time.Duration(updateInterval) * time.Second,
+		ownerID:        ownerID,
 	}
 
 	return c, nil
 }
 
+// SetEventRecorder sets the EventRecorder that problems with records are reported to
+func (c *DNSController) SetEventRecorder(eventRecorder EventRecorder) {
+	c.eventRecorder = eventRecorder
+}
+
 // Run starts the DnsController.
 func (c *DNSController) Run() {
 	klog.Infof("starting DNS controller")
@@ -123,7 +142,11 @@ func (c *DNSController) runWatcher(stopCh <-chan struct{}) {
 			return
 		}
 
+		c.updatePendingChangesAge()
+
 		if err != nil {
+			syncFailuresTotal.Inc()
+
 			// Increment the update failure counter
 			failures := atomic.AddUint64(&c.failCount, 1)
 			// Avoid overflowing the exponential backoff interval
@@ -144,10 +167,31 @@ func (c *DNSController) runWatcher(stopCh <-chan struct{}) {
 
 type snapshot struct {
 	changeCount  uint64
-	records      []Record
+	records      []snapshotRecord
 	aliasTargets map[string][]Record
 
-	recordValues map[recordKey][]string
+	recordValues  map[recordKey][]string
+	recordOptions map[recordKey]recordOptions
+}
+
+// snapshotRecord is a record in a snapshot, along with where it was set
+type snapshotRecord struct {
+	Record
+	source recordSource
+}
+
+// recordSource identifies where a record was set
+type recordSource struct {
+	scope      string
+	recordName string
+}
+
+// recordOptions are the settings of a record set other than its values
+type recordOptions struct {
+	// TTL is the time-to-live of the record set in seconds
+	TTL int64
+	// RoutingPolicy is the routing policy of the record set
+	RoutingPolicy dnsprovider.RoutingPolicy
 }
 
 func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
@@ -162,29 +206,43 @@ func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
 
 	if c.lastSuccessfulSnapshot != nil && s.changeCount == c.lastSuccessfulSnapshot.changeCount {
 		klog.V(6).Infof("No changes since DNS values last successfully applied")
+		c.markSynced()
 		return nil
 	}
 
+	if c.pendingSince.IsZero() {
+		c.pendingSince = time.Now()
+	}
+
 	recordCount := 0
+	for _, scope := range c.scopes {
+		scopeRecordCount := 0
+		for _, scopeRecords := range scope.Records {
+			scopeRecordCount += len(scopeRecords)
+		}
+		scopeRecords.WithLabelValues(scope.ScopeName).Set(float64(scopeRecordCount))
+		recordCount += scopeRecordCount
+	}
+
 	for _, scope := range c.scopes {
 		if !scope.Ready {
 			klog.Infof("scope not yet ready: %s", scope.ScopeName)
 			return nil
 		}
-		for _, scopeRecords := range scope.Records {
-			recordCount += len(scopeRecords)
-		}
 	}
 
-	records := make([]Record, 0, recordCount)
+	records := make([]snapshotRecord, 0, recordCount)
 	for _, scope := range c.scopes {
-		for _, scopeRecords := range scope.Records {
+		for recordName, scopeRecords := range scope.Records {
 			for i := range scopeRecords {
 				r := &scopeRecords[i]
 				if r.AliasTarget {
 					aliasTargets[r.FQDN] = append(aliasTargets[r.FQDN], *r)
 				} else {
-					records = append(records, *r)
+					records = append(records, snapshotRecord{
+						Record: *r,
+						source: recordSource{scope: scope.ScopeName, recordName: recordName},
+					})
 				}
 			}
 		}
@@ -199,6 +257,8 @@ func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
 type recordKey struct {
 	RecordType RecordType
 	FQDN       string
+	// SetIdentifier distinguishes record sets with the same name and type that have a routing policy
+	SetIdentifier string
 }
 
 func (c *DNSController) runOnce() error {
@@ -211,9 +271,32 @@ func (c *DNSController) runOnce() error {
 	}
 
 	newValueMap := make(map[recordKey][]string)
+	newOptionsMap := make(map[recordKey]recordOptions)
+	sources := make(map[recordKey][]recordSource)
 	{
+		addValue := func(r *snapshotRecord, key recordKey, value string) {
+			newValueMap[key] = append(newValueMap[key], value)
+
+			// The record set uses the lowest TTL of its records
+			options := newOptionsMap[key]
+			if r.TTL != 0 && (options.TTL == 0 || r.TTL < options.TTL) {
+				options.TTL = r.TTL
+			}
+			if options.RoutingPolicy.Type == "" {
+				options.RoutingPolicy = r.RoutingPolicy
+			} else if options.RoutingPolicy != r.RoutingPolicy {
+				klog.Warningf("Ignoring conflicting routing policy of %s for %s", r, key)
+			}
+			newOptionsMap[key] = options
+
+			if !slices.Contains(sources[key], r.source) {
+				sources[key] = append(sources[key], r.source)
+			}
+		}
+
 		// Resolve and build map
-		for _, r := range snapshot.records {
+		for i := range snapshot.records {
+			r := &snapshot.records[i]
 			if r.RecordType == RecordTypeAlias {
 				aliasRecords := snapshot.aliasTargets[r.Value]
 				if len(aliasRecords) == 0 {
@@ -221,19 +304,21 @@ func (c *DNSController) runOnce() error {
 				}
 				for _, aliasRecord := range aliasRecords {
 					key := recordKey{
-						RecordType: aliasRecord.RecordType,
-						FQDN:       r.FQDN,
+						RecordType:    aliasRecord.RecordType,
+						FQDN:          EnsureDotSuffix(r.FQDN),
+						SetIdentifier: r.RoutingPolicy.SetIdentifier,
 					}
 					// TODO: Support chains: alias of alias (etc)
-					newValueMap[key] = append(newValueMap[key], aliasRecord.Value)
+					addValue(r, key, aliasRecord.Value)
 				}
 				continue
 			} else {
 				key := recordKey{
-					RecordType: r.RecordType,
-					FQDN:       r.FQDN,
+					RecordType:    r.RecordType,
+					FQDN:          EnsureDotSuffix(r.FQDN),
+					SetIdentifier: r.RoutingPolicy.SetIdentifier,
 				}
-				newValueMap[key] = append(newValueMap[key], r.Value)
+				addValue(r, key, r.Value)
 				continue
 			}
 		}
@@ -243,17 +328,33 @@ func (c *DNSController) runOnce() error {
 			sort.Strings(values)
 			newValueMap[k] = values
 		}
+		for k, options := range newOptionsMap {
+			if options.TTL == 0 {
+				options.TTL = int64(DefaultTTL.Seconds())
+				newOptionsMap[k] = options
+			}
+		}
 		snapshot.recordValues = newValueMap
+		snapshot.recordOptions = newOptionsMap
+	}
+
+	op, err := newDNSOp(c.zoneRules, c.dnsCache, c.ownerID)
+	if err != nil {
+		return err
 	}
 
 	var oldValueMap map[recordKey][]string
+	var oldOptionsMap map[recordKey]recordOptions
 	if c.lastSuccessfulSnapshot != nil {
 		oldValueMap = c.lastSuccessfulSnapshot.recordValues
-	}
-
-	op, err := newDNSOp(c.zoneRules, c.dnsCache)
-	if err != nil {
-		return err
+		oldOptionsMap = c.lastSuccessfulSnapshot.recordOptions
+	} else if c.ownerID != "" {
+		// On startup, compare against the records we own, so that we garbage collect
+		// records that are no longer in any scope, e.g. because they were removed while we were not running.
+		oldValueMap, oldOptionsMap, err = op.listOwnedRecords()
+		if err != nil {
+			return err
+		}
 	}
 
 	// Store a list of all the errors, so that one bad apple doesn't block every other request
@@ -265,14 +366,19 @@ func (c *DNSController) runOnce() error {
 			return fmt.Errorf("stop requested")
 		}
 		oldValues := oldValueMap[k]
+		newOptions := newOptionsMap[k]
 
-		if util.StringSlicesEqual(newValues, oldValues) {
+		if util.StringSlicesEqual(newValues, oldValues) && newOptions == oldOptionsMap[k] {
 			klog.V(4).Infof("no change to records for %s", k)
 			continue
 		}
 
-		ttl := DefaultTTL
-		klog.Infof("Using default TTL of %v", ttl)
+		if err := op.checkCapabilities(k, newOptions); err != nil {
+			// Retrying won't help, so we report the problem rather than failing the update
+			klog.Warningf("not updating records for %s: %v", k, err)
+			c.reportWarning(sources[k], "UnsupportedDNSRecord", err.Error())
+			continue
+		}
 
 		klog.V(4).Infof("updating records for %s: %v -> %v", k, oldValues, newValues)
 
@@ -293,7 +399,7 @@ func (c *DNSController) runOnce() error {
 			dedup = append(dedup, s)
 		}
 
-		err := op.updateRecords(k, dedup, int64(ttl.Seconds()))
+		err := op.updateRecords(k, dedup, newOptions)
 		if err != nil {
 			klog.Infof("error updating records for %s: %v", k, err)
 			errors = append(errors, err)
@@ -322,7 +428,7 @@ func (c *DNSController) runOnce() error {
 		}
 
 		klog.V(2).Infof("Applying DNS changeset for zone %s", key)
-		if err := changeset.Apply(ctx); err != nil {
+		if err := changeset.apply(ctx); err != nil {
 			klog.Warningf("error applying DNS changeset for zone %s: %v", key, err)
 			errors = append(errors, fmt.Errorf("error applying DNS changeset for zone %s: %v", key, err))
 		}
@@ -336,13 +442,15 @@ func (c *DNSController) runOnce() error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	c.lastSuccessfulSnapshot = snapshot
+	c.pendingSince = time.Time{}
+	c.markSynced()
 	return nil
 }
 
 func (c *DNSController) RemoveRecordsImmediate(records []Record) error {
 	ctx := context.TODO()
 
-	op, err := newDNSOp(c.zoneRules, c.dnsCache)
+	op, err := newDNSOp(c.zoneRules, c.dnsCache, c.ownerID)
 	if err != nil {
 		return err
 	}
@@ -352,8 +460,9 @@ func (c *DNSController) RemoveRecordsImmediate(records []Record) error {
 
 	for _, r := range records {
 		k := recordKey{
-			RecordType: r.RecordType,
-			FQDN:       r.FQDN,
+			RecordType:    r.RecordType,
+			FQDN:          r.FQDN,
+			SetIdentifier: r.RoutingPolicy.SetIdentifier,
 		}
 
 		err := op.deleteRecords(k)
@@ -365,7 +474,7 @@ func (c *DNSController) RemoveRecordsImmediate(records []Record) error {
 
 	for key, changeset := range op.changesets {
 		klog.V(2).Infof("Applying DNS changeset for zone %s", key)
-		if err := changeset.Apply(ctx); err != nil {
+		if err := changeset.apply(ctx); err != nil {
 			klog.Warningf("error applying DNS changeset for zone %s: %v", key, err)
 			errors = append(errors, fmt.Errorf("error applying DNS changeset for zone %s: %v", key, err))
 		}
@@ -378,16 +487,62 @@ func (c *DNSController) RemoveRecordsImmediate(records []Record) error {
 	return nil
 }
 
+// markSynced records that DNS matches the desired records; the caller must hold the mutex
+func (c *DNSController) markSynced() {
+	c.lastSyncTime = time.Now()
+	lastSuccessfulSyncTimestamp.Set(float64(c.lastSyncTime.Unix()))
+}
+
+// updatePendingChangesAge updates the metric of the age of the changes that have not yet been applied to DNS
+func (c *DNSController) updatePendingChangesAge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.pendingSince.IsZero() {
+		pendingChangesAge.Set(0)
+	} else {
+		pendingChangesAge.Set(time.Since(c.pendingSince).Seconds())
+	}
+}
+
+// CheckSynced returns an error if DNS has not been known to match the desired records for longer than maxAge,
+// for example because the DNS provider is failing, or the watchers have not yet listed the initial resources
+func (c *DNSController) CheckSynced(maxAge time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.lastSyncTime.IsZero() {
+		return fmt.Errorf("DNS records have not yet been synchronized")
+	}
+	if age := time.Since(c.lastSyncTime); age > maxAge {
+		return fmt.Errorf("DNS records were last synchronized %s ago", age.Round(time.Second))
+	}
+	return nil
+}
+
+// reportWarning reports a problem with a record set to the objects its records were configured on
+func (c *DNSController) reportWarning(sources []recordSource, reason string, message string) {
+	if c.eventRecorder == nil {
+		return
+	}
+	for _, source := range sources {
+		c.eventRecorder.Warning(source.scope, source.recordName, reason, message)
+	}
+}
+
 // dnsOp manages a single dns change; we cache results and state for the duration of the operation
 type dnsOp struct {
 	dnsCache     *dnsCache
 	zones        map[string]dnsprovider.Zone
 	recordsCache map[string][]dnsprovider.ResourceRecordSet
 
-	changesets map[string]dnsprovider.ResourceRecordChangeset
+	changesets map[string]*countingChangeset
+
+	// ownerID identifies us in the TXT records recording ownership, if set
+	ownerID string
 }
 
-func newDNSOp(zoneRules *ZoneRules, dnsCache *dnsCache) (*dnsOp, error) {
+func newDNSOp(zoneRules *ZoneRules, dnsCache *dnsCache, ownerID string) (*dnsOp, error) {
 	zones, err := dnsCache.ListZones(zoneListCacheValidity)
 	if err != nil {
 		return nil, fmt.Errorf("error querying for zones: %v", err)
@@ -425,8 +580,9 @@ func newDNSOp(zoneRules *ZoneRules, dnsCache *dnsCache) (*dnsOp, error) {
 	o := &dnsOp{
 		dnsCache:     dnsCache,
 		zones:        zoneMap,
-		changesets:   make(map[string]dnsprovider.ResourceRecordChangeset),
+		changesets:   make(map[string]*countingChangeset),
 		recordsCache: make(map[string][]dnsprovider.ResourceRecordSet),
+		ownerID:      ownerID,
 	}
 
 	return o, nil
@@ -462,7 +618,10 @@ func (o *dnsOp) getChangeset(zone dnsprovider.Zone) (dnsprovider.ResourceRecordC
 		if !ok {
 			return nil, fmt.Errorf("zone does not support resource records %q", zone.Name())
 		}
-		changeset = rrsProvider.StartChangeset()
+		changeset = &countingChangeset{
+			ResourceRecordChangeset: rrsProvider.StartChangeset(),
+			zoneName:                zone.Name(),
+		}
 		o.changesets[key] = changeset
 	}
 
@@ -482,7 +641,9 @@ func (o *dnsOp) listRecords(zone dnsprovider.Zone) ([]dnsprovider.ResourceRecord
 
 		klog.V(2).Infof("Querying all dnsprovider records for zone %q", zone.Name())
 		var err error
+		start := time.Now()
 		rrs, err = rrsProvider.List()
+		observeProviderRequest(operationListRecords, start, err)
 		if err != nil {
 			return nil, fmt.Errorf("error querying resource records for zone %q: %v", zone.Name(), err)
 		}
@@ -510,11 +671,25 @@ func (o *dnsOp) deleteRecords(k recordKey) error {
 		return fmt.Errorf("error querying resource records for zone %q: %v", zone.Name(), err)
 	}
 
+	ownershipRecord, owned, err := o.findOwnershipRecord(zone, k)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		klog.Warningf("Not deleting records for %s, they are not owned by %q", k, o.ownerID)
+		return nil
+	}
+
 	cs, err := o.getChangeset(zone)
 	if err != nil {
 		return err
 	}
 
+	if ownershipRecord != nil {
+		klog.V(2).Infof("Deleting ownership record %s", ownershipRecord.Name())
+		cs.Remove(ownershipRecord)
+	}
+
 	for _, rr := range rrs {
 		rrName := EnsureDotSuffix(rr.Name())
 		if rrName != fqdn {
@@ -525,6 +700,10 @@ func (o *dnsOp) deleteRecords(k recordKey) error {
 			klog.V(8).Infof("Skipping delete of record %q (type %s != %s)", rrName, rr.Type(), k.RecordType)
 			continue
 		}
+		if setIdentifier := dnsprovider.GetRoutingPolicy(rr).SetIdentifier; setIdentifier != k.SetIdentifier {
+			klog.V(8).Infof("Skipping delete of record %q (set identifier %q != %q)", rrName, setIdentifier, k.SetIdentifier)
+			continue
+		}
 
 		klog.V(2).Infof("Deleting resource record %s %s", rrName, rr.Type())
 		cs.Remove(rr)
@@ -537,7 +716,7 @@ func FixWildcards(s string) string {
 	return strings.Replace(s, "\\052", "*", 1)
 }
 
-func (o *dnsOp) updateRecords(k recordKey, newRecords []string, ttl int64) error {
+func (o *dnsOp) updateRecords(k recordKey, newRecords []string, options recordOptions) error {
 	fqdn := EnsureDotSuffix(k.FQDN)
 
 	zone := o.findZone(fqdn)
@@ -569,6 +748,10 @@ func (o *dnsOp) updateRecords(k recordKey, newRecords []string, ttl int64) error
 			klog.V(8).Infof("Skipping record %q (type %s != %s)", rrName, rr.Type(), k.RecordType)
 			continue
 		}
+		if setIdentifier := dnsprovider.GetRoutingPolicy(rr).SetIdentifier; setIdentifier != k.SetIdentifier {
+			klog.V(8).Infof("Skipping record %q (set identifier %q != %q)", rrName, setIdentifier, k.SetIdentifier)
+			continue
+		}
 
 		if existing != nil {
 			klog.Warningf("Found multiple matching records: %v and %v", existing, rr)
@@ -578,15 +761,72 @@ func (o *dnsOp) updateRecords(k recordKey, newRecords []string, ttl int64) error
 		existing = rr
 	}
 
+	ownershipRecord, owned, err := o.findOwnershipRecord(zone, k)
+	if err != nil {
+		return err
+	}
+	if ownershipRecord != nil && !owned {
+		klog.Warningf("Not updating records for %s, they are not owned by %q", k, o.ownerID)
+		return nil
+	}
+	if existing != nil && !owned {
+		// Records without an ownership record, such as the placeholder records created by kOps and the records
+		// published before the owner id was set, are adopted when we publish a record with the same name.
+		klog.Infof("Adopting records for %s", k)
+	}
+
 	cs, err := o.getChangeset(zone)
 	if err != nil {
 		return err
 	}
 
 	klog.V(2).Infof("Adding DNS changes to batch %s %s", k, newRecords)
-	rr := rrsProvider.New(fqdn, newRecords, ttl, rrstype.RrsType(k.RecordType))
+	rr, err := newResourceRecordSet(rrsProvider, fqdn, newRecords, rrstype.RrsType(k.RecordType), options)
+	if err != nil {
+		return err
+	}
 	cs.Upsert(rr)
 
+	if o.ownerID != "" {
+		// The ownership record has the same routing policy, so that there is one for each set identifier
+		rr, err := newResourceRecordSet(rrsProvider, ownershipRecordName(k), []string{ownershipRecordValue(o.ownerID)}, rrstype.TXT, options)
+		if err != nil {
+			return err
+		}
+		cs.Upsert(rr)
+	}
+
+	return nil
+}
+
+// newResourceRecordSet builds a record set with the given options
+func newResourceRecordSet(rrsProvider dnsprovider.ResourceRecordSets, fqdn string, rrdatas []string, recordType rrstype.RrsType, options recordOptions) (dnsprovider.ResourceRecordSet, error) {
+	if options.RoutingPolicy.Type == "" {
+		return rrsProvider.New(fqdn, rrdatas, options.TTL, recordType), nil
+	}
+
+	routingPolicyProvider, ok := rrsProvider.(dnsprovider.RoutingPolicyResourceRecordSets)
+	if !ok {
+		return nil, fmt.Errorf("DNS provider does not support routing policies")
+	}
+	return routingPolicyProvider.NewWithRoutingPolicy(fqdn, rrdatas, options.TTL, recordType, options.RoutingPolicy), nil
+}
+
+// checkCapabilities returns an error if the DNS provider of the zone of the record set does not support its type or options
+func (o *dnsOp) checkCapabilities(k recordKey, options recordOptions) error {
+	zone := o.findZone(EnsureDotSuffix(k.FQDN))
+	if zone == nil {
+		// updateRecords reports the missing zone
+		return nil
+	}
+
+	capabilities := o.dnsCache.Capabilities(zone)
+	if !capabilities.SupportsRecordType(rrstype.RrsType(k.RecordType)) {
+		return fmt.Errorf("the DNS provider of zone %q does not support %s records", zone.Name(), k.RecordType)
+	}
+	if !capabilities.SupportsRoutingPolicy(options.RoutingPolicy.Type) {
+		return fmt.Errorf("the DNS provider of zone %q does not support %s routing", zone.Name(), options.RoutingPolicy.Type)
+	}
 	return nil
 }
 
@@ -639,6 +879,14 @@ func (s *DNSControllerScope) AllKeys() []string {
 	return keys
 }
 
+// Warning implements Scope::Warning, reporting the problem through the event recorder of the controller, if set
+func (s *DNSControllerScope) Warning(recordName string, reason string, message string) {
+	if s.parent.eventRecorder == nil {
+		return
+	}
+	s.parent.eventRecorder.Warning(s.ScopeName, recordName, reason, message)
+}
+
 // recordsSliceEquals compares two []Record
 func recordsSliceEquals(l, r []Record) bool {
 	if len(l) != len(r) {
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+)
+
+const metricsNamespace = "dns_controller"
+
+// The operations of the DNS provider API that we record metrics for
+const (
+	operationListZones      = "list_zones"
+	operationListRecords    = "list_records"
+	operationApplyChangeset = "apply_changeset"
+)
+
+var (
+	zoneChangesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "zone_changes_total",
+		Help:      "Number of record set changes applied to each DNS zone, by action (upsert or delete).",
+	}, []string{"zone", "action"})
+
+	providerRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "provider_request_duration_seconds",
+		Help:      "Latency of requests to the DNS provider API, by operation.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"operation"})
+
+	providerErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "provider_errors_total",
+		Help:      "Number of failed requests to the DNS provider API, by operation.",
+	}, []string{"operation"})
+
+	syncFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "sync_failures_total",
+		Help:      "Number of failed attempts to apply the desired records to DNS.",
+	})
+
+	lastSuccessfulSyncTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "last_successful_sync_timestamp_seconds",
+		Help:      "Time at which DNS was last known to match the desired records, in seconds since the epoch.",
+	})
+
+	scopeRecords = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "scope_records",
+		Help:      "Number of desired records in each scope.",
+	}, []string{"scope"})
+
+	pendingChangesAge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "pending_changes_age_seconds",
+		Help:      "Time since changes to the desired records were first seen that have not yet been applied to DNS, or 0 if there are none.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		zoneChangesTotal,
+		providerRequestDuration,
+		providerErrorsTotal,
+		syncFailuresTotal,
+		lastSuccessfulSyncTimestamp,
+		scopeRecords,
+		pendingChangesAge,
+	)
+}
+
+// observeProviderRequest records the latency and result of a request to the DNS provider API
+func observeProviderRequest(operation string, start time.Time, err error) {
+	providerRequestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		providerErrorsTotal.WithLabelValues(operation).Inc()
+	}
+}
+
+// countingChangeset counts the changes added to a changeset, for the zone change metrics
+type countingChangeset struct {
+	dnsprovider.ResourceRecordChangeset
+
+	zoneName string
+	upserts  int
+	removals int
+}
+
+var _ dnsprovider.ResourceRecordChangeset = &countingChangeset{}
+
+// Add implements dnsprovider.ResourceRecordChangeset
+func (c *countingChangeset) Add(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.ResourceRecordChangeset.Add(rrset)
+	c.upserts++
+	return c
+}
+
+// Remove implements dnsprovider.ResourceRecordChangeset
+func (c *countingChangeset) Remove(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.ResourceRecordChangeset.Remove(rrset)
+	c.removals++
+	return c
+}
+
+// Upsert implements dnsprovider.ResourceRecordChangeset
+func (c *countingChangeset) Upsert(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.ResourceRecordChangeset.Upsert(rrset)
+	c.upserts++
+	return c
+}
+
+// apply applies the changeset, recording the request and the changes in the metrics
+func (c *countingChangeset) apply(ctx context.Context) error {
+	start := time.Now()
+	err := c.Apply(ctx)
+	observeProviderRequest(operationApplyChangeset, start, err)
+	if err != nil {
+		return err
+	}
+
+	zoneChangesTotal.WithLabelValues(c.zoneName, "upsert").Add(float64(c.upserts))
+	zoneChangesTotal.WithLabelValues(c.zoneName, "delete").Add(float64(c.removals))
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsroute53 "github.com/aws/aws-sdk-go-v2/service/route53"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53"
+	route53testing "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53/stubs"
+)
+
+func TestDNSControllerCheckSynced(t *testing.T) {
+	ctx := context.TODO()
+
+	service := route53testing.NewRoute53APIStub()
+	if _, err := service.CreateHostedZone(ctx, &awsroute53.CreateHostedZoneInput{
+		CallerReference: aws.String("Nonce"),
+		Name:            aws.String("metrics.example.com."),
+	}); err != nil {
+		t.Fatalf("error creating zone: %v", err)
+	}
+	provider := route53.New(service)
+
+	zoneRules, err := ParseZoneRules(nil)
+	if err != nil {
+		t.Fatalf("error parsing zone rules: %v", err)
+	}
+	c, err := NewDNSController([]dnsprovider.Interface{provider}, zoneRules, 1, "")
+	if err != nil {
+		t.Fatalf("error building DNS controller: %v", err)
+	}
+
+	scope, err := c.CreateScope("service")
+	if err != nil {
+		t.Fatalf("error creating scope: %v", err)
+	}
+	scope.Replace("kube-system/api", []Record{{RecordType: RecordTypeA, FQDN: "api.metrics.example.com.", Value: "10.0.0.1"}})
+
+	// Nothing is applied until the scope is ready
+	if err := c.runOnce(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.CheckSynced(time.Hour); err == nil {
+		t.Errorf("expected CheckSynced to fail before the first sync")
+	}
+
+	scope.MarkReady()
+	if err := c.runOnce(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.CheckSynced(time.Hour); err != nil {
+		t.Errorf("unexpected error from CheckSynced after sync: %v", err)
+	}
+
+	if err := c.CheckSynced(0); err == nil {
+		t.Errorf("expected CheckSynced to fail when the last sync is older than the threshold")
+	}
+}
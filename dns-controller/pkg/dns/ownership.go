@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+// Ownership of records is recorded in a TXT record next to each managed record, similar to the external-dns TXT registry.
+// A record of type A for api.example.com. is owned through the TXT record _dns-controller-a.api.example.com.
+const (
+	// ownershipRecordPrefix is the prefix of the name of the TXT records recording ownership
+	ownershipRecordPrefix = "_dns-controller-"
+	// ownershipWildcardLabel replaces the wildcard label in the name of TXT records recording ownership of wildcard records
+	ownershipWildcardLabel = "_wildcard"
+
+	ownershipHeritage = "heritage=dns-controller"
+	ownershipOwnerKey = "dns-controller/owner="
+)
+
+// ownedRecordTypes are the record types that dns-controller manages, and records ownership of
+var ownedRecordTypes = []RecordType{RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeSRV, RecordTypeTXT}
+
+// ownershipRecordName returns the name of the TXT record that records ownership of the records for k
+func ownershipRecordName(k recordKey) string {
+	fqdn := EnsureDotSuffix(k.FQDN)
+	if strings.HasPrefix(fqdn, "*.") {
+		fqdn = ownershipWildcardLabel + fqdn[1:]
+	}
+	return ownershipRecordPrefix + strings.ToLower(string(k.RecordType)) + "." + fqdn
+}
+
+// parseOwnershipRecordName returns the key of the records that the TXT record with the given name records ownership of
+func parseOwnershipRecordName(name string) (recordKey, bool) {
+	name = EnsureDotSuffix(FixWildcards(name))
+	if !strings.HasPrefix(name, ownershipRecordPrefix) {
+		return recordKey{}, false
+	}
+	recordType, fqdn, found := strings.Cut(strings.TrimPrefix(name, ownershipRecordPrefix), ".")
+	if !found || fqdn == "" {
+		return recordKey{}, false
+	}
+	if strings.HasPrefix(fqdn, ownershipWildcardLabel+".") {
+		fqdn = "*" + strings.TrimPrefix(fqdn, ownershipWildcardLabel)
+	}
+
+	for _, t := range ownedRecordTypes {
+		if strings.EqualFold(string(t), recordType) {
+			return recordKey{RecordType: t, FQDN: fqdn}, true
+		}
+	}
+	return recordKey{}, false
+}
+
+// ownershipRecordValue returns the value of the TXT record that records ownership by ownerID
+func ownershipRecordValue(ownerID string) string {
+	return "\"" + ownershipHeritage + "," + ownershipOwnerKey + ownerID + "\""
+}
+
+// parseOwnershipRecordValue returns the owner recorded in the values of a TXT record,
+// or false if the record was not written by dns-controller
+func parseOwnershipRecordValue(rrdatas []string) (string, bool) {
+	for _, rrdata := range rrdatas {
+		fields := strings.Split(strings.Trim(rrdata, "\""), ",")
+		if len(fields) != 2 || fields[0] != ownershipHeritage || !strings.HasPrefix(fields[1], ownershipOwnerKey) {
+			continue
+		}
+		return strings.TrimPrefix(fields[1], ownershipOwnerKey), true
+	}
+	return "", false
+}
+
+// findRecord returns the record with the given name, type and set identifier in the zone, or nil if there is none
+func (o *dnsOp) findRecord(zone dnsprovider.Zone, fqdn string, recordType rrstype.RrsType, setIdentifier string) (dnsprovider.ResourceRecordSet, error) {
+	rrs, err := o.listRecords(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var found dnsprovider.ResourceRecordSet
+	for _, rr := range rrs {
+		rrName := EnsureDotSuffix(FixWildcards(rr.Name()))
+		if rrName != fqdn || rr.Type() != recordType || dnsprovider.GetRoutingPolicy(rr).SetIdentifier != setIdentifier {
+			continue
+		}
+		if found != nil {
+			klog.Warningf("Found multiple matching records: %v and %v", found, rr)
+		}
+		found = rr
+	}
+	return found, nil
+}
+
+// findOwnershipRecord returns the TXT record recording ownership of the records for k, and whether we own them.
+// Ownership is not tracked if no owner id is configured, so all records are considered owned.
+func (o *dnsOp) findOwnershipRecord(zone dnsprovider.Zone, k recordKey) (dnsprovider.ResourceRecordSet, bool, error) {
+	if o.ownerID == "" {
+		return nil, true, nil
+	}
+
+	rr, err := o.findRecord(zone, ownershipRecordName(k), rrstype.TXT, k.SetIdentifier)
+	if err != nil || rr == nil {
+		return nil, false, err
+	}
+	owner, ok := parseOwnershipRecordValue(rr.Rrdatas())
+	return rr, ok && owner == o.ownerID, nil
+}
+
+// listOwnedRecords returns the values and options of all the records we own in the managed zones
+func (o *dnsOp) listOwnedRecords() (map[recordKey][]string, map[recordKey]recordOptions, error) {
+	owned := make(map[recordKey][]string)
+	ownedOptions := make(map[recordKey]recordOptions)
+
+	seen := make(map[string]bool)
+	for _, zone := range o.zones {
+		key := zone.Name() + "::" + zone.ID()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		rrs, err := o.listRecords(zone)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, rr := range rrs {
+			if rr.Type() != rrstype.TXT {
+				continue
+			}
+			k, ok := parseOwnershipRecordName(rr.Name())
+			if !ok {
+				continue
+			}
+			k.SetIdentifier = dnsprovider.GetRoutingPolicy(rr).SetIdentifier
+			if owner, ok := parseOwnershipRecordValue(rr.Rrdatas()); !ok || owner != o.ownerID {
+				continue
+			}
+			if o.findZone(EnsureDotSuffix(k.FQDN)) != zone {
+				// Another managed zone is responsible for the record
+				continue
+			}
+
+			record, err := o.findRecord(zone, EnsureDotSuffix(k.FQDN), rrstype.RrsType(k.RecordType), k.SetIdentifier)
+			if err != nil {
+				return nil, nil, err
+			}
+			var values []string
+			if record != nil {
+				values = append(values, record.Rrdatas()...)
+				sort.Strings(values)
+				ownedOptions[k] = recordOptions{
+					TTL:           record.Ttl(),
+					RoutingPolicy: dnsprovider.GetRoutingPolicy(record),
+				}
+			}
+			owned[k] = values
+		}
+	}
+
+	return owned, ownedOptions, nil
+}
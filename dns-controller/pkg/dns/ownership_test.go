@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsroute53 "github.com/aws/aws-sdk-go-v2/service/route53"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53"
+	route53testing "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53/stubs"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+func TestOwnershipRecordName(t *testing.T) {
+	grid := []struct {
+		key      recordKey
+		expected string
+	}{
+		{
+			key:      recordKey{RecordType: RecordTypeA, FQDN: "api.example.com"},
+			expected: "_dns-controller-a.api.example.com.",
+		},
+		{
+			key:      recordKey{RecordType: RecordTypeCNAME, FQDN: "www.example.com."},
+			expected: "_dns-controller-cname.www.example.com.",
+		},
+		{
+			key:      recordKey{RecordType: RecordTypeAAAA, FQDN: "*.apps.example.com."},
+			expected: "_dns-controller-aaaa._wildcard.apps.example.com.",
+		},
+	}
+	for _, g := range grid {
+		actual := ownershipRecordName(g.key)
+		if actual != g.expected {
+			t.Errorf("unexpected ownership record name for %v: expected %q, got %q", g.key, g.expected, actual)
+			continue
+		}
+
+		key, ok := parseOwnershipRecordName(actual)
+		if !ok {
+			t.Errorf("unable to parse ownership record name %q", actual)
+		} else if key.RecordType != g.key.RecordType || key.FQDN != EnsureDotSuffix(g.key.FQDN) {
+			t.Errorf("unexpected key parsed from %q: %v", actual, key)
+		}
+	}
+
+	for _, name := range []string{"api.example.com.", "_dns-controller-mx.api.example.com.", "_dns-controller-a."} {
+		if key, ok := parseOwnershipRecordName(name); ok {
+			t.Errorf("unexpectedly parsed %q as ownership record name: %v", name, key)
+		}
+	}
+}
+
+func TestParseOwnershipRecordValue(t *testing.T) {
+	grid := []struct {
+		rrdatas  []string
+		owner    string
+		expected bool
+	}{
+		{
+			rrdatas:  []string{ownershipRecordValue("cluster-a")},
+			owner:    "cluster-a",
+			expected: true,
+		},
+		{
+			rrdatas:  []string{"\"v=spf1 -all\"", "heritage=dns-controller,dns-controller/owner=cluster-b"},
+			owner:    "cluster-b",
+			expected: true,
+		},
+		{
+			rrdatas: []string{"\"heritage=external-dns,external-dns/owner=default\""},
+		},
+	}
+	for _, g := range grid {
+		owner, ok := parseOwnershipRecordValue(g.rrdatas)
+		if ok != g.expected || owner != g.owner {
+			t.Errorf("unexpected owner for %q: expected %q (%v), got %q (%v)", g.rrdatas, g.owner, g.expected, owner, ok)
+		}
+	}
+}
+
+func TestDNSControllerOwnership(t *testing.T) {
+	ctx := context.TODO()
+
+	service := route53testing.NewRoute53APIStub()
+	if _, err := service.CreateHostedZone(ctx, &awsroute53.CreateHostedZoneInput{
+		CallerReference: aws.String("Nonce"),
+		Name:            aws.String("example.com."),
+	}); err != nil {
+		t.Fatalf("error creating zone: %v", err)
+	}
+	provider := route53.New(service)
+
+	// Records created by other tooling, and by dns-controllers with other owner ids
+	addRecords(t, provider, map[string][]string{
+		"A::foreign.example.com.":                                      {"10.0.0.1"},
+		"A::other.example.com.":                                        {"10.0.0.2"},
+		"TXT::_dns-controller-a.other.example.com.":                    {ownershipRecordValue("cluster-b")},
+		"A::stale.example.com.":                                        {"10.0.0.3"},
+		"TXT::_dns-controller-a.stale.example.com.":                    {ownershipRecordValue("cluster-a")},
+		"A::api.example.com.":                                          {"10.0.0.4"},
+		"TXT::_dns-controller-a.api.example.com.":                      {ownershipRecordValue("cluster-a")},
+		"TXT::_dns-controller-a.unrelated.example.com.":                {ownershipRecordValue("cluster-b")},
+		"CNAME::unrelated-cname.example.com.":                          {"foreign.example.com."},
+		"TXT::_dns-controller-cname.orphan.example.com.":               {ownershipRecordValue("cluster-a")},
+		"A::api.internal.example.com.":                                 {"203.0.113.123"},
+		"A::kops-controller.internal.example.com.":                     {"203.0.113.123"},
+		"TXT::_dns-controller-a.kops-controller.internal.example.com.": {ownershipRecordValue("cluster-b")},
+	})
+
+	zoneRules, err := ParseZoneRules(nil)
+	if err != nil {
+		t.Fatalf("error parsing zone rules: %v", err)
+	}
+	c, err := NewDNSController([]dnsprovider.Interface{provider}, zoneRules, 1, "cluster-a")
+	if err != nil {
+		t.Fatalf("error building DNS controller: %v", err)
+	}
+
+	scope, err := c.CreateScope("service")
+	if err != nil {
+		t.Fatalf("error creating scope: %v", err)
+	}
+	scope.Replace("kube-system/api", []Record{{RecordType: RecordTypeA, FQDN: "api.example.com.", Value: "10.1.0.4"}})
+	scope.Replace("kube-system/new", []Record{{RecordType: RecordTypeA, FQDN: "new.example.com.", Value: "10.1.0.5"}})
+	scope.Replace("kube-system/foreign", []Record{{RecordType: RecordTypeA, FQDN: "foreign.example.com.", Value: "10.1.0.1"}})
+	scope.Replace("kube-system/other", []Record{{RecordType: RecordTypeA, FQDN: "other.example.com.", Value: "10.1.0.2"}})
+	scope.Replace("kube-system/api-internal", []Record{{RecordType: RecordTypeA, FQDN: "api.internal.example.com.", Value: "10.1.0.6"}})
+	scope.Replace("kube-system/kops-controller", []Record{{RecordType: RecordTypeA, FQDN: "kops-controller.internal.example.com.", Value: "10.1.0.7"}})
+	scope.MarkReady()
+
+	if err := c.runOnce(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string][]string{
+		"A::other.example.com.":                         {"10.0.0.2"},
+		"TXT::_dns-controller-a.other.example.com.":     {ownershipRecordValue("cluster-b")},
+		"A::api.example.com.":                           {"10.1.0.4"},
+		"TXT::_dns-controller-a.api.example.com.":       {ownershipRecordValue("cluster-a")},
+		"A::new.example.com.":                           {"10.1.0.5"},
+		"TXT::_dns-controller-a.new.example.com.":       {ownershipRecordValue("cluster-a")},
+		"TXT::_dns-controller-a.unrelated.example.com.": {ownershipRecordValue("cluster-b")},
+		"CNAME::unrelated-cname.example.com.":           {"foreign.example.com."},
+		// Records without an ownership record that we publish are adopted, unless another dns-controller owns them
+		"A::foreign.example.com.":                                      {"10.1.0.1"},
+		"TXT::_dns-controller-a.foreign.example.com.":                  {ownershipRecordValue("cluster-a")},
+		"A::api.internal.example.com.":                                 {"10.1.0.6"},
+		"TXT::_dns-controller-a.api.internal.example.com.":             {ownershipRecordValue("cluster-a")},
+		"A::kops-controller.internal.example.com.":                     {"203.0.113.123"},
+		"TXT::_dns-controller-a.kops-controller.internal.example.com.": {ownershipRecordValue("cluster-b")},
+	}
+	if actual := listRecords(t, provider); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected records after startup:\n%v\nexpected:\n%v", actual, expected)
+	}
+
+	// Records removed from a scope are only deleted if we own them
+	scope.Replace("kube-system/new", nil)
+	scope.Replace("kube-system/other", nil)
+	if err := c.runOnce(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	delete(expected, "A::new.example.com.")
+	delete(expected, "TXT::_dns-controller-a.new.example.com.")
+	if actual := listRecords(t, provider); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected records after removal:\n%v\nexpected:\n%v", actual, expected)
+	}
+}
+
+// addRecords creates records keyed by type and name
+func addRecords(t *testing.T, provider dnsprovider.Interface, records map[string][]string) {
+	zone := testZone(t, provider)
+	rrsProvider, _ := zone.ResourceRecordSets()
+
+	cs := rrsProvider.StartChangeset()
+	for key, rrdatas := range records {
+		recordType, name, _ := strings.Cut(key, "::")
+		cs.Add(rrsProvider.New(name, rrdatas, 60, rrstype.RrsType(recordType)))
+	}
+	if err := cs.Apply(context.TODO()); err != nil {
+		t.Fatalf("error adding records: %v", err)
+	}
+}
+
+// listRecords returns the records keyed by type and name
+func listRecords(t *testing.T, provider dnsprovider.Interface) map[string][]string {
+	zone := testZone(t, provider)
+	rrsProvider, _ := zone.ResourceRecordSets()
+
+	rrs, err := rrsProvider.List()
+	if err != nil {
+		t.Fatalf("error listing records: %v", err)
+	}
+
+	records := make(map[string][]string)
+	for _, rr := range rrs {
+		rrdatas := append([]string{}, rr.Rrdatas()...)
+		sort.Strings(rrdatas)
+		records[string(rr.Type())+"::"+EnsureDotSuffix(rr.Name())] = rrdatas
+	}
+	return records
+}
+
+func testZone(t *testing.T, provider dnsprovider.Interface) dnsprovider.Zone {
+	zonesProvider, _ := provider.Zones()
+	zones, err := zonesProvider.List()
+	if err != nil || len(zones) != 1 {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	return zones[0]
+}
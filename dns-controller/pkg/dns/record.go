@@ -16,6 +16,12 @@ limitations under the License.
 
 package dns
 
+import (
+	"strconv"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+)
+
 type RecordType string
 
 const (
@@ -25,6 +31,8 @@ const (
 	RecordTypeA     = "A"
 	RecordTypeAAAA  = "AAAA"
 	RecordTypeCNAME = "CNAME"
+	RecordTypeSRV   = "SRV"
+	RecordTypeTXT   = "TXT"
 
 	RoleTypeExternal = "external"
 	RoleTypeInternal = "internal"
@@ -35,6 +43,12 @@ type Record struct {
 	FQDN       string
 	Value      string
 
+	// TTL is the time-to-live of the record in seconds, or 0 for the DefaultTTL
+	TTL int64
+	// RoutingPolicy is the routing policy of the record.
+	// Records with different set identifiers are managed as separate record sets.
+	RoutingPolicy dnsprovider.RoutingPolicy
+
 	// If AliasTarget is set, this entry will not actually be set in DNS,
 	// but will be used as an expansion for Records with type=RecordTypeAlias,
 	// where the referring record has Value = our FQDN
@@ -49,6 +63,19 @@ func AliasForNodesInRole(role, roleType string) string {
 func (r *Record) String() string {
 	s := "Record:[Type=" + string(r.RecordType) + ",FQDN=" + r.FQDN + ",Value=" + r.Value
 
+	if r.TTL != 0 {
+		s += ",TTL=" + strconv.FormatInt(r.TTL, 10)
+	}
+	if r.RoutingPolicy.Type != "" {
+		s += ",RoutingPolicy=" + string(r.RoutingPolicy.Type) + ",SetIdentifier=" + r.RoutingPolicy.SetIdentifier
+		switch r.RoutingPolicy.Type {
+		case dnsprovider.RoutingPolicyWeighted:
+			s += ",Weight=" + strconv.FormatInt(r.RoutingPolicy.Weight, 10)
+		case dnsprovider.RoutingPolicyGeo:
+			s += ",Location=" + r.RoutingPolicy.Location
+		}
+	}
+
 	if r.AliasTarget {
 		s += ",AliasTarget"
 	}
@@ -16,6 +16,21 @@ limitations under the License.
 
 package watchers
 
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/dns-controller/pkg/dns"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+)
+
 const (
 	// AnnotationNameDNSExternal is used to set up a DNS name for accessing the resource from outside the cluster
 	// For a service of Type=LoadBalancer, it would map to the external LB hostname or IP
@@ -24,4 +39,194 @@ const (
 	// AnnotationNameDNSInternal is used to set up a DNS name for accessing the resource from inside the cluster
 	// This is only supported on Pods currently, and maps to the Internal address
 	AnnotationNameDNSInternal = "dns.alpha.kubernetes.io/internal"
+
+	// AnnotationNameDNSTTL sets the time-to-live of the DNS records of the resource, in seconds or as a duration (e.g. "5m")
+	AnnotationNameDNSTTL = "dns.alpha.kubernetes.io/ttl"
+
+	// AnnotationNameDNSTXT sets TXT records on the DNS names of the resource, with one value per line
+	AnnotationNameDNSTXT = "dns.alpha.kubernetes.io/txt"
+
+	// AnnotationNameDNSSRV enables SRV records for the named ports of a Service, e.g. _https._tcp.<name> for a TCP port named https
+	AnnotationNameDNSSRV = "dns.alpha.kubernetes.io/srv"
+
+	// AnnotationNameDNSSetIdentifier distinguishes the DNS records of the resource from the records with the same names
+	// of other resources or clusters; it is required with a routing policy
+	AnnotationNameDNSSetIdentifier = "dns.alpha.kubernetes.io/set-identifier"
+
+	// AnnotationNameDNSWeight enables weighted routing, with the relative weight of the DNS records of the resource
+	AnnotationNameDNSWeight = "dns.alpha.kubernetes.io/weight"
+
+	// AnnotationNameDNSGeoLocation enables geo routing, serving the DNS records of the resource to clients in a location:
+	// a country code (e.g. "DE"), optionally with a subdivision (e.g. "US-CA"), a continent code (e.g. "continent:EU"), or "*" for all other locations
+	AnnotationNameDNSGeoLocation = "dns.alpha.kubernetes.io/geolocation"
 )
+
+// geoLocationRegex matches the locations of AnnotationNameDNSGeoLocation
+var geoLocationRegex = regexp.MustCompile(`^(\*|[A-Z]{2}(-[A-Z0-9]{1,3})?|` + dnsprovider.GeoLocationContinentPrefix + `[A-Z]{2})$`)
+
+// invalidAnnotationReason is the reason of the events reporting invalid annotations
+const invalidAnnotationReason = "InvalidDNSAnnotation"
+
+// applyRecordAnnotations adds the TXT records, and sets the TTL and routing policy, configured by the annotations of a resource.
+// fqdns are the DNS names of the resource, whose records are set for key in scope; invalid annotations are ignored,
+// and reported as warning events on the resource.
+func applyRecordAnnotations(scope dns.Scope, key string, resource string, records []dns.Record, fqdns []string, annotations map[string]string) []dns.Record {
+	warn := func(message string) {
+		klog.Warningf("%s of %s", message, resource)
+		scope.Warning(key, invalidAnnotationReason, message)
+	}
+
+	if txt := annotations[AnnotationNameDNSTXT]; txt != "" {
+		for _, fqdn := range fqdns {
+			if hasCNAME(records, fqdn) {
+				// A CNAME cannot coexist with other records
+				warn(fmt.Sprintf("Ignoring %s annotation for %s, which is a CNAME", AnnotationNameDNSTXT, fqdn))
+				continue
+			}
+			for _, line := range strings.Split(txt, "\n") {
+				line = strings.TrimSpace(line)
+				if line == "" {
+					continue
+				}
+				records = append(records, dns.Record{
+					RecordType: dns.RecordTypeTXT,
+					FQDN:       fqdn,
+					Value:      quoteTXT(line),
+				})
+			}
+		}
+	}
+
+	ttl, err := parseTTLAnnotation(annotations)
+	if err != nil {
+		warn(fmt.Sprintf("Ignoring invalid %s annotation: %v", AnnotationNameDNSTTL, err))
+	}
+	routingPolicy, err := parseRoutingPolicyAnnotations(annotations)
+	if err != nil {
+		warn(fmt.Sprintf("Ignoring invalid routing policy annotations: %v", err))
+	}
+	for i := range records {
+		records[i].TTL = ttl
+		records[i].RoutingPolicy = routingPolicy
+	}
+
+	return records
+}
+
+// parseTTLAnnotation returns the TTL in seconds set with AnnotationNameDNSTTL, or 0 if it is not set
+func parseTTLAnnotation(annotations map[string]string) (int64, error) {
+	value := strings.TrimSpace(annotations[AnnotationNameDNSTTL])
+	if value == "" {
+		return 0, nil
+	}
+
+	ttl, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number of seconds or a duration", value)
+		}
+		ttl = int64(d.Seconds())
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("TTL must be at least one second, was %q", value)
+	}
+	return ttl, nil
+}
+
+// parseRoutingPolicyAnnotations returns the routing policy set with the routing policy annotations,
+// or the simple routing policy if they are not set
+func parseRoutingPolicyAnnotations(annotations map[string]string) (dnsprovider.RoutingPolicy, error) {
+	setIdentifier := strings.TrimSpace(annotations[AnnotationNameDNSSetIdentifier])
+	weight := strings.TrimSpace(annotations[AnnotationNameDNSWeight])
+	location := strings.TrimSpace(annotations[AnnotationNameDNSGeoLocation])
+
+	policy := dnsprovider.RoutingPolicy{SetIdentifier: setIdentifier}
+	switch {
+	case weight == "" && location == "":
+		if setIdentifier != "" {
+			return dnsprovider.RoutingPolicy{}, fmt.Errorf("%s requires %s or %s", AnnotationNameDNSSetIdentifier, AnnotationNameDNSWeight, AnnotationNameDNSGeoLocation)
+		}
+		return policy, nil
+	case weight != "" && location != "":
+		return dnsprovider.RoutingPolicy{}, fmt.Errorf("%s and %s are mutually exclusive", AnnotationNameDNSWeight, AnnotationNameDNSGeoLocation)
+	case weight != "":
+		policy.Type = dnsprovider.RoutingPolicyWeighted
+		w, err := strconv.ParseInt(weight, 10, 64)
+		if err != nil || w < 0 || w > 255 {
+			return dnsprovider.RoutingPolicy{}, fmt.Errorf("%s must be a number between 0 and 255, was %q", AnnotationNameDNSWeight, weight)
+		}
+		policy.Weight = w
+	default:
+		policy.Type = dnsprovider.RoutingPolicyGeo
+		if !geoLocationRegex.MatchString(location) {
+			return dnsprovider.RoutingPolicy{}, fmt.Errorf("%s must be a country code, a country subdivision code, %q followed by a continent code or \"*\", was %q", AnnotationNameDNSGeoLocation, dnsprovider.GeoLocationContinentPrefix, location)
+		}
+		policy.Location = location
+	}
+
+	if setIdentifier == "" {
+		return dnsprovider.RoutingPolicy{}, fmt.Errorf("%s is required with %s", AnnotationNameDNSSetIdentifier, policy.Type)
+	}
+	return policy, nil
+}
+
+// quoteTXT returns the value as a TXT record in presentation format, split into strings of at most 255 characters
+func quoteTXT(value string) string {
+	var quoted []string
+	for len(value) > 0 {
+		n := min(len(value), 255)
+		s := strings.ReplaceAll(value[:n], `\`, `\\`)
+		s = strings.ReplaceAll(s, `"`, `\"`)
+		quoted = append(quoted, `"`+s+`"`)
+		value = value[n:]
+	}
+	return strings.Join(quoted, " ")
+}
+
+// srvRecords returns the SRV records for the named ports of a Service exposed on fqdn
+func srvRecords(service *v1.Service, fqdn string) []dns.Record {
+	var records []dns.Record
+	for _, port := range service.Spec.Ports {
+		if port.Name == "" {
+			continue
+		}
+
+		portNumber := port.Port
+		if service.Spec.Type == v1.ServiceTypeNodePort {
+			portNumber = port.NodePort
+		}
+		if portNumber == 0 {
+			continue
+		}
+
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = v1.ProtocolTCP
+		}
+		records = append(records, dns.Record{
+			RecordType: dns.RecordTypeSRV,
+			FQDN:       "_" + port.Name + "._" + strings.ToLower(string(protocol)) + "." + fqdn,
+			Value:      fmt.Sprintf("0 0 %d %s", portNumber, fqdn),
+		})
+	}
+	return records
+}
+
+// appendFQDN appends the fqdn to the list, if it is not yet in it
+func appendFQDN(fqdns []string, fqdn string) []string {
+	if slices.Contains(fqdns, fqdn) {
+		return fqdns
+	}
+	return append(fqdns, fqdn)
+}
+
+// hasCNAME returns true if there is a CNAME record for fqdn in records
+func hasCNAME(records []dns.Record, fqdn string) bool {
+	for _, r := range records {
+		if r.FQDN == fqdn && r.RecordType == dns.RecordTypeCNAME {
+			return true
+		}
+	}
+	return false
+}
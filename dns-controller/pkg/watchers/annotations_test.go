@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/dns-controller/pkg/dns"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+)
+
+func TestServiceRecordAnnotations(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "somesvc",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationNameDNSExternal:      "a.foo.com",
+				AnnotationNameDNSSRV:           "true",
+				AnnotationNameDNSTXT:           "v=spf1 -all\nsite-verification=\"abc\"",
+				AnnotationNameDNSTTL:           "5m",
+				AnnotationNameDNSSetIdentifier: "blue",
+				AnnotationNameDNSWeight:        "10",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{
+				{Name: "https", Port: 443, Protocol: corev1.ProtocolTCP},
+				{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+				{Port: 8080},
+			},
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+			},
+		},
+	}
+
+	scope := &fakeScope{records: make(map[string][]dns.Record)}
+	c := &ServiceController{scope: scope}
+	c.updateServiceRecords(service)
+
+	policy := dnsprovider.RoutingPolicy{
+		Type:          dnsprovider.RoutingPolicyWeighted,
+		SetIdentifier: "blue",
+		Weight:        10,
+	}
+	expected := []dns.Record{
+		{RecordType: dns.RecordTypeA, FQDN: "a.foo.com.", Value: "10.0.0.1", TTL: 300, RoutingPolicy: policy},
+		{RecordType: dns.RecordTypeSRV, FQDN: "_https._tcp.a.foo.com.", Value: "0 0 443 a.foo.com.", TTL: 300, RoutingPolicy: policy},
+		{RecordType: dns.RecordTypeSRV, FQDN: "_dns._udp.a.foo.com.", Value: "0 0 53 a.foo.com.", TTL: 300, RoutingPolicy: policy},
+		{RecordType: dns.RecordTypeTXT, FQDN: "a.foo.com.", Value: `"v=spf1 -all"`, TTL: 300, RoutingPolicy: policy},
+		{RecordType: dns.RecordTypeTXT, FQDN: "a.foo.com.", Value: `"site-verification=\"abc\""`, TTL: 300, RoutingPolicy: policy},
+	}
+	if diff := cmp.Diff(expected, scope.records["default/somesvc"]); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+func TestServiceRecordAnnotationsCNAME(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "somesvc",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationNameDNSExternal: "a.foo.com",
+				AnnotationNameDNSTXT:      "ignored",
+				AnnotationNameDNSTTL:      "not-a-ttl",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}},
+			},
+		},
+	}
+
+	scope := &fakeScope{records: make(map[string][]dns.Record)}
+	c := &ServiceController{scope: scope}
+	c.updateServiceRecords(service)
+
+	expected := []dns.Record{
+		{RecordType: dns.RecordTypeCNAME, FQDN: "a.foo.com.", Value: "lb.example.com"},
+	}
+	if diff := cmp.Diff(expected, scope.records["default/somesvc"]); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+
+	// The ignored annotations are reported on the service
+	expectedWarnings := []string{
+		"InvalidDNSAnnotation: Ignoring dns.alpha.kubernetes.io/txt annotation for a.foo.com., which is a CNAME",
+		"InvalidDNSAnnotation: Ignoring invalid dns.alpha.kubernetes.io/ttl annotation: \"not-a-ttl\" is not a number of seconds or a duration",
+	}
+	if diff := cmp.Diff(expectedWarnings, scope.warnings["default/somesvc"]); diff != "" {
+		t.Errorf("unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseRoutingPolicyAnnotations(t *testing.T) {
+	grid := []struct {
+		annotations map[string]string
+		expected    dnsprovider.RoutingPolicy
+		expectedErr string
+	}{
+		{
+			annotations: map[string]string{},
+		},
+		{
+			annotations: map[string]string{
+				AnnotationNameDNSSetIdentifier: "a",
+				AnnotationNameDNSWeight:        "0",
+			},
+			expected: dnsprovider.RoutingPolicy{Type: dnsprovider.RoutingPolicyWeighted, SetIdentifier: "a"},
+		},
+		{
+			annotations: map[string]string{
+				AnnotationNameDNSSetIdentifier: "eu",
+				AnnotationNameDNSGeoLocation:   "continent:EU",
+			},
+			expected: dnsprovider.RoutingPolicy{Type: dnsprovider.RoutingPolicyGeo, SetIdentifier: "eu", Location: "continent:EU"},
+		},
+		{
+			annotations: map[string]string{
+				AnnotationNameDNSSetIdentifier: "ca",
+				AnnotationNameDNSGeoLocation:   "US-CA",
+			},
+			expected: dnsprovider.RoutingPolicy{Type: dnsprovider.RoutingPolicyGeo, SetIdentifier: "ca", Location: "US-CA"},
+		},
+		{
+			annotations: map[string]string{
+				AnnotationNameDNSSetIdentifier: "a",
+			},
+			expectedErr: "requires",
+		},
+		{
+			annotations: map[string]string{
+				AnnotationNameDNSWeight: "10",
+			},
+			expectedErr: "is required",
+		},
+		{
+			annotations: map[string]string{
+				AnnotationNameDNSSetIdentifier: "a",
+				AnnotationNameDNSWeight:        "256",
+			},
+			expectedErr: "between 0 and 255",
+		},
+		{
+			annotations: map[string]string{
+				AnnotationNameDNSSetIdentifier: "a",
+				AnnotationNameDNSWeight:        "10",
+				AnnotationNameDNSGeoLocation:   "DE",
+			},
+			expectedErr: "mutually exclusive",
+		},
+		{
+			annotations: map[string]string{
+				AnnotationNameDNSSetIdentifier: "a",
+				AnnotationNameDNSGeoLocation:   "Germany",
+			},
+			expectedErr: "must be a country code",
+		},
+	}
+
+	for _, g := range grid {
+		actual, err := parseRoutingPolicyAnnotations(g.annotations)
+		if g.expectedErr != "" {
+			if err == nil || !strings.Contains(err.Error(), g.expectedErr) {
+				t.Errorf("expected error containing %q for %v, got %v", g.expectedErr, g.annotations, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %v: %v", g.annotations, err)
+			continue
+		}
+		if actual != g.expected {
+			t.Errorf("unexpected routing policy for %v: expected %+v, got %+v", g.annotations, g.expected, actual)
+		}
+	}
+}
+
+func TestQuoteTXT(t *testing.T) {
+	grid := map[string]string{
+		"hello":                  `"hello"`,
+		`say "hi"`:               `"say \"hi\""`,
+		`back\slash`:             `"back\\slash"`,
+		strings.Repeat("a", 300): `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`,
+	}
+	for value, expected := range grid {
+		if actual := quoteTXT(value); actual != expected {
+			t.Errorf("unexpected quoting of %q: expected %s, got %s", value, expected, actual)
+		}
+	}
+}
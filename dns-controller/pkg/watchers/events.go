@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchers
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	gatewayapi "sigs.k8s.io/gateway-api/apis/v1"
+
+	"k8s.io/kops/dns-controller/pkg/dns"
+)
+
+// EventRecorder reports problems with records as events on the objects they were configured on
+type EventRecorder struct {
+	recorder record.EventRecorder
+}
+
+var _ dns.EventRecorder = &EventRecorder{}
+
+// NewEventRecorder creates an EventRecorder
+func NewEventRecorder(client kubernetes.Interface) *EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartStructuredLogging(4)
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+
+	return &EventRecorder{
+		recorder: broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "dns-controller"}),
+	}
+}
+
+// Warning implements dns.EventRecorder
+func (r *EventRecorder) Warning(scope string, recordName string, reason string, message string) {
+	ref := objectReference(scope, recordName)
+	if ref == nil {
+		klog.V(4).Infof("not recording event for %s/%s: %s", scope, recordName, message)
+		return
+	}
+	r.recorder.Event(ref, v1.EventTypeWarning, reason, message)
+}
+
+// objectReference returns the object that the records with recordName in the named scope were configured on,
+// or nil if they were not configured on an object
+func objectReference(scope string, recordName string) *v1.ObjectReference {
+	ref := &v1.ObjectReference{}
+	switch scope {
+	case "service":
+		ref.Kind = "Service"
+		ref.APIVersion = "v1"
+	case "pod":
+		ref.Kind = "Pod"
+		ref.APIVersion = "v1"
+	case "ingress":
+		ref.Kind = "Ingress"
+		ref.APIVersion = "networking.k8s.io/v1"
+	case "gateway":
+		ref.Kind = "Gateway"
+		ref.APIVersion = gatewayapi.GroupVersion.String()
+	case "httproute":
+		ref.Kind = "HTTPRoute"
+		ref.APIVersion = gatewayapi.GroupVersion.String()
+	case "grpcroute":
+		ref.Kind = "GRPCRoute"
+		ref.APIVersion = gatewayapi.GroupVersion.String()
+	case "node":
+		ref.Kind = "Node"
+		ref.APIVersion = "v1"
+		ref.Name = recordName
+		return ref
+	default:
+		return nil
+	}
+
+	namespace, name, found := strings.Cut(recordName, "/")
+	if !found {
+		return nil
+	}
+	ref.Namespace = namespace
+	ref.Name = name
+	return ref
+}
@@ -0,0 +1,415 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	gatewayapi "sigs.k8s.io/gateway-api/apis/v1"
+
+	"k8s.io/kops/dns-controller/pkg/dns"
+	"k8s.io/kops/dns-controller/pkg/util"
+	"k8s.io/kops/upup/pkg/fi/utils"
+)
+
+var (
+	gatewayResource   = gatewayapi.SchemeGroupVersion.WithResource("gateways")
+	httpRouteResource = gatewayapi.SchemeGroupVersion.WithResource("httproutes")
+	grpcRouteResource = gatewayapi.SchemeGroupVersion.WithResource("grpcroutes")
+)
+
+// aliasForGateway returns the alias that the records of the routes attached to a Gateway point at
+func aliasForGateway(namespace, name string) string {
+	return "gateway/" + namespace + "/" + name
+}
+
+// GatewayController watches for Gateway API Gateways, and creates records for the hostnames of their listeners
+type GatewayController struct {
+	util.Stoppable
+	client    dynamic.Interface
+	namespace string
+	scope     dns.Scope
+}
+
+// NewGatewayController creates a GatewayController
+func NewGatewayController(client dynamic.Interface, dns dns.Context, namespace string) (*GatewayController, error) {
+	scope, err := dns.CreateScope("gateway")
+	if err != nil {
+		return nil, fmt.Errorf("error building dns scope: %v", err)
+	}
+	c := &GatewayController{
+		client:    client,
+		namespace: namespace,
+		scope:     scope,
+	}
+
+	return c, nil
+}
+
+// Run starts the GatewayController.
+func (c *GatewayController) Run() {
+	klog.Infof("starting gateway controller")
+
+	stopCh := c.StopChannel()
+	go runUnstructuredWatcher(stopCh, c.client, gatewayResource, c.namespace, c.scope, func(u *unstructured.Unstructured) (string, error) {
+		gateway := &gatewayapi.Gateway{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, gateway); err != nil {
+			return "", fmt.Errorf("error parsing gateway %s/%s: %v", u.GetNamespace(), u.GetName(), err)
+		}
+		return c.updateGatewayRecords(gateway), nil
+	})
+
+	<-stopCh
+	klog.Infof("shutting down gateway controller")
+}
+
+// updateGatewayRecords will apply the records for the specified gateway.  It returns the key that was set.
+func (c *GatewayController) updateGatewayRecords(gateway *gatewayapi.Gateway) string {
+	var records []dns.Record
+	var fqdns []string
+
+	alias := aliasForGateway(gateway.Namespace, gateway.Name)
+
+	// The addresses of the gateway are alias targets, so that the records of attached routes can point at them
+	var targets []dns.Record
+	for _, address := range gateway.Status.Addresses {
+		addressType := gatewayapi.IPAddressType
+		if address.Type != nil {
+			addressType = *address.Type
+		}
+
+		switch addressType {
+		case gatewayapi.IPAddressType:
+			var recordType dns.RecordType = dns.RecordTypeA
+			if utils.IsIPv6IP(address.Value) {
+				recordType = dns.RecordTypeAAAA
+			}
+			targets = append(targets, dns.Record{
+				RecordType:  recordType,
+				FQDN:        alias,
+				Value:       address.Value,
+				AliasTarget: true,
+			})
+		case gatewayapi.HostnameAddressType:
+			targets = append(targets, dns.Record{
+				RecordType:  dns.RecordTypeCNAME,
+				FQDN:        alias,
+				Value:       address.Value,
+				AliasTarget: true,
+			})
+		default:
+			klog.V(2).Infof("Ignoring address %q of unsupported type %q of gateway %s/%s", address.Value, addressType, gateway.Namespace, gateway.Name)
+		}
+	}
+
+	if len(targets) != 0 {
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.Hostname == nil || *listener.Hostname == "" {
+				continue
+			}
+
+			fqdn := dns.EnsureDotSuffix(string(*listener.Hostname))
+			if slices.Contains(fqdns, fqdn) {
+				continue
+			}
+			fqdns = append(fqdns, fqdn)
+			records = append(records, dns.Record{
+				RecordType: dns.RecordTypeAlias,
+				FQDN:       fqdn,
+				Value:      alias,
+			})
+		}
+	}
+
+	key := gateway.Namespace + "/" + gateway.Name
+	records = applyRecordAnnotations(c.scope, key, "gateway "+key, records, fqdns, gateway.Annotations)
+	records = append(records, targets...)
+
+	c.scope.Replace(key, records)
+	return key
+}
+
+// RouteController watches for Gateway API HTTPRoutes or GRPCRoutes, and creates records for their hostnames
+// pointing at the Gateways that accepted them
+type RouteController struct {
+	util.Stoppable
+	client    dynamic.Interface
+	namespace string
+	resource  schema.GroupVersionResource
+	kind      string
+	scope     dns.Scope
+}
+
+// NewHTTPRouteController creates a RouteController for HTTPRoutes
+func NewHTTPRouteController(client dynamic.Interface, dns dns.Context, namespace string) (*RouteController, error) {
+	return newRouteController(client, dns, namespace, httpRouteResource, "httproute")
+}
+
+// NewGRPCRouteController creates a RouteController for GRPCRoutes
+func NewGRPCRouteController(client dynamic.Interface, dns dns.Context, namespace string) (*RouteController, error) {
+	return newRouteController(client, dns, namespace, grpcRouteResource, "grpcroute")
+}
+
+func newRouteController(client dynamic.Interface, dns dns.Context, namespace string, resource schema.GroupVersionResource, kind string) (*RouteController, error) {
+	scope, err := dns.CreateScope(kind)
+	if err != nil {
+		return nil, fmt.Errorf("error building dns scope: %v", err)
+	}
+	c := &RouteController{
+		client:    client,
+		namespace: namespace,
+		resource:  resource,
+		kind:      kind,
+		scope:     scope,
+	}
+
+	return c, nil
+}
+
+// Run starts the RouteController.
+func (c *RouteController) Run() {
+	klog.Infof("starting %s controller", c.kind)
+
+	stopCh := c.StopChannel()
+	go runUnstructuredWatcher(stopCh, c.client, c.resource, c.namespace, c.scope, func(u *unstructured.Unstructured) (string, error) {
+		var hostnames []gatewayapi.Hostname
+		var spec gatewayapi.CommonRouteSpec
+		var status gatewayapi.RouteStatus
+
+		switch c.resource {
+		case httpRouteResource:
+			route := &gatewayapi.HTTPRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, route); err != nil {
+				return "", fmt.Errorf("error parsing httproute %s/%s: %v", u.GetNamespace(), u.GetName(), err)
+			}
+			hostnames, spec, status = route.Spec.Hostnames, route.Spec.CommonRouteSpec, route.Status.RouteStatus
+		case grpcRouteResource:
+			route := &gatewayapi.GRPCRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, route); err != nil {
+				return "", fmt.Errorf("error parsing grpcroute %s/%s: %v", u.GetNamespace(), u.GetName(), err)
+			}
+			hostnames, spec, status = route.Spec.Hostnames, route.Spec.CommonRouteSpec, route.Status.RouteStatus
+		}
+
+		objectMeta := metav1.ObjectMeta{
+			Namespace:   u.GetNamespace(),
+			Name:        u.GetName(),
+			Annotations: u.GetAnnotations(),
+		}
+		return c.updateRouteRecords(objectMeta, hostnames, spec, status), nil
+	})
+
+	<-stopCh
+	klog.Infof("shutting down %s controller", c.kind)
+}
+
+// updateRouteRecords will apply the records for the specified route.  It returns the key that was set.
+func (c *RouteController) updateRouteRecords(route metav1.ObjectMeta, hostnames []gatewayapi.Hostname, spec gatewayapi.CommonRouteSpec, status gatewayapi.RouteStatus) string {
+	var records []dns.Record
+	var fqdns []string
+
+	var aliases []string
+	for _, parentRef := range spec.ParentRefs {
+		if !isGatewayRef(parentRef) {
+			continue
+		}
+		namespace := route.Namespace
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+
+		// Only point at gateways that accepted the route, so that routes cannot claim hostnames
+		// on gateways that do not allow them
+		if !isRouteAccepted(status, route.Namespace, namespace, string(parentRef.Name)) {
+			klog.V(4).Infof("Route %s/%s is not accepted by gateway %s/%s", route.Namespace, route.Name, namespace, parentRef.Name)
+			continue
+		}
+
+		alias := aliasForGateway(namespace, string(parentRef.Name))
+		if !slices.Contains(aliases, alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	if len(aliases) != 0 {
+		for _, hostname := range hostnames {
+			fqdn := dns.EnsureDotSuffix(string(hostname))
+			if slices.Contains(fqdns, fqdn) {
+				continue
+			}
+			fqdns = append(fqdns, fqdn)
+
+			for _, alias := range aliases {
+				records = append(records, dns.Record{
+					RecordType: dns.RecordTypeAlias,
+					FQDN:       fqdn,
+					Value:      alias,
+				})
+			}
+		}
+	}
+
+	key := route.Namespace + "/" + route.Name
+	records = applyRecordAnnotations(c.scope, key, c.kind+" "+key, records, fqdns, route.Annotations)
+
+	c.scope.Replace(key, records)
+	return key
+}
+
+// isGatewayRef returns true if the parent reference of a route refers to a Gateway
+func isGatewayRef(parentRef gatewayapi.ParentReference) bool {
+	if parentRef.Group != nil && string(*parentRef.Group) != gatewayapi.GroupName {
+		return false
+	}
+	if parentRef.Kind != nil && string(*parentRef.Kind) != "Gateway" {
+		return false
+	}
+	return true
+}
+
+// isRouteAccepted returns true if the status of a route in routeNamespace reports that the named gateway accepted it
+func isRouteAccepted(status gatewayapi.RouteStatus, routeNamespace, namespace, name string) bool {
+	for _, parent := range status.Parents {
+		if !isGatewayRef(parent.ParentRef) || string(parent.ParentRef.Name) != name {
+			continue
+		}
+		parentNamespace := routeNamespace
+		if parent.ParentRef.Namespace != nil {
+			parentNamespace = string(*parent.ParentRef.Namespace)
+		}
+		if parentNamespace != namespace {
+			continue
+		}
+		if meta.IsStatusConditionTrue(parent.Conditions, string(gatewayapi.RouteConditionAccepted)) {
+			return true
+		}
+	}
+	return false
+}
+
+// runUnstructuredWatcher lists and then watches the resources, calling update for each resource that is added or modified,
+// and removing the records of resources that are deleted from the scope, until stopCh is closed
+func runUnstructuredWatcher(stopCh <-chan struct{}, client dynamic.Interface, resource schema.GroupVersionResource, namespace string, scope dns.Scope, update func(u *unstructured.Unstructured) (string, error)) {
+	runOnce := func() (bool, error) {
+		ctx := context.TODO()
+
+		var listOpts metav1.ListOptions
+		klog.V(4).Infof("querying without label filter")
+
+		allKeys := scope.AllKeys()
+		list, err := client.Resource(resource).Namespace(namespace).List(ctx, listOpts)
+		if err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				// The CRD is not installed, so there are no resources and thus no records;
+				// we must not hold back the records of the other scopes while we wait for it to be installed.
+				for _, key := range allKeys {
+					scope.Replace(key, nil)
+				}
+				scope.MarkReady()
+				return false, fmt.Errorf("%s are not served by the API server: %v", resource.Resource, err)
+			}
+			return false, fmt.Errorf("error listing %s: %v", resource.Resource, err)
+		}
+		foundKeys := make(map[string]bool)
+		for i := range list.Items {
+			u := &list.Items[i]
+			klog.V(4).Infof("found %s: %v", resource.Resource, u.GetName())
+			key, err := update(u)
+			if err != nil {
+				klog.Warningf("%v", err)
+				key = u.GetNamespace() + "/" + u.GetName()
+			}
+			foundKeys[key] = true
+		}
+		for _, key := range allKeys {
+			if !foundKeys[key] {
+				// The resource previously existed, but no longer exists; delete it from the scope
+				klog.V(2).Infof("removing %s not found in list: %s", resource.Resource, key)
+				scope.Replace(key, nil)
+			}
+		}
+		scope.MarkReady()
+
+		listOpts.Watch = true
+		listOpts.ResourceVersion = list.GetResourceVersion()
+		watcher, err := client.Resource(resource).Namespace(namespace).Watch(ctx, listOpts)
+		if err != nil {
+			return false, fmt.Errorf("error watching %s: %v", resource.Resource, err)
+		}
+		ch := watcher.ResultChan()
+		for {
+			select {
+			case <-stopCh:
+				klog.Infof("Got stop signal")
+				return true, nil
+			case event, ok := <-ch:
+				if !ok {
+					klog.Infof("%s watch channel closed", resource.Resource)
+					return false, nil
+				}
+
+				u, ok := event.Object.(*unstructured.Unstructured)
+				if !ok {
+					klog.Warningf("Unexpected object in %s watch: %T", resource.Resource, event.Object)
+					continue
+				}
+				klog.V(4).Infof("%s changed: %s %v", resource.Resource, event.Type, u.GetName())
+
+				switch event.Type {
+				case watch.Added, watch.Modified:
+					if _, err := update(u); err != nil {
+						klog.Warningf("%v", err)
+					}
+
+				case watch.Deleted:
+					scope.Replace(u.GetNamespace()+"/"+u.GetName(), nil)
+
+				default:
+					klog.Warningf("Unknown event type: %v", event.Type)
+				}
+			}
+		}
+	}
+
+	for {
+		stop, err := runOnce()
+		if stop {
+			return
+		}
+
+		if err != nil {
+			klog.Warningf("Unexpected error in event watch, will retry: %v", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(10 * time.Second):
+			}
+		}
+	}
+}
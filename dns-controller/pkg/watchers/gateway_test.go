@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kops/dns-controller/pkg/dns"
+	gatewayapi "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestGatewayRecords(t *testing.T) {
+	hostname := gatewayapi.Hostname("a.foo.com")
+	wildcard := gatewayapi.Hostname("*.b.foo.com")
+	hostnameType := gatewayapi.HostnameAddressType
+
+	gateway := &gatewayapi.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "somegateway",
+			Namespace: "infra",
+			Annotations: map[string]string{
+				AnnotationNameDNSTTL: "120",
+			},
+		},
+		Spec: gatewayapi.GatewaySpec{
+			Listeners: []gatewayapi.Listener{
+				{Name: "http", Hostname: &hostname},
+				{Name: "https", Hostname: &hostname},
+				{Name: "wildcard", Hostname: &wildcard},
+				{Name: "any"},
+			},
+		},
+		Status: gatewayapi.GatewayStatus{
+			Addresses: []gatewayapi.GatewayStatusAddress{
+				{Value: "10.0.0.1"},
+				{Value: "2001:db8::1"},
+				{Type: &hostnameType, Value: "lb.example.com"},
+			},
+		},
+	}
+
+	scope := &fakeScope{records: make(map[string][]dns.Record)}
+	c := &GatewayController{scope: scope}
+	c.updateGatewayRecords(gateway)
+
+	expected := []dns.Record{
+		{RecordType: dns.RecordTypeAlias, FQDN: "a.foo.com.", Value: "gateway/infra/somegateway", TTL: 120},
+		{RecordType: dns.RecordTypeAlias, FQDN: "*.b.foo.com.", Value: "gateway/infra/somegateway", TTL: 120},
+		{RecordType: dns.RecordTypeA, FQDN: "gateway/infra/somegateway", Value: "10.0.0.1", AliasTarget: true},
+		{RecordType: dns.RecordTypeAAAA, FQDN: "gateway/infra/somegateway", Value: "2001:db8::1", AliasTarget: true},
+		{RecordType: dns.RecordTypeCNAME, FQDN: "gateway/infra/somegateway", Value: "lb.example.com", AliasTarget: true},
+	}
+	if diff := cmp.Diff(expected, scope.records["infra/somegateway"]); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+func TestRouteRecords(t *testing.T) {
+	infra := gatewayapi.Namespace("infra")
+	accepted := []metav1.Condition{
+		{Type: string(gatewayapi.RouteConditionAccepted), Status: metav1.ConditionTrue},
+	}
+	rejected := []metav1.Condition{
+		{Type: string(gatewayapi.RouteConditionAccepted), Status: metav1.ConditionFalse},
+	}
+	service := gatewayapi.Kind("Service")
+
+	route := metav1.ObjectMeta{
+		Name:      "someroute",
+		Namespace: "app",
+	}
+	hostnames := []gatewayapi.Hostname{"api.foo.com", "api.foo.com", "www.foo.com"}
+	spec := gatewayapi.CommonRouteSpec{
+		ParentRefs: []gatewayapi.ParentReference{
+			{Name: "public", Namespace: &infra},
+			{Name: "local"},
+			{Name: "rejected", Namespace: &infra},
+			{Name: "pending", Namespace: &infra},
+			{Name: "notagateway", Kind: &service},
+		},
+	}
+	status := gatewayapi.RouteStatus{
+		Parents: []gatewayapi.RouteParentStatus{
+			{ParentRef: gatewayapi.ParentReference{Name: "public", Namespace: &infra}, Conditions: accepted},
+			{ParentRef: gatewayapi.ParentReference{Name: "local"}, Conditions: accepted},
+			{ParentRef: gatewayapi.ParentReference{Name: "rejected", Namespace: &infra}, Conditions: rejected},
+			{ParentRef: gatewayapi.ParentReference{Name: "notagateway", Kind: &service}, Conditions: accepted},
+		},
+	}
+
+	scope := &fakeScope{records: make(map[string][]dns.Record)}
+	c := &RouteController{kind: "httproute", scope: scope}
+	c.updateRouteRecords(route, hostnames, spec, status)
+
+	expected := []dns.Record{
+		{RecordType: dns.RecordTypeAlias, FQDN: "api.foo.com.", Value: "gateway/infra/public"},
+		{RecordType: dns.RecordTypeAlias, FQDN: "api.foo.com.", Value: "gateway/app/local"},
+		{RecordType: dns.RecordTypeAlias, FQDN: "www.foo.com.", Value: "gateway/infra/public"},
+		{RecordType: dns.RecordTypeAlias, FQDN: "www.foo.com.", Value: "gateway/app/local"},
+	}
+	if diff := cmp.Diff(expected, scope.records["app/someroute"]); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+func TestRouteRecordsNotAccepted(t *testing.T) {
+	route := metav1.ObjectMeta{
+		Name:      "someroute",
+		Namespace: "app",
+	}
+	spec := gatewayapi.CommonRouteSpec{
+		ParentRefs: []gatewayapi.ParentReference{{Name: "public"}},
+	}
+
+	scope := &fakeScope{records: make(map[string][]dns.Record)}
+	c := &RouteController{kind: "grpcroute", scope: scope}
+	c.updateRouteRecords(route, []gatewayapi.Hostname{"api.foo.com"}, spec, gatewayapi.RouteStatus{})
+
+	if records := scope.records["app/someroute"]; len(records) != 0 {
+		t.Errorf("expected no records for route that was not accepted, got %v", records)
+	}
+}
+
+// notServedClient is a dynamic client for an API server that does not serve any custom resources.
+type notServedClient struct {
+	dynamic.Interface
+}
+
+func (c *notServedClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &notServedResource{resource: resource}
+}
+
+type notServedResource struct {
+	dynamic.NamespaceableResourceInterface
+	resource schema.GroupVersionResource
+}
+
+func (r *notServedResource) Namespace(string) dynamic.ResourceInterface {
+	return r
+}
+
+func (r *notServedResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return nil, apierrors.NewNotFound(r.resource.GroupResource(), "")
+}
+
+func TestRouteControllerReadyWithoutCRD(t *testing.T) {
+	ready := make(chan struct{})
+	scope := &fakeScope{readyCh: ready, records: make(map[string][]dns.Record)}
+	c, err := NewHTTPRouteController(&notServedClient{}, &fakeDNSContext{scope: scope}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	go c.Run()
+	defer c.Stop()
+
+	select {
+	case <-ready:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("scope was not marked ready when httproutes are not served")
+	}
+}
@@ -144,6 +144,7 @@ func (c *IngressController) runWatcher(stopCh <-chan struct{}) {
 // updateIngressRecords will apply the records for the specified ingress.  It returns the key that was set.
 func (c *IngressController) updateIngressRecords(ingress *v1.Ingress) string {
 	var records []dns.Record
+	var fqdns []string
 
 	var ingresses []dns.Record
 	for i := range ingress.Status.LoadBalancer.Ingress {
@@ -173,6 +174,9 @@ func (c *IngressController) updateIngressRecords(ingress *v1.Ingress) string {
 		}
 
 		fqdn := dns.EnsureDotSuffix(rule.Host)
+		if len(ingresses) != 0 {
+			fqdns = appendFQDN(fqdns, fqdn)
+		}
 		for _, ingress := range ingresses {
 			r := ingress
 			r.FQDN = fqdn
@@ -181,6 +185,8 @@ func (c *IngressController) updateIngressRecords(ingress *v1.Ingress) string {
 	}
 
 	key := ingress.Namespace + "/" + ingress.Name
+	records = applyRecordAnnotations(c.scope, key, "ingress "+key, records, fqdns, ingress.Annotations)
+
 	c.scope.Replace(key, records)
 	return key
 }
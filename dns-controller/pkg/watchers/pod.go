@@ -145,6 +145,7 @@ func (c *PodController) runWatcher(stopCh <-chan struct{}) {
 // updatePodRecords will apply the records for the specified pod.  It returns the key that was set.
 func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 	var records []dns.Record
+	var fqdns []string
 
 	specExternal := pod.Annotations[AnnotationNameDNSExternal]
 	if specExternal != "" {
@@ -162,6 +163,9 @@ func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 			token = strings.TrimSpace(token)
 
 			fqdn := dns.EnsureDotSuffix(token)
+			if len(aliases) != 0 {
+				fqdns = appendFQDN(fqdns, fqdn)
+			}
 			for _, alias := range aliases {
 				records = append(records, dns.Record{
 					RecordType: dns.RecordTypeAlias,
@@ -190,6 +194,9 @@ func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 			token = strings.TrimSpace(token)
 
 			fqdn := dns.EnsureDotSuffix(token)
+			if len(aliases) != 0 {
+				fqdns = appendFQDN(fqdns, fqdn)
+			}
 			for _, alias := range aliases {
 				records = append(records, dns.Record{
 					RecordType: dns.RecordTypeAlias,
@@ -203,6 +210,8 @@ func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 	}
 
 	key := pod.Namespace + "/" + pod.Name
+	records = applyRecordAnnotations(c.scope, key, "pod "+key, records, fqdns, pod.Annotations)
+
 	c.scope.Replace(key, records)
 	return key
 }
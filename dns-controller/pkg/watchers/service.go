@@ -145,6 +145,8 @@ func (c *ServiceController) runWatcher(stopCh <-chan struct{}) {
 // updateServiceRecords will apply the records for the specified service.
 // It returns the key that was set (or "" if no key was set)
 func (c *ServiceController) updateServiceRecords(service *v1.Service) string {
+	key := service.Namespace + "/" + service.Name
+
 	var records []dns.Record
 
 	specExternal := service.Annotations[AnnotationNameDNSExternal]
@@ -205,21 +207,30 @@ func (c *ServiceController) updateServiceRecords(service *v1.Service) string {
 			tokens = append(tokens, strings.Split(specInternal, ",")...)
 		}
 
+		var fqdns []string
 		for _, token := range tokens {
 			token = strings.TrimSpace(token)
 
 			fqdn := dns.EnsureDotSuffix(token)
+			fqdns = appendFQDN(fqdns, fqdn)
 			for _, ingress := range ingresses {
 				r := ingress
 				r.FQDN = fqdn
 				records = append(records, r)
 			}
 		}
+
+		if len(ingresses) != 0 && service.Annotations[AnnotationNameDNSSRV] == "true" {
+			for _, fqdn := range fqdns {
+				records = append(records, srvRecords(service, fqdn)...)
+			}
+		}
+
+		records = applyRecordAnnotations(c.scope, key, "service "+key, records, fqdns, service.Annotations)
 	} else {
 		klog.V(8).Infof("Service %s/%s did not have %s annotation", service.Namespace, service.Name, AnnotationNameDNSExternal)
 	}
 
-	key := service.Namespace + "/" + service.Name
 	c.scope.Replace(key, records)
 	return key
 }
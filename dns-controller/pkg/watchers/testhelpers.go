@@ -19,8 +19,9 @@ package watchers
 import "k8s.io/kops/dns-controller/pkg/dns"
 
 type fakeScope struct {
-	readyCh chan struct{}
-	records map[string][]dns.Record
+	readyCh  chan struct{}
+	records  map[string][]dns.Record
+	warnings map[string][]string
 }
 
 func (f *fakeScope) Replace(recordName string, records []dns.Record) {
@@ -38,6 +39,13 @@ func (*fakeScope) AllKeys() []string {
 	return []string{}
 }
 
+func (f *fakeScope) Warning(recordName string, reason string, message string) {
+	if f.warnings == nil {
+		f.warnings = make(map[string][]string)
+	}
+	f.warnings[recordName] = append(f.warnings[recordName], reason+": "+message)
+}
+
 type fakeDNSContext struct {
 	scope *fakeScope
 }
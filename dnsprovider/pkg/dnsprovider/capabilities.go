@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsprovider
+
+import (
+	"slices"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+// Capabilities describes the optional features supported by a DNS provider
+type Capabilities struct {
+	// RecordTypes are the record types the provider can manage
+	RecordTypes []rrstype.RrsType
+	// RoutingPolicies are the routing policies the provider can apply to record sets
+	RoutingPolicies []RoutingPolicyType
+}
+
+// SupportsRecordType returns true if the provider can manage records of the given type
+func (c Capabilities) SupportsRecordType(recordType rrstype.RrsType) bool {
+	return slices.Contains(c.RecordTypes, recordType)
+}
+
+// SupportsRoutingPolicy returns true if the provider can apply the given routing policy to record sets
+func (c Capabilities) SupportsRoutingPolicy(policyType RoutingPolicyType) bool {
+	return policyType == "" || slices.Contains(c.RoutingPolicies, policyType)
+}
+
+// DefaultCapabilities are the capabilities of providers that do not implement CapabilitiesInterface
+var DefaultCapabilities = Capabilities{
+	RecordTypes: []rrstype.RrsType{rrstype.A, rrstype.AAAA, rrstype.CNAME, rrstype.TXT},
+}
+
+// CapabilitiesInterface is implemented by providers that support more than the DefaultCapabilities
+type CapabilitiesInterface interface {
+	// Capabilities returns the optional features supported by the provider
+	Capabilities() Capabilities
+}
+
+// GetCapabilities returns the capabilities of the provider
+func GetCapabilities(provider Interface) Capabilities {
+	if c, ok := provider.(CapabilitiesInterface); ok {
+		return c.Capabilities()
+	}
+	return DefaultCapabilities
+}
+
+// RoutingPolicyType is the type of a routing policy
+type RoutingPolicyType string
+
+const (
+	// RoutingPolicyWeighted answers queries with one of the record sets with the same name and type,
+	// chosen randomly in proportion to their weights
+	RoutingPolicyWeighted RoutingPolicyType = "weighted"
+	// RoutingPolicyGeo answers queries with the record set for the location of the client
+	RoutingPolicyGeo RoutingPolicyType = "geo"
+)
+
+// RoutingPolicy configures how the provider chooses between record sets with the same name and type.
+// The zero value is the simple routing policy, where there is a single record set for each name and type.
+type RoutingPolicy struct {
+	// Type is the type of the routing policy
+	Type RoutingPolicyType
+	// SetIdentifier distinguishes the record sets with the same name and type
+	SetIdentifier string
+	// Weight is the relative weight of the record set, for weighted routing
+	Weight int64
+	// Location is the location of the clients the record set is served to, for geo routing.
+	// It is an ISO 3166 country code (e.g. "DE"), optionally with a subdivision (e.g. "US-CA"),
+	// a continent code with the GeoLocationContinentPrefix (e.g. "continent:EU"), or "*" for clients in all other locations.
+	Location string
+}
+
+// GeoLocationContinentPrefix is the prefix of continent codes in RoutingPolicy.Location,
+// because continent codes overlap with country codes
+const GeoLocationContinentPrefix = "continent:"
+
+// RoutingPolicyResourceRecordSets is implemented by the ResourceRecordSets of providers that support routing policies
+type RoutingPolicyResourceRecordSets interface {
+	// NewWithRoutingPolicy allocates a new ResourceRecordSet with a routing policy,
+	// which can then be passed to ResourceRecordChangeset Add(), Remove() or Upsert()
+	NewWithRoutingPolicy(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType, policy RoutingPolicy) ResourceRecordSet
+}
+
+// RoutingPolicyResourceRecordSet is implemented by ResourceRecordSets that can have a routing policy
+type RoutingPolicyResourceRecordSet interface {
+	// RoutingPolicy returns the routing policy of the record set
+	RoutingPolicy() RoutingPolicy
+}
+
+// GetRoutingPolicy returns the routing policy of the record set, which is the simple routing policy if it has none
+func GetRoutingPolicy(rrset ResourceRecordSet) RoutingPolicy {
+	if r, ok := rrset.(RoutingPolicyResourceRecordSet); ok {
+		return r.RoutingPolicy()
+	}
+	return RoutingPolicy{}
+}
@@ -19,10 +19,12 @@ package route53
 import (
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/aws/route53/stubs"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
 )
 
 // Compile time check for interface adherence
 var _ dnsprovider.Interface = Interface{}
+var _ dnsprovider.CapabilitiesInterface = Interface{}
 
 type Interface struct {
 	service stubs.Route53API
@@ -37,3 +39,11 @@ func New(service stubs.Route53API) *Interface {
 func (i Interface) Zones() (zones dnsprovider.Zones, supported bool) {
 	return Zones{&i}, true
 }
+
+// Capabilities implements dnsprovider.CapabilitiesInterface
+func (i Interface) Capabilities() dnsprovider.Capabilities {
+	return dnsprovider.Capabilities{
+		RecordTypes:     []rrstype.RrsType{rrstype.A, rrstype.AAAA, rrstype.CNAME, rrstype.SRV, rrstype.TXT},
+		RoutingPolicies: []dnsprovider.RoutingPolicyType{dnsprovider.RoutingPolicyWeighted, dnsprovider.RoutingPolicyGeo},
+	}
+}
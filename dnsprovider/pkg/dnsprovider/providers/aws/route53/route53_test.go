@@ -302,3 +302,43 @@ func TestContract(t *testing.T) {
 
 	tests.TestContract(t, sets)
 }
+
+/* TestResourceRecordSetsRoutingPolicy verifies that we can add weighted records of the same name and type with different set identifiers */
+func TestResourceRecordSetsRoutingPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	zone := firstZone(t)
+	sets := rrs(t, zone)
+	policySets := sets.(dnsprovider.RoutingPolicyResourceRecordSets)
+
+	blue := policySets.NewWithRoutingPolicy("www12."+zone.Name(), []string{"10.10.10.10"}, 180, rrstype.A, dnsprovider.RoutingPolicy{
+		Type:          dnsprovider.RoutingPolicyWeighted,
+		SetIdentifier: "blue",
+		Weight:        10,
+	})
+	green := policySets.NewWithRoutingPolicy("www12."+zone.Name(), []string{"10.10.10.11"}, 180, rrstype.A, dnsprovider.RoutingPolicy{
+		Type:          dnsprovider.RoutingPolicyWeighted,
+		SetIdentifier: "green",
+		Weight:        20,
+	})
+	err := sets.StartChangeset().Add(blue).Add(green).Apply(ctx)
+	if err != nil {
+		t.Fatalf("Failed to add weighted resource record sets: %v", err)
+	}
+	defer sets.StartChangeset().Remove(blue).Remove(green).Apply(ctx)
+
+	weights := make(map[string]int64)
+	for _, record := range listRrsOrFail(t, sets) {
+		if record.Name() != blue.Name() {
+			continue
+		}
+		policy := dnsprovider.GetRoutingPolicy(record)
+		if policy.Type != dnsprovider.RoutingPolicyWeighted {
+			t.Errorf("Expected weighted routing policy for %v, got %+v", record, policy)
+		}
+		weights[policy.SetIdentifier] = policy.Weight
+	}
+	if weights["blue"] != 10 || weights["green"] != 20 || len(weights) != 2 {
+		t.Errorf("Unexpected weights of added resource record sets: %v", weights)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+)
+
+// applyRoutingPolicy sets the fields of the route53 record set for the routing policy
+func applyRoutingPolicy(rrs *route53types.ResourceRecordSet, policy dnsprovider.RoutingPolicy) {
+	switch policy.Type {
+	case dnsprovider.RoutingPolicyWeighted:
+		rrs.SetIdentifier = aws.String(policy.SetIdentifier)
+		rrs.Weight = aws.Int64(policy.Weight)
+	case dnsprovider.RoutingPolicyGeo:
+		rrs.SetIdentifier = aws.String(policy.SetIdentifier)
+		rrs.GeoLocation = geoLocationFromString(policy.Location)
+	}
+}
+
+// geoLocationFromString parses the location of a dnsprovider.RoutingPolicy
+func geoLocationFromString(location string) *route53types.GeoLocation {
+	if continent, found := strings.CutPrefix(location, dnsprovider.GeoLocationContinentPrefix); found {
+		return &route53types.GeoLocation{ContinentCode: aws.String(continent)}
+	}
+	country, subdivision, found := strings.Cut(location, "-")
+	geoLocation := &route53types.GeoLocation{CountryCode: aws.String(country)}
+	if found {
+		geoLocation.SubdivisionCode = aws.String(subdivision)
+	}
+	return geoLocation
+}
+
+// geoLocationToString formats the location of a dnsprovider.RoutingPolicy
+func geoLocationToString(geoLocation *route53types.GeoLocation) string {
+	switch {
+	case geoLocation.ContinentCode != nil:
+		return dnsprovider.GeoLocationContinentPrefix + aws.ToString(geoLocation.ContinentCode)
+	case geoLocation.SubdivisionCode != nil:
+		return aws.ToString(geoLocation.CountryCode) + "-" + aws.ToString(geoLocation.SubdivisionCode)
+	default:
+		return aws.ToString(geoLocation.CountryCode)
+	}
+}
+
+// changeKey returns the key identifying the record set in a changeset
+func changeKey(rrs dnsprovider.ResourceRecordSet) string {
+	key := string(rrs.Type()) + "::" + rrs.Name()
+	if setIdentifier := dnsprovider.GetRoutingPolicy(rrs).SetIdentifier; setIdentifier != "" {
+		key += "::" + setIdentifier
+	}
+	return key
+}
@@ -65,6 +65,7 @@ func buildChange(action route53types.ChangeAction, rrs dnsprovider.ResourceRecor
 			TTL:  aws.Int64(rrs.Ttl()),
 		},
 	}
+	applyRoutingPolicy(change.ResourceRecordSet, dnsprovider.GetRoutingPolicy(rrs))
 
 	for _, rrdata := range rrs.Rrdatas() {
 		rr := route53types.ResourceRecord{
@@ -85,17 +86,17 @@ func (c *ResourceRecordChangeset) Apply(ctx context.Context) error {
 
 	removals := make(map[string]route53types.Change)
 	for _, removal := range c.removals {
-		removals[string(removal.Type())+"::"+removal.Name()] = buildChange(route53types.ChangeActionDelete, removal)
+		removals[changeKey(removal)] = buildChange(route53types.ChangeActionDelete, removal)
 	}
 
 	additions := make(map[string]route53types.Change)
 	for _, addition := range c.additions {
-		additions[string(addition.Type())+"::"+addition.Name()] = buildChange(route53types.ChangeActionCreate, addition)
+		additions[changeKey(addition)] = buildChange(route53types.ChangeActionCreate, addition)
 	}
 
 	upserts := make(map[string]route53types.Change)
 	for _, upsert := range c.upserts {
-		upserts[string(upsert.Type())+"::"+upsert.Name()] = buildChange(route53types.ChangeActionUpsert, upsert)
+		upserts[changeKey(upsert)] = buildChange(route53types.ChangeActionUpsert, upsert)
 	}
 
 	doneKeys := make(map[string]bool)
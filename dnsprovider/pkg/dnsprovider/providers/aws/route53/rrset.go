@@ -26,6 +26,7 @@ import (
 
 // Compile time check for interface adherence
 var _ dnsprovider.ResourceRecordSet = ResourceRecordSet{}
+var _ dnsprovider.RoutingPolicyResourceRecordSet = ResourceRecordSet{}
 
 type ResourceRecordSet struct {
 	impl   *route53types.ResourceRecordSet
@@ -53,6 +54,22 @@ func (rrset ResourceRecordSet) Type() rrstype.RrsType {
 	return rrstype.RrsType(rrset.impl.Type)
 }
 
+// RoutingPolicy implements dnsprovider.RoutingPolicyResourceRecordSet
+func (rrset ResourceRecordSet) RoutingPolicy() dnsprovider.RoutingPolicy {
+	policy := dnsprovider.RoutingPolicy{
+		SetIdentifier: aws.ToString(rrset.impl.SetIdentifier),
+	}
+	switch {
+	case rrset.impl.Weight != nil:
+		policy.Type = dnsprovider.RoutingPolicyWeighted
+		policy.Weight = aws.ToInt64(rrset.impl.Weight)
+	case rrset.impl.GeoLocation != nil:
+		policy.Type = dnsprovider.RoutingPolicyGeo
+		policy.Location = geoLocationToString(rrset.impl.GeoLocation)
+	}
+	return policy
+}
+
 // Route53ResourceRecordSet returns the route53 ResourceRecordSet object for the ResourceRecordSet
 // This is a "back door" that allows for limited access to the ResourceRecordSet,
 // without having to requery it, so that we can expose AWS specific functionality.
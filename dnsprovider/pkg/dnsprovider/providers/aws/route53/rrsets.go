@@ -29,6 +29,7 @@ import (
 
 // Compile time check for interface adherence
 var _ dnsprovider.ResourceRecordSets = ResourceRecordSets{}
+var _ dnsprovider.RoutingPolicyResourceRecordSets = ResourceRecordSets{}
 
 type ResourceRecordSets struct {
 	zone *Zone
@@ -105,6 +106,13 @@ func (r ResourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstyp
 	}
 }
 
+// NewWithRoutingPolicy implements dnsprovider.RoutingPolicyResourceRecordSets
+func (r ResourceRecordSets) NewWithRoutingPolicy(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType, policy dnsprovider.RoutingPolicy) dnsprovider.ResourceRecordSet {
+	rrset := r.New(name, rrdatas, ttl, rrstype).(ResourceRecordSet)
+	applyRoutingPolicy(rrset.impl, policy)
+	return rrset
+}
+
 // Zone returns the parent zone
 func (rrset ResourceRecordSets) Zone() dnsprovider.Zone {
 	return rrset.zone
@@ -19,9 +19,11 @@ package clouddns
 import (
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/google/clouddns/internal/interfaces"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
 )
 
 var _ dnsprovider.Interface = Interface{}
+var _ dnsprovider.CapabilitiesInterface = Interface{}
 
 type Interface struct {
 	project_ string
@@ -38,6 +40,13 @@ func (i Interface) Zones() (zones dnsprovider.Zones, supported bool) {
 	return Zones{i.service.ManagedZones(), &i}, true
 }
 
+// Capabilities implements dnsprovider.CapabilitiesInterface
+func (i Interface) Capabilities() dnsprovider.Capabilities {
+	return dnsprovider.Capabilities{
+		RecordTypes: []rrstype.RrsType{rrstype.A, rrstype.AAAA, rrstype.CNAME, rrstype.SRV, rrstype.TXT},
+	}
+}
+
 func (i Interface) project() string {
 	return i.project_
 }
@@ -19,9 +19,11 @@ package designate
 import (
 	"github.com/gophercloud/gophercloud"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
 )
 
 var _ dnsprovider.Interface = Interface{}
+var _ dnsprovider.CapabilitiesInterface = Interface{}
 
 type Interface struct {
 	sc *gophercloud.ServiceClient
@@ -36,3 +38,10 @@ func New(sc *gophercloud.ServiceClient) *Interface {
 func (i Interface) Zones() (zones dnsprovider.Zones, supported bool) {
 	return Zones{&i}, true
 }
+
+// Capabilities implements dnsprovider.CapabilitiesInterface
+func (i Interface) Capabilities() dnsprovider.Capabilities {
+	return dnsprovider.Capabilities{
+		RecordTypes: []rrstype.RrsType{rrstype.A, rrstype.AAAA, rrstype.CNAME, rrstype.SRV, rrstype.TXT},
+	}
+}
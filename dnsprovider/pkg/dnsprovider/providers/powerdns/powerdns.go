@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package powerdns implements a DNS provider for the HTTP API of the PowerDNS Authoritative Server.
+package powerdns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+var _ dnsprovider.Interface = &Interface{}
+var _ dnsprovider.CapabilitiesInterface = &Interface{}
+
+const (
+	ProviderName = "powerdns"
+
+	defaultServerID = "localhost"
+	defaultTimeout  = 30 * time.Second
+)
+
+func init() {
+	dnsprovider.RegisterDNSProvider(ProviderName, func(config io.Reader) (dnsprovider.Interface, error) {
+		return NewProvider(ConfigFromEnv(os.Getenv))
+	})
+}
+
+// Config is the configuration of the PowerDNS API
+type Config struct {
+	// APIURL is the base URL of the API, e.g. http://pdns.example.com:8081
+	APIURL string
+	// APIKey is the key used to authenticate to the API
+	APIKey string
+	// ServerID is the id of the server in the API, defaults to localhost
+	ServerID string
+	// HTTPClient is the client used for requests to the API
+	HTTPClient *http.Client
+}
+
+// ConfigFromEnv builds the configuration from the PDNS_* environment variables, as returned by getenv
+func ConfigFromEnv(getenv func(key string) string) Config {
+	return Config{
+		APIURL:   getenv("PDNS_API_URL"),
+		APIKey:   getenv("PDNS_API_KEY"),
+		ServerID: getenv("PDNS_SERVER_ID"),
+	}
+}
+
+// Interface implements dnsprovider.Interface
+type Interface struct {
+	config  Config
+	baseURL *url.URL
+}
+
+// NewProvider returns an implementation of dnsprovider.Interface
+func NewProvider(config Config) (*Interface, error) {
+	if config.APIURL == "" {
+		return nil, fmt.Errorf("PDNS_API_URL is required")
+	}
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("PDNS_API_KEY is required")
+	}
+	baseURL, err := url.Parse(strings.TrimSuffix(config.APIURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PDNS_API_URL %q: %w", config.APIURL, err)
+	}
+	if config.ServerID == "" {
+		config.ServerID = defaultServerID
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &Interface{config: config, baseURL: baseURL}, nil
+}
+
+// Zones returns an implementation of dnsprovider.Zones
+func (i *Interface) Zones() (dnsprovider.Zones, bool) {
+	return &zones{iface: i}, true
+}
+
+// Capabilities implements dnsprovider.CapabilitiesInterface
+func (i *Interface) Capabilities() dnsprovider.Capabilities {
+	return dnsprovider.Capabilities{
+		RecordTypes: []rrstype.RrsType{rrstype.A, rrstype.AAAA, rrstype.CNAME, rrstype.SRV, rrstype.TXT},
+	}
+}
+
+// apiError is the body of an error response of the API
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// do sends a request to the path under the server endpoint of the API, decoding the response into out if not nil
+func (i *Interface) do(ctx context.Context, method string, path string, in interface{}, out interface{}) error {
+	u := *i.baseURL
+	u.Path += "/api/v1/servers/" + url.PathEscape(i.config.ServerID) + path
+	u.RawPath = ""
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("error encoding request: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	req.Header.Set("X-API-Key", i.config.APIKey)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	klog.V(4).Infof("PowerDNS API request %s %s", method, u.Path)
+	resp, err := i.config.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling %s %s: %w", method, u.Path, err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response of %s %s: %w", method, u.Path, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &apiError{}
+		if err := json.Unmarshal(b, apiErr); err == nil && apiErr.Error != "" {
+			return fmt.Errorf("error calling %s %s: %s: %s", method, u.Path, resp.Status, apiErr.Error)
+		}
+		return fmt.Errorf("error calling %s %s: %s", method, u.Path, resp.Status)
+	}
+	if out != nil && len(b) != 0 {
+		if err := json.Unmarshal(b, out); err != nil {
+			return fmt.Errorf("error decoding response of %s %s: %w", method, u.Path, err)
+		}
+	}
+	return nil
+}
+
+// apiZone is a zone in the API
+type apiZone struct {
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name"`
+	Kind        string     `json:"kind,omitempty"`
+	Nameservers []string   `json:"nameservers"`
+	RRSets      []apiRRSet `json:"rrsets,omitempty"`
+}
+
+// apiZonePatch is the body of a request updating the records of a zone
+type apiZonePatch struct {
+	RRSets []apiRRSet `json:"rrsets"`
+}
+
+// apiRRSet is a set of records with the same name and type in the API
+type apiRRSet struct {
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	TTL        int64       `json:"ttl,omitempty"`
+	ChangeType string      `json:"changetype,omitempty"`
+	Records    []apiRecord `json:"records"`
+}
+
+// apiRecord is a single record in the API
+type apiRecord struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+// zones implements dnsprovider.Zones
+type zones struct {
+	iface *Interface
+}
+
+var _ dnsprovider.Zones = &zones{}
+
+// List returns the zones of the server
+func (z *zones) List() ([]dnsprovider.Zone, error) {
+	var apiZones []apiZone
+	if err := z.iface.do(context.TODO(), http.MethodGet, "/zones", nil, &apiZones); err != nil {
+		return nil, err
+	}
+
+	var zones []dnsprovider.Zone
+	for _, apiZone := range apiZones {
+		zones = append(zones, &zone{id: apiZone.ID, name: apiZone.Name, iface: z.iface})
+	}
+	return zones, nil
+}
+
+// Add creates a native zone on the server
+func (z *zones) Add(newZone dnsprovider.Zone) (dnsprovider.Zone, error) {
+	request := &apiZone{
+		Name:        fqdn(newZone.Name()),
+		Kind:        "Native",
+		Nameservers: []string{},
+	}
+	created := &apiZone{}
+	if err := z.iface.do(context.TODO(), http.MethodPost, "/zones", request, created); err != nil {
+		return nil, err
+	}
+	return &zone{id: created.ID, name: created.Name, iface: z.iface}, nil
+}
+
+// Remove deletes the zone from the server
+func (z *zones) Remove(zone dnsprovider.Zone) error {
+	return z.iface.do(context.TODO(), http.MethodDelete, "/zones/"+url.PathEscape(zone.ID()), nil, nil)
+}
+
+// New returns a new implementation of dnsprovider.Zone
+func (z *zones) New(name string) (dnsprovider.Zone, error) {
+	return &zone{name: fqdn(name), iface: z.iface}, nil
+}
+
+// zone implements dnsprovider.Zone
+type zone struct {
+	id    string
+	name  string
+	iface *Interface
+}
+
+var _ dnsprovider.Zone = &zone{}
+
+// Name returns the fully qualified name of the zone
+func (z *zone) Name() string {
+	return z.name
+}
+
+// ID returns the id of the zone in the API
+func (z *zone) ID() string {
+	return z.id
+}
+
+// ResourceRecordSets returns an implementation of dnsprovider.ResourceRecordSets
+func (z *zone) ResourceRecordSets() (dnsprovider.ResourceRecordSets, bool) {
+	return &resourceRecordSets{zone: z}, true
+}
+
+// fqdn returns the name with a trailing dot, which the API requires for all names
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
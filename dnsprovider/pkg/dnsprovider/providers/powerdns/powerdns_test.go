@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/tests"
+)
+
+const testAPIKey = "secret"
+
+// fakeAPI is an in-memory implementation of the zone endpoints of the PowerDNS API
+type fakeAPI struct {
+	mutex sync.Mutex
+	zones map[string]*apiZone
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *httptest.Server) {
+	f := &fakeAPI{zones: make(map[string]*apiZone)}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if req.Header.Get("X-API-Key") != testAPIKey {
+		writeJSON(w, http.StatusUnauthorized, &apiError{Error: "Unauthorized"})
+		return
+	}
+
+	path, found := strings.CutPrefix(req.URL.Path, "/api/v1/servers/localhost/zones")
+	if !found {
+		writeJSON(w, http.StatusNotFound, &apiError{Error: "Not Found"})
+		return
+	}
+	id := strings.TrimPrefix(path, "/")
+
+	switch {
+	case id == "" && req.Method == http.MethodGet:
+		var zones []apiZone
+		for _, zone := range f.zones {
+			zones = append(zones, apiZone{ID: zone.ID, Name: zone.Name, Kind: zone.Kind})
+		}
+		sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+		writeJSON(w, http.StatusOK, zones)
+
+	case id == "" && req.Method == http.MethodPost:
+		zone := &apiZone{}
+		if err := json.NewDecoder(req.Body).Decode(zone); err != nil || !strings.HasSuffix(zone.Name, ".") {
+			writeJSON(w, http.StatusUnprocessableEntity, &apiError{Error: "invalid zone"})
+			return
+		}
+		zone.ID = zone.Name
+		f.zones[zone.ID] = zone
+		writeJSON(w, http.StatusCreated, zone)
+
+	case f.zones[id] == nil:
+		writeJSON(w, http.StatusNotFound, &apiError{Error: "Could not find domain '" + id + "'"})
+
+	case req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, f.zones[id])
+
+	case req.Method == http.MethodDelete:
+		delete(f.zones, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	case req.Method == http.MethodPatch:
+		patch := &apiZonePatch{}
+		if err := json.NewDecoder(req.Body).Decode(patch); err != nil {
+			writeJSON(w, http.StatusBadRequest, &apiError{Error: err.Error()})
+			return
+		}
+		zone := f.zones[id]
+		for _, change := range patch.RRSets {
+			if !strings.HasSuffix(change.Name, ".") {
+				writeJSON(w, http.StatusUnprocessableEntity, &apiError{Error: "name is not canonical"})
+				return
+			}
+			var rrsets []apiRRSet
+			for _, rrset := range zone.RRSets {
+				if rrset.Name != change.Name || rrset.Type != change.Type {
+					rrsets = append(rrsets, rrset)
+				}
+			}
+			switch change.ChangeType {
+			case "REPLACE":
+				rrsets = append(rrsets, apiRRSet{Name: change.Name, Type: change.Type, TTL: change.TTL, Records: change.Records})
+			case "DELETE":
+			default:
+				writeJSON(w, http.StatusUnprocessableEntity, &apiError{Error: "invalid changetype"})
+				return
+			}
+			zone.RRSets = rrsets
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, &apiError{Error: "Method Not Allowed"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func newTestProvider(t *testing.T, server *httptest.Server) dnsprovider.Interface {
+	provider, err := NewProvider(Config{APIURL: server.URL + "/", APIKey: testAPIKey})
+	if err != nil {
+		t.Fatalf("error building provider: %v", err)
+	}
+	return provider
+}
+
+func TestNewProvider(t *testing.T) {
+	if _, err := NewProvider(Config{APIKey: testAPIKey}); err == nil {
+		t.Errorf("expected error without API URL")
+	}
+	if _, err := NewProvider(Config{APIURL: "http://127.0.0.1:8081"}); err == nil {
+		t.Errorf("expected error without API key")
+	}
+	provider, err := NewProvider(Config{APIURL: "http://127.0.0.1:8081", APIKey: testAPIKey})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.config.ServerID != defaultServerID {
+		t.Errorf("expected server id %q, got %q", defaultServerID, provider.config.ServerID)
+	}
+}
+
+func TestZones(t *testing.T) {
+	_, server := newFakeAPI(t)
+	provider := newTestProvider(t, server)
+
+	zonesProvider, _ := provider.Zones()
+	newZone, _ := zonesProvider.New("example.com")
+	zone, err := zonesProvider.Add(newZone)
+	if err != nil {
+		t.Fatalf("error adding zone: %v", err)
+	}
+	if zone.Name() != "example.com." || zone.ID() != "example.com." {
+		t.Errorf("unexpected zone %q with id %q", zone.Name(), zone.ID())
+	}
+
+	zones, err := zonesProvider.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	if len(zones) != 1 || zones[0].Name() != "example.com." {
+		t.Fatalf("unexpected zones: %v", zones)
+	}
+
+	if err := zonesProvider.Remove(zones[0]); err != nil {
+		t.Fatalf("error removing zone: %v", err)
+	}
+	if zones, err := zonesProvider.List(); err != nil || len(zones) != 0 {
+		t.Errorf("expected no zones after removal, got %v (%v)", zones, err)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	_, server := newFakeAPI(t)
+	provider := newTestProvider(t, server)
+
+	zonesProvider, _ := provider.Zones()
+	newZone, _ := zonesProvider.New("example.com.")
+	zone, err := zonesProvider.Add(newZone)
+	if err != nil {
+		t.Fatalf("error adding zone: %v", err)
+	}
+	rrsets, _ := zone.ResourceRecordSets()
+
+	apply := func(build func(cs dnsprovider.ResourceRecordChangeset)) {
+		t.Helper()
+		cs := rrsets.StartChangeset()
+		build(cs)
+		if err := cs.Apply(context.TODO()); err != nil {
+			t.Fatalf("error applying changeset: %v", err)
+		}
+	}
+
+	apply(func(cs dnsprovider.ResourceRecordChangeset) {
+		cs.Add(rrsets.New("api.example.com", []string{"10.0.0.1"}, 60, rrstype.A))
+		cs.Add(rrsets.New("api.example.com", []string{"10.0.0.2"}, 60, rrstype.A))
+		cs.Add(rrsets.New("www.example.com.", []string{"api.example.com."}, 60, rrstype.CNAME))
+	})
+	expectRecords(t, rrsets, map[string][]string{
+		"A::api.example.com.":     {"10.0.0.1", "10.0.0.2"},
+		"CNAME::www.example.com.": {"api.example.com."},
+	})
+
+	apply(func(cs dnsprovider.ResourceRecordChangeset) {
+		cs.Remove(rrsets.New("api.example.com.", []string{"10.0.0.1"}, 60, rrstype.A))
+		cs.Remove(rrsets.New("www.example.com.", []string{"api.example.com."}, 60, rrstype.CNAME))
+		cs.Upsert(rrsets.New("_owner.api.example.com.", []string{"\"heritage=dns-controller\""}, 60, rrstype.TXT))
+	})
+	expectRecords(t, rrsets, map[string][]string{
+		"A::api.example.com.":          {"10.0.0.2"},
+		"TXT::_owner.api.example.com.": {"\"heritage=dns-controller\""},
+	})
+
+	// The conformance tests of the dnsprovider package
+	tests.TestContract(t, rrsets)
+	tests.CommonTestResourceRecordSetsReplace(t, zone)
+	tests.CommonTestResourceRecordSetsReplaceAll(t, zone)
+	tests.CommonTestResourceRecordSetsDifferentTypes(t, zone)
+}
+
+func TestUnauthenticated(t *testing.T) {
+	_, server := newFakeAPI(t)
+	provider, err := NewProvider(Config{APIURL: server.URL, APIKey: "wrong"})
+	if err != nil {
+		t.Fatalf("error building provider: %v", err)
+	}
+
+	zonesProvider, _ := provider.Zones()
+	if _, err := zonesProvider.List(); err == nil || !strings.Contains(err.Error(), "Unauthorized") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+// expectRecords checks the records of the zone
+func expectRecords(t *testing.T, rrsets dnsprovider.ResourceRecordSets, expected map[string][]string) {
+	t.Helper()
+
+	list, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("error listing records: %v", err)
+	}
+
+	actual := make(map[string][]string)
+	for _, rrset := range list {
+		rrdatas := append([]string{}, rrset.Rrdatas()...)
+		sort.Strings(rrdatas)
+		actual[string(rrset.Type())+"::"+rrset.Name()] = rrdatas
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected records:\n%v\nexpected:\n%v", actual, expected)
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+// resourceRecordSets implements dnsprovider.ResourceRecordSets
+type resourceRecordSets struct {
+	zone *zone
+}
+
+var _ dnsprovider.ResourceRecordSets = &resourceRecordSets{}
+
+// List returns the enabled records of the zone
+func (r *resourceRecordSets) List() ([]dnsprovider.ResourceRecordSet, error) {
+	apiRRSets, err := r.listAPIRRSets(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	var rrsets []dnsprovider.ResourceRecordSet
+	for _, apiRRSet := range apiRRSets {
+		rrset := &resourceRecordSet{
+			name:       apiRRSet.Name,
+			ttl:        apiRRSet.TTL,
+			recordType: rrstype.RrsType(apiRRSet.Type),
+		}
+		for _, record := range apiRRSet.Records {
+			if !record.Disabled {
+				rrset.rrdatas = append(rrset.rrdatas, record.Content)
+			}
+		}
+		if len(rrset.rrdatas) != 0 {
+			rrsets = append(rrsets, rrset)
+		}
+	}
+	return rrsets, nil
+}
+
+// listAPIRRSets returns the records of the zone as returned by the API
+func (r *resourceRecordSets) listAPIRRSets(ctx context.Context) ([]apiRRSet, error) {
+	apiZone := &apiZone{}
+	if err := r.zone.iface.do(ctx, http.MethodGet, "/zones/"+url.PathEscape(r.zone.id), nil, apiZone); err != nil {
+		return nil, err
+	}
+	return apiZone.RRSets, nil
+}
+
+// Get returns the records with the given name
+func (r *resourceRecordSets) Get(name string) ([]dnsprovider.ResourceRecordSet, error) {
+	rrsets, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []dnsprovider.ResourceRecordSet
+	for _, rrset := range rrsets {
+		if strings.EqualFold(rrset.Name(), fqdn(name)) {
+			matches = append(matches, rrset)
+		}
+	}
+	return matches, nil
+}
+
+// New returns an implementation of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType) dnsprovider.ResourceRecordSet {
+	return &resourceRecordSet{
+		name:       fqdn(name),
+		rrdatas:    rrdatas,
+		ttl:        ttl,
+		recordType: rrstype,
+	}
+}
+
+// StartChangeset returns an implementation of dnsprovider.ResourceRecordChangeset
+func (r *resourceRecordSets) StartChangeset() dnsprovider.ResourceRecordChangeset {
+	return &resourceRecordChangeset{rrsets: r}
+}
+
+// Zone returns the zone of the records
+func (r *resourceRecordSets) Zone() dnsprovider.Zone {
+	return r.zone
+}
+
+// resourceRecordSet implements dnsprovider.ResourceRecordSet
+type resourceRecordSet struct {
+	name       string
+	rrdatas    []string
+	ttl        int64
+	recordType rrstype.RrsType
+}
+
+var _ dnsprovider.ResourceRecordSet = &resourceRecordSet{}
+
+// Name returns the fully qualified name of the records
+func (r *resourceRecordSet) Name() string {
+	return r.name
+}
+
+// Rrdatas returns the content of the records
+func (r *resourceRecordSet) Rrdatas() []string {
+	return r.rrdatas
+}
+
+// Ttl returns the time-to-live of the records
+func (r *resourceRecordSet) Ttl() int64 {
+	return r.ttl
+}
+
+// Type returns the type of the records
+func (r *resourceRecordSet) Type() rrstype.RrsType {
+	return r.recordType
+}
+
+// resourceRecordChangeset implements dnsprovider.ResourceRecordChangeset
+type resourceRecordChangeset struct {
+	rrsets *resourceRecordSets
+
+	additions []dnsprovider.ResourceRecordSet
+	removals  []dnsprovider.ResourceRecordSet
+	upserts   []dnsprovider.ResourceRecordSet
+}
+
+var _ dnsprovider.ResourceRecordChangeset = &resourceRecordChangeset{}
+
+// Add adds the creation of the records to the changeset
+func (c *resourceRecordChangeset) Add(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.additions = append(c.additions, rrset)
+	return c
+}
+
+// Remove adds the removal of the records to the changeset
+func (c *resourceRecordChangeset) Remove(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.removals = append(c.removals, rrset)
+	return c
+}
+
+// Upsert adds the replacement of all records with the same name and type to the changeset
+func (c *resourceRecordChangeset) Upsert(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.upserts = append(c.upserts, rrset)
+	return c
+}
+
+// Apply sends all the changes to the API in a single PATCH of the zone.
+// The API replaces or deletes whole sets of records, so we compute the resulting sets from the current records.
+func (c *resourceRecordChangeset) Apply(ctx context.Context) error {
+	if c.IsEmpty() {
+		return nil
+	}
+
+	current, err := c.rrsets.listAPIRRSets(ctx)
+	if err != nil {
+		return err
+	}
+
+	key := func(name string, recordType string) string {
+		return strings.ToLower(fqdn(name)) + "::" + recordType
+	}
+	existing := make(map[string]*apiRRSet)
+	for i := range current {
+		existing[key(current[i].Name, current[i].Type)] = &current[i]
+	}
+
+	var changes []*apiRRSet
+	changed := make(map[string]*apiRRSet)
+	change := func(rrset dnsprovider.ResourceRecordSet) *apiRRSet {
+		k := key(rrset.Name(), string(rrset.Type()))
+		if change := changed[k]; change != nil {
+			return change
+		}
+		change := &apiRRSet{
+			Name: fqdn(rrset.Name()),
+			Type: string(rrset.Type()),
+			TTL:  rrset.Ttl(),
+		}
+		if e := existing[k]; e != nil {
+			change.TTL = e.TTL
+			change.Records = append(change.Records, e.Records...)
+		}
+		changed[k] = change
+		changes = append(changes, change)
+		return change
+	}
+
+	for _, rrset := range c.removals {
+		change := change(rrset)
+		change.Records = slices.DeleteFunc(change.Records, func(record apiRecord) bool {
+			return slices.Contains(rrset.Rrdatas(), record.Content)
+		})
+	}
+	for _, rrset := range c.upserts {
+		change := change(rrset)
+		change.TTL = rrset.Ttl()
+		change.Records = nil
+		for _, rrdata := range rrset.Rrdatas() {
+			change.Records = append(change.Records, apiRecord{Content: rrdata})
+		}
+	}
+	for _, rrset := range c.additions {
+		change := change(rrset)
+		change.TTL = rrset.Ttl()
+		for _, rrdata := range rrset.Rrdatas() {
+			if !slices.ContainsFunc(change.Records, func(record apiRecord) bool { return record.Content == rrdata }) {
+				change.Records = append(change.Records, apiRecord{Content: rrdata})
+			}
+		}
+	}
+
+	request := &apiZonePatch{}
+	for _, change := range changes {
+		if len(change.Records) == 0 {
+			change.ChangeType = "DELETE"
+			change.Records = []apiRecord{}
+		} else {
+			change.ChangeType = "REPLACE"
+		}
+		request.RRSets = append(request.RRSets, *change)
+	}
+
+	klog.V(2).Infof("Updating %d record sets in zone %s", len(request.RRSets), c.rrsets.zone.name)
+
+	return c.rrsets.zone.iface.do(ctx, http.MethodPatch, "/zones/"+url.PathEscape(c.rrsets.zone.id), request, nil)
+}
+
+// IsEmpty returns true if the changeset has no changes
+func (c *resourceRecordChangeset) IsEmpty() bool {
+	return len(c.additions) == 0 && len(c.removals) == 0 && len(c.upserts) == 0
+}
+
+// ResourceRecordSets returns the resourceRecordSets of the changeset
+func (c *resourceRecordChangeset) ResourceRecordSets() dnsprovider.ResourceRecordSets {
+	return c.rrsets
+}
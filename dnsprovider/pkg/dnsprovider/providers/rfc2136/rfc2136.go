@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rfc2136 implements a DNS provider for DNS servers that support
+// dynamic updates (RFC 2136) authenticated with TSIG (RFC 8945), listing records with zone transfers (AXFR).
+package rfc2136
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+var _ dnsprovider.Interface = &Interface{}
+var _ dnsprovider.CapabilitiesInterface = &Interface{}
+
+const (
+	ProviderName = "rfc2136"
+
+	defaultTSIGAlgorithm = dns.HmacSHA256
+	defaultTimeout       = 30 * time.Second
+)
+
+func init() {
+	dnsprovider.RegisterDNSProvider(ProviderName, func(config io.Reader) (dnsprovider.Interface, error) {
+		return NewProvider(ConfigFromEnv(os.Getenv))
+	})
+}
+
+// Config is the configuration of the DNS server
+type Config struct {
+	// Nameserver is the address of the DNS server, as host or host:port
+	Nameserver string
+	// Zones are the zones we manage on the DNS server; they can't be discovered through RFC 2136
+	Zones []string
+	// TSIGKeyName is the name of the TSIG key used to authenticate updates and zone transfers
+	TSIGKeyName string
+	// TSIGSecret is the base64 encoded secret of the TSIG key
+	TSIGSecret string
+	// TSIGAlgorithm is the algorithm of the TSIG key, defaults to hmac-sha256
+	TSIGAlgorithm string
+	// Timeout is the timeout of requests to the DNS server
+	Timeout time.Duration
+}
+
+// ConfigFromEnv builds the configuration from the RFC2136_* environment variables, as returned by getenv
+func ConfigFromEnv(getenv func(key string) string) Config {
+	config := Config{
+		Nameserver:    getenv("RFC2136_NAMESERVER"),
+		TSIGKeyName:   getenv("RFC2136_TSIG_KEYNAME"),
+		TSIGSecret:    getenv("RFC2136_TSIG_SECRET"),
+		TSIGAlgorithm: getenv("RFC2136_TSIG_ALGORITHM"),
+	}
+	for _, zone := range strings.Split(getenv("RFC2136_ZONES"), ",") {
+		if zone = strings.TrimSpace(zone); zone != "" {
+			config.Zones = append(config.Zones, zone)
+		}
+	}
+	return config
+}
+
+// Interface implements dnsprovider.Interface
+type Interface struct {
+	config Config
+}
+
+// NewProvider returns an implementation of dnsprovider.Interface
+func NewProvider(config Config) (*Interface, error) {
+	if config.Nameserver == "" {
+		return nil, fmt.Errorf("RFC2136_NAMESERVER is required")
+	}
+	if _, _, err := net.SplitHostPort(config.Nameserver); err != nil {
+		config.Nameserver = net.JoinHostPort(config.Nameserver, "53")
+	}
+	if len(config.Zones) == 0 {
+		return nil, fmt.Errorf("RFC2136_ZONES is required")
+	}
+	if (config.TSIGKeyName == "") != (config.TSIGSecret == "") {
+		return nil, fmt.Errorf("RFC2136_TSIG_KEYNAME and RFC2136_TSIG_SECRET must be set together")
+	}
+	if config.TSIGKeyName != "" {
+		config.TSIGKeyName = dns.Fqdn(config.TSIGKeyName)
+		if config.TSIGAlgorithm == "" {
+			config.TSIGAlgorithm = defaultTSIGAlgorithm
+		}
+		config.TSIGAlgorithm = dns.Fqdn(strings.ToLower(config.TSIGAlgorithm))
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	return &Interface{config: config}, nil
+}
+
+// Zones returns an implementation of dnsprovider.Zones
+func (i *Interface) Zones() (dnsprovider.Zones, bool) {
+	return &zones{iface: i}, true
+}
+
+// Capabilities implements dnsprovider.CapabilitiesInterface
+func (i *Interface) Capabilities() dnsprovider.Capabilities {
+	return dnsprovider.Capabilities{
+		RecordTypes: []rrstype.RrsType{rrstype.A, rrstype.AAAA, rrstype.CNAME, rrstype.SRV, rrstype.TXT},
+	}
+}
+
+// sign adds the TSIG record to a message, if TSIG is configured
+func (i *Interface) sign(m *dns.Msg) {
+	if i.config.TSIGKeyName != "" {
+		m.SetTsig(i.config.TSIGKeyName, i.config.TSIGAlgorithm, 300, time.Now().Unix())
+	}
+}
+
+// tsigSecret returns the TSIG secrets used to sign and verify messages
+func (i *Interface) tsigSecret() map[string]string {
+	if i.config.TSIGKeyName == "" {
+		return nil
+	}
+	return map[string]string{i.config.TSIGKeyName: i.config.TSIGSecret}
+}
+
+// zones implements dnsprovider.Zones
+type zones struct {
+	iface *Interface
+}
+
+var _ dnsprovider.Zones = &zones{}
+
+// List returns the configured zones
+func (z *zones) List() ([]dnsprovider.Zone, error) {
+	var zones []dnsprovider.Zone
+	for _, name := range z.iface.config.Zones {
+		zones = append(zones, &zone{name: dns.Fqdn(name), iface: z.iface})
+	}
+	return zones, nil
+}
+
+// Add is not supported, zones must be created on the DNS server
+func (z *zones) Add(newZone dnsprovider.Zone) (dnsprovider.Zone, error) {
+	return nil, fmt.Errorf("creating zones is not supported by the %s DNS provider", ProviderName)
+}
+
+// Remove is not supported, zones must be removed on the DNS server
+func (z *zones) Remove(zone dnsprovider.Zone) error {
+	return fmt.Errorf("removing zones is not supported by the %s DNS provider", ProviderName)
+}
+
+// New returns a new implementation of dnsprovider.Zone
+func (z *zones) New(name string) (dnsprovider.Zone, error) {
+	return &zone{name: dns.Fqdn(name), iface: z.iface}, nil
+}
+
+// zone implements dnsprovider.Zone
+type zone struct {
+	name  string
+	iface *Interface
+}
+
+var _ dnsprovider.Zone = &zone{}
+
+// Name returns the fully qualified name of the zone
+func (z *zone) Name() string {
+	return z.name
+}
+
+// ID returns the name of the zone, zones have no other identifier
+func (z *zone) ID() string {
+	return z.name
+}
+
+// ResourceRecordSets returns an implementation of dnsprovider.ResourceRecordSets
+func (z *zone) ResourceRecordSets() (dnsprovider.ResourceRecordSets, bool) {
+	return &resourceRecordSets{zone: z}, true
+}
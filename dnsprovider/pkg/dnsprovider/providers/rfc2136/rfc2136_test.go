@@ -0,0 +1,344 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/tests"
+)
+
+const (
+	testZone       = "example.com."
+	testKeyName    = "kops."
+	testKeySecret  = "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0IQ=="
+	testSOARecord  = "example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 3600 600 86400 60"
+	testNSRecord   = "example.com. 3600 IN NS ns1.example.com."
+	testNameserver = "ns1.example.com. 3600 IN A 192.0.2.53"
+)
+
+// testServer is an in-process DNS server that supports zone transfers and dynamic updates of a single zone
+type testServer struct {
+	mutex   sync.Mutex
+	records []dns.RR
+
+	server *dns.Server
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{}
+	for _, record := range []string{testSOARecord, testNSRecord, testNameserver} {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			t.Fatalf("error parsing record %q: %v", record, err)
+		}
+		s.records = append(s.records, rr)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+
+	started := make(chan struct{})
+	s.server = &dns.Server{
+		Listener:          listener,
+		Handler:           s,
+		TsigSecret:        map[string]string{testKeyName: testKeySecret},
+		NotifyStartedFunc: func() { close(started) },
+		// The default accept func rejects dynamic updates
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go func() {
+		if err := s.server.ActivateAndServe(); err != nil {
+			t.Errorf("error serving DNS: %v", err)
+		}
+	}()
+	<-started
+	t.Cleanup(func() { s.server.Shutdown() })
+
+	return s
+}
+
+func (s *testServer) address() string {
+	return s.server.Listener.Addr().String()
+}
+
+func (s *testServer) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	response := new(dns.Msg)
+	response.SetReply(req)
+	if req.IsTsig() == nil || w.TsigStatus() != nil {
+		response.Rcode = dns.RcodeRefused
+		w.WriteMsg(response)
+		return
+	}
+	response.SetTsig(testKeyName, dns.HmacSHA256, 300, time.Now().Unix())
+
+	switch {
+	case req.Opcode == dns.OpcodeUpdate:
+		for _, rr := range req.Ns {
+			s.update(rr)
+		}
+		w.WriteMsg(response)
+
+	case len(req.Question) == 1 && req.Question[0].Qtype == dns.TypeAXFR:
+		// The SOA record is first in s.records, and must start and end the transfer
+		records := append(append([]dns.RR{}, s.records...), s.records[0])
+		ch := make(chan *dns.Envelope)
+		transfer := &dns.Transfer{TsigSecret: map[string]string{testKeyName: testKeySecret}}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transfer.Out(w, req, ch)
+		}()
+		ch <- &dns.Envelope{RR: records}
+		close(ch)
+		wg.Wait()
+
+	default:
+		response.Rcode = dns.RcodeNotImplemented
+		w.WriteMsg(response)
+	}
+}
+
+// update applies a record from the update section of a dynamic update
+func (s *testServer) update(rr dns.RR) {
+	header := rr.Header()
+	switch header.Class {
+	case dns.ClassANY:
+		// Delete the RRset
+		s.records = filterRecords(s.records, func(r dns.RR) bool {
+			return !strings.EqualFold(r.Header().Name, header.Name) || r.Header().Rrtype != header.Rrtype
+		})
+	case dns.ClassNONE:
+		// Delete the record
+		s.records = filterRecords(s.records, func(r dns.RR) bool {
+			return !strings.EqualFold(r.Header().Name, header.Name) || r.Header().Rrtype != header.Rrtype || !dns.IsDuplicate(r, withClass(rr, dns.ClassINET))
+		})
+	default:
+		for _, r := range s.records {
+			if dns.IsDuplicate(r, rr) {
+				return
+			}
+		}
+		s.records = append(s.records, rr)
+	}
+}
+
+func withClass(rr dns.RR, class uint16) dns.RR {
+	rr = dns.Copy(rr)
+	rr.Header().Class = class
+	return rr
+}
+
+func filterRecords(records []dns.RR, keep func(dns.RR) bool) []dns.RR {
+	var kept []dns.RR
+	for _, r := range records {
+		if keep(r) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+func newTestProvider(t *testing.T, s *testServer) dnsprovider.Interface {
+	provider, err := NewProvider(Config{
+		Nameserver:  s.address(),
+		Zones:       []string{"example.com"},
+		TSIGKeyName: "kops",
+		TSIGSecret:  testKeySecret,
+		Timeout:     5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("error building provider: %v", err)
+	}
+	return provider
+}
+
+func TestNewProvider(t *testing.T) {
+	grid := []struct {
+		config      Config
+		nameserver  string
+		expectError bool
+	}{
+		{
+			config:     Config{Nameserver: "192.0.2.53", Zones: []string{"example.com"}},
+			nameserver: "192.0.2.53:53",
+		},
+		{
+			config:     Config{Nameserver: "[2001:db8::53]:5353", Zones: []string{"example.com"}, TSIGKeyName: "kops", TSIGSecret: testKeySecret},
+			nameserver: "[2001:db8::53]:5353",
+		},
+		{
+			config:      Config{Zones: []string{"example.com"}},
+			expectError: true,
+		},
+		{
+			config:      Config{Nameserver: "192.0.2.53"},
+			expectError: true,
+		},
+		{
+			config:      Config{Nameserver: "192.0.2.53", Zones: []string{"example.com"}, TSIGKeyName: "kops"},
+			expectError: true,
+		},
+	}
+	for _, g := range grid {
+		provider, err := NewProvider(g.config)
+		if g.expectError {
+			if err == nil {
+				t.Errorf("expected error for %+v", g.config)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %+v: %v", g.config, err)
+			continue
+		}
+		if provider.config.Nameserver != g.nameserver {
+			t.Errorf("expected nameserver %q, got %q", g.nameserver, provider.config.Nameserver)
+		}
+	}
+}
+
+func TestZones(t *testing.T) {
+	s := newTestServer(t)
+	provider := newTestProvider(t, s)
+
+	zonesProvider, _ := provider.Zones()
+	zones, err := zonesProvider.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	if len(zones) != 1 || zones[0].Name() != testZone {
+		t.Fatalf("unexpected zones: %v", zones)
+	}
+
+	if _, err := zonesProvider.Add(zones[0]); err == nil {
+		t.Errorf("expected error adding zone")
+	}
+}
+
+func TestRecords(t *testing.T) {
+	s := newTestServer(t)
+	provider := newTestProvider(t, s)
+
+	zonesProvider, _ := provider.Zones()
+	zones, err := zonesProvider.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	rrsets, _ := zones[0].ResourceRecordSets()
+
+	apply := func(build func(cs dnsprovider.ResourceRecordChangeset)) {
+		t.Helper()
+		cs := rrsets.StartChangeset()
+		build(cs)
+		if err := cs.Apply(context.TODO()); err != nil {
+			t.Fatalf("error applying changeset: %v", err)
+		}
+	}
+
+	apply(func(cs dnsprovider.ResourceRecordChangeset) {
+		cs.Add(rrsets.New("api.example.com", []string{"10.0.0.1", "10.0.0.2"}, 60, rrstype.A))
+		cs.Add(rrsets.New("_owner.api.example.com", []string{"\"heritage=dns-controller\""}, 60, rrstype.TXT))
+		cs.Add(rrsets.New("www.example.com.", []string{"api.example.com."}, 60, rrstype.CNAME))
+	})
+	expectRecords(t, rrsets, map[string][]string{
+		"A::api.example.com.":          {"10.0.0.1", "10.0.0.2"},
+		"TXT::_owner.api.example.com.": {"\"heritage=dns-controller\""},
+		"CNAME::www.example.com.":      {"api.example.com."},
+	})
+
+	apply(func(cs dnsprovider.ResourceRecordChangeset) {
+		cs.Upsert(rrsets.New("api.example.com.", []string{"10.0.0.3"}, 60, rrstype.A))
+		cs.Remove(rrsets.New("www.example.com.", []string{"api.example.com."}, 60, rrstype.CNAME))
+	})
+	expectRecords(t, rrsets, map[string][]string{
+		"A::api.example.com.":          {"10.0.0.3"},
+		"TXT::_owner.api.example.com.": {"\"heritage=dns-controller\""},
+	})
+
+	matches, err := rrsets.Get("api.example.com")
+	if err != nil {
+		t.Fatalf("error getting records: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Type() != rrstype.A {
+		t.Errorf("unexpected records for api.example.com: %v", matches)
+	}
+
+	// The conformance tests of the dnsprovider package
+	tests.TestContract(t, rrsets)
+	tests.CommonTestResourceRecordSetsReplace(t, zones[0])
+	tests.CommonTestResourceRecordSetsReplaceAll(t, zones[0])
+	tests.CommonTestResourceRecordSetsDifferentTypes(t, zones[0])
+}
+
+func TestUnauthenticated(t *testing.T) {
+	s := newTestServer(t)
+	provider, err := NewProvider(Config{Nameserver: s.address(), Zones: []string{"example.com"}})
+	if err != nil {
+		t.Fatalf("error building provider: %v", err)
+	}
+
+	zonesProvider, _ := provider.Zones()
+	zones, _ := zonesProvider.List()
+	rrsets, _ := zones[0].ResourceRecordSets()
+
+	cs := rrsets.StartChangeset()
+	cs.Add(rrsets.New("api.example.com.", []string{"10.0.0.1"}, 60, rrstype.A))
+	if err := cs.Apply(context.TODO()); err == nil || !strings.Contains(err.Error(), "REFUSED") {
+		t.Errorf("expected update to be refused, got %v", err)
+	}
+}
+
+// expectRecords checks the records of the zone, ignoring the SOA and NS records of the zone itself
+func expectRecords(t *testing.T, rrsets dnsprovider.ResourceRecordSets, expected map[string][]string) {
+	t.Helper()
+
+	list, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("error listing records: %v", err)
+	}
+
+	actual := make(map[string][]string)
+	for _, rrset := range list {
+		if rrset.Name() == testZone || rrset.Name() == "ns1.example.com." {
+			continue
+		}
+		rrdatas := append([]string{}, rrset.Rrdatas()...)
+		sort.Strings(rrdatas)
+		actual[string(rrset.Type())+"::"+rrset.Name()] = rrdatas
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected records:\n%v\nexpected:\n%v", actual, expected)
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+// resourceRecordSets implements dnsprovider.ResourceRecordSets
+type resourceRecordSets struct {
+	zone *zone
+}
+
+var _ dnsprovider.ResourceRecordSets = &resourceRecordSets{}
+
+// List returns the records of the zone, fetched with a zone transfer
+func (r *resourceRecordSets) List() ([]dnsprovider.ResourceRecordSet, error) {
+	iface := r.zone.iface
+
+	m := new(dns.Msg)
+	m.SetAxfr(r.zone.name)
+	iface.sign(m)
+
+	t := &dns.Transfer{
+		DialTimeout:  iface.config.Timeout,
+		ReadTimeout:  iface.config.Timeout,
+		WriteTimeout: iface.config.Timeout,
+		TsigSecret:   iface.tsigSecret(),
+	}
+	envelopes, err := t.In(m, iface.config.Nameserver)
+	if err != nil {
+		return nil, fmt.Errorf("error transferring zone %q: %w", r.zone.name, err)
+	}
+
+	var rrsets []dnsprovider.ResourceRecordSet
+	byKey := make(map[string]*resourceRecordSet)
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			return nil, fmt.Errorf("error transferring zone %q: %w", r.zone.name, envelope.Error)
+		}
+		for _, rr := range envelope.RR {
+			header := rr.Header()
+			recordType := rrstype.RrsType(dns.TypeToString[header.Rrtype])
+			rrdata := strings.TrimPrefix(rr.String(), header.String())
+
+			key := strings.ToLower(header.Name) + "::" + string(recordType)
+			rrset := byKey[key]
+			if rrset == nil {
+				rrset = &resourceRecordSet{
+					name:       header.Name,
+					ttl:        int64(header.Ttl),
+					recordType: recordType,
+				}
+				byKey[key] = rrset
+				rrsets = append(rrsets, rrset)
+			}
+			// The SOA record is sent at the start and the end of the transfer
+			if !slices.Contains(rrset.rrdatas, rrdata) {
+				rrset.rrdatas = append(rrset.rrdatas, rrdata)
+			}
+		}
+	}
+
+	return rrsets, nil
+}
+
+// Get returns the records with the given name
+func (r *resourceRecordSets) Get(name string) ([]dnsprovider.ResourceRecordSet, error) {
+	rrsets, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []dnsprovider.ResourceRecordSet
+	for _, rrset := range rrsets {
+		if strings.EqualFold(rrset.Name(), dns.Fqdn(name)) {
+			matches = append(matches, rrset)
+		}
+	}
+	return matches, nil
+}
+
+// New returns an implementation of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType) dnsprovider.ResourceRecordSet {
+	return &resourceRecordSet{
+		name:       dns.Fqdn(name),
+		rrdatas:    rrdatas,
+		ttl:        ttl,
+		recordType: rrstype,
+	}
+}
+
+// StartChangeset returns an implementation of dnsprovider.ResourceRecordChangeset
+func (r *resourceRecordSets) StartChangeset() dnsprovider.ResourceRecordChangeset {
+	return &resourceRecordChangeset{rrsets: r}
+}
+
+// Zone returns the zone of the records
+func (r *resourceRecordSets) Zone() dnsprovider.Zone {
+	return r.zone
+}
+
+// resourceRecordSet implements dnsprovider.ResourceRecordSet
+type resourceRecordSet struct {
+	name       string
+	rrdatas    []string
+	ttl        int64
+	recordType rrstype.RrsType
+}
+
+var _ dnsprovider.ResourceRecordSet = &resourceRecordSet{}
+
+// Name returns the fully qualified name of the records
+func (r *resourceRecordSet) Name() string {
+	return r.name
+}
+
+// Rrdatas returns the data of the records in presentation format
+func (r *resourceRecordSet) Rrdatas() []string {
+	return r.rrdatas
+}
+
+// Ttl returns the time-to-live of the records
+func (r *resourceRecordSet) Ttl() int64 {
+	return r.ttl
+}
+
+// Type returns the type of the records
+func (r *resourceRecordSet) Type() rrstype.RrsType {
+	return r.recordType
+}
+
+// toRRs parses the records into their wire representation
+func (r *resourceRecordSet) toRRs() ([]dns.RR, error) {
+	var rrs []dns.RR
+	for _, rrdata := range r.rrdatas {
+		rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", r.name, r.ttl, r.recordType, rrdata))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s record %q for %s: %w", r.recordType, rrdata, r.name, err)
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs, nil
+}
+
+// resourceRecordChangeset implements dnsprovider.ResourceRecordChangeset
+type resourceRecordChangeset struct {
+	rrsets *resourceRecordSets
+
+	additions []dnsprovider.ResourceRecordSet
+	removals  []dnsprovider.ResourceRecordSet
+	upserts   []dnsprovider.ResourceRecordSet
+}
+
+var _ dnsprovider.ResourceRecordChangeset = &resourceRecordChangeset{}
+
+// Add adds the creation of the records to the changeset
+func (c *resourceRecordChangeset) Add(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.additions = append(c.additions, rrset)
+	return c
+}
+
+// Remove adds the removal of the records to the changeset
+func (c *resourceRecordChangeset) Remove(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.removals = append(c.removals, rrset)
+	return c
+}
+
+// Upsert adds the replacement of all records with the same name and type to the changeset
+func (c *resourceRecordChangeset) Upsert(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	c.upserts = append(c.upserts, rrset)
+	return c
+}
+
+// Apply sends all the changes to the DNS server in a single dynamic update, which is applied atomically
+func (c *resourceRecordChangeset) Apply(ctx context.Context) error {
+	if c.IsEmpty() {
+		return nil
+	}
+
+	zone := c.rrsets.zone
+	iface := zone.iface
+
+	m := new(dns.Msg)
+	m.SetUpdate(zone.name)
+
+	for _, rrset := range c.removals {
+		rrs, err := toRRs(rrset)
+		if err != nil {
+			return err
+		}
+		m.Remove(rrs)
+	}
+	for _, rrset := range c.upserts {
+		rrs, err := toRRs(rrset)
+		if err != nil {
+			return err
+		}
+		m.RemoveRRset([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: dns.Fqdn(rrset.Name()), Rrtype: dns.StringToType[string(rrset.Type())]}}})
+		m.Insert(rrs)
+	}
+	for _, rrset := range c.additions {
+		rrs, err := toRRs(rrset)
+		if err != nil {
+			return err
+		}
+		m.Insert(rrs)
+	}
+	iface.sign(m)
+
+	klog.V(2).Infof("Sending dynamic update for zone %s with %d changes", zone.name, len(m.Ns))
+
+	client := &dns.Client{
+		Net:        "tcp",
+		Timeout:    iface.config.Timeout,
+		TsigSecret: iface.tsigSecret(),
+	}
+	response, _, err := client.ExchangeContext(ctx, m, iface.config.Nameserver)
+	if err != nil {
+		return fmt.Errorf("error updating zone %q: %w", zone.name, err)
+	}
+	if response.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("error updating zone %q: %s", zone.name, dns.RcodeToString[response.Rcode])
+	}
+
+	return nil
+}
+
+// IsEmpty returns true if the changeset has no changes
+func (c *resourceRecordChangeset) IsEmpty() bool {
+	return len(c.additions) == 0 && len(c.removals) == 0 && len(c.upserts) == 0
+}
+
+// ResourceRecordSets returns the resourceRecordSets of the changeset
+func (c *resourceRecordChangeset) ResourceRecordSets() dnsprovider.ResourceRecordSets {
+	return c.rrsets
+}
+
+// toRRs parses records that may have been created by another implementation of dnsprovider.ResourceRecordSets
+func toRRs(rrset dnsprovider.ResourceRecordSet) ([]dns.RR, error) {
+	r := &resourceRecordSet{
+		name:       dns.Fqdn(rrset.Name()),
+		rrdatas:    rrset.Rrdatas(),
+		ttl:        rrset.Ttl(),
+		recordType: rrset.Type(),
+	}
+	return r.toRRs()
+}
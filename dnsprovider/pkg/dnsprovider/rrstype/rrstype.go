@@ -24,6 +24,7 @@ const (
 	A     = RrsType("A")
 	AAAA  = RrsType("AAAA")
 	CNAME = RrsType("CNAME")
+	SRV   = RrsType("SRV")
 	TXT   = RrsType("TXT")
 	// TODO:  Add other types as required
 )
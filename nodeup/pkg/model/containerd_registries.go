@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// containerdRegistryHostsDir is the directory containerd reads the registry host configuration from.
+// See https://github.com/containerd/containerd/blob/main/docs/hosts.md
+const containerdRegistryHostsDir = "/etc/containerd/certs.d"
+
+// dockerConfigAuths is the subset of the dockerconfig secret we use for registry credentials
+type dockerConfigAuths struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth,omitempty"`
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
+	} `json:"auths"`
+}
+
+// buildRegistryHosts writes a hosts.toml file, and any CA bundles, for each configured registry
+func (b *ContainerdBuilder) buildRegistryHosts(c *fi.NodeupModelBuilderContext) error {
+	containerd := b.NodeupConfig.ContainerdConfig
+	if containerd == nil || len(containerd.Registries) == 0 {
+		return nil
+	}
+
+	var auths *dockerConfigAuths
+	for _, registry := range containerd.Registries {
+		if registry.AuthFromDockerConfig {
+			var err error
+			if auths, err = b.loadDockerConfigAuths(); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	var names []string
+	for name := range containerd.Registries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		registry := containerd.Registries[name]
+		dir := filepath.Join(containerdRegistryHostsDir, name)
+
+		var lines []string
+		hasCredentials := false
+
+		server := registry.Server
+		if server == "" && name != "_default" {
+			server = defaultRegistryServer(name)
+		}
+		if server != "" {
+			lines = append(lines, "server = "+strconv.Quote(server))
+		}
+		if registry.CABundle != "" {
+			caPath := filepath.Join(dir, "ca.crt")
+			c.AddTask(&nodetasks.File{
+				Path:     caPath,
+				Contents: fi.NewStringResource(registry.CABundle),
+				Type:     nodetasks.FileType_File,
+				Mode:     s("0644"),
+			})
+			lines = append(lines, "ca = "+strconv.Quote(caPath))
+		}
+		if registry.SkipVerify {
+			lines = append(lines, "skip_verify = true")
+		}
+		if registry.AuthFromDockerConfig && server != "" {
+			if header := registryAuthHeader(auths, name, server); header != "" {
+				lines = append(lines, "", "[header]", "  Authorization = "+strconv.Quote(header))
+				hasCredentials = true
+			}
+		}
+
+		for _, mirror := range registry.Mirrors {
+			table := "host." + strconv.Quote(mirror.Endpoint)
+			lines = append(lines, "", "["+table+"]")
+
+			capabilities := mirror.Capabilities
+			if len(capabilities) == 0 {
+				capabilities = []string{"pull", "resolve"}
+			}
+			var quoted []string
+			for _, capability := range capabilities {
+				quoted = append(quoted, strconv.Quote(capability))
+			}
+			lines = append(lines, "  capabilities = ["+strings.Join(quoted, ", ")+"]")
+
+			if mirror.CABundle != "" {
+				caPath := filepath.Join(dir, registryHostFileName(mirror.Endpoint)+".crt")
+				c.AddTask(&nodetasks.File{
+					Path:     caPath,
+					Contents: fi.NewStringResource(mirror.CABundle),
+					Type:     nodetasks.FileType_File,
+					Mode:     s("0644"),
+				})
+				lines = append(lines, "  ca = "+strconv.Quote(caPath))
+			}
+			if mirror.SkipVerify {
+				lines = append(lines, "  skip_verify = true")
+			}
+			if mirror.OverridePath {
+				lines = append(lines, "  override_path = true")
+			}
+			if registry.AuthFromDockerConfig {
+				if header := registryAuthHeader(auths, "", mirror.Endpoint); header != "" {
+					lines = append(lines, "", "  ["+table+".header]", "    Authorization = "+strconv.Quote(header))
+					hasCredentials = true
+				}
+			}
+		}
+
+		// Credentials must only be readable by root
+		mode := "0644"
+		if hasCredentials {
+			mode = "0600"
+		}
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join(dir, "hosts.toml"),
+			Contents: fi.NewStringResource(strings.Join(lines, "\n") + "\n"),
+			Type:     nodetasks.FileType_File,
+			Mode:     s(mode),
+		})
+	}
+
+	return nil
+}
+
+// loadDockerConfigAuths reads the credentials from the dockerconfig secret, if it exists
+func (b *ContainerdBuilder) loadDockerConfigAuths() (*dockerConfigAuths, error) {
+	if b.SecretStore == nil {
+		return nil, nil
+	}
+	secret, _ := b.SecretStore.Secret("dockerconfig")
+	if secret == nil {
+		return nil, nil
+	}
+
+	auths := &dockerConfigAuths{}
+	if err := json.Unmarshal(secret.Data, auths); err != nil {
+		return nil, fmt.Errorf("error parsing dockerconfig secret: %w", err)
+	}
+	return auths, nil
+}
+
+// registryAuthHeader returns the Authorization header for the registry, or "" if there are no credentials for it.
+// The credentials are looked up by registry name, then by the host of the endpoint.
+func registryAuthHeader(auths *dockerConfigAuths, name string, endpoint string) string {
+	if auths == nil {
+		return ""
+	}
+
+	var keys []string
+	if name != "" {
+		keys = append(keys, name)
+		if name == "docker.io" {
+			keys = append(keys, "index.docker.io")
+		}
+	}
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		keys = append(keys, u.Host)
+	}
+
+	for _, key := range keys {
+		for k, auth := range auths.Auths {
+			if registryHost(k) != key {
+				continue
+			}
+			if auth.Auth != "" {
+				return "Basic " + auth.Auth
+			}
+			if auth.Username != "" {
+				return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password))
+			}
+		}
+	}
+	return ""
+}
+
+// registryHost returns the host of a dockerconfig auths key, which may be a host or a URL
+func registryHost(key string) string {
+	if strings.Contains(key, "://") {
+		if u, err := url.Parse(key); err == nil {
+			return u.Host
+		}
+	}
+	host, _, _ := strings.Cut(key, "/")
+	return host
+}
+
+// defaultRegistryServer returns the URL containerd uses for a registry when no server is configured
+func defaultRegistryServer(name string) string {
+	if name == "docker.io" {
+		return "https://registry-1.docker.io"
+	}
+	return "https://" + name
+}
+
+// registryHostFileName returns a file name for the host of a registry endpoint
+func registryHostFileName(endpoint string) string {
+	host := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return strings.ReplaceAll(host, ":", "_")
+}
+
+// usesRegistryHosts returns true if containerd should read the registry configuration from containerdRegistryHostsDir
+func usesRegistryHosts(containerd *kops.ContainerdConfig) bool {
+	return containerd != nil && len(containerd.Registries) > 0
+}
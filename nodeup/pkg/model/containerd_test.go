@@ -17,6 +17,7 @@ limitations under the License.
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"path"
 	"path/filepath"
@@ -48,6 +49,10 @@ func TestContainerdBuilder_Complex(t *testing.T) {
 	runContainerdBuilderTest(t, "complex", distributions.DistributionUbuntu2004)
 }
 
+func TestContainerdBuilder_Registries(t *testing.T) {
+	runContainerdBuilderTest(t, "registries", distributions.DistributionUbuntu2004)
+}
+
 func TestContainerdBuilder_BuildFlags(t *testing.T) {
 	grid := []struct {
 		config   kops.ContainerdConfig
@@ -254,3 +259,34 @@ func TestAppendGPURuntimeContainerdConfig(t *testing.T) {
 		t.Error("new config did not match expected new config")
 	}
 }
+
+func TestRegistryAuthHeader(t *testing.T) {
+	auths := &dockerConfigAuths{}
+	if err := json.Unmarshal([]byte(`{
+		"auths": {
+			"https://index.docker.io/v1/": {"auth": "dXNlcjpwYXNz"},
+			"mirror.example.com": {"username": "mirror", "password": "secret"}
+		}
+	}`), auths); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	grid := []struct {
+		name     string
+		endpoint string
+		expected string
+	}{
+		{name: "docker.io", endpoint: "https://registry-1.docker.io", expected: "Basic dXNlcjpwYXNz"},
+		{endpoint: "https://mirror.example.com/v2", expected: "Basic bWlycm9yOnNlY3JldA=="},
+		{name: "registry.example.com", endpoint: "https://registry.example.com"},
+	}
+	for _, g := range grid {
+		actual := registryAuthHeader(auths, g.name, g.endpoint)
+		if actual != g.expected {
+			t.Errorf("unexpected header for %q %q: expected %q, got %q", g.name, g.endpoint, g.expected, actual)
+		}
+	}
+	if actual := registryAuthHeader(nil, "docker.io", "https://registry-1.docker.io"); actual != "" {
+		t.Errorf("expected no header without dockerconfig, got %q", actual)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/hardening"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+	"sigs.k8s.io/yaml"
+)
+
+// HardeningReportPath is where nodeup records which hardening controls were applied.
+const HardeningReportPath = "/etc/kubernetes/hardening-report.yaml"
+
+// hardeningSSHDConfigPath is the sshd drop-in with the hardening settings.
+// sshd uses the first value it reads for most settings, so the drop-in must sort before those of the distribution
+// (such as 50-cloud-init.conf).
+const hardeningSSHDConfigPath = "/etc/ssh/sshd_config.d/01-kops-hardening.conf"
+
+// HardeningBuilder applies the controls of the configured hardening profile.
+// Kubelet controls are applied by the KubeletBuilder.
+type HardeningBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &HardeningBuilder{}
+
+// Build is responsible for applying the hardening controls
+func (b *HardeningBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if b.NodeupConfig.Hardening == nil {
+		return nil
+	}
+
+	results, err := hardening.Evaluate(b.NodeupConfig.Hardening, b.BootConfig.InstanceGroupRole)
+	if err != nil {
+		return err
+	}
+
+	var sysctls, modules, auditRules, sshdSettings []string
+	for _, result := range results {
+		if result.Status != hardening.StatusApplied {
+			klog.Infof("hardening control %q not applied: %s", result.ID, result.Reason)
+			continue
+		}
+
+		control := result.Control
+		if len(control.AuditRules) > 0 && b.auditPackage() == "" {
+			result.Status = hardening.StatusNotApplicable
+			result.Reason = "auditd is not supported on this distribution"
+			klog.Infof("hardening control %q not applied: %s", result.ID, result.Reason)
+			continue
+		}
+		if len(control.SSHDSettings) > 0 && b.sshdService() == "" {
+			result.Status = hardening.StatusNotApplicable
+			result.Reason = "sshd configuration drop-ins are not supported on this distribution"
+			klog.Infof("hardening control %q not applied: %s", result.ID, result.Reason)
+			continue
+		}
+
+		klog.Infof("applying hardening control %q: %s", result.ID, result.Description)
+
+		if len(control.Sysctls) > 0 {
+			sysctls = append(sysctls, "# "+control.ID)
+			sysctls = append(sysctls, control.Sysctls...)
+			sysctls = append(sysctls, "")
+		}
+		modules = append(modules, control.DisabledKernelModules...)
+		auditRules = append(auditRules, control.AuditRules...)
+		sshdSettings = append(sshdSettings, control.SSHDSettings...)
+
+		for _, perm := range control.FilePermissions {
+			p := perm.Path
+			if perm.Unit != "" {
+				dir, err := nodetasks.SystemdSystemPath(b.Distribution)
+				if err != nil {
+					return err
+				}
+				p = path.Join(dir, perm.Unit)
+			}
+			c.AddTask(&nodetasks.Chmod{
+				Path: p,
+				Mode: perm.Mode,
+			})
+		}
+	}
+
+	if len(sysctls) > 0 {
+		c.AddTask(&nodetasks.File{
+			Path:            "/etc/sysctl.d/99-kops-hardening.conf",
+			Contents:        fi.NewStringResource(strings.Join(sysctls, "\n")),
+			Type:            nodetasks.FileType_File,
+			OnChangeExecute: [][]string{{"sysctl", "--system"}},
+		})
+	}
+
+	if len(modules) > 0 {
+		var lines []string
+		for _, module := range modules {
+			lines = append(lines, "install "+module+" /bin/false", "blacklist "+module)
+		}
+		c.AddTask(&nodetasks.File{
+			Path:     "/etc/modprobe.d/kops-hardening.conf",
+			Contents: fi.NewStringResource(strings.Join(lines, "\n") + "\n"),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0644"),
+		})
+	}
+
+	if len(auditRules) > 0 {
+		pkg := &nodetasks.Package{Name: b.auditPackage()}
+		c.EnsureTask(pkg)
+		c.AddTask(&nodetasks.File{
+			Path: "/etc/audit/rules.d/99-kops-hardening.rules",
+			// The rules can only be loaded once the audit package is installed
+			Contents: &fi.NodeupTaskDependentResource{
+				Resource: fi.NewStringResource(strings.Join(auditRules, "\n") + "\n"),
+				Task:     pkg,
+			},
+			Type:            nodetasks.FileType_File,
+			Mode:            s("0640"),
+			OnChangeExecute: [][]string{{"augenrules", "--load"}},
+		})
+	}
+
+	if len(sshdSettings) > 0 {
+		c.AddTask(&nodetasks.File{
+			Path:            hardeningSSHDConfigPath,
+			Contents:        fi.NewStringResource(strings.Join(sshdSettings, "\n") + "\n"),
+			Type:            nodetasks.FileType_File,
+			Mode:            s("0600"),
+			OnChangeExecute: [][]string{{"systemctl", "try-reload-or-restart", b.sshdService()}},
+		})
+	}
+
+	report, err := yaml.Marshal(map[string]interface{}{
+		"profile":  b.NodeupConfig.Hardening.Profile,
+		"controls": results,
+	})
+	if err != nil {
+		return fmt.Errorf("error building hardening report: %w", err)
+	}
+	c.AddTask(&nodetasks.File{
+		Path:     HardeningReportPath,
+		Contents: fi.NewBytesResource(report),
+		Type:     nodetasks.FileType_File,
+		Mode:     s("0644"),
+	})
+
+	return nil
+}
+
+// auditPackage returns the name of the package providing auditd, or "" if we can't install it.
+func (b *HardeningBuilder) auditPackage() string {
+	if b.Distribution.IsDebianFamily() {
+		return "auditd"
+	}
+	if b.Distribution.IsRHELFamily() {
+		return "audit"
+	}
+	return ""
+}
+
+// sshdService returns the name of the sshd service, or "" if sshd does not read configuration drop-ins.
+func (b *HardeningBuilder) sshdService() string {
+	switch b.Distribution {
+	case distributions.DistributionAmazonLinux2023, distributions.DistributionRhel9, distributions.DistributionRocky9:
+		return "sshd"
+	}
+	if b.Distribution.IsDebianFamily() {
+		return "ssh"
+	}
+	return ""
+}
+
+// applyHardeningToKubelet applies the kubelet settings of the hardening profile to the kubelet config.
+func (c *NodeupModelContext) applyHardeningToKubelet(kubelet *kops.KubeletConfigSpec) error {
+	if c.NodeupConfig.Hardening == nil {
+		return nil
+	}
+
+	controls, err := hardening.AppliedControls(c.NodeupConfig.Hardening, c.BootConfig.InstanceGroupRole)
+	if err != nil {
+		return err
+	}
+	for _, control := range controls {
+		if control.Kubelet != nil {
+			control.Kubelet(kubelet)
+		}
+	}
+	return nil
+}
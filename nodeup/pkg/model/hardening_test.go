@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"path"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+func TestHardeningBuilder(t *testing.T) {
+	RunGoldenTest(t, "tests/golden/hardening", "hardening", func(nodeupModelContext *NodeupModelContext, target *fi.NodeupModelBuilderContext) error {
+		builder := HardeningBuilder{NodeupModelContext: nodeupModelContext}
+		return builder.Build(target)
+	})
+}
+
+func TestHardeningSSHDConfigPath(t *testing.T) {
+	// sshd uses the first value it reads, so our drop-in must be read before those of the distribution
+	for _, distributionDropIn := range []string{"10-ubuntu.conf", "40-redhat-crypto-policies.conf", "50-cloud-init.conf", "50-redhat.conf"} {
+		if name := path.Base(hardeningSSHDConfigPath); name >= distributionDropIn {
+			t.Errorf("sshd drop-in %q is read after %q", name, distributionDropIn)
+		}
+	}
+
+	RunGoldenTest(t, "tests/golden/hardening", "hardening", func(nodeupModelContext *NodeupModelContext, target *fi.NodeupModelBuilderContext) error {
+		builder := HardeningBuilder{NodeupModelContext: nodeupModelContext}
+		if err := builder.Build(target); err != nil {
+			return err
+		}
+
+		found := false
+		for _, task := range target.Tasks {
+			if file, ok := task.(*nodetasks.File); ok && file.Path == hardeningSSHDConfigPath {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("sshd drop-in %q not found", hardeningSSHDConfigPath)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// ImagePrePullBuilder pulls the container images of the instance group while the instance boots
+type ImagePrePullBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &ImagePrePullBuilder{}
+
+// Build is responsible for pulling the images in the imagePrePull list of the instance group
+func (b *ImagePrePullBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	// Instances entering a warm pool pull the images in the WarmPoolBuilder
+	if b.ConfigurationMode == ConfigurationModeWarming {
+		return nil
+	}
+
+	for _, image := range b.NodeupConfig.PrePullImages {
+		c.EnsureTask(&nodetasks.PullImageTask{
+			Name: image,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sort"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestImagePrePullBuilder(t *testing.T) {
+	images := []string{"registry.example.com/app:1.0", "registry.example.com/sidecar:2.0"}
+
+	grid := []struct {
+		configurationMode string
+		expected          []string
+	}{
+		{
+			configurationMode: "",
+			expected:          []string{"PullImageTask/registry.example.com/app:1.0", "PullImageTask/registry.example.com/sidecar:2.0"},
+		},
+		{
+			// Instances entering a warm pool pull the images in the WarmPoolBuilder
+			configurationMode: ConfigurationModeWarming,
+		},
+	}
+	for _, g := range grid {
+		t.Run("mode="+g.configurationMode, func(t *testing.T) {
+			builder := &ImagePrePullBuilder{
+				NodeupModelContext: &NodeupModelContext{
+					NodeupConfig:      &nodeup.Config{PrePullImages: images},
+					ConfigurationMode: g.configurationMode,
+				},
+			}
+			c := &fi.NodeupModelBuilderContext{
+				Tasks: make(map[string]fi.NodeupTask),
+			}
+			if err := builder.Build(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var actual []string
+			for key := range c.Tasks {
+				actual = append(actual, key)
+			}
+			sort.Strings(actual)
+			if len(actual) != len(g.expected) {
+				t.Fatalf("expected tasks %v, got %v", g.expected, actual)
+			}
+			for i := range actual {
+				if actual[i] != g.expected[i] {
+					t.Errorf("expected tasks %v, got %v", g.expected, actual)
+				}
+			}
+		})
+	}
+}
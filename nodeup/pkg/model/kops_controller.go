@@ -53,6 +53,14 @@ func (b *KopsControllerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		Shell: "/sbin/nologin",
 	})
 
+	// kops-controller appends its bootstrap audit log here
+	c.AddTask(&nodetasks.File{
+		Path:  "/var/log/kops-controller",
+		Type:  nodetasks.FileType_Directory,
+		Mode:  s("0750"),
+		Owner: s(wellknownusers.KopsControllerName),
+	})
+
 	issueCert := &nodetasks.IssueCert{
 		Name:           "kops-controller",
 		Signer:         fi.CertificateIDCA,
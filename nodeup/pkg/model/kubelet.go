@@ -232,6 +232,10 @@ func (b *KubeletBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		*/
 	}
 
+	if err := b.buildNodeTuning(c); err != nil {
+		return err
+	}
+
 	c.AddTask(b.buildSystemdService())
 
 	return nil
@@ -723,6 +727,14 @@ func (b *KubeletBuilder) buildKubeletConfigSpec(ctx context.Context) (*kops.Kube
 		c.AuthenticationTokenWebhook = fi.PtrTo(true)
 	}
 
+	if err := b.applyHardeningToKubelet(&c); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyNodeTuningToKubelet(&c); err != nil {
+		return nil, err
+	}
+
 	return &c, nil
 }
 
@@ -17,6 +17,7 @@ limitations under the License.
 package model
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"k8s.io/klog/v2"
@@ -52,6 +53,16 @@ func (b *NerdctlBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		Mode:     s("0755"),
 	})
 
+	// Use the same registry configuration as containerd
+	if usesRegistryHosts(b.NodeupConfig.ContainerdConfig) {
+		c.AddTask(&nodetasks.File{
+			Path:     "/etc/nerdctl/nerdctl.toml",
+			Contents: fi.NewStringResource(fmt.Sprintf("hosts_dir = [%q]\n", containerdRegistryHostsDir)),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0644"),
+		})
+	}
+
 	return nil
 }
 
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/systemd"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+const (
+	// nodeTuningScriptPath is the script that configures swap and huge pages before the kubelet starts
+	nodeTuningScriptPath = "/opt/kops/bin/node-tuning-setup"
+	// nodeTuningSwapFile is the swap file we create when no swap device is configured
+	nodeTuningSwapFile = "/var/lib/kops-swapfile"
+)
+
+// defaultHugePageSize is the huge page size that can be reserved with the vm.nr_hugepages sysctl
+var defaultHugePageSize = resource.MustParse("2Mi")
+
+// nodeTuningSysctls returns the sysctls for swap and huge pages of the default size
+func nodeTuningSysctls(tuning *kops.NodeTuningSpec) []string {
+	var sysctls []string
+
+	if tuning.Swap != nil && tuning.Swap.Swappiness != nil {
+		sysctls = append(sysctls,
+			"# Node tuning: swap",
+			fmt.Sprintf("vm.swappiness = %d", *tuning.Swap.Swappiness),
+			"")
+	}
+
+	for _, hugePages := range tuning.HugePages {
+		if hugePages.PageSize.Cmp(defaultHugePageSize) == 0 {
+			sysctls = append(sysctls,
+				"# Node tuning: huge pages",
+				fmt.Sprintf("vm.nr_hugepages = %d", hugePages.Count),
+				"")
+		}
+	}
+
+	return sysctls
+}
+
+// buildNodeTuning writes the script that configures swap and huge pages of other than the default size.
+// The script runs before every start of the kubelet, so the kubelet sees the configured swap and huge pages.
+func (b *KubeletBuilder) buildNodeTuning(c *fi.NodeupModelBuilderContext) error {
+	tuning := b.NodeupConfig.NodeTuning
+	if tuning == nil {
+		return nil
+	}
+
+	var lines []string
+	if swap := tuning.Swap; swap != nil {
+		if swap.Device != "" {
+			lines = append(lines,
+				fmt.Sprintf("SWAP_DEVICE=%q", swap.Device),
+				`if ! swapon --show=NAME --noheadings | grep -qx "$(readlink -f "${SWAP_DEVICE}")"; then`,
+				`  if ! blkid -t TYPE=swap "${SWAP_DEVICE}" > /dev/null; then`,
+				`    mkswap "${SWAP_DEVICE}"`,
+				`  fi`,
+				`  swapon "${SWAP_DEVICE}"`,
+				`fi`,
+			)
+		} else if swap.Size != nil {
+			lines = append(lines,
+				fmt.Sprintf("SWAP_FILE=%q", nodeTuningSwapFile),
+				fmt.Sprintf("SWAP_SIZE=%d", swap.Size.Value()),
+				`if [[ -f "${SWAP_FILE}" && "$(stat -c %s "${SWAP_FILE}")" != "${SWAP_SIZE}" ]]; then`,
+				`  echo "Resizing swap file ${SWAP_FILE}"`,
+				`  swapoff "${SWAP_FILE}" || true`,
+				`  rm -f "${SWAP_FILE}"`,
+				`fi`,
+				`if [[ ! -f "${SWAP_FILE}" ]]; then`,
+				`  fallocate -l "${SWAP_SIZE}" "${SWAP_FILE}"`,
+				`  chmod 0600 "${SWAP_FILE}"`,
+				`  mkswap "${SWAP_FILE}"`,
+				`fi`,
+				`if ! swapon --show=NAME --noheadings | grep -qx "${SWAP_FILE}"; then`,
+				`  swapon "${SWAP_FILE}"`,
+				`fi`,
+			)
+		}
+	}
+
+	for _, hugePages := range tuning.HugePages {
+		if hugePages.PageSize.Cmp(defaultHugePageSize) == 0 {
+			// Reserved with the vm.nr_hugepages sysctl
+			continue
+		}
+		lines = append(lines,
+			fmt.Sprintf("echo %d > /sys/kernel/mm/hugepages/hugepages-%dkB/nr_hugepages", hugePages.Count, hugePages.PageSize.Value()/1024),
+		)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	script := "#!/bin/bash\n\nset -o errexit\nset -o nounset\nset -o pipefail\n\n" + strings.Join(lines, "\n") + "\n"
+	c.AddTask(&nodetasks.File{
+		Path:     nodeTuningScriptPath,
+		Contents: fi.NewStringResource(script),
+		Type:     nodetasks.FileType_File,
+		Mode:     s("0755"),
+	})
+
+	manifest := &systemd.Manifest{}
+	manifest.Set("Service", "ExecStartPre", nodeTuningScriptPath)
+	c.AddTask(&nodetasks.File{
+		Path:            path.Join(nodetasks.SystemdDropInDirectory(kubeletService), kops.ReservedSystemdOverridePrefix+"node-tuning.conf"),
+		Contents:        fi.NewStringResource(manifest.Render()),
+		Type:            nodetasks.FileType_File,
+		Mode:            s("0644"),
+		BeforeServices:  []string{kubeletService},
+		OnChangeExecute: [][]string{{"systemctl", "daemon-reload"}},
+	})
+
+	return nil
+}
+
+// applyNodeTuningToKubelet applies the swap and CPU manager settings to the kubelet config.
+func (c *NodeupModelContext) applyNodeTuningToKubelet(kubelet *kops.KubeletConfigSpec) error {
+	tuning := c.NodeupConfig.NodeTuning
+	if tuning == nil {
+		return nil
+	}
+
+	if swap := tuning.Swap; swap != nil {
+		kubelet.FailSwapOn = fi.PtrTo(false)
+		kubelet.MemorySwapBehavior = swap.Behavior
+		if kubelet.MemorySwapBehavior == "" {
+			kubelet.MemorySwapBehavior = kops.SwapBehaviorLimitedSwap
+		}
+		if _, found := kubelet.FeatureGates["NodeSwap"]; !found {
+			if kubelet.FeatureGates == nil {
+				kubelet.FeatureGates = make(map[string]string)
+			}
+			kubelet.FeatureGates["NodeSwap"] = "true"
+		}
+	}
+
+	if cpuManager := tuning.CPUManager; cpuManager != nil {
+		kubelet.CpuManagerPolicy = cpuManager.Policy
+		if kubelet.CpuManagerPolicy == "" {
+			kubelet.CpuManagerPolicy = "static"
+		}
+		if kubelet.ReservedSystemCPUs == "" {
+			// The default is sized from the machine type when the instance group spec is populated
+			if cpuManager.ReservedCPUs == nil {
+				return fmt.Errorf("the number of CPUs reserved for the CPU manager was not set")
+			}
+			kubelet.ReservedSystemCPUs = reservedCPUSet(*cpuManager.ReservedCPUs)
+		}
+		if cpuManager.TopologyManagerPolicy != "" {
+			kubelet.TopologyManagerPolicy = cpuManager.TopologyManagerPolicy
+		}
+	}
+
+	return nil
+}
+
+// reservedCPUSet returns the CPU set of the first reserved CPUs
+func reservedCPUSet(reserved int32) string {
+	if reserved == 1 {
+		return "0"
+	}
+	return fmt.Sprintf("0-%d", reserved-1)
+}
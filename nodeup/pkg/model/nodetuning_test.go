@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestNodeTuningBuilder(t *testing.T) {
+	RunGoldenTest(t, "tests/golden/nodetuning", "nodetuning", func(nodeupModelContext *NodeupModelContext, target *fi.NodeupModelBuilderContext) error {
+		builder := KubeletBuilder{NodeupModelContext: nodeupModelContext}
+		return builder.buildNodeTuning(target)
+	})
+}
+
+func TestNodeTuningSysctls(t *testing.T) {
+	tuning := &kops.NodeTuningSpec{
+		Swap: &kops.NodeSwapSpec{
+			Size:       resource.NewQuantity(1<<30, resource.BinarySI),
+			Swappiness: fi.PtrTo(int32(10)),
+		},
+		HugePages: []kops.HugePagesSpec{
+			{PageSize: resource.MustParse("1Gi"), Count: 2},
+			{PageSize: resource.MustParse("2048Ki"), Count: 512},
+		},
+	}
+	expected := []string{
+		"# Node tuning: swap",
+		"vm.swappiness = 10",
+		"",
+		"# Node tuning: huge pages",
+		"vm.nr_hugepages = 512",
+		"",
+	}
+	if actual := nodeTuningSysctls(tuning); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected sysctls: %q", actual)
+	}
+}
+
+func TestReservedCPUSet(t *testing.T) {
+	for reserved, expected := range map[int32]string{1: "0", 2: "0-1", 4: "0-3"} {
+		if actual := reservedCPUSet(reserved); actual != expected {
+			t.Errorf("expected %q for %d CPUs, got %q", expected, reserved, actual)
+		}
+	}
+}
+
+func TestApplyNodeTuningToKubelet(t *testing.T) {
+	c := &NodeupModelContext{
+		NodeupConfig: &nodeup.Config{
+			NodeTuning: &kops.NodeTuningSpec{
+				Swap: &kops.NodeSwapSpec{
+					Size: resource.NewQuantity(1<<30, resource.BinarySI),
+				},
+				CPUManager: &kops.CPUManagerSpec{
+					ReservedCPUs:          fi.PtrTo(int32(2)),
+					TopologyManagerPolicy: "single-numa-node",
+				},
+			},
+		},
+	}
+
+	kubelet := &kops.KubeletConfigSpec{
+		FailSwapOn:   fi.PtrTo(true),
+		FeatureGates: map[string]string{"NodeSwap": "false"},
+	}
+	if err := c.applyNodeTuningToKubelet(kubelet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &kops.KubeletConfigSpec{
+		FailSwapOn:            fi.PtrTo(false),
+		FeatureGates:          map[string]string{"NodeSwap": "false"},
+		MemorySwapBehavior:    kops.SwapBehaviorLimitedSwap,
+		CpuManagerPolicy:      "static",
+		ReservedSystemCPUs:    "0-1",
+		TopologyManagerPolicy: "single-numa-node",
+	}
+	if !reflect.DeepEqual(kubelet, expected) {
+		t.Errorf("unexpected kubelet config: %+v", kubelet)
+	}
+}
@@ -167,9 +167,15 @@ type ProtokubeFlags struct {
 	GossipListen   *string `json:"gossip-listen" flag:"gossip-listen"`
 	GossipSecret   *string `json:"gossip-secret" flag:"gossip-secret"`
 
+	GossipAdditionalSecrets []string `json:"gossip-additional-secrets,omitempty" flag:"gossip-additional-secrets,repeat"`
+	GossipSealing           string   `json:"gossip-sealing,omitempty" flag:"gossip-sealing"`
+
 	GossipProtocolSecondary *string `json:"gossip-protocol-secondary" flag:"gossip-protocol-secondary" flag-include-empty:"true"`
 	GossipListenSecondary   *string `json:"gossip-listen-secondary" flag:"gossip-listen-secondary"`
 	GossipSecretSecondary   *string `json:"gossip-secret-secondary" flag:"gossip-secret-secondary"`
+
+	GossipAdditionalSecretsSecondary []string `json:"gossip-additional-secrets-secondary,omitempty" flag:"gossip-additional-secrets-secondary,repeat"`
+	GossipSealingSecondary           string   `json:"gossip-sealing-secondary,omitempty" flag:"gossip-sealing-secondary"`
 }
 
 // ProtokubeFlags is responsible for building the command line flags for protokube
@@ -206,11 +212,15 @@ func (t *ProtokubeBuilder) ProtokubeFlags() (*ProtokubeFlags, error) {
 			f.GossipProtocol = t.NodeupConfig.GossipConfig.Protocol
 			f.GossipListen = t.NodeupConfig.GossipConfig.Listen
 			f.GossipSecret = t.NodeupConfig.GossipConfig.Secret
+			f.GossipAdditionalSecrets = t.NodeupConfig.GossipConfig.AdditionalSecrets
+			f.GossipSealing = t.NodeupConfig.GossipConfig.Sealing
 
 			if t.NodeupConfig.GossipConfig.Secondary != nil {
 				f.GossipProtocolSecondary = t.NodeupConfig.GossipConfig.Secondary.Protocol
 				f.GossipListenSecondary = t.NodeupConfig.GossipConfig.Secondary.Listen
 				f.GossipSecretSecondary = t.NodeupConfig.GossipConfig.Secondary.Secret
+				f.GossipAdditionalSecretsSecondary = t.NodeupConfig.GossipConfig.Secondary.AdditionalSecrets
+				f.GossipSealingSecondary = t.NodeupConfig.GossipConfig.Secondary.Sealing
 			}
 		}
 
@@ -186,6 +186,10 @@ func (b *SysctlBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 			"")
 	}
 
+	if b.NodeupConfig.NodeTuning != nil {
+		sysctls = append(sysctls, nodeTuningSysctls(b.NodeupConfig.NodeTuning)...)
+	}
+
 	sysctls = append(sysctls, b.NodeupConfig.SysctlParameters...)
 
 	c.AddTask(&nodetasks.File{
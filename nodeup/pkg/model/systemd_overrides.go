@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"path"
+	"strings"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// SystemdOverridesBuilder writes the systemd drop-ins for units managed by kOps
+type SystemdOverridesBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &SystemdOverridesBuilder{}
+
+// Build is responsible for writing the systemd drop-ins.
+// The drop-ins are written before the unit's Service task runs, which restarts the unit
+// when a drop-in is newer than the running process (if SmartRestart is enabled).
+func (b *SystemdOverridesBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	for _, override := range b.NodeupConfig.SystemdOverrides {
+		unit := b.EnsureSystemdSuffix(override.Unit)
+
+		contents := override.Manifest
+		if !strings.HasSuffix(contents, "\n") {
+			contents += "\n"
+		}
+
+		c.AddTask(&nodetasks.File{
+			Path:            path.Join(nodetasks.SystemdDropInDirectory(unit), override.Name+".conf"),
+			Contents:        fi.NewStringResource(contents),
+			Type:            nodetasks.FileType_File,
+			Mode:            s("0644"),
+			BeforeServices:  []string{unit},
+			OnChangeExecute: [][]string{{"systemctl", "daemon-reload"}},
+		})
+	}
+
+	return nil
+}
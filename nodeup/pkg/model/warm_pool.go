@@ -34,10 +34,15 @@ func (b *WarmPoolBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		return nil
 	}
 
-	// Pre-pull container images during pre-initialization
+	// Pre-pull container images during pre-initialization, so they are cached before the instance is stopped
 	if b.NodeupConfig != nil && b.ConfigurationMode == "Warming" {
 		for _, image := range b.NodeupConfig.WarmPoolImages {
-			c.AddTask(&nodetasks.PullImageTask{
+			c.EnsureTask(&nodetasks.PullImageTask{
+				Name: image,
+			})
+		}
+		for _, image := range b.NodeupConfig.PrePullImages {
+			c.EnsureTask(&nodetasks.PullImageTask{
 				Name: image,
 			})
 		}
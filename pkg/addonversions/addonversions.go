@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package addonversions holds the compatibility matrix of the addons whose version can be pinned in the cluster spec.
+package addonversions
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed compatibility.yaml
+var compatibilityYAML []byte
+
+// Addon describes an addon whose version can be pinned, and the versions this release of kOps supports.
+type Addon struct {
+	// Name is the name of the addon, for example coredns.addons.k8s.io.
+	Name string `json:"name"`
+	// DefaultVersion is the version kOps installs when the addon is not pinned.
+	DefaultVersion string `json:"defaultVersion"`
+	// Images are the repositories of the images of the addon; their tag is set to the pinned version.
+	Images []string `json:"images"`
+	// Versions are the versions that work with the manifest of the addon.
+	Versions []VersionRange `json:"versions"`
+}
+
+// VersionRange is a range of versions of an addon.
+type VersionRange struct {
+	// Range is a semver range of versions of the addon.
+	Range string `json:"range"`
+	// KubernetesVersion optionally restricts the range to a semver range of kubernetes versions.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+}
+
+type matrix struct {
+	Addons []*Addon `json:"addons"`
+}
+
+// All returns the addons whose version can be pinned.
+func All() ([]*Addon, error) {
+	m := &matrix{}
+	if err := yaml.UnmarshalStrict(compatibilityYAML, m); err != nil {
+		return nil, fmt.Errorf("parsing addon compatibility matrix: %w", err)
+	}
+	return m.Addons, nil
+}
+
+// Find returns the named addon, or nil if its version cannot be pinned.
+func Find(name string) (*Addon, error) {
+	addons, err := All()
+	if err != nil {
+		return nil, err
+	}
+	for _, addon := range addons {
+		if addon.Name == name {
+			return addon, nil
+		}
+	}
+	return nil, nil
+}
+
+// ParseVersion parses an addon version, which must be prefixed with "v".
+func ParseVersion(version string) (semver.Version, error) {
+	if !strings.HasPrefix(version, "v") {
+		return semver.Version{}, fmt.Errorf("version %q must be prefixed with \"v\"", version)
+	}
+	sv, err := semver.Parse(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("cannot parse version %q: %w", version, err)
+	}
+	return sv, nil
+}
+
+// CheckVersion returns an error if the version of the addon is not supported with the kubernetes version.
+func (a *Addon) CheckVersion(version string, kubernetesVersion semver.Version) error {
+	sv, err := ParseVersion(version)
+	if err != nil {
+		return err
+	}
+
+	var ranges []string
+	for _, v := range a.Versions {
+		if v.KubernetesVersion != "" {
+			kubernetesRange, err := semver.ParseRange(v.KubernetesVersion)
+			if err != nil {
+				return fmt.Errorf("cannot parse kubernetesVersion %q of addon %q: %w", v.KubernetesVersion, a.Name, err)
+			}
+			if !kubernetesRange(kubernetesVersion) {
+				continue
+			}
+		}
+
+		versionRange, err := semver.ParseRange(v.Range)
+		if err != nil {
+			return fmt.Errorf("cannot parse range %q of addon %q: %w", v.Range, a.Name, err)
+		}
+		if versionRange(sv) {
+			return nil
+		}
+		ranges = append(ranges, fmt.Sprintf("%q", v.Range))
+	}
+
+	if len(ranges) == 0 {
+		return fmt.Errorf("no version of addon %q is supported with kubernetes %s", a.Name, kubernetesVersion)
+	}
+	return fmt.Errorf("version %s of addon %q is not supported with kubernetes %s; supported versions are %s", version, a.Name, kubernetesVersion, strings.Join(ranges, ", "))
+}
+
+// SetImageVersion returns the image with its tag set to the version, if it is an image of the addon.
+// Images are matched by repository, ignoring the registry, so that the pinned version also applies to images
+// from a different registry.
+func (a *Addon) SetImageVersion(image string, version string) string {
+	repository := image
+	if i := strings.Index(repository, "@"); i != -1 {
+		repository = repository[:i]
+	}
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+
+	for _, addonImage := range a.Images {
+		if repository == addonImage || strings.HasSuffix(repository, "/"+withoutRegistry(addonImage)) {
+			return repository + ":" + version
+		}
+	}
+	return image
+}
+
+// withoutRegistry returns the repository without its registry host.
+func withoutRegistry(repository string) string {
+	_, path, found := strings.Cut(repository, "/")
+	if !found {
+		return repository
+	}
+	return path
+}
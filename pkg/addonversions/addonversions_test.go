@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonversions
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+)
+
+// TestMatrixMatchesManifests checks that every addon in the matrix exists, that its images are used by its
+// manifest templates, and that images with a literal tag use the default version.
+func TestMatrixMatchesManifests(t *testing.T) {
+	addons, err := All()
+	if err != nil {
+		t.Fatalf("All() failed: %v", err)
+	}
+
+	for _, addon := range addons {
+		t.Run(addon.Name, func(t *testing.T) {
+			if err := addon.CheckVersion(addon.DefaultVersion, semver.MustParse("1.29.0")); err != nil {
+				t.Errorf("default version is not supported: %v", err)
+			}
+
+			templates, err := filepath.Glob(filepath.Join("..", "..", "upup", "models", "cloudup", "resources", "addons", addon.Name, "*.template"))
+			if err != nil {
+				t.Fatalf("listing templates: %v", err)
+			}
+			if len(templates) == 0 {
+				t.Fatalf("no templates found for addon")
+			}
+			var contents strings.Builder
+			for _, template := range templates {
+				b, err := os.ReadFile(template)
+				if err != nil {
+					t.Fatalf("reading %s: %v", template, err)
+				}
+				contents.Write(b)
+			}
+
+			for _, image := range addon.Images {
+				if !strings.Contains(contents.String(), withoutRegistry(image)+":") {
+					t.Errorf("image %q is not used by the templates", image)
+				}
+				tags := regexp.MustCompile(regexp.QuoteMeta(image)+`:(v[0-9][^"\s{]*)`).FindAllStringSubmatch(contents.String(), -1)
+				for _, tag := range tags {
+					if tag[1] != addon.DefaultVersion {
+						t.Errorf("image %q has tag %q in the templates, expected the default version %q", image, tag[1], addon.DefaultVersion)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	addon := &Addon{
+		Name: "example.addons.k8s.io",
+		Versions: []VersionRange{
+			{Range: ">=1.2.0 <1.3.0", KubernetesVersion: "<1.29.0"},
+			{Range: ">=1.3.0 <1.4.0"},
+		},
+	}
+
+	grid := []struct {
+		Version           string
+		KubernetesVersion string
+		ExpectedError     string
+	}{
+		{Version: "v1.2.1", KubernetesVersion: "1.28.0"},
+		{Version: "v1.3.0", KubernetesVersion: "1.29.0"},
+		{Version: "v1.2.1", KubernetesVersion: "1.29.0", ExpectedError: `version v1.2.1 of addon "example.addons.k8s.io" is not supported with kubernetes 1.29.0; supported versions are ">=1.3.0 <1.4.0"`},
+		{Version: "v1.4.0", KubernetesVersion: "1.28.0", ExpectedError: `supported versions are ">=1.2.0 <1.3.0", ">=1.3.0 <1.4.0"`},
+		{Version: "1.3.0", KubernetesVersion: "1.29.0", ExpectedError: `version "1.3.0" must be prefixed with "v"`},
+	}
+	for _, g := range grid {
+		err := addon.CheckVersion(g.Version, semver.MustParse(g.KubernetesVersion))
+		if g.ExpectedError == "" {
+			if err != nil {
+				t.Errorf("CheckVersion(%q, %q) failed: %v", g.Version, g.KubernetesVersion, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), g.ExpectedError) {
+			t.Errorf("CheckVersion(%q, %q) returned %v, expected error containing %q", g.Version, g.KubernetesVersion, err, g.ExpectedError)
+		}
+	}
+}
+
+func TestSetImageVersion(t *testing.T) {
+	addon := &Addon{
+		Name:   "coredns.addons.k8s.io",
+		Images: []string{"registry.k8s.io/coredns/coredns"},
+	}
+
+	grid := []struct {
+		Image    string
+		Expected string
+	}{
+		{Image: "registry.k8s.io/coredns/coredns:v1.11.1", Expected: "registry.k8s.io/coredns/coredns:v1.11.3"},
+		{Image: "registry.k8s.io/coredns/coredns:v1.11.1@sha256:1eeb4c7316bacb1d4c8ead65571cd92dd21e27359f0d4917f1a5822a73b75db1", Expected: "registry.k8s.io/coredns/coredns:v1.11.3"},
+		{Image: "registry.k8s.io/coredns/coredns", Expected: "registry.k8s.io/coredns/coredns:v1.11.3"},
+		{Image: "localhost:5000/coredns/coredns:v1.11.1", Expected: "localhost:5000/coredns/coredns:v1.11.3"},
+		{Image: "registry.k8s.io/cpa/cluster-proportional-autoscaler:v1.8.9", Expected: "registry.k8s.io/cpa/cluster-proportional-autoscaler:v1.8.9"},
+		{Image: "registry.k8s.io/other/coredns:v1.11.1", Expected: "registry.k8s.io/other/coredns:v1.11.1"},
+	}
+	for _, g := range grid {
+		actual := addon.SetImageVersion(g.Image, "v1.11.3")
+		if actual != g.Expected {
+			t.Errorf("SetImageVersion(%q) = %q, expected %q", g.Image, actual, g.Expected)
+		}
+	}
+}
@@ -34,6 +34,9 @@ var DefaultChannelBase = "https://raw.githubusercontent.com/kubernetes/kops/mast
 
 const (
 	DefaultChannel = "stable"
+
+	// AddonChannelSuffix is appended to the location of a channel to find its AddonChannel.
+	AddonChannelSuffix = "-addons"
 )
 
 type Channel struct {
@@ -58,6 +61,21 @@ type ChannelSpec struct {
 	Packages []PackageVersionSpec `json:"packages,omitempty"`
 }
 
+// AddonChannel lists the versions of addons available in a channel, for "kops upgrade addons".
+// It is stored next to its channel, with the suffix AddonChannelSuffix, rather than in the channel itself,
+// as older releases of kOps reject channels with fields they do not know.
+type AddonChannel struct {
+	metav1.TypeMeta `json:",inline"`
+	ObjectMeta      metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AddonChannelSpec `json:"spec,omitempty"`
+}
+
+type AddonChannelSpec struct {
+	// Addons specifies the versions of addons available in the channel.
+	Addons []PackageVersionSpec `json:"addons,omitempty"`
+}
+
 type KopsVersionSpec struct {
 	Range string `json:"range,omitempty"`
 
@@ -154,6 +172,33 @@ func LoadChannel(vfsContext *vfs.VFSContext, location string) (*Channel, error)
 	return channel, nil
 }
 
+// LoadAddonChannel loads the AddonChannel of the channel at the specified VFS location
+func LoadAddonChannel(vfsContext *vfs.VFSContext, location string) (*AddonChannel, error) {
+	resolvedURL, err := ResolveChannel(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolvedURL == nil {
+		return &AddonChannel{}, nil
+	}
+
+	resolvedURL.Path += AddonChannelSuffix
+	resolved := resolvedURL.String()
+
+	klog.V(2).Infof("Loading addon channel from %q", resolved)
+	channelBytes, err := vfsContext.ReadFile(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("error reading addon channel %q: %v", resolved, err)
+	}
+	channel := &AddonChannel{}
+	if err := ParseRawYaml(channelBytes, channel); err != nil {
+		return nil, fmt.Errorf("error parsing addon channel %q: %v", resolved, err)
+	}
+
+	return channel, nil
+}
+
 // ParseChannel parses a Channel object
 func ParseChannel(channelBytes []byte) (*Channel, error) {
 	channel := &Channel{}
@@ -380,35 +425,12 @@ func (c *Channel) GetPackageVersion(name string, kubernetesVersion *semver.Versi
 			continue
 		}
 
-		if pkg.KubernetesVersion != "" {
-			versionRange, err := semver.ParseRange(pkg.KubernetesVersion)
-			if err != nil {
-				klog.Warningf("cannot parse KubernetesVersion=%q", pkg.KubernetesVersion)
-				continue
-			}
-
-			if !versionRange(*kubernetesVersion) {
-				klog.V(2).Infof("Kubernetes version %q does not match range: %s", kubernetesVersion, pkg.KubernetesVersion)
-				continue
-			}
+		match, err := pkg.matches(kubernetesVersion)
+		if err != nil {
+			return nil, err
 		}
-
-		if pkg.KopsVersion != "" {
-			kopsVersion, err := util.ParseVersion(kopsbase.KOPS_RELEASE_VERSION)
-			if err != nil {
-				return nil, fmt.Errorf("parsing kops version %q: %w", kopsbase.KOPS_RELEASE_VERSION, err)
-			}
-
-			versionRange, err := semver.ParseRange(pkg.KopsVersion)
-			if err != nil {
-				klog.Warningf("cannot parse KopsVersion=%q", pkg.KopsVersion)
-				continue
-			}
-
-			if !kopsVersion.IsInRange(versionRange) {
-				klog.V(2).Infof("kOps version %q does not match range: %s", kopsVersion, pkg.KopsVersion)
-				continue
-			}
+		if !match {
+			continue
 		}
 
 		matches = append(matches, pkg)
@@ -427,3 +449,63 @@ func (c *Channel) GetPackageVersion(name string, kubernetesVersion *semver.Versi
 	}
 	return v, nil
 }
+
+// GetAddonVersions returns the versions of the addon available in the channel for the kubernetes version.
+func (c *AddonChannel) GetAddonVersions(name string, kubernetesVersion *semver.Version) ([]string, error) {
+	var versions []string
+
+	for i := range c.Spec.Addons {
+		addon := &c.Spec.Addons[i]
+		if addon.Name != name {
+			continue
+		}
+
+		match, err := addon.matches(kubernetesVersion)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		versions = append(versions, addon.Version)
+	}
+
+	return versions, nil
+}
+
+// matches returns true if the package applies to the kubernetes version and to this version of kOps.
+func (p *PackageVersionSpec) matches(kubernetesVersion *semver.Version) (bool, error) {
+	if p.KubernetesVersion != "" {
+		versionRange, err := semver.ParseRange(p.KubernetesVersion)
+		if err != nil {
+			klog.Warningf("cannot parse KubernetesVersion=%q", p.KubernetesVersion)
+			return false, nil
+		}
+
+		if !versionRange(*kubernetesVersion) {
+			klog.V(2).Infof("Kubernetes version %q does not match range: %s", kubernetesVersion, p.KubernetesVersion)
+			return false, nil
+		}
+	}
+
+	if p.KopsVersion != "" {
+		kopsVersion, err := util.ParseVersion(kopsbase.KOPS_RELEASE_VERSION)
+		if err != nil {
+			return false, fmt.Errorf("parsing kops version %q: %w", kopsbase.KOPS_RELEASE_VERSION, err)
+		}
+
+		versionRange, err := semver.ParseRange(p.KopsVersion)
+		if err != nil {
+			klog.Warningf("cannot parse KopsVersion=%q", p.KopsVersion)
+			return false, nil
+		}
+
+		if !kopsVersion.IsInRange(versionRange) {
+			klog.V(2).Infof("kOps version %q does not match range: %s", kopsVersion, p.KopsVersion)
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
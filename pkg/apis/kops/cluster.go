@@ -55,6 +55,10 @@ type ClusterSpec struct {
 	Channel string `json:"channel,omitempty"`
 	// Additional addons that should be installed on the cluster
 	Addons []AddonSpec `json:"addons,omitempty"`
+	// AddonPatches are patches applied to the manifests of addons managed by kOps
+	AddonPatches []AddonPatchSpec `json:"addonPatches,omitempty"`
+	// AddonVersions pins the versions of addons managed by kOps, within the versions supported by this release of kOps
+	AddonVersions []AddonVersionSpec `json:"addonVersions,omitempty"`
 	// ConfigStore configures the stores that nodes use to get their configuration.
 	ConfigStore ConfigStoreSpec `json:"configStore"`
 	// CloudProvider configures the cloud provider to use.
@@ -72,6 +76,9 @@ type ClusterSpec struct {
 	// Note that DNSZone can either by the host name of the zone (containing dots),
 	// or can be an identifier for the zone.
 	DNSZone string `json:"dnsZone,omitempty"`
+	// DNSProvider is the DNS provider that manages DNSZone, if not the DNS service of the cloud provider [rfc2136, powerdns].
+	// The provider is configured through environment variables.
+	DNSProvider string `json:"dnsProvider,omitempty"`
 	// DNSControllerGossipConfig for the cluster assuming the use of gossip DNS
 	DNSControllerGossipConfig *DNSControllerGossipConfig `json:"dnsControllerGossipConfig,omitempty"`
 	// ClusterDNSDomain is the suffix we use for internal DNS names (normally cluster.local)
@@ -137,6 +144,8 @@ type ClusterSpec struct {
 	CloudLabels map[string]string `json:"cloudLabels,omitempty"`
 	// Hooks for custom actions e.g. on first installation
 	Hooks []HookSpec `json:"hooks,omitempty"`
+	// SystemdOverrides are drop-ins for the systemd units managed by kOps
+	SystemdOverrides []SystemdOverrideSpec `json:"systemdOverrides,omitempty"`
 	// Assets is alternative locations for files and containers; the API under construction, will remove this comment once this API is fully functional.
 	Assets *AssetsSpec `json:"assets,omitempty"`
 	// IAM field adds control over the IAM security policies applied to resources
@@ -152,6 +161,9 @@ type ClusterSpec struct {
 	// specified, each parameter must follow the form variable=value, the way
 	// it would appear in sysctl.conf.
 	SysctlParameters []string `json:"sysctlParameters,omitempty"`
+	// Hardening configures the OS and kubelet hardening profile applied to all nodes.
+	// It can be overridden by the hardening configuration specified in the instance group.
+	Hardening *HardeningSpec `json:"hardening,omitempty"`
 	// RollingUpdate defines the default rolling-update settings for instance groups.
 	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
 	// ClusterAutoscaler defines the cluster autoscaler configuration.
@@ -162,6 +174,8 @@ type ClusterSpec struct {
 	SnapshotController *SnapshotControllerConfig `json:"snapshotController,omitempty"`
 	// Karpenter defines the Karpenter configuration.
 	Karpenter *KarpenterConfig `json:"karpenter,omitempty"`
+	// KopsController configures kops-controller.
+	KopsController *KopsControllerConfig `json:"kopsController,omitempty"`
 }
 
 // ConfigStoreSpec configures the stores that nodes use to get their configuration.
@@ -268,6 +282,23 @@ type KarpenterConfig struct {
 	CPURequest    *resource.Quantity `json:"cpuRequest,omitempty"`
 }
 
+// KopsControllerConfig configures kops-controller.
+type KopsControllerConfig struct {
+	// BootstrapAdmission configures the rules a node must satisfy before kops-controller issues it certificates.
+	BootstrapAdmission *BootstrapAdmissionSpec `json:"bootstrapAdmission,omitempty"`
+}
+
+// BootstrapAdmissionSpec configures the admission rules kops-controller evaluates when a node bootstraps.
+type BootstrapAdmissionSpec struct {
+	// MaxNodesPerInstanceGroup limits the number of nodes that can be registered in an instance group, keyed by instance group name.
+	// Only registered nodes are counted, so nodes bootstrapping at the same time can exceed the limit.
+	MaxNodesPerInstanceGroup map[string]int32 `json:"maxNodesPerInstanceGroup,omitempty"`
+	// AllowedInstanceTypes restricts bootstrapping to instances of the listed machine types.
+	AllowedInstanceTypes []string `json:"allowedInstanceTypes,omitempty"`
+	// DeniedInstanceIDs lists the instances that are never allowed to bootstrap.
+	DeniedInstanceIDs []string `json:"deniedInstanceIDs,omitempty"`
+}
+
 // ServiceAccountIssuerDiscoveryConfig configures an OIDC Issuer.
 type ServiceAccountIssuerDiscoveryConfig struct {
 	// DiscoveryStore is the VFS path to where OIDC Issuer Discovery metadata is stored.
@@ -326,6 +357,84 @@ type NodeAuthorizerSpec struct {
 type AddonSpec struct {
 	// Manifest is a path to the manifest that defines the addon
 	Manifest string `json:"manifest,omitempty"`
+	// Helm renders the addon from a Helm chart when the cluster is updated, instead of using a channel manifest.
+	Helm *HelmAddonSpec `json:"helm,omitempty"`
+	// Kustomize builds the addon from a kustomization when the cluster is updated, instead of using a channel manifest.
+	Kustomize *KustomizeAddonSpec `json:"kustomize,omitempty"`
+}
+
+// HelmAddonSpec defines an addon rendered from a Helm chart
+type HelmAddonSpec struct {
+	// Name is the name of the addon, also used as the Helm release name.
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace the chart is rendered into.
+	Namespace string `json:"namespace,omitempty"`
+	// Chart is the name of the chart in the repository, or an OCI reference of the form oci://registry/path/chart.
+	Chart string `json:"chart,omitempty"`
+	// Repository is the URL of the chart repository. It is not used for OCI charts.
+	Repository string `json:"repository,omitempty"`
+	// Version is the version of the chart.
+	Version string `json:"version,omitempty"`
+	// Values is a YAML document of values for the chart.
+	Values string `json:"values,omitempty"`
+	// ValuesFile is the location of a YAML file of values for the chart, either a state store URL or a path relative to the cluster's configuration base.
+	// Values takes precedence over values from the file.
+	ValuesFile string `json:"valuesFile,omitempty"`
+}
+
+// KustomizeAddonSpec defines an addon built from a kustomization
+type KustomizeAddonSpec struct {
+	// Name is the name of the addon.
+	Name string `json:"name,omitempty"`
+	// Path is the directory holding the kustomization and the bases it uses, either a state store URL or a local path.
+	// A local directory is uploaded to the state store when the cluster is updated.
+	Path string `json:"path,omitempty"`
+	// Overlay is the directory of the kustomization to build, relative to Path. It defaults to Path itself.
+	Overlay string `json:"overlay,omitempty"`
+}
+
+// AddonPatchType is the type of an addon patch.
+type AddonPatchType string
+
+const (
+	// AddonPatchTypeStrategicMerge is a strategic merge patch, as used by kubectl patch and kustomize.
+	// Kinds that are not built into Kubernetes are patched with a JSON merge patch.
+	AddonPatchTypeStrategicMerge AddonPatchType = "StrategicMerge"
+	// AddonPatchTypeJSON6902 is a JSON patch, as defined by RFC 6902.
+	AddonPatchTypeJSON6902 AddonPatchType = "JSON6902"
+)
+
+// AddonPatchSpec defines a patch to the objects of an addon managed by kOps.
+type AddonPatchSpec struct {
+	// Addon is the name of the addon to patch, for example coredns.addons.k8s.io.
+	Addon string `json:"addon,omitempty"`
+	// Target selects the objects of the addon to patch.
+	Target AddonPatchTarget `json:"target,omitempty"`
+	// Type is the type of the patch. Defaults to StrategicMerge.
+	Type AddonPatchType `json:"type,omitempty"`
+	// Patch is the patch, as a YAML document.
+	Patch string `json:"patch,omitempty"`
+}
+
+// AddonPatchTarget selects the objects an addon patch applies to.
+// Fields that are not set match any value.
+type AddonPatchTarget struct {
+	// Group is the API group of the objects, for example apps.
+	Group string `json:"group,omitempty"`
+	// Kind is the kind of the objects, for example Deployment.
+	Kind string `json:"kind,omitempty"`
+	// Namespace is the namespace of the objects.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the objects.
+	Name string `json:"name,omitempty"`
+}
+
+// AddonVersionSpec pins the version of an addon managed by kOps.
+type AddonVersionSpec struct {
+	// Name is the name of the addon, for example coredns.addons.k8s.io.
+	Name string `json:"name,omitempty"`
+	// Version is the version of the addon, for example v1.11.3.
+	Version string `json:"version,omitempty"`
 }
 
 // FileAssetSpec defines the structure for a file asset
@@ -352,6 +461,39 @@ type AssetsSpec struct {
 	FileRepository *string `json:"fileRepository,omitempty"`
 	// ContainerProxy is a url for a pull-through proxy of a container registry.
 	ContainerProxy *string `json:"containerProxy,omitempty"`
+	// Verification is a policy for verifying the signatures of images and files before they are used.
+	Verification *AssetVerificationSpec `json:"verification,omitempty"`
+}
+
+// AssetVerificationSpec is a policy for verifying the cosign signatures of images and files.
+type AssetVerificationSpec struct {
+	// Images are the signature policies of container images. The policy with the longest prefix of the image name applies.
+	Images []SignaturePolicySpec `json:"images,omitempty"`
+	// Files are the signature policies of files. The policy with the longest prefix of the canonical URL of the file applies.
+	Files []SignaturePolicySpec `json:"files,omitempty"`
+	// FulcioRoots are the PEM-encoded certificates of the authorities issuing keyless signing certificates,
+	// followed by any intermediate certificates.
+	FulcioRoots []string `json:"fulcioRoots,omitempty"`
+	// RekorPublicKeys are the PEM-encoded public keys of the transparency logs recording keyless signatures.
+	RekorPublicKeys []string `json:"rekorPublicKeys,omitempty"`
+}
+
+// SignaturePolicySpec requires artifacts to be signed by one of its public keys or keyless identities.
+type SignaturePolicySpec struct {
+	// Prefix selects the artifacts the policy applies to, for example registry.k8s.io/ or https://dl.k8s.io/.
+	Prefix string `json:"prefix,omitempty"`
+	// PublicKeys are the PEM-encoded public keys that may sign the artifacts.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// KeylessIdentities are the identities whose keyless signing certificates may sign the artifacts.
+	KeylessIdentities []KeylessIdentitySpec `json:"keylessIdentities,omitempty"`
+}
+
+// KeylessIdentitySpec is the identity in a keyless signing certificate.
+type KeylessIdentitySpec struct {
+	// Issuer is the OIDC issuer that authenticated the signer, for example https://accounts.google.com.
+	Issuer string `json:"issuer,omitempty"`
+	// Subject is the email address or URI of the signer.
+	Subject string `json:"subject,omitempty"`
 }
 
 // IAMSpec adds control over the IAM security policies applied to resources
@@ -388,6 +530,25 @@ type HookSpec struct {
 	UseRawManifest bool `json:"useRawManifest,omitempty"`
 }
 
+// DefaultSystemdOverrideName is the name of a systemd drop-in when SystemdOverrideSpec.Name is not set
+const DefaultSystemdOverrideName = "99-kops-override"
+
+// ReservedSystemdOverridePrefix is the prefix of the systemd drop-ins that kOps writes itself;
+// SystemdOverrideSpec.Name may not start with it
+const ReservedSystemdOverridePrefix = "10-kops-"
+
+// SystemdOverrideSpec is a systemd drop-in that overrides settings of a unit managed by kOps
+type SystemdOverrideSpec struct {
+	// Unit is the name of the systemd unit to override, e.g. kubelet.service
+	Unit string `json:"unit,omitempty"`
+	// Name is the name of the drop-in file, without the .conf extension. Default: 99-kops-override
+	Name string `json:"name,omitempty"`
+	// Roles is an optional list of roles the drop-in should be rolled out to, defaults to all
+	Roles []InstanceGroupRole `json:"roles,omitempty"`
+	// Manifest is the content of the drop-in, e.g. "[Service]\nLimitNOFILE=1048576"
+	Manifest string `json:"manifest,omitempty"`
+}
+
 // ExecContainerAction defines an hood action
 type ExecContainerAction struct {
 	// Image is the container image.
@@ -631,12 +792,18 @@ type ExternalDNSConfig struct {
 	// WatchIngress indicates you want the dns-controller to watch and create dns entries for ingress resources.
 	// Default: true if provider is 'external-dns', false otherwise.
 	WatchIngress *bool `json:"watchIngress,omitempty"`
+	// WatchGateway indicates you want the dns-controller to watch and create dns entries for Gateway API
+	// Gateway, HTTPRoute and GRPCRoute resources.
+	WatchGateway *bool `json:"watchGateway,omitempty"`
 	// WatchNamespace is namespace to watch, defaults to all (use to control whom can creates dns entries)
 	WatchNamespace string `json:"watchNamespace,omitempty"`
 	// Provider determines which implementation of ExternalDNS to use.
 	// 'dns-controller' will use kOps DNS Controller.
 	// 'external-dns' will use kubernetes-sigs/external-dns.
 	Provider ExternalDNSProvider `json:"provider,omitempty"`
+	// OwnerID, if set, makes dns-controller record its ownership of the records it manages in TXT records.
+	// dns-controller then only updates or deletes records it owns, and removes owned records that are no longer wanted on startup.
+	OwnerID string `json:"ownerID,omitempty"`
 }
 
 // EtcdProviderType describes etcd cluster provisioning types (Standalone, Manager)
@@ -963,6 +1130,16 @@ func (c *ClusterSpec) GetCloudProvider() CloudProviderID {
 	return ""
 }
 
+// AddonVersion returns the version of the named addon pinned in AddonVersions, or "" if it is not pinned.
+func (c *ClusterSpec) AddonVersion(name string) string {
+	for _, v := range c.AddonVersions {
+		if v.Name == name {
+			return v.Version
+		}
+	}
+	return ""
+}
+
 // EnvVar represents an environment variable present in a Container.
 type EnvVar struct {
 	// Name of the environment variable. Must be a C_IDENTIFIER.
@@ -980,10 +1157,30 @@ type EnvVar struct {
 	Value string `json:"value,omitempty"`
 }
 
+// GossipProtocolKopsController is the gossip protocol where instances discover the hosts from kops-controller,
+// instead of gossiping with each other.
+const GossipProtocolKopsController = "kops-controller"
+
+const (
+	// GossipSealingEnforced seals the memberlist gossip state we send, and only accepts sealed state
+	GossipSealingEnforced = "Enforced"
+	// GossipSealingPermissive seals the memberlist gossip state we send, but also accepts state that was not sealed,
+	// while instances that do not seal the state are replaced
+	GossipSealingPermissive = "Permissive"
+	// GossipSealingDisabled does not seal the memberlist gossip state we send, but accepts sealed state,
+	// while instances of kOps versions that cannot open sealed state are replaced
+	GossipSealingDisabled = "Disabled"
+)
+
 type GossipConfig struct {
-	Protocol  *string                `json:"protocol,omitempty"`
-	Listen    *string                `json:"listen,omitempty"`
-	Secret    *string                `json:"secret,omitempty"`
+	// Protocol is the gossip protocol: mesh, memberlist, or kops-controller to discover the hosts from kops-controller.
+	Protocol *string `json:"protocol,omitempty"`
+	Listen   *string `json:"listen,omitempty"`
+	Secret   *string `json:"secret,omitempty"`
+	// AdditionalSecrets are accepted on gossip as well as Secret, so that the secret can be rotated
+	AdditionalSecrets []string `json:"additionalSecrets,omitempty"`
+	// Sealing controls the sealing of the memberlist gossip state with the secret [Enforced, Permissive, Disabled]. Default: Permissive
+	Sealing   string                 `json:"sealing,omitempty"`
 	Secondary *GossipConfigSecondary `json:"secondary,omitempty"`
 }
 
@@ -991,12 +1188,20 @@ type GossipConfigSecondary struct {
 	Protocol *string `json:"protocol,omitempty"`
 	Listen   *string `json:"listen,omitempty"`
 	Secret   *string `json:"secret,omitempty"`
+	// AdditionalSecrets are accepted on gossip as well as Secret, so that the secret can be rotated
+	AdditionalSecrets []string `json:"additionalSecrets,omitempty"`
+	// Sealing controls the sealing of the memberlist gossip state with the secret [Enforced, Permissive, Disabled]. Default: Permissive
+	Sealing string `json:"sealing,omitempty"`
 }
 
 type DNSControllerGossipConfig struct {
-	Protocol  *string                             `json:"protocol,omitempty"`
-	Listen    *string                             `json:"listen,omitempty"`
-	Secret    *string                             `json:"secret,omitempty"`
+	Protocol *string `json:"protocol,omitempty"`
+	Listen   *string `json:"listen,omitempty"`
+	Secret   *string `json:"secret,omitempty"`
+	// AdditionalSecrets are accepted on gossip as well as Secret, so that the secret can be rotated
+	AdditionalSecrets []string `json:"additionalSecrets,omitempty"`
+	// Sealing controls the sealing of the memberlist gossip state with the secret [Enforced, Permissive, Disabled]. Default: Permissive
+	Sealing   string                              `json:"sealing,omitempty"`
 	Secondary *DNSControllerGossipConfigSecondary `json:"secondary,omitempty"`
 	Seed      *string                             `json:"seed,omitempty"`
 }
@@ -1005,7 +1210,11 @@ type DNSControllerGossipConfigSecondary struct {
 	Protocol *string `json:"protocol,omitempty"`
 	Listen   *string `json:"listen,omitempty"`
 	Secret   *string `json:"secret,omitempty"`
-	Seed     *string `json:"seed,omitempty"`
+	// AdditionalSecrets are accepted on gossip as well as Secret, so that the secret can be rotated
+	AdditionalSecrets []string `json:"additionalSecrets,omitempty"`
+	// Sealing controls the sealing of the memberlist gossip state with the secret [Enforced, Permissive, Disabled]. Default: Permissive
+	Sealing string  `json:"sealing,omitempty"`
+	Seed    *string `json:"seed,omitempty"`
 }
 
 type RollingUpdate struct {
@@ -209,6 +209,8 @@ type KubeletConfigSpec struct {
 	RegistryBurst *int32 `json:"registryBurst,omitempty" flag:"registry-burst"`
 	// TopologyManagerPolicy determines the allocation policy for the topology manager.
 	TopologyManagerPolicy string `json:"topologyManagerPolicy,omitempty" flag:"topology-manager-policy"`
+	// ReservedSystemCPUs is the set of CPUs reserved for the system and Kubernetes daemons, e.g. 0-1.
+	ReservedSystemCPUs string `json:"reservedSystemCPUs,omitempty" flag:"reserved-cpus"`
 	// rotateCertificates enables client certificate rotation.
 	RotateCertificates *bool `json:"rotateCertificates,omitempty" flag:"rotate-certificates"`
 	// Default kubelet behaviour for kernel tuning. If set, kubelet errors if any of kernel tunables is different than kubelet defaults.
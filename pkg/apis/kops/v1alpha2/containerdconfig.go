@@ -35,6 +35,9 @@ type ContainerdConfig struct {
 	Packages *PackagesConfig `json:"packages,omitempty"`
 	// RegistryMirrors is list of image registries
 	RegistryMirrors map[string][]string `json:"registryMirrors,omitempty"`
+	// Registries configures the image registries, keyed by registry host (e.g. docker.io), or "_default" for all registries.
+	// The configuration is written to hosts.toml files under /etc/containerd/certs.d and cannot be combined with RegistryMirrors.
+	Registries map[string]ContainerdRegistryConfig `json:"registries,omitempty"`
 	// Root directory for persistent data (default "/var/lib/containerd").
 	Root *string `json:"root,omitempty" flag:"root"`
 	// SkipInstall prevents kOps from installing and modifying containerd in any way (default "false").
@@ -53,6 +56,34 @@ type ContainerdConfig struct {
 	NRI *NRIConfig `json:"nri,omitempty"`
 }
 
+// ContainerdRegistryConfig configures how containerd pulls images from a registry.
+type ContainerdRegistryConfig struct {
+	// Server is the URL of the registry, used when no mirror can serve the image. Default: https://<registry host>
+	Server string `json:"server,omitempty"`
+	// Mirrors are the registry mirrors, tried in order before the server.
+	Mirrors []ContainerdRegistryMirror `json:"mirrors,omitempty"`
+	// SkipVerify disables TLS certificate verification of the server.
+	SkipVerify bool `json:"skipVerify,omitempty"`
+	// CABundle is a PEM-encoded bundle of CA certificates trusted for the server.
+	CABundle string `json:"caBundle,omitempty"`
+	// AuthFromDockerConfig authenticates to the server and mirrors with their credentials from the dockerconfig secret.
+	AuthFromDockerConfig bool `json:"authFromDockerConfig,omitempty"`
+}
+
+// ContainerdRegistryMirror is a mirror of an image registry.
+type ContainerdRegistryMirror struct {
+	// Endpoint is the URL of the mirror, e.g. https://mirror.example.com
+	Endpoint string `json:"endpoint,omitempty"`
+	// Capabilities are the operations supported by the mirror [pull, resolve, push]. Default: pull, resolve
+	Capabilities []string `json:"capabilities,omitempty"`
+	// SkipVerify disables TLS certificate verification of the mirror.
+	SkipVerify bool `json:"skipVerify,omitempty"`
+	// CABundle is a PEM-encoded bundle of CA certificates trusted for the mirror.
+	CABundle string `json:"caBundle,omitempty"`
+	// OverridePath indicates that the endpoint includes the API root path of the mirror, e.g. https://mirror.example.com/v2/docker.io
+	OverridePath bool `json:"overridePath,omitempty"`
+}
+
 type NRIConfig struct {
 	// Enable NRI support in containerd
 	Enabled *bool `json:"enabled,omitempty"`
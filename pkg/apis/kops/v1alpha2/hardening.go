@@ -0,0 +1,28 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+// HardeningSpec configures the OS and kubelet hardening applied by nodeup.
+type HardeningSpec struct {
+	// Profile is the hardening profile to apply.
+	// Valid values:
+	//   'cis-level1': the CIS Kubernetes and Linux benchmark Level 1 controls
+	//   'cis-level2': the Level 1 controls plus the CIS Level 2 controls
+	Profile string `json:"profile,omitempty"`
+	// ExcludedControls is a list of control IDs from the profile that should not be applied.
+	ExcludedControls []string `json:"excludedControls,omitempty"`
+}
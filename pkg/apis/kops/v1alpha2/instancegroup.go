@@ -108,6 +108,8 @@ type InstanceGroupSpec struct {
 	Zones []string `json:"zones,omitempty"`
 	// Hooks is a list of hooks for this instanceGroup, note: these can override the cluster wide ones if required
 	Hooks []HookSpec `json:"hooks,omitempty"`
+	// SystemdOverrides is a list of systemd drop-ins for this instance group, note: these override the cluster wide ones with the same unit and name
+	SystemdOverrides []SystemdOverrideSpec `json:"systemdOverrides,omitempty"`
 	// MaxPrice indicates this is a spot-pricing group, with the specified value as our max-price bid
 	MaxPrice *string `json:"maxPrice,omitempty"`
 	// SpotDurationInMinutes indicates this is a spot-block group, with the specified value as the spot reservation time
@@ -153,6 +155,10 @@ type InstanceGroupSpec struct {
 	// specified, each parameter must follow the form variable=value, the way
 	// it would appear in sysctl.conf.
 	SysctlParameters []string `json:"sysctlParameters,omitempty"`
+	// Hardening overrides the hardening configuration from the ClusterSpec.
+	Hardening *HardeningSpec `json:"hardening,omitempty"`
+	// NodeTuning configures swap, huge pages and CPU management of the nodes.
+	NodeTuning *NodeTuningSpec `json:"nodeTuning,omitempty"`
 	// RollingUpdate defines the rolling-update behavior
 	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
 	// InstanceInterruptionBehavior defines if a spot instance should be terminated, hibernated,
@@ -170,6 +176,9 @@ type InstanceGroupSpec struct {
 	UpdatePolicy *string `json:"updatePolicy,omitempty"`
 	// WarmPool configures an ASG warm pool for the instance group
 	WarmPool *WarmPoolSpec `json:"warmPool,omitempty"`
+	// ImagePrePull is a list of container images that nodeup pulls while the instance boots.
+	// For instance groups with a warm pool, the images are pulled before the instance enters the warm pool.
+	ImagePrePull []string `json:"imagePrePull,omitempty"`
 	// Containerd specifies override configuration for instance group
 	Containerd *ContainerdConfig `json:"containerd,omitempty"`
 	// Packages specifies additional packages to be installed.
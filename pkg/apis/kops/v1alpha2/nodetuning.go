@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import "k8s.io/apimachinery/pkg/api/resource"
+
+// NodeTuningSpec configures swap, huge pages and CPU management of the nodes in an instance group.
+type NodeTuningSpec struct {
+	// Swap configures swap on the nodes and lets workloads use it.
+	Swap *NodeSwapSpec `json:"swap,omitempty"`
+	// HugePages reserves huge pages for workloads.
+	HugePages []HugePagesSpec `json:"hugePages,omitempty"`
+	// CPUManager configures exclusive CPUs and NUMA alignment for workloads.
+	CPUManager *CPUManagerSpec `json:"cpuManager,omitempty"`
+}
+
+// NodeSwapSpec configures swap on a node.
+type NodeSwapSpec struct {
+	// Size is the size of the swap file. Not used if Device is set.
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Device is a block device or partition to use as swap instead of a swap file, e.g. /dev/nvme1n1.
+	Device string `json:"device,omitempty"`
+	// Swappiness sets the vm.swappiness kernel parameter.
+	Swappiness *int32 `json:"swappiness,omitempty"`
+	// Behavior controls how workloads use swap [LimitedSwap, UnlimitedSwap]. Default: LimitedSwap
+	Behavior string `json:"behavior,omitempty"`
+}
+
+// HugePagesSpec reserves huge pages of a given size.
+type HugePagesSpec struct {
+	// PageSize is the size of the huge pages [2Mi, 1Gi].
+	PageSize resource.Quantity `json:"pageSize"`
+	// Count is the number of huge pages to reserve.
+	Count int32 `json:"count"`
+}
+
+// CPUManagerSpec configures the kubelet CPU and topology managers.
+type CPUManagerSpec struct {
+	// Policy is the kubelet CPU manager policy [none, static]. Default: static
+	Policy string `json:"policy,omitempty"`
+	// ReservedCPUs is the number of CPUs reserved for the system and Kubernetes daemons.
+	// Default on AWS: 1 CPU for machine types with up to 16 CPUs, 2 CPUs for up to 64 CPUs, 4 CPUs above that.
+	// Required on other clouds.
+	ReservedCPUs *int32 `json:"reservedCPUs,omitempty"`
+	// TopologyManagerPolicy is the kubelet topology manager policy [none, best-effort, restricted, single-numa-node].
+	TopologyManagerPolicy string `json:"topologyManagerPolicy,omitempty"`
+}
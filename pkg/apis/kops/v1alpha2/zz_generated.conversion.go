@@ -94,6 +94,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AddonPatchSpec)(nil), (*kops.AddonPatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(a.(*AddonPatchSpec), b.(*kops.AddonPatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonPatchSpec)(nil), (*AddonPatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(a.(*kops.AddonPatchSpec), b.(*AddonPatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AddonPatchTarget)(nil), (*kops.AddonPatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(a.(*AddonPatchTarget), b.(*kops.AddonPatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonPatchTarget)(nil), (*AddonPatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(a.(*kops.AddonPatchTarget), b.(*AddonPatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AddonVersionSpec)(nil), (*kops.AddonVersionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AddonVersionSpec_To_kops_AddonVersionSpec(a.(*AddonVersionSpec), b.(*kops.AddonVersionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonVersionSpec)(nil), (*AddonVersionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonVersionSpec_To_v1alpha2_AddonVersionSpec(a.(*kops.AddonVersionSpec), b.(*AddonVersionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AddonSpec)(nil), (*kops.AddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AddonSpec_To_kops_AddonSpec(a.(*AddonSpec), b.(*kops.AddonSpec), scope)
 	}); err != nil {
@@ -124,6 +154,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AssetVerificationSpec)(nil), (*kops.AssetVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(a.(*AssetVerificationSpec), b.(*kops.AssetVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AssetVerificationSpec)(nil), (*AssetVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(a.(*kops.AssetVerificationSpec), b.(*AssetVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AssetsSpec)(nil), (*kops.AssetsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AssetsSpec_To_kops_AssetsSpec(a.(*AssetsSpec), b.(*kops.AssetsSpec), scope)
 	}); err != nil {
@@ -184,6 +224,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BootstrapAdmissionSpec)(nil), (*kops.BootstrapAdmissionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(a.(*BootstrapAdmissionSpec), b.(*kops.BootstrapAdmissionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.BootstrapAdmissionSpec)(nil), (*BootstrapAdmissionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_BootstrapAdmissionSpec_To_v1alpha2_BootstrapAdmissionSpec(a.(*kops.BootstrapAdmissionSpec), b.(*BootstrapAdmissionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CNINetworkingSpec)(nil), (*kops.CNINetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CNINetworkingSpec_To_kops_CNINetworkingSpec(a.(*CNINetworkingSpec), b.(*kops.CNINetworkingSpec), scope)
 	}); err != nil {
@@ -194,6 +244,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CPUManagerSpec)(nil), (*kops.CPUManagerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CPUManagerSpec_To_kops_CPUManagerSpec(a.(*CPUManagerSpec), b.(*kops.CPUManagerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CPUManagerSpec)(nil), (*CPUManagerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CPUManagerSpec_To_v1alpha2_CPUManagerSpec(a.(*kops.CPUManagerSpec), b.(*CPUManagerSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CalicoNetworkingSpec)(nil), (*kops.CalicoNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CalicoNetworkingSpec_To_kops_CalicoNetworkingSpec(a.(*CalicoNetworkingSpec), b.(*kops.CalicoNetworkingSpec), scope)
 	}); err != nil {
@@ -304,6 +364,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryConfig)(nil), (*kops.ContainerdRegistryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(a.(*ContainerdRegistryConfig), b.(*kops.ContainerdRegistryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryConfig)(nil), (*ContainerdRegistryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(a.(*kops.ContainerdRegistryConfig), b.(*ContainerdRegistryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryMirror)(nil), (*kops.ContainerdRegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(a.(*ContainerdRegistryMirror), b.(*kops.ContainerdRegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryMirror)(nil), (*ContainerdRegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(a.(*kops.ContainerdRegistryMirror), b.(*ContainerdRegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DCGMExporterConfig)(nil), (*kops.DCGMExporterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DCGMExporterConfig_To_kops_DCGMExporterConfig(a.(*DCGMExporterConfig), b.(*kops.DCGMExporterConfig), scope)
 	}); err != nil {
@@ -504,6 +584,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HardeningSpec)(nil), (*kops.HardeningSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_HardeningSpec_To_kops_HardeningSpec(a.(*HardeningSpec), b.(*kops.HardeningSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.HardeningSpec)(nil), (*HardeningSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_HardeningSpec_To_v1alpha2_HardeningSpec(a.(*kops.HardeningSpec), b.(*HardeningSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HelmAddonSpec)(nil), (*kops.HelmAddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_HelmAddonSpec_To_kops_HelmAddonSpec(a.(*HelmAddonSpec), b.(*kops.HelmAddonSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.HelmAddonSpec)(nil), (*HelmAddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_HelmAddonSpec_To_v1alpha2_HelmAddonSpec(a.(*kops.HelmAddonSpec), b.(*HelmAddonSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HubbleSpec)(nil), (*kops.HubbleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_HubbleSpec_To_kops_HubbleSpec(a.(*HubbleSpec), b.(*kops.HubbleSpec), scope)
 	}); err != nil {
@@ -514,6 +614,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HugePagesSpec)(nil), (*kops.HugePagesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_HugePagesSpec_To_kops_HugePagesSpec(a.(*HugePagesSpec), b.(*kops.HugePagesSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.HugePagesSpec)(nil), (*HugePagesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_HugePagesSpec_To_v1alpha2_HugePagesSpec(a.(*kops.HugePagesSpec), b.(*HugePagesSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IAMProfileSpec)(nil), (*kops.IAMProfileSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_IAMProfileSpec_To_kops_IAMProfileSpec(a.(*IAMProfileSpec), b.(*kops.IAMProfileSpec), scope)
 	}); err != nil {
@@ -584,6 +694,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KeylessIdentitySpec)(nil), (*kops.KeylessIdentitySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(a.(*KeylessIdentitySpec), b.(*kops.KeylessIdentitySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KeylessIdentitySpec)(nil), (*KeylessIdentitySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KeylessIdentitySpec_To_v1alpha2_KeylessIdentitySpec(a.(*kops.KeylessIdentitySpec), b.(*KeylessIdentitySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Keyset)(nil), (*kops.Keyset)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Keyset_To_kops_Keyset(a.(*Keyset), b.(*kops.Keyset), scope)
 	}); err != nil {
@@ -644,6 +764,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KopsControllerConfig)(nil), (*kops.KopsControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(a.(*KopsControllerConfig), b.(*kops.KopsControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KopsControllerConfig)(nil), (*KopsControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(a.(*kops.KopsControllerConfig), b.(*KopsControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KubeAPIServerConfig)(nil), (*kops.KubeAPIServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(a.(*KubeAPIServerConfig), b.(*kops.KubeAPIServerConfig), scope)
 	}); err != nil {
@@ -724,6 +854,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KustomizeAddonSpec)(nil), (*kops.KustomizeAddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(a.(*KustomizeAddonSpec), b.(*kops.KustomizeAddonSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KustomizeAddonSpec)(nil), (*KustomizeAddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KustomizeAddonSpec_To_v1alpha2_KustomizeAddonSpec(a.(*kops.KustomizeAddonSpec), b.(*KustomizeAddonSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LeaderElectionConfiguration)(nil), (*kops.LeaderElectionConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LeaderElectionConfiguration_To_kops_LeaderElectionConfiguration(a.(*LeaderElectionConfiguration), b.(*kops.LeaderElectionConfiguration), scope)
 	}); err != nil {
@@ -884,6 +1024,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NodeSwapSpec)(nil), (*kops.NodeSwapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeSwapSpec_To_kops_NodeSwapSpec(a.(*NodeSwapSpec), b.(*kops.NodeSwapSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NodeSwapSpec)(nil), (*NodeSwapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NodeSwapSpec_To_v1alpha2_NodeSwapSpec(a.(*kops.NodeSwapSpec), b.(*NodeSwapSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NodeTerminationHandlerSpec)(nil), (*kops.NodeTerminationHandlerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_NodeTerminationHandlerSpec_To_kops_NodeTerminationHandlerSpec(a.(*NodeTerminationHandlerSpec), b.(*kops.NodeTerminationHandlerSpec), scope)
 	}); err != nil {
@@ -894,6 +1044,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NodeTuningSpec)(nil), (*kops.NodeTuningSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeTuningSpec_To_kops_NodeTuningSpec(a.(*NodeTuningSpec), b.(*kops.NodeTuningSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NodeTuningSpec)(nil), (*NodeTuningSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NodeTuningSpec_To_v1alpha2_NodeTuningSpec(a.(*kops.NodeTuningSpec), b.(*NodeTuningSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NvidiaGPUConfig)(nil), (*kops.NvidiaGPUConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_NvidiaGPUConfig_To_kops_NvidiaGPUConfig(a.(*NvidiaGPUConfig), b.(*kops.NvidiaGPUConfig), scope)
 	}); err != nil {
@@ -1104,6 +1264,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SignaturePolicySpec)(nil), (*kops.SignaturePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SignaturePolicySpec_To_kops_SignaturePolicySpec(a.(*SignaturePolicySpec), b.(*kops.SignaturePolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SignaturePolicySpec)(nil), (*SignaturePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SignaturePolicySpec_To_v1alpha2_SignaturePolicySpec(a.(*kops.SignaturePolicySpec), b.(*SignaturePolicySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SnapshotControllerConfig)(nil), (*kops.SnapshotControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_SnapshotControllerConfig_To_kops_SnapshotControllerConfig(a.(*SnapshotControllerConfig), b.(*kops.SnapshotControllerConfig), scope)
 	}); err != nil {
@@ -1114,6 +1284,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SystemdOverrideSpec)(nil), (*kops.SystemdOverrideSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(a.(*SystemdOverrideSpec), b.(*kops.SystemdOverrideSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SystemdOverrideSpec)(nil), (*SystemdOverrideSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SystemdOverrideSpec_To_v1alpha2_SystemdOverrideSpec(a.(*kops.SystemdOverrideSpec), b.(*SystemdOverrideSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TargetSpec)(nil), (*kops.TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_TargetSpec_To_kops_TargetSpec(a.(*TargetSpec), b.(*kops.TargetSpec), scope)
 	}); err != nil {
@@ -1463,8 +1643,104 @@ func Convert_kops_AccessLogSpec_To_v1alpha2_AccessLogSpec(in *kops.AccessLogSpec
 	return autoConvert_kops_AccessLogSpec_To_v1alpha2_AccessLogSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(in *AddonPatchSpec, out *kops.AddonPatchSpec, s conversion.Scope) error {
+	out.Addon = in.Addon
+	if err := Convert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Type = kops.AddonPatchType(in.Type)
+	out.Patch = in.Patch
+	return nil
+}
+
+// Convert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(in *AddonPatchSpec, out *kops.AddonPatchSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(in, out, s)
+}
+
+func autoConvert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(in *kops.AddonPatchSpec, out *AddonPatchSpec, s conversion.Scope) error {
+	out.Addon = in.Addon
+	if err := Convert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Type = AddonPatchType(in.Type)
+	out.Patch = in.Patch
+	return nil
+}
+
+// Convert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec is an autogenerated conversion function.
+func Convert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(in *kops.AddonPatchSpec, out *AddonPatchSpec, s conversion.Scope) error {
+	return autoConvert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(in *AddonPatchTarget, out *kops.AddonPatchTarget, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget is an autogenerated conversion function.
+func Convert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(in *AddonPatchTarget, out *kops.AddonPatchTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AddonPatchTarget_To_kops_AddonPatchTarget(in, out, s)
+}
+
+func autoConvert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(in *kops.AddonPatchTarget, out *AddonPatchTarget, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget is an autogenerated conversion function.
+func Convert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(in *kops.AddonPatchTarget, out *AddonPatchTarget, s conversion.Scope) error {
+	return autoConvert_kops_AddonPatchTarget_To_v1alpha2_AddonPatchTarget(in, out, s)
+}
+
+func autoConvert_v1alpha2_AddonVersionSpec_To_kops_AddonVersionSpec(in *AddonVersionSpec, out *kops.AddonVersionSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_v1alpha2_AddonVersionSpec_To_kops_AddonVersionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AddonVersionSpec_To_kops_AddonVersionSpec(in *AddonVersionSpec, out *kops.AddonVersionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AddonVersionSpec_To_kops_AddonVersionSpec(in, out, s)
+}
+
+func autoConvert_kops_AddonVersionSpec_To_v1alpha2_AddonVersionSpec(in *kops.AddonVersionSpec, out *AddonVersionSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_kops_AddonVersionSpec_To_v1alpha2_AddonVersionSpec is an autogenerated conversion function.
+func Convert_kops_AddonVersionSpec_To_v1alpha2_AddonVersionSpec(in *kops.AddonVersionSpec, out *AddonVersionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AddonVersionSpec_To_v1alpha2_AddonVersionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AddonSpec_To_kops_AddonSpec(in *AddonSpec, out *kops.AddonSpec, s conversion.Scope) error {
 	out.Manifest = in.Manifest
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(kops.HelmAddonSpec)
+		if err := Convert_v1alpha2_HelmAddonSpec_To_kops_HelmAddonSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Helm = nil
+	}
+	if in.Kustomize != nil {
+		in, out := &in.Kustomize, &out.Kustomize
+		*out = new(kops.KustomizeAddonSpec)
+		if err := Convert_v1alpha2_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Kustomize = nil
+	}
 	return nil
 }
 
@@ -1475,6 +1751,24 @@ func Convert_v1alpha2_AddonSpec_To_kops_AddonSpec(in *AddonSpec, out *kops.Addon
 
 func autoConvert_kops_AddonSpec_To_v1alpha2_AddonSpec(in *kops.AddonSpec, out *AddonSpec, s conversion.Scope) error {
 	out.Manifest = in.Manifest
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmAddonSpec)
+		if err := Convert_kops_HelmAddonSpec_To_v1alpha2_HelmAddonSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Helm = nil
+	}
+	if in.Kustomize != nil {
+		in, out := &in.Kustomize, &out.Kustomize
+		*out = new(KustomizeAddonSpec)
+		if err := Convert_kops_KustomizeAddonSpec_To_v1alpha2_KustomizeAddonSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Kustomize = nil
+	}
 	return nil
 }
 
@@ -1547,10 +1841,85 @@ func Convert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpec(in
 	return autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(in *AssetVerificationSpec, out *kops.AssetVerificationSpec, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]kops.SignaturePolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_SignaturePolicySpec_To_kops_SignaturePolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]kops.SignaturePolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_SignaturePolicySpec_To_kops_SignaturePolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Files = nil
+	}
+	out.FulcioRoots = in.FulcioRoots
+	out.RekorPublicKeys = in.RekorPublicKeys
+	return nil
+}
+
+// Convert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(in *AssetVerificationSpec, out *kops.AssetVerificationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(in, out, s)
+}
+
+func autoConvert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(in *kops.AssetVerificationSpec, out *AssetVerificationSpec, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]SignaturePolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SignaturePolicySpec_To_v1alpha2_SignaturePolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]SignaturePolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SignaturePolicySpec_To_v1alpha2_SignaturePolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Files = nil
+	}
+	out.FulcioRoots = in.FulcioRoots
+	out.RekorPublicKeys = in.RekorPublicKeys
+	return nil
+}
+
+// Convert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec is an autogenerated conversion function.
+func Convert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(in *kops.AssetVerificationSpec, out *AssetVerificationSpec, s conversion.Scope) error {
+	return autoConvert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AssetsSpec_To_kops_AssetsSpec(in *AssetsSpec, out *kops.AssetsSpec, s conversion.Scope) error {
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(kops.AssetVerificationSpec)
+		if err := Convert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -1563,6 +1932,15 @@ func autoConvert_kops_AssetsSpec_To_v1alpha2_AssetsSpec(in *kops.AssetsSpec, out
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(AssetVerificationSpec)
+		if err := Convert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -1771,6 +2149,30 @@ func Convert_kops_BastionSpec_To_v1alpha2_BastionSpec(in *kops.BastionSpec, out
 	return autoConvert_kops_BastionSpec_To_v1alpha2_BastionSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(in *BootstrapAdmissionSpec, out *kops.BootstrapAdmissionSpec, s conversion.Scope) error {
+	out.MaxNodesPerInstanceGroup = in.MaxNodesPerInstanceGroup
+	out.AllowedInstanceTypes = in.AllowedInstanceTypes
+	out.DeniedInstanceIDs = in.DeniedInstanceIDs
+	return nil
+}
+
+// Convert_v1alpha2_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(in *BootstrapAdmissionSpec, out *kops.BootstrapAdmissionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(in, out, s)
+}
+
+func autoConvert_kops_BootstrapAdmissionSpec_To_v1alpha2_BootstrapAdmissionSpec(in *kops.BootstrapAdmissionSpec, out *BootstrapAdmissionSpec, s conversion.Scope) error {
+	out.MaxNodesPerInstanceGroup = in.MaxNodesPerInstanceGroup
+	out.AllowedInstanceTypes = in.AllowedInstanceTypes
+	out.DeniedInstanceIDs = in.DeniedInstanceIDs
+	return nil
+}
+
+// Convert_kops_BootstrapAdmissionSpec_To_v1alpha2_BootstrapAdmissionSpec is an autogenerated conversion function.
+func Convert_kops_BootstrapAdmissionSpec_To_v1alpha2_BootstrapAdmissionSpec(in *kops.BootstrapAdmissionSpec, out *BootstrapAdmissionSpec, s conversion.Scope) error {
+	return autoConvert_kops_BootstrapAdmissionSpec_To_v1alpha2_BootstrapAdmissionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_CNINetworkingSpec_To_kops_CNINetworkingSpec(in *CNINetworkingSpec, out *kops.CNINetworkingSpec, s conversion.Scope) error {
 	out.UsesSecondaryIP = in.UsesSecondaryIP
 	return nil
@@ -1791,6 +2193,30 @@ func Convert_kops_CNINetworkingSpec_To_v1alpha2_CNINetworkingSpec(in *kops.CNINe
 	return autoConvert_kops_CNINetworkingSpec_To_v1alpha2_CNINetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_CPUManagerSpec_To_kops_CPUManagerSpec(in *CPUManagerSpec, out *kops.CPUManagerSpec, s conversion.Scope) error {
+	out.Policy = in.Policy
+	out.ReservedCPUs = in.ReservedCPUs
+	out.TopologyManagerPolicy = in.TopologyManagerPolicy
+	return nil
+}
+
+// Convert_v1alpha2_CPUManagerSpec_To_kops_CPUManagerSpec is an autogenerated conversion function.
+func Convert_v1alpha2_CPUManagerSpec_To_kops_CPUManagerSpec(in *CPUManagerSpec, out *kops.CPUManagerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CPUManagerSpec_To_kops_CPUManagerSpec(in, out, s)
+}
+
+func autoConvert_kops_CPUManagerSpec_To_v1alpha2_CPUManagerSpec(in *kops.CPUManagerSpec, out *CPUManagerSpec, s conversion.Scope) error {
+	out.Policy = in.Policy
+	out.ReservedCPUs = in.ReservedCPUs
+	out.TopologyManagerPolicy = in.TopologyManagerPolicy
+	return nil
+}
+
+// Convert_kops_CPUManagerSpec_To_v1alpha2_CPUManagerSpec is an autogenerated conversion function.
+func Convert_kops_CPUManagerSpec_To_v1alpha2_CPUManagerSpec(in *kops.CPUManagerSpec, out *CPUManagerSpec, s conversion.Scope) error {
+	return autoConvert_kops_CPUManagerSpec_To_v1alpha2_CPUManagerSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_CalicoNetworkingSpec_To_kops_CalicoNetworkingSpec(in *CalicoNetworkingSpec, out *kops.CalicoNetworkingSpec, s conversion.Scope) error {
 	out.Registry = in.Registry
 	out.Version = in.Version
@@ -2424,6 +2850,28 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Addons = nil
 	}
+	if in.AddonPatches != nil {
+		in, out := &in.AddonPatches, &out.AddonPatches
+		*out = make([]kops.AddonPatchSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AddonPatchSpec_To_kops_AddonPatchSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonPatches = nil
+	}
+	if in.AddonVersions != nil {
+		in, out := &in.AddonVersions, &out.AddonVersions
+		*out = make([]kops.AddonVersionSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AddonVersionSpec_To_kops_AddonVersionSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonVersions = nil
+	}
 	out.ConfigStore = in.ConfigStore
 	// INFO: in.ConfigBase opted out of conversion generation
 	out.CloudProvider = in.CloudProvider
@@ -2451,6 +2899,7 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	// INFO: in.KeyStore opted out of conversion generation
 	// INFO: in.LegacyConfigStore opted out of conversion generation
 	out.DNSZone = in.DNSZone
+	out.DNSProvider = in.DNSProvider
 	if in.DNSControllerGossipConfig != nil {
 		in, out := &in.DNSControllerGossipConfig, &out.DNSControllerGossipConfig
 		*out = new(kops.DNSControllerGossipConfig)
@@ -2690,6 +3139,17 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Hooks = nil
 	}
+	if in.SystemdOverrides != nil {
+		in, out := &in.SystemdOverrides, &out.SystemdOverrides
+		*out = make([]kops.SystemdOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SystemdOverrides = nil
+	}
 	if in.Assets != nil {
 		in, out := &in.Assets, &out.Assets
 		*out = new(kops.AssetsSpec)
@@ -2721,6 +3181,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	}
 	out.UseHostCertificates = in.UseHostCertificates
 	out.SysctlParameters = in.SysctlParameters
+	if in.Hardening != nil {
+		in, out := &in.Hardening, &out.Hardening
+		*out = new(kops.HardeningSpec)
+		if err := Convert_v1alpha2_HardeningSpec_To_kops_HardeningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hardening = nil
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(kops.RollingUpdate)
@@ -2767,6 +3236,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Karpenter = nil
 	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(kops.KopsControllerConfig)
+		if err := Convert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
 	// INFO: in.PodIdentityWebhook opted out of conversion generation
 	return nil
 }
@@ -2784,6 +3262,28 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Addons = nil
 	}
+	if in.AddonPatches != nil {
+		in, out := &in.AddonPatches, &out.AddonPatches
+		*out = make([]AddonPatchSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AddonPatchSpec_To_v1alpha2_AddonPatchSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonPatches = nil
+	}
+	if in.AddonVersions != nil {
+		in, out := &in.AddonVersions, &out.AddonVersions
+		*out = make([]AddonVersionSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AddonVersionSpec_To_v1alpha2_AddonVersionSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonVersions = nil
+	}
 	out.ConfigStore = in.ConfigStore
 	out.CloudProvider = in.CloudProvider
 	if in.GossipConfig != nil {
@@ -2798,6 +3298,7 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	out.ContainerRuntime = in.ContainerRuntime
 	out.KubernetesVersion = in.KubernetesVersion
 	out.DNSZone = in.DNSZone
+	out.DNSProvider = in.DNSProvider
 	if in.DNSControllerGossipConfig != nil {
 		in, out := &in.DNSControllerGossipConfig, &out.DNSControllerGossipConfig
 		*out = new(DNSControllerGossipConfig)
@@ -3026,6 +3527,17 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Hooks = nil
 	}
+	if in.SystemdOverrides != nil {
+		in, out := &in.SystemdOverrides, &out.SystemdOverrides
+		*out = make([]SystemdOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SystemdOverrideSpec_To_v1alpha2_SystemdOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SystemdOverrides = nil
+	}
 	if in.Assets != nil {
 		in, out := &in.Assets, &out.Assets
 		*out = new(AssetsSpec)
@@ -3056,6 +3568,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	}
 	out.UseHostCertificates = in.UseHostCertificates
 	out.SysctlParameters = in.SysctlParameters
+	if in.Hardening != nil {
+		in, out := &in.Hardening, &out.Hardening
+		*out = new(HardeningSpec)
+		if err := Convert_kops_HardeningSpec_To_v1alpha2_HardeningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hardening = nil
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(RollingUpdate)
@@ -3101,6 +3622,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Karpenter = nil
 	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(KopsControllerConfig)
+		if err := Convert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
 	return nil
 }
 
@@ -3177,6 +3707,19 @@ func autoConvert_v1alpha2_ContainerdConfig_To_kops_ContainerdConfig(in *Containe
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]kops.ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(kops.ContainerdRegistryConfig)
+			if err := Convert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Registries = nil
+	}
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3232,6 +3775,19 @@ func autoConvert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in *kops.Con
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(ContainerdRegistryConfig)
+			if err := Convert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Registries = nil
+	}
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3272,6 +3828,82 @@ func Convert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in *kops.Contain
 	return autoConvert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in *ContainerdRegistryConfig, out *kops.ContainerdRegistryConfig, s conversion.Scope) error {
+	out.Server = in.Server
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]kops.ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Mirrors = nil
+	}
+	out.SkipVerify = in.SkipVerify
+	out.CABundle = in.CABundle
+	out.AuthFromDockerConfig = in.AuthFromDockerConfig
+	return nil
+}
+
+// Convert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig is an autogenerated conversion function.
+func Convert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in *ContainerdRegistryConfig, out *kops.ContainerdRegistryConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(in *kops.ContainerdRegistryConfig, out *ContainerdRegistryConfig, s conversion.Scope) error {
+	out.Server = in.Server
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Mirrors = nil
+	}
+	out.SkipVerify = in.SkipVerify
+	out.CABundle = in.CABundle
+	out.AuthFromDockerConfig = in.AuthFromDockerConfig
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(in *kops.ContainerdRegistryConfig, out *ContainerdRegistryConfig, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(in, out, s)
+}
+
+func autoConvert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in *ContainerdRegistryMirror, out *kops.ContainerdRegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Capabilities = in.Capabilities
+	out.SkipVerify = in.SkipVerify
+	out.CABundle = in.CABundle
+	out.OverridePath = in.OverridePath
+	return nil
+}
+
+// Convert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror is an autogenerated conversion function.
+func Convert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in *ContainerdRegistryMirror, out *kops.ContainerdRegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(in *kops.ContainerdRegistryMirror, out *ContainerdRegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Capabilities = in.Capabilities
+	out.SkipVerify = in.SkipVerify
+	out.CABundle = in.CABundle
+	out.OverridePath = in.OverridePath
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(in *kops.ContainerdRegistryMirror, out *ContainerdRegistryMirror, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(in, out, s)
+}
+
 func autoConvert_v1alpha2_DCGMExporterConfig_To_kops_DCGMExporterConfig(in *DCGMExporterConfig, out *kops.DCGMExporterConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -3314,6 +3946,8 @@ func autoConvert_v1alpha2_DNSControllerGossipConfig_To_kops_DNSControllerGossipC
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	if in.Secondary != nil {
 		in, out := &in.Secondary, &out.Secondary
 		*out = new(kops.DNSControllerGossipConfigSecondary)
@@ -3336,6 +3970,8 @@ func autoConvert_kops_DNSControllerGossipConfig_To_v1alpha2_DNSControllerGossipC
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	if in.Secondary != nil {
 		in, out := &in.Secondary, &out.Secondary
 		*out = new(DNSControllerGossipConfigSecondary)
@@ -3358,6 +3994,8 @@ func autoConvert_v1alpha2_DNSControllerGossipConfigSecondary_To_kops_DNSControll
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	out.Seed = in.Seed
 	return nil
 }
@@ -3371,6 +4009,8 @@ func autoConvert_kops_DNSControllerGossipConfigSecondary_To_v1alpha2_DNSControll
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	out.Seed = in.Seed
 	return nil
 }
@@ -3793,15 +4433,19 @@ func Convert_kops_ExecContainerAction_To_v1alpha2_ExecContainerAction(in *kops.E
 func autoConvert_v1alpha2_ExternalDNSConfig_To_kops_ExternalDNSConfig(in *ExternalDNSConfig, out *kops.ExternalDNSConfig, s conversion.Scope) error {
 	// INFO: in.Disable opted out of conversion generation
 	out.WatchIngress = in.WatchIngress
+	out.WatchGateway = in.WatchGateway
 	out.WatchNamespace = in.WatchNamespace
 	out.Provider = kops.ExternalDNSProvider(in.Provider)
+	out.OwnerID = in.OwnerID
 	return nil
 }
 
 func autoConvert_kops_ExternalDNSConfig_To_v1alpha2_ExternalDNSConfig(in *kops.ExternalDNSConfig, out *ExternalDNSConfig, s conversion.Scope) error {
 	out.WatchIngress = in.WatchIngress
+	out.WatchGateway = in.WatchGateway
 	out.WatchNamespace = in.WatchNamespace
 	out.Provider = ExternalDNSProvider(in.Provider)
+	out.OwnerID = in.OwnerID
 	return nil
 }
 
@@ -3914,6 +4558,8 @@ func autoConvert_v1alpha2_GossipConfig_To_kops_GossipConfig(in *GossipConfig, ou
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	if in.Secondary != nil {
 		in, out := &in.Secondary, &out.Secondary
 		*out = new(kops.GossipConfigSecondary)
@@ -3935,6 +4581,8 @@ func autoConvert_kops_GossipConfig_To_v1alpha2_GossipConfig(in *kops.GossipConfi
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	if in.Secondary != nil {
 		in, out := &in.Secondary, &out.Secondary
 		*out = new(GossipConfigSecondary)
@@ -3956,6 +4604,8 @@ func autoConvert_v1alpha2_GossipConfigSecondary_To_kops_GossipConfigSecondary(in
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	return nil
 }
 
@@ -3968,6 +4618,8 @@ func autoConvert_kops_GossipConfigSecondary_To_v1alpha2_GossipConfigSecondary(in
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	return nil
 }
 
@@ -3998,6 +4650,60 @@ func Convert_kops_HTTPProxy_To_v1alpha2_HTTPProxy(in *kops.HTTPProxy, out *HTTPP
 	return autoConvert_kops_HTTPProxy_To_v1alpha2_HTTPProxy(in, out, s)
 }
 
+func autoConvert_v1alpha2_HardeningSpec_To_kops_HardeningSpec(in *HardeningSpec, out *kops.HardeningSpec, s conversion.Scope) error {
+	out.Profile = in.Profile
+	out.ExcludedControls = in.ExcludedControls
+	return nil
+}
+
+// Convert_v1alpha2_HardeningSpec_To_kops_HardeningSpec is an autogenerated conversion function.
+func Convert_v1alpha2_HardeningSpec_To_kops_HardeningSpec(in *HardeningSpec, out *kops.HardeningSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_HardeningSpec_To_kops_HardeningSpec(in, out, s)
+}
+
+func autoConvert_kops_HardeningSpec_To_v1alpha2_HardeningSpec(in *kops.HardeningSpec, out *HardeningSpec, s conversion.Scope) error {
+	out.Profile = in.Profile
+	out.ExcludedControls = in.ExcludedControls
+	return nil
+}
+
+// Convert_kops_HardeningSpec_To_v1alpha2_HardeningSpec is an autogenerated conversion function.
+func Convert_kops_HardeningSpec_To_v1alpha2_HardeningSpec(in *kops.HardeningSpec, out *HardeningSpec, s conversion.Scope) error {
+	return autoConvert_kops_HardeningSpec_To_v1alpha2_HardeningSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_HelmAddonSpec_To_kops_HelmAddonSpec(in *HelmAddonSpec, out *kops.HelmAddonSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Chart = in.Chart
+	out.Repository = in.Repository
+	out.Version = in.Version
+	out.Values = in.Values
+	out.ValuesFile = in.ValuesFile
+	return nil
+}
+
+// Convert_v1alpha2_HelmAddonSpec_To_kops_HelmAddonSpec is an autogenerated conversion function.
+func Convert_v1alpha2_HelmAddonSpec_To_kops_HelmAddonSpec(in *HelmAddonSpec, out *kops.HelmAddonSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_HelmAddonSpec_To_kops_HelmAddonSpec(in, out, s)
+}
+
+func autoConvert_kops_HelmAddonSpec_To_v1alpha2_HelmAddonSpec(in *kops.HelmAddonSpec, out *HelmAddonSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Chart = in.Chart
+	out.Repository = in.Repository
+	out.Version = in.Version
+	out.Values = in.Values
+	out.ValuesFile = in.ValuesFile
+	return nil
+}
+
+// Convert_kops_HelmAddonSpec_To_v1alpha2_HelmAddonSpec is an autogenerated conversion function.
+func Convert_kops_HelmAddonSpec_To_v1alpha2_HelmAddonSpec(in *kops.HelmAddonSpec, out *HelmAddonSpec, s conversion.Scope) error {
+	return autoConvert_kops_HelmAddonSpec_To_v1alpha2_HelmAddonSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_HookSpec_To_kops_HookSpec(in *HookSpec, out *kops.HookSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Enabled = in.Enabled
@@ -4076,6 +4782,28 @@ func Convert_kops_HubbleSpec_To_v1alpha2_HubbleSpec(in *kops.HubbleSpec, out *Hu
 	return autoConvert_kops_HubbleSpec_To_v1alpha2_HubbleSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_HugePagesSpec_To_kops_HugePagesSpec(in *HugePagesSpec, out *kops.HugePagesSpec, s conversion.Scope) error {
+	out.PageSize = in.PageSize
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_v1alpha2_HugePagesSpec_To_kops_HugePagesSpec is an autogenerated conversion function.
+func Convert_v1alpha2_HugePagesSpec_To_kops_HugePagesSpec(in *HugePagesSpec, out *kops.HugePagesSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_HugePagesSpec_To_kops_HugePagesSpec(in, out, s)
+}
+
+func autoConvert_kops_HugePagesSpec_To_v1alpha2_HugePagesSpec(in *kops.HugePagesSpec, out *HugePagesSpec, s conversion.Scope) error {
+	out.PageSize = in.PageSize
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_kops_HugePagesSpec_To_v1alpha2_HugePagesSpec is an autogenerated conversion function.
+func Convert_kops_HugePagesSpec_To_v1alpha2_HugePagesSpec(in *kops.HugePagesSpec, out *HugePagesSpec, s conversion.Scope) error {
+	return autoConvert_kops_HugePagesSpec_To_v1alpha2_HugePagesSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_IAMProfileSpec_To_kops_IAMProfileSpec(in *IAMProfileSpec, out *kops.IAMProfileSpec, s conversion.Scope) error {
 	out.Profile = in.Profile
 	return nil
@@ -4265,6 +4993,17 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.Hooks = nil
 	}
+	if in.SystemdOverrides != nil {
+		in, out := &in.SystemdOverrides, &out.SystemdOverrides
+		*out = make([]kops.SystemdOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SystemdOverrides = nil
+	}
 	out.MaxPrice = in.MaxPrice
 	out.SpotDurationInMinutes = in.SpotDurationInMinutes
 	out.CPUCredits = in.CPUCredits
@@ -4340,6 +5079,24 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	out.SecurityGroupOverride = in.SecurityGroupOverride
 	out.InstanceProtection = in.InstanceProtection
 	out.SysctlParameters = in.SysctlParameters
+	if in.Hardening != nil {
+		in, out := &in.Hardening, &out.Hardening
+		*out = new(kops.HardeningSpec)
+		if err := Convert_v1alpha2_HardeningSpec_To_kops_HardeningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hardening = nil
+	}
+	if in.NodeTuning != nil {
+		in, out := &in.NodeTuning, &out.NodeTuning
+		*out = new(kops.NodeTuningSpec)
+		if err := Convert_v1alpha2_NodeTuningSpec_To_kops_NodeTuningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeTuning = nil
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(kops.RollingUpdate)
@@ -4370,6 +5127,7 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.WarmPool = nil
 	}
+	out.ImagePrePull = in.ImagePrePull
 	if in.Containerd != nil {
 		in, out := &in.Containerd, &out.Containerd
 		*out = new(kops.ContainerdConfig)
@@ -4441,6 +5199,17 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	} else {
 		out.Hooks = nil
 	}
+	if in.SystemdOverrides != nil {
+		in, out := &in.SystemdOverrides, &out.SystemdOverrides
+		*out = make([]SystemdOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SystemdOverrideSpec_To_v1alpha2_SystemdOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SystemdOverrides = nil
+	}
 	out.MaxPrice = in.MaxPrice
 	out.SpotDurationInMinutes = in.SpotDurationInMinutes
 	out.CPUCredits = in.CPUCredits
@@ -4516,6 +5285,24 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	out.SecurityGroupOverride = in.SecurityGroupOverride
 	out.InstanceProtection = in.InstanceProtection
 	out.SysctlParameters = in.SysctlParameters
+	if in.Hardening != nil {
+		in, out := &in.Hardening, &out.Hardening
+		*out = new(HardeningSpec)
+		if err := Convert_kops_HardeningSpec_To_v1alpha2_HardeningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hardening = nil
+	}
+	if in.NodeTuning != nil {
+		in, out := &in.NodeTuning, &out.NodeTuning
+		*out = new(NodeTuningSpec)
+		if err := Convert_kops_NodeTuningSpec_To_v1alpha2_NodeTuningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeTuning = nil
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(RollingUpdate)
@@ -4546,6 +5333,7 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	} else {
 		out.WarmPool = nil
 	}
+	out.ImagePrePull = in.ImagePrePull
 	if in.Containerd != nil {
 		in, out := &in.Containerd, &out.Containerd
 		*out = new(ContainerdConfig)
@@ -4680,6 +5468,28 @@ func Convert_kops_KarpenterConfig_To_v1alpha2_KarpenterConfig(in *kops.Karpenter
 	return autoConvert_kops_KarpenterConfig_To_v1alpha2_KarpenterConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(in *KeylessIdentitySpec, out *kops.KeylessIdentitySpec, s conversion.Scope) error {
+	out.Issuer = in.Issuer
+	out.Subject = in.Subject
+	return nil
+}
+
+// Convert_v1alpha2_KeylessIdentitySpec_To_kops_KeylessIdentitySpec is an autogenerated conversion function.
+func Convert_v1alpha2_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(in *KeylessIdentitySpec, out *kops.KeylessIdentitySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(in, out, s)
+}
+
+func autoConvert_kops_KeylessIdentitySpec_To_v1alpha2_KeylessIdentitySpec(in *kops.KeylessIdentitySpec, out *KeylessIdentitySpec, s conversion.Scope) error {
+	out.Issuer = in.Issuer
+	out.Subject = in.Subject
+	return nil
+}
+
+// Convert_kops_KeylessIdentitySpec_To_v1alpha2_KeylessIdentitySpec is an autogenerated conversion function.
+func Convert_kops_KeylessIdentitySpec_To_v1alpha2_KeylessIdentitySpec(in *kops.KeylessIdentitySpec, out *KeylessIdentitySpec, s conversion.Scope) error {
+	return autoConvert_kops_KeylessIdentitySpec_To_v1alpha2_KeylessIdentitySpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_Keyset_To_kops_Keyset(in *Keyset, out *kops.Keyset, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_KeysetSpec_To_kops_KeysetSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -4862,6 +5672,42 @@ func Convert_kops_KopeioNetworkingSpec_To_v1alpha2_KopeioNetworkingSpec(in *kops
 	return autoConvert_kops_KopeioNetworkingSpec_To_v1alpha2_KopeioNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(in *KopsControllerConfig, out *kops.KopsControllerConfig, s conversion.Scope) error {
+	if in.BootstrapAdmission != nil {
+		in, out := &in.BootstrapAdmission, &out.BootstrapAdmission
+		*out = new(kops.BootstrapAdmissionSpec)
+		if err := Convert_v1alpha2_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BootstrapAdmission = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig is an autogenerated conversion function.
+func Convert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(in *KopsControllerConfig, out *kops.KopsControllerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KopsControllerConfig_To_kops_KopsControllerConfig(in, out, s)
+}
+
+func autoConvert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(in *kops.KopsControllerConfig, out *KopsControllerConfig, s conversion.Scope) error {
+	if in.BootstrapAdmission != nil {
+		in, out := &in.BootstrapAdmission, &out.BootstrapAdmission
+		*out = new(BootstrapAdmissionSpec)
+		if err := Convert_kops_BootstrapAdmissionSpec_To_v1alpha2_BootstrapAdmissionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BootstrapAdmission = nil
+	}
+	return nil
+}
+
+// Convert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig is an autogenerated conversion function.
+func Convert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(in *kops.KopsControllerConfig, out *KopsControllerConfig, s conversion.Scope) error {
+	return autoConvert_kops_KopsControllerConfig_To_v1alpha2_KopsControllerConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *KubeAPIServerConfig, out *kops.KubeAPIServerConfig, s conversion.Scope) error {
 	out.Image = in.Image
 	out.DisableBasicAuth = in.DisableBasicAuth
@@ -5540,6 +6386,7 @@ func autoConvert_v1alpha2_KubeletConfigSpec_To_kops_KubeletConfigSpec(in *Kubele
 	out.RegistryPullQPS = in.RegistryPullQPS
 	out.RegistryBurst = in.RegistryBurst
 	out.TopologyManagerPolicy = in.TopologyManagerPolicy
+	out.ReservedSystemCPUs = in.ReservedSystemCPUs
 	out.RotateCertificates = in.RotateCertificates
 	out.ProtectKernelDefaults = in.ProtectKernelDefaults
 	out.CgroupDriver = in.CgroupDriver
@@ -5644,6 +6491,7 @@ func autoConvert_kops_KubeletConfigSpec_To_v1alpha2_KubeletConfigSpec(in *kops.K
 	out.RegistryPullQPS = in.RegistryPullQPS
 	out.RegistryBurst = in.RegistryBurst
 	out.TopologyManagerPolicy = in.TopologyManagerPolicy
+	out.ReservedSystemCPUs = in.ReservedSystemCPUs
 	out.RotateCertificates = in.RotateCertificates
 	out.ProtectKernelDefaults = in.ProtectKernelDefaults
 	out.CgroupDriver = in.CgroupDriver
@@ -5702,6 +6550,30 @@ func Convert_kops_KuberouterNetworkingSpec_To_v1alpha2_KuberouterNetworkingSpec(
 	return autoConvert_kops_KuberouterNetworkingSpec_To_v1alpha2_KuberouterNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(in *KustomizeAddonSpec, out *kops.KustomizeAddonSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Path = in.Path
+	out.Overlay = in.Overlay
+	return nil
+}
+
+// Convert_v1alpha2_KustomizeAddonSpec_To_kops_KustomizeAddonSpec is an autogenerated conversion function.
+func Convert_v1alpha2_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(in *KustomizeAddonSpec, out *kops.KustomizeAddonSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(in, out, s)
+}
+
+func autoConvert_kops_KustomizeAddonSpec_To_v1alpha2_KustomizeAddonSpec(in *kops.KustomizeAddonSpec, out *KustomizeAddonSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Path = in.Path
+	out.Overlay = in.Overlay
+	return nil
+}
+
+// Convert_kops_KustomizeAddonSpec_To_v1alpha2_KustomizeAddonSpec is an autogenerated conversion function.
+func Convert_kops_KustomizeAddonSpec_To_v1alpha2_KustomizeAddonSpec(in *kops.KustomizeAddonSpec, out *KustomizeAddonSpec, s conversion.Scope) error {
+	return autoConvert_kops_KustomizeAddonSpec_To_v1alpha2_KustomizeAddonSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_LeaderElectionConfiguration_To_kops_LeaderElectionConfiguration(in *LeaderElectionConfiguration, out *kops.LeaderElectionConfiguration, s conversion.Scope) error {
 	out.LeaderElect = in.LeaderElect
 	out.LeaderElectLeaseDuration = in.LeaderElectLeaseDuration
@@ -6540,6 +7412,32 @@ func Convert_kops_NodeProblemDetectorConfig_To_v1alpha2_NodeProblemDetectorConfi
 	return autoConvert_kops_NodeProblemDetectorConfig_To_v1alpha2_NodeProblemDetectorConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_NodeSwapSpec_To_kops_NodeSwapSpec(in *NodeSwapSpec, out *kops.NodeSwapSpec, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Device = in.Device
+	out.Swappiness = in.Swappiness
+	out.Behavior = in.Behavior
+	return nil
+}
+
+// Convert_v1alpha2_NodeSwapSpec_To_kops_NodeSwapSpec is an autogenerated conversion function.
+func Convert_v1alpha2_NodeSwapSpec_To_kops_NodeSwapSpec(in *NodeSwapSpec, out *kops.NodeSwapSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeSwapSpec_To_kops_NodeSwapSpec(in, out, s)
+}
+
+func autoConvert_kops_NodeSwapSpec_To_v1alpha2_NodeSwapSpec(in *kops.NodeSwapSpec, out *NodeSwapSpec, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Device = in.Device
+	out.Swappiness = in.Swappiness
+	out.Behavior = in.Behavior
+	return nil
+}
+
+// Convert_kops_NodeSwapSpec_To_v1alpha2_NodeSwapSpec is an autogenerated conversion function.
+func Convert_kops_NodeSwapSpec_To_v1alpha2_NodeSwapSpec(in *kops.NodeSwapSpec, out *NodeSwapSpec, s conversion.Scope) error {
+	return autoConvert_kops_NodeSwapSpec_To_v1alpha2_NodeSwapSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_NodeTerminationHandlerSpec_To_kops_NodeTerminationHandlerSpec(in *NodeTerminationHandlerSpec, out *kops.NodeTerminationHandlerSpec, s conversion.Scope) error {
 	out.DeleteSQSMsgIfNodeNotFound = in.DeleteSQSMsgIfNodeNotFound
 	out.Enabled = in.Enabled
@@ -6594,6 +7492,82 @@ func Convert_kops_NodeTerminationHandlerSpec_To_v1alpha2_NodeTerminationHandlerS
 	return autoConvert_kops_NodeTerminationHandlerSpec_To_v1alpha2_NodeTerminationHandlerSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_NodeTuningSpec_To_kops_NodeTuningSpec(in *NodeTuningSpec, out *kops.NodeTuningSpec, s conversion.Scope) error {
+	if in.Swap != nil {
+		in, out := &in.Swap, &out.Swap
+		*out = new(kops.NodeSwapSpec)
+		if err := Convert_v1alpha2_NodeSwapSpec_To_kops_NodeSwapSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Swap = nil
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = make([]kops.HugePagesSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_HugePagesSpec_To_kops_HugePagesSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HugePages = nil
+	}
+	if in.CPUManager != nil {
+		in, out := &in.CPUManager, &out.CPUManager
+		*out = new(kops.CPUManagerSpec)
+		if err := Convert_v1alpha2_CPUManagerSpec_To_kops_CPUManagerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CPUManager = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_NodeTuningSpec_To_kops_NodeTuningSpec is an autogenerated conversion function.
+func Convert_v1alpha2_NodeTuningSpec_To_kops_NodeTuningSpec(in *NodeTuningSpec, out *kops.NodeTuningSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeTuningSpec_To_kops_NodeTuningSpec(in, out, s)
+}
+
+func autoConvert_kops_NodeTuningSpec_To_v1alpha2_NodeTuningSpec(in *kops.NodeTuningSpec, out *NodeTuningSpec, s conversion.Scope) error {
+	if in.Swap != nil {
+		in, out := &in.Swap, &out.Swap
+		*out = new(NodeSwapSpec)
+		if err := Convert_kops_NodeSwapSpec_To_v1alpha2_NodeSwapSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Swap = nil
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = make([]HugePagesSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_HugePagesSpec_To_v1alpha2_HugePagesSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HugePages = nil
+	}
+	if in.CPUManager != nil {
+		in, out := &in.CPUManager, &out.CPUManager
+		*out = new(CPUManagerSpec)
+		if err := Convert_kops_CPUManagerSpec_To_v1alpha2_CPUManagerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CPUManager = nil
+	}
+	return nil
+}
+
+// Convert_kops_NodeTuningSpec_To_v1alpha2_NodeTuningSpec is an autogenerated conversion function.
+func Convert_kops_NodeTuningSpec_To_v1alpha2_NodeTuningSpec(in *kops.NodeTuningSpec, out *NodeTuningSpec, s conversion.Scope) error {
+	return autoConvert_kops_NodeTuningSpec_To_v1alpha2_NodeTuningSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_NvidiaGPUConfig_To_kops_NvidiaGPUConfig(in *NvidiaGPUConfig, out *kops.NvidiaGPUConfig, s conversion.Scope) error {
 	out.DriverPackage = in.DriverPackage
 	out.Enabled = in.Enabled
@@ -7280,6 +8254,50 @@ func Convert_kops_ServiceAccountIssuerDiscoveryConfig_To_v1alpha2_ServiceAccount
 	return autoConvert_kops_ServiceAccountIssuerDiscoveryConfig_To_v1alpha2_ServiceAccountIssuerDiscoveryConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_SignaturePolicySpec_To_kops_SignaturePolicySpec(in *SignaturePolicySpec, out *kops.SignaturePolicySpec, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.PublicKeys = in.PublicKeys
+	if in.KeylessIdentities != nil {
+		in, out := &in.KeylessIdentities, &out.KeylessIdentities
+		*out = make([]kops.KeylessIdentitySpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.KeylessIdentities = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_SignaturePolicySpec_To_kops_SignaturePolicySpec is an autogenerated conversion function.
+func Convert_v1alpha2_SignaturePolicySpec_To_kops_SignaturePolicySpec(in *SignaturePolicySpec, out *kops.SignaturePolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_SignaturePolicySpec_To_kops_SignaturePolicySpec(in, out, s)
+}
+
+func autoConvert_kops_SignaturePolicySpec_To_v1alpha2_SignaturePolicySpec(in *kops.SignaturePolicySpec, out *SignaturePolicySpec, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.PublicKeys = in.PublicKeys
+	if in.KeylessIdentities != nil {
+		in, out := &in.KeylessIdentities, &out.KeylessIdentities
+		*out = make([]KeylessIdentitySpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_KeylessIdentitySpec_To_v1alpha2_KeylessIdentitySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.KeylessIdentities = nil
+	}
+	return nil
+}
+
+// Convert_kops_SignaturePolicySpec_To_v1alpha2_SignaturePolicySpec is an autogenerated conversion function.
+func Convert_kops_SignaturePolicySpec_To_v1alpha2_SignaturePolicySpec(in *kops.SignaturePolicySpec, out *SignaturePolicySpec, s conversion.Scope) error {
+	return autoConvert_kops_SignaturePolicySpec_To_v1alpha2_SignaturePolicySpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_SnapshotControllerConfig_To_kops_SnapshotControllerConfig(in *SnapshotControllerConfig, out *kops.SnapshotControllerConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.InstallDefaultClass = in.InstallDefaultClass
@@ -7302,6 +8320,48 @@ func Convert_kops_SnapshotControllerConfig_To_v1alpha2_SnapshotControllerConfig(
 	return autoConvert_kops_SnapshotControllerConfig_To_v1alpha2_SnapshotControllerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(in *SystemdOverrideSpec, out *kops.SystemdOverrideSpec, s conversion.Scope) error {
+	out.Unit = in.Unit
+	out.Name = in.Name
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]kops.InstanceGroupRole, len(*in))
+		for i := range *in {
+			(*out)[i] = kops.InstanceGroupRole((*in)[i])
+		}
+	} else {
+		out.Roles = nil
+	}
+	out.Manifest = in.Manifest
+	return nil
+}
+
+// Convert_v1alpha2_SystemdOverrideSpec_To_kops_SystemdOverrideSpec is an autogenerated conversion function.
+func Convert_v1alpha2_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(in *SystemdOverrideSpec, out *kops.SystemdOverrideSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(in, out, s)
+}
+
+func autoConvert_kops_SystemdOverrideSpec_To_v1alpha2_SystemdOverrideSpec(in *kops.SystemdOverrideSpec, out *SystemdOverrideSpec, s conversion.Scope) error {
+	out.Unit = in.Unit
+	out.Name = in.Name
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]InstanceGroupRole, len(*in))
+		for i := range *in {
+			(*out)[i] = InstanceGroupRole((*in)[i])
+		}
+	} else {
+		out.Roles = nil
+	}
+	out.Manifest = in.Manifest
+	return nil
+}
+
+// Convert_kops_SystemdOverrideSpec_To_v1alpha2_SystemdOverrideSpec is an autogenerated conversion function.
+func Convert_kops_SystemdOverrideSpec_To_v1alpha2_SystemdOverrideSpec(in *kops.SystemdOverrideSpec, out *SystemdOverrideSpec, s conversion.Scope) error {
+	return autoConvert_kops_SystemdOverrideSpec_To_v1alpha2_SystemdOverrideSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_TargetSpec_To_kops_TargetSpec(in *TargetSpec, out *kops.TargetSpec, s conversion.Scope) error {
 	if in.Terraform != nil {
 		in, out := &in.Terraform, &out.Terraform
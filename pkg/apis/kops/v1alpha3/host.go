@@ -34,6 +34,29 @@ type Host struct {
 type HostSpec struct {
 	PublicKey     string `json:"publicKey,omitempty"`
 	InstanceGroup string `json:"instanceGroup,omitempty"`
+
+	// TPM holds the TPM identity recorded when the host was enrolled.
+	// When set, the host must bootstrap using TPM attestation; PublicKey is not trusted.
+	TPM *HostTPMSpec `json:"tpm,omitempty"`
+}
+
+// HostTPMSpec describes the TPM identity of a host, established by credential activation during enrollment.
+type HostTPMSpec struct {
+	// EndorsementKey is the PEM encoded public endorsement key of the host's TPM.
+	EndorsementKey string `json:"endorsementKey,omitempty"`
+	// AttestationKey is the base64 encoded TPMT_PUBLIC of the attestation key,
+	// which was proven to be resident in the same TPM as the endorsement key.
+	AttestationKey string `json:"attestationKey,omitempty"`
+	// PCRs are the SHA-256 PCR values that quotes from the host must match.
+	PCRs []HostPCRValue `json:"pcrs,omitempty"`
+}
+
+// HostPCRValue is the expected value of a single PCR.
+type HostPCRValue struct {
+	// Index is the PCR index.
+	Index int `json:"index"`
+	// Value is the hex encoded SHA-256 PCR value.
+	Value string `json:"value"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -104,6 +104,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AddonPatchSpec)(nil), (*kops.AddonPatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AddonPatchSpec_To_kops_AddonPatchSpec(a.(*AddonPatchSpec), b.(*kops.AddonPatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonPatchSpec)(nil), (*AddonPatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonPatchSpec_To_v1alpha3_AddonPatchSpec(a.(*kops.AddonPatchSpec), b.(*AddonPatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AddonPatchTarget)(nil), (*kops.AddonPatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AddonPatchTarget_To_kops_AddonPatchTarget(a.(*AddonPatchTarget), b.(*kops.AddonPatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonPatchTarget)(nil), (*AddonPatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonPatchTarget_To_v1alpha3_AddonPatchTarget(a.(*kops.AddonPatchTarget), b.(*AddonPatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AddonVersionSpec)(nil), (*kops.AddonVersionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AddonVersionSpec_To_kops_AddonVersionSpec(a.(*AddonVersionSpec), b.(*kops.AddonVersionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AddonVersionSpec)(nil), (*AddonVersionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AddonVersionSpec_To_v1alpha3_AddonVersionSpec(a.(*kops.AddonVersionSpec), b.(*AddonVersionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AddonSpec)(nil), (*kops.AddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AddonSpec_To_kops_AddonSpec(a.(*AddonSpec), b.(*kops.AddonSpec), scope)
 	}); err != nil {
@@ -134,6 +164,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AssetVerificationSpec)(nil), (*kops.AssetVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(a.(*AssetVerificationSpec), b.(*kops.AssetVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AssetVerificationSpec)(nil), (*AssetVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(a.(*kops.AssetVerificationSpec), b.(*AssetVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AssetsSpec)(nil), (*kops.AssetsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AssetsSpec_To_kops_AssetsSpec(a.(*AssetsSpec), b.(*kops.AssetsSpec), scope)
 	}); err != nil {
@@ -194,6 +234,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BootstrapAdmissionSpec)(nil), (*kops.BootstrapAdmissionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(a.(*BootstrapAdmissionSpec), b.(*kops.BootstrapAdmissionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.BootstrapAdmissionSpec)(nil), (*BootstrapAdmissionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_BootstrapAdmissionSpec_To_v1alpha3_BootstrapAdmissionSpec(a.(*kops.BootstrapAdmissionSpec), b.(*BootstrapAdmissionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CNINetworkingSpec)(nil), (*kops.CNINetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CNINetworkingSpec_To_kops_CNINetworkingSpec(a.(*CNINetworkingSpec), b.(*kops.CNINetworkingSpec), scope)
 	}); err != nil {
@@ -204,6 +254,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CPUManagerSpec)(nil), (*kops.CPUManagerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CPUManagerSpec_To_kops_CPUManagerSpec(a.(*CPUManagerSpec), b.(*kops.CPUManagerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CPUManagerSpec)(nil), (*CPUManagerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CPUManagerSpec_To_v1alpha3_CPUManagerSpec(a.(*kops.CPUManagerSpec), b.(*CPUManagerSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CalicoNetworkingSpec)(nil), (*kops.CalicoNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CalicoNetworkingSpec_To_kops_CalicoNetworkingSpec(a.(*CalicoNetworkingSpec), b.(*kops.CalicoNetworkingSpec), scope)
 	}); err != nil {
@@ -354,6 +414,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryConfig)(nil), (*kops.ContainerdRegistryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(a.(*ContainerdRegistryConfig), b.(*kops.ContainerdRegistryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryConfig)(nil), (*ContainerdRegistryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(a.(*kops.ContainerdRegistryConfig), b.(*ContainerdRegistryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryMirror)(nil), (*kops.ContainerdRegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(a.(*ContainerdRegistryMirror), b.(*kops.ContainerdRegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryMirror)(nil), (*ContainerdRegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(a.(*kops.ContainerdRegistryMirror), b.(*ContainerdRegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DCGMExporterConfig)(nil), (*kops.DCGMExporterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DCGMExporterConfig_To_kops_DCGMExporterConfig(a.(*DCGMExporterConfig), b.(*kops.DCGMExporterConfig), scope)
 	}); err != nil {
@@ -584,6 +664,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HardeningSpec)(nil), (*kops.HardeningSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_HardeningSpec_To_kops_HardeningSpec(a.(*HardeningSpec), b.(*kops.HardeningSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.HardeningSpec)(nil), (*HardeningSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_HardeningSpec_To_v1alpha3_HardeningSpec(a.(*kops.HardeningSpec), b.(*HardeningSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HelmAddonSpec)(nil), (*kops.HelmAddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_HelmAddonSpec_To_kops_HelmAddonSpec(a.(*HelmAddonSpec), b.(*kops.HelmAddonSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.HelmAddonSpec)(nil), (*HelmAddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_HelmAddonSpec_To_v1alpha3_HelmAddonSpec(a.(*kops.HelmAddonSpec), b.(*HelmAddonSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HetznerSpec)(nil), (*kops.HetznerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_HetznerSpec_To_kops_HetznerSpec(a.(*HetznerSpec), b.(*kops.HetznerSpec), scope)
 	}); err != nil {
@@ -614,6 +714,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HugePagesSpec)(nil), (*kops.HugePagesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_HugePagesSpec_To_kops_HugePagesSpec(a.(*HugePagesSpec), b.(*kops.HugePagesSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.HugePagesSpec)(nil), (*HugePagesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_HugePagesSpec_To_v1alpha3_HugePagesSpec(a.(*kops.HugePagesSpec), b.(*HugePagesSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IAMProfileSpec)(nil), (*kops.IAMProfileSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_IAMProfileSpec_To_kops_IAMProfileSpec(a.(*IAMProfileSpec), b.(*kops.IAMProfileSpec), scope)
 	}); err != nil {
@@ -704,6 +814,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KeylessIdentitySpec)(nil), (*kops.KeylessIdentitySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(a.(*KeylessIdentitySpec), b.(*kops.KeylessIdentitySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KeylessIdentitySpec)(nil), (*KeylessIdentitySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KeylessIdentitySpec_To_v1alpha3_KeylessIdentitySpec(a.(*kops.KeylessIdentitySpec), b.(*KeylessIdentitySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Keyset)(nil), (*kops.Keyset)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Keyset_To_kops_Keyset(a.(*Keyset), b.(*kops.Keyset), scope)
 	}); err != nil {
@@ -764,6 +884,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KopsControllerConfig)(nil), (*kops.KopsControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_KopsControllerConfig_To_kops_KopsControllerConfig(a.(*KopsControllerConfig), b.(*kops.KopsControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KopsControllerConfig)(nil), (*KopsControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KopsControllerConfig_To_v1alpha3_KopsControllerConfig(a.(*kops.KopsControllerConfig), b.(*KopsControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KubeAPIServerConfig)(nil), (*kops.KubeAPIServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(a.(*KubeAPIServerConfig), b.(*kops.KubeAPIServerConfig), scope)
 	}); err != nil {
@@ -844,6 +974,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KustomizeAddonSpec)(nil), (*kops.KustomizeAddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(a.(*KustomizeAddonSpec), b.(*kops.KustomizeAddonSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KustomizeAddonSpec)(nil), (*KustomizeAddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KustomizeAddonSpec_To_v1alpha3_KustomizeAddonSpec(a.(*kops.KustomizeAddonSpec), b.(*KustomizeAddonSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LeaderElectionConfiguration)(nil), (*kops.LeaderElectionConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_LeaderElectionConfiguration_To_kops_LeaderElectionConfiguration(a.(*LeaderElectionConfiguration), b.(*kops.LeaderElectionConfiguration), scope)
 	}); err != nil {
@@ -974,6 +1114,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NodeSwapSpec)(nil), (*kops.NodeSwapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NodeSwapSpec_To_kops_NodeSwapSpec(a.(*NodeSwapSpec), b.(*kops.NodeSwapSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NodeSwapSpec)(nil), (*NodeSwapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NodeSwapSpec_To_v1alpha3_NodeSwapSpec(a.(*kops.NodeSwapSpec), b.(*NodeSwapSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NodeTerminationHandlerSpec)(nil), (*kops.NodeTerminationHandlerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_NodeTerminationHandlerSpec_To_kops_NodeTerminationHandlerSpec(a.(*NodeTerminationHandlerSpec), b.(*kops.NodeTerminationHandlerSpec), scope)
 	}); err != nil {
@@ -984,6 +1134,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NodeTuningSpec)(nil), (*kops.NodeTuningSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NodeTuningSpec_To_kops_NodeTuningSpec(a.(*NodeTuningSpec), b.(*kops.NodeTuningSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NodeTuningSpec)(nil), (*NodeTuningSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NodeTuningSpec_To_v1alpha3_NodeTuningSpec(a.(*kops.NodeTuningSpec), b.(*NodeTuningSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NvidiaGPUConfig)(nil), (*kops.NvidiaGPUConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_NvidiaGPUConfig_To_kops_NvidiaGPUConfig(a.(*NvidiaGPUConfig), b.(*kops.NvidiaGPUConfig), scope)
 	}); err != nil {
@@ -1204,6 +1364,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SignaturePolicySpec)(nil), (*kops.SignaturePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SignaturePolicySpec_To_kops_SignaturePolicySpec(a.(*SignaturePolicySpec), b.(*kops.SignaturePolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SignaturePolicySpec)(nil), (*SignaturePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SignaturePolicySpec_To_v1alpha3_SignaturePolicySpec(a.(*kops.SignaturePolicySpec), b.(*SignaturePolicySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SnapshotControllerConfig)(nil), (*kops.SnapshotControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_SnapshotControllerConfig_To_kops_SnapshotControllerConfig(a.(*SnapshotControllerConfig), b.(*kops.SnapshotControllerConfig), scope)
 	}); err != nil {
@@ -1214,6 +1384,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SystemdOverrideSpec)(nil), (*kops.SystemdOverrideSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(a.(*SystemdOverrideSpec), b.(*kops.SystemdOverrideSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SystemdOverrideSpec)(nil), (*SystemdOverrideSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SystemdOverrideSpec_To_v1alpha3_SystemdOverrideSpec(a.(*kops.SystemdOverrideSpec), b.(*SystemdOverrideSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TargetSpec)(nil), (*kops.TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_TargetSpec_To_kops_TargetSpec(a.(*TargetSpec), b.(*kops.TargetSpec), scope)
 	}); err != nil {
@@ -1623,8 +1803,104 @@ func Convert_kops_AccessLogSpec_To_v1alpha3_AccessLogSpec(in *kops.AccessLogSpec
 	return autoConvert_kops_AccessLogSpec_To_v1alpha3_AccessLogSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AddonPatchSpec_To_kops_AddonPatchSpec(in *AddonPatchSpec, out *kops.AddonPatchSpec, s conversion.Scope) error {
+	out.Addon = in.Addon
+	if err := Convert_v1alpha3_AddonPatchTarget_To_kops_AddonPatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Type = kops.AddonPatchType(in.Type)
+	out.Patch = in.Patch
+	return nil
+}
+
+// Convert_v1alpha3_AddonPatchSpec_To_kops_AddonPatchSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AddonPatchSpec_To_kops_AddonPatchSpec(in *AddonPatchSpec, out *kops.AddonPatchSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AddonPatchSpec_To_kops_AddonPatchSpec(in, out, s)
+}
+
+func autoConvert_kops_AddonPatchSpec_To_v1alpha3_AddonPatchSpec(in *kops.AddonPatchSpec, out *AddonPatchSpec, s conversion.Scope) error {
+	out.Addon = in.Addon
+	if err := Convert_kops_AddonPatchTarget_To_v1alpha3_AddonPatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Type = AddonPatchType(in.Type)
+	out.Patch = in.Patch
+	return nil
+}
+
+// Convert_kops_AddonPatchSpec_To_v1alpha3_AddonPatchSpec is an autogenerated conversion function.
+func Convert_kops_AddonPatchSpec_To_v1alpha3_AddonPatchSpec(in *kops.AddonPatchSpec, out *AddonPatchSpec, s conversion.Scope) error {
+	return autoConvert_kops_AddonPatchSpec_To_v1alpha3_AddonPatchSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_AddonPatchTarget_To_kops_AddonPatchTarget(in *AddonPatchTarget, out *kops.AddonPatchTarget, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha3_AddonPatchTarget_To_kops_AddonPatchTarget is an autogenerated conversion function.
+func Convert_v1alpha3_AddonPatchTarget_To_kops_AddonPatchTarget(in *AddonPatchTarget, out *kops.AddonPatchTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AddonPatchTarget_To_kops_AddonPatchTarget(in, out, s)
+}
+
+func autoConvert_kops_AddonPatchTarget_To_v1alpha3_AddonPatchTarget(in *kops.AddonPatchTarget, out *AddonPatchTarget, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_AddonPatchTarget_To_v1alpha3_AddonPatchTarget is an autogenerated conversion function.
+func Convert_kops_AddonPatchTarget_To_v1alpha3_AddonPatchTarget(in *kops.AddonPatchTarget, out *AddonPatchTarget, s conversion.Scope) error {
+	return autoConvert_kops_AddonPatchTarget_To_v1alpha3_AddonPatchTarget(in, out, s)
+}
+
+func autoConvert_v1alpha3_AddonVersionSpec_To_kops_AddonVersionSpec(in *AddonVersionSpec, out *kops.AddonVersionSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_v1alpha3_AddonVersionSpec_To_kops_AddonVersionSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AddonVersionSpec_To_kops_AddonVersionSpec(in *AddonVersionSpec, out *kops.AddonVersionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AddonVersionSpec_To_kops_AddonVersionSpec(in, out, s)
+}
+
+func autoConvert_kops_AddonVersionSpec_To_v1alpha3_AddonVersionSpec(in *kops.AddonVersionSpec, out *AddonVersionSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_kops_AddonVersionSpec_To_v1alpha3_AddonVersionSpec is an autogenerated conversion function.
+func Convert_kops_AddonVersionSpec_To_v1alpha3_AddonVersionSpec(in *kops.AddonVersionSpec, out *AddonVersionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AddonVersionSpec_To_v1alpha3_AddonVersionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AddonSpec_To_kops_AddonSpec(in *AddonSpec, out *kops.AddonSpec, s conversion.Scope) error {
 	out.Manifest = in.Manifest
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(kops.HelmAddonSpec)
+		if err := Convert_v1alpha3_HelmAddonSpec_To_kops_HelmAddonSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Helm = nil
+	}
+	if in.Kustomize != nil {
+		in, out := &in.Kustomize, &out.Kustomize
+		*out = new(kops.KustomizeAddonSpec)
+		if err := Convert_v1alpha3_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Kustomize = nil
+	}
 	return nil
 }
 
@@ -1635,6 +1911,24 @@ func Convert_v1alpha3_AddonSpec_To_kops_AddonSpec(in *AddonSpec, out *kops.Addon
 
 func autoConvert_kops_AddonSpec_To_v1alpha3_AddonSpec(in *kops.AddonSpec, out *AddonSpec, s conversion.Scope) error {
 	out.Manifest = in.Manifest
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmAddonSpec)
+		if err := Convert_kops_HelmAddonSpec_To_v1alpha3_HelmAddonSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Helm = nil
+	}
+	if in.Kustomize != nil {
+		in, out := &in.Kustomize, &out.Kustomize
+		*out = new(KustomizeAddonSpec)
+		if err := Convert_kops_KustomizeAddonSpec_To_v1alpha3_KustomizeAddonSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Kustomize = nil
+	}
 	return nil
 }
 
@@ -1707,10 +2001,85 @@ func Convert_kops_AmazonVPCNetworkingSpec_To_v1alpha3_AmazonVPCNetworkingSpec(in
 	return autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha3_AmazonVPCNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(in *AssetVerificationSpec, out *kops.AssetVerificationSpec, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]kops.SignaturePolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_SignaturePolicySpec_To_kops_SignaturePolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]kops.SignaturePolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_SignaturePolicySpec_To_kops_SignaturePolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Files = nil
+	}
+	out.FulcioRoots = in.FulcioRoots
+	out.RekorPublicKeys = in.RekorPublicKeys
+	return nil
+}
+
+// Convert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(in *AssetVerificationSpec, out *kops.AssetVerificationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(in, out, s)
+}
+
+func autoConvert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(in *kops.AssetVerificationSpec, out *AssetVerificationSpec, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]SignaturePolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SignaturePolicySpec_To_v1alpha3_SignaturePolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]SignaturePolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SignaturePolicySpec_To_v1alpha3_SignaturePolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Files = nil
+	}
+	out.FulcioRoots = in.FulcioRoots
+	out.RekorPublicKeys = in.RekorPublicKeys
+	return nil
+}
+
+// Convert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec is an autogenerated conversion function.
+func Convert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(in *kops.AssetVerificationSpec, out *AssetVerificationSpec, s conversion.Scope) error {
+	return autoConvert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AssetsSpec_To_kops_AssetsSpec(in *AssetsSpec, out *kops.AssetsSpec, s conversion.Scope) error {
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(kops.AssetVerificationSpec)
+		if err := Convert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -1723,6 +2092,15 @@ func autoConvert_kops_AssetsSpec_To_v1alpha3_AssetsSpec(in *kops.AssetsSpec, out
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(AssetVerificationSpec)
+		if err := Convert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -1945,6 +2323,30 @@ func Convert_kops_BastionSpec_To_v1alpha3_BastionSpec(in *kops.BastionSpec, out
 	return autoConvert_kops_BastionSpec_To_v1alpha3_BastionSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(in *BootstrapAdmissionSpec, out *kops.BootstrapAdmissionSpec, s conversion.Scope) error {
+	out.MaxNodesPerInstanceGroup = in.MaxNodesPerInstanceGroup
+	out.AllowedInstanceTypes = in.AllowedInstanceTypes
+	out.DeniedInstanceIDs = in.DeniedInstanceIDs
+	return nil
+}
+
+// Convert_v1alpha3_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec is an autogenerated conversion function.
+func Convert_v1alpha3_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(in *BootstrapAdmissionSpec, out *kops.BootstrapAdmissionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(in, out, s)
+}
+
+func autoConvert_kops_BootstrapAdmissionSpec_To_v1alpha3_BootstrapAdmissionSpec(in *kops.BootstrapAdmissionSpec, out *BootstrapAdmissionSpec, s conversion.Scope) error {
+	out.MaxNodesPerInstanceGroup = in.MaxNodesPerInstanceGroup
+	out.AllowedInstanceTypes = in.AllowedInstanceTypes
+	out.DeniedInstanceIDs = in.DeniedInstanceIDs
+	return nil
+}
+
+// Convert_kops_BootstrapAdmissionSpec_To_v1alpha3_BootstrapAdmissionSpec is an autogenerated conversion function.
+func Convert_kops_BootstrapAdmissionSpec_To_v1alpha3_BootstrapAdmissionSpec(in *kops.BootstrapAdmissionSpec, out *BootstrapAdmissionSpec, s conversion.Scope) error {
+	return autoConvert_kops_BootstrapAdmissionSpec_To_v1alpha3_BootstrapAdmissionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_CNINetworkingSpec_To_kops_CNINetworkingSpec(in *CNINetworkingSpec, out *kops.CNINetworkingSpec, s conversion.Scope) error {
 	out.UsesSecondaryIP = in.UsesSecondaryIP
 	return nil
@@ -1965,6 +2367,30 @@ func Convert_kops_CNINetworkingSpec_To_v1alpha3_CNINetworkingSpec(in *kops.CNINe
 	return autoConvert_kops_CNINetworkingSpec_To_v1alpha3_CNINetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_CPUManagerSpec_To_kops_CPUManagerSpec(in *CPUManagerSpec, out *kops.CPUManagerSpec, s conversion.Scope) error {
+	out.Policy = in.Policy
+	out.ReservedCPUs = in.ReservedCPUs
+	out.TopologyManagerPolicy = in.TopologyManagerPolicy
+	return nil
+}
+
+// Convert_v1alpha3_CPUManagerSpec_To_kops_CPUManagerSpec is an autogenerated conversion function.
+func Convert_v1alpha3_CPUManagerSpec_To_kops_CPUManagerSpec(in *CPUManagerSpec, out *kops.CPUManagerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CPUManagerSpec_To_kops_CPUManagerSpec(in, out, s)
+}
+
+func autoConvert_kops_CPUManagerSpec_To_v1alpha3_CPUManagerSpec(in *kops.CPUManagerSpec, out *CPUManagerSpec, s conversion.Scope) error {
+	out.Policy = in.Policy
+	out.ReservedCPUs = in.ReservedCPUs
+	out.TopologyManagerPolicy = in.TopologyManagerPolicy
+	return nil
+}
+
+// Convert_kops_CPUManagerSpec_To_v1alpha3_CPUManagerSpec is an autogenerated conversion function.
+func Convert_kops_CPUManagerSpec_To_v1alpha3_CPUManagerSpec(in *kops.CPUManagerSpec, out *CPUManagerSpec, s conversion.Scope) error {
+	return autoConvert_kops_CPUManagerSpec_To_v1alpha3_CPUManagerSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_CalicoNetworkingSpec_To_kops_CalicoNetworkingSpec(in *CalicoNetworkingSpec, out *kops.CalicoNetworkingSpec, s conversion.Scope) error {
 	out.Registry = in.Registry
 	out.Version = in.Version
@@ -2670,6 +3096,28 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Addons = nil
 	}
+	if in.AddonPatches != nil {
+		in, out := &in.AddonPatches, &out.AddonPatches
+		*out = make([]kops.AddonPatchSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AddonPatchSpec_To_kops_AddonPatchSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonPatches = nil
+	}
+	if in.AddonVersions != nil {
+		in, out := &in.AddonVersions, &out.AddonVersions
+		*out = make([]kops.AddonVersionSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AddonVersionSpec_To_kops_AddonVersionSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonVersions = nil
+	}
 	if err := Convert_v1alpha3_ConfigStoreSpec_To_kops_ConfigStoreSpec(&in.ConfigStore, &out.ConfigStore, s); err != nil {
 		return err
 	}
@@ -2688,6 +3136,7 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	out.ContainerRuntime = in.ContainerRuntime
 	out.KubernetesVersion = in.KubernetesVersion
 	out.DNSZone = in.DNSZone
+	out.DNSProvider = in.DNSProvider
 	if in.DNSControllerGossipConfig != nil {
 		in, out := &in.DNSControllerGossipConfig, &out.DNSControllerGossipConfig
 		*out = new(kops.DNSControllerGossipConfig)
@@ -2908,6 +3357,17 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Hooks = nil
 	}
+	if in.SystemdOverrides != nil {
+		in, out := &in.SystemdOverrides, &out.SystemdOverrides
+		*out = make([]kops.SystemdOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SystemdOverrides = nil
+	}
 	if in.Assets != nil {
 		in, out := &in.Assets, &out.Assets
 		*out = new(kops.AssetsSpec)
@@ -2938,6 +3398,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	}
 	out.UseHostCertificates = in.UseHostCertificates
 	out.SysctlParameters = in.SysctlParameters
+	if in.Hardening != nil {
+		in, out := &in.Hardening, &out.Hardening
+		*out = new(kops.HardeningSpec)
+		if err := Convert_v1alpha3_HardeningSpec_To_kops_HardeningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hardening = nil
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(kops.RollingUpdate)
@@ -2983,6 +3452,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Karpenter = nil
 	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(kops.KopsControllerConfig)
+		if err := Convert_v1alpha3_KopsControllerConfig_To_kops_KopsControllerConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
 	return nil
 }
 
@@ -3004,6 +3482,28 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Addons = nil
 	}
+	if in.AddonPatches != nil {
+		in, out := &in.AddonPatches, &out.AddonPatches
+		*out = make([]AddonPatchSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AddonPatchSpec_To_v1alpha3_AddonPatchSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonPatches = nil
+	}
+	if in.AddonVersions != nil {
+		in, out := &in.AddonVersions, &out.AddonVersions
+		*out = make([]AddonVersionSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AddonVersionSpec_To_v1alpha3_AddonVersionSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AddonVersions = nil
+	}
 	if err := Convert_kops_ConfigStoreSpec_To_v1alpha3_ConfigStoreSpec(&in.ConfigStore, &out.ConfigStore, s); err != nil {
 		return err
 	}
@@ -3022,6 +3522,7 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	out.ContainerRuntime = in.ContainerRuntime
 	out.KubernetesVersion = in.KubernetesVersion
 	out.DNSZone = in.DNSZone
+	out.DNSProvider = in.DNSProvider
 	if in.DNSControllerGossipConfig != nil {
 		in, out := &in.DNSControllerGossipConfig, &out.DNSControllerGossipConfig
 		*out = new(DNSControllerGossipConfig)
@@ -3242,6 +3743,17 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Hooks = nil
 	}
+	if in.SystemdOverrides != nil {
+		in, out := &in.SystemdOverrides, &out.SystemdOverrides
+		*out = make([]SystemdOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SystemdOverrideSpec_To_v1alpha3_SystemdOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SystemdOverrides = nil
+	}
 	if in.Assets != nil {
 		in, out := &in.Assets, &out.Assets
 		*out = new(AssetsSpec)
@@ -3272,6 +3784,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	}
 	out.UseHostCertificates = in.UseHostCertificates
 	out.SysctlParameters = in.SysctlParameters
+	if in.Hardening != nil {
+		in, out := &in.Hardening, &out.Hardening
+		*out = new(HardeningSpec)
+		if err := Convert_kops_HardeningSpec_To_v1alpha3_HardeningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hardening = nil
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(RollingUpdate)
@@ -3317,6 +3838,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Karpenter = nil
 	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(KopsControllerConfig)
+		if err := Convert_kops_KopsControllerConfig_To_v1alpha3_KopsControllerConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
 	return nil
 }
 
@@ -3422,6 +3952,19 @@ func autoConvert_v1alpha3_ContainerdConfig_To_kops_ContainerdConfig(in *Containe
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]kops.ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(kops.ContainerdRegistryConfig)
+			if err := Convert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Registries = nil
+	}
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3477,6 +4020,19 @@ func autoConvert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in *kops.Con
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(ContainerdRegistryConfig)
+			if err := Convert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Registries = nil
+	}
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3517,6 +4073,82 @@ func Convert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in *kops.Contain
 	return autoConvert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in *ContainerdRegistryConfig, out *kops.ContainerdRegistryConfig, s conversion.Scope) error {
+	out.Server = in.Server
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]kops.ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Mirrors = nil
+	}
+	out.SkipVerify = in.SkipVerify
+	out.CABundle = in.CABundle
+	out.AuthFromDockerConfig = in.AuthFromDockerConfig
+	return nil
+}
+
+// Convert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig is an autogenerated conversion function.
+func Convert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in *ContainerdRegistryConfig, out *kops.ContainerdRegistryConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(in *kops.ContainerdRegistryConfig, out *ContainerdRegistryConfig, s conversion.Scope) error {
+	out.Server = in.Server
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Mirrors = nil
+	}
+	out.SkipVerify = in.SkipVerify
+	out.CABundle = in.CABundle
+	out.AuthFromDockerConfig = in.AuthFromDockerConfig
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(in *kops.ContainerdRegistryConfig, out *ContainerdRegistryConfig, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(in, out, s)
+}
+
+func autoConvert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in *ContainerdRegistryMirror, out *kops.ContainerdRegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Capabilities = in.Capabilities
+	out.SkipVerify = in.SkipVerify
+	out.CABundle = in.CABundle
+	out.OverridePath = in.OverridePath
+	return nil
+}
+
+// Convert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror is an autogenerated conversion function.
+func Convert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in *ContainerdRegistryMirror, out *kops.ContainerdRegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(in *kops.ContainerdRegistryMirror, out *ContainerdRegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Capabilities = in.Capabilities
+	out.SkipVerify = in.SkipVerify
+	out.CABundle = in.CABundle
+	out.OverridePath = in.OverridePath
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(in *kops.ContainerdRegistryMirror, out *ContainerdRegistryMirror, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(in, out, s)
+}
+
 func autoConvert_v1alpha3_DCGMExporterConfig_To_kops_DCGMExporterConfig(in *DCGMExporterConfig, out *kops.DCGMExporterConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -3559,6 +4191,8 @@ func autoConvert_v1alpha3_DNSControllerGossipConfig_To_kops_DNSControllerGossipC
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	if in.Secondary != nil {
 		in, out := &in.Secondary, &out.Secondary
 		*out = new(kops.DNSControllerGossipConfigSecondary)
@@ -3581,6 +4215,8 @@ func autoConvert_kops_DNSControllerGossipConfig_To_v1alpha3_DNSControllerGossipC
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	if in.Secondary != nil {
 		in, out := &in.Secondary, &out.Secondary
 		*out = new(DNSControllerGossipConfigSecondary)
@@ -3603,6 +4239,8 @@ func autoConvert_v1alpha3_DNSControllerGossipConfigSecondary_To_kops_DNSControll
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	out.Seed = in.Seed
 	return nil
 }
@@ -3616,6 +4254,8 @@ func autoConvert_kops_DNSControllerGossipConfigSecondary_To_v1alpha3_DNSControll
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	out.Seed = in.Seed
 	return nil
 }
@@ -4053,8 +4693,10 @@ func Convert_kops_ExecContainerAction_To_v1alpha3_ExecContainerAction(in *kops.E
 
 func autoConvert_v1alpha3_ExternalDNSConfig_To_kops_ExternalDNSConfig(in *ExternalDNSConfig, out *kops.ExternalDNSConfig, s conversion.Scope) error {
 	out.WatchIngress = in.WatchIngress
+	out.WatchGateway = in.WatchGateway
 	out.WatchNamespace = in.WatchNamespace
 	out.Provider = kops.ExternalDNSProvider(in.Provider)
+	out.OwnerID = in.OwnerID
 	return nil
 }
 
@@ -4065,8 +4707,10 @@ func Convert_v1alpha3_ExternalDNSConfig_To_kops_ExternalDNSConfig(in *ExternalDN
 
 func autoConvert_kops_ExternalDNSConfig_To_v1alpha3_ExternalDNSConfig(in *kops.ExternalDNSConfig, out *ExternalDNSConfig, s conversion.Scope) error {
 	out.WatchIngress = in.WatchIngress
+	out.WatchGateway = in.WatchGateway
 	out.WatchNamespace = in.WatchNamespace
 	out.Provider = ExternalDNSProvider(in.Provider)
+	out.OwnerID = in.OwnerID
 	return nil
 }
 
@@ -4231,6 +4875,8 @@ func autoConvert_v1alpha3_GossipConfig_To_kops_GossipConfig(in *GossipConfig, ou
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	if in.Secondary != nil {
 		in, out := &in.Secondary, &out.Secondary
 		*out = new(kops.GossipConfigSecondary)
@@ -4252,6 +4898,8 @@ func autoConvert_kops_GossipConfig_To_v1alpha3_GossipConfig(in *kops.GossipConfi
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	if in.Secondary != nil {
 		in, out := &in.Secondary, &out.Secondary
 		*out = new(GossipConfigSecondary)
@@ -4273,6 +4921,8 @@ func autoConvert_v1alpha3_GossipConfigSecondary_To_kops_GossipConfigSecondary(in
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	return nil
 }
 
@@ -4285,6 +4935,8 @@ func autoConvert_kops_GossipConfigSecondary_To_v1alpha3_GossipConfigSecondary(in
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
 	out.Secret = in.Secret
+	out.AdditionalSecrets = in.AdditionalSecrets
+	out.Sealing = in.Sealing
 	return nil
 }
 
@@ -4315,6 +4967,60 @@ func Convert_kops_HTTPProxy_To_v1alpha3_HTTPProxy(in *kops.HTTPProxy, out *HTTPP
 	return autoConvert_kops_HTTPProxy_To_v1alpha3_HTTPProxy(in, out, s)
 }
 
+func autoConvert_v1alpha3_HardeningSpec_To_kops_HardeningSpec(in *HardeningSpec, out *kops.HardeningSpec, s conversion.Scope) error {
+	out.Profile = in.Profile
+	out.ExcludedControls = in.ExcludedControls
+	return nil
+}
+
+// Convert_v1alpha3_HardeningSpec_To_kops_HardeningSpec is an autogenerated conversion function.
+func Convert_v1alpha3_HardeningSpec_To_kops_HardeningSpec(in *HardeningSpec, out *kops.HardeningSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_HardeningSpec_To_kops_HardeningSpec(in, out, s)
+}
+
+func autoConvert_kops_HardeningSpec_To_v1alpha3_HardeningSpec(in *kops.HardeningSpec, out *HardeningSpec, s conversion.Scope) error {
+	out.Profile = in.Profile
+	out.ExcludedControls = in.ExcludedControls
+	return nil
+}
+
+// Convert_kops_HardeningSpec_To_v1alpha3_HardeningSpec is an autogenerated conversion function.
+func Convert_kops_HardeningSpec_To_v1alpha3_HardeningSpec(in *kops.HardeningSpec, out *HardeningSpec, s conversion.Scope) error {
+	return autoConvert_kops_HardeningSpec_To_v1alpha3_HardeningSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_HelmAddonSpec_To_kops_HelmAddonSpec(in *HelmAddonSpec, out *kops.HelmAddonSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Chart = in.Chart
+	out.Repository = in.Repository
+	out.Version = in.Version
+	out.Values = in.Values
+	out.ValuesFile = in.ValuesFile
+	return nil
+}
+
+// Convert_v1alpha3_HelmAddonSpec_To_kops_HelmAddonSpec is an autogenerated conversion function.
+func Convert_v1alpha3_HelmAddonSpec_To_kops_HelmAddonSpec(in *HelmAddonSpec, out *kops.HelmAddonSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_HelmAddonSpec_To_kops_HelmAddonSpec(in, out, s)
+}
+
+func autoConvert_kops_HelmAddonSpec_To_v1alpha3_HelmAddonSpec(in *kops.HelmAddonSpec, out *HelmAddonSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Chart = in.Chart
+	out.Repository = in.Repository
+	out.Version = in.Version
+	out.Values = in.Values
+	out.ValuesFile = in.ValuesFile
+	return nil
+}
+
+// Convert_kops_HelmAddonSpec_To_v1alpha3_HelmAddonSpec is an autogenerated conversion function.
+func Convert_kops_HelmAddonSpec_To_v1alpha3_HelmAddonSpec(in *kops.HelmAddonSpec, out *HelmAddonSpec, s conversion.Scope) error {
+	return autoConvert_kops_HelmAddonSpec_To_v1alpha3_HelmAddonSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_HetznerSpec_To_kops_HetznerSpec(in *HetznerSpec, out *kops.HetznerSpec, s conversion.Scope) error {
 	return nil
 }
@@ -4421,6 +5127,28 @@ func Convert_kops_HubbleSpec_To_v1alpha3_HubbleSpec(in *kops.HubbleSpec, out *Hu
 	return autoConvert_kops_HubbleSpec_To_v1alpha3_HubbleSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_HugePagesSpec_To_kops_HugePagesSpec(in *HugePagesSpec, out *kops.HugePagesSpec, s conversion.Scope) error {
+	out.PageSize = in.PageSize
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_v1alpha3_HugePagesSpec_To_kops_HugePagesSpec is an autogenerated conversion function.
+func Convert_v1alpha3_HugePagesSpec_To_kops_HugePagesSpec(in *HugePagesSpec, out *kops.HugePagesSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_HugePagesSpec_To_kops_HugePagesSpec(in, out, s)
+}
+
+func autoConvert_kops_HugePagesSpec_To_v1alpha3_HugePagesSpec(in *kops.HugePagesSpec, out *HugePagesSpec, s conversion.Scope) error {
+	out.PageSize = in.PageSize
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_kops_HugePagesSpec_To_v1alpha3_HugePagesSpec is an autogenerated conversion function.
+func Convert_kops_HugePagesSpec_To_v1alpha3_HugePagesSpec(in *kops.HugePagesSpec, out *HugePagesSpec, s conversion.Scope) error {
+	return autoConvert_kops_HugePagesSpec_To_v1alpha3_HugePagesSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_IAMProfileSpec_To_kops_IAMProfileSpec(in *IAMProfileSpec, out *kops.IAMProfileSpec, s conversion.Scope) error {
 	out.Profile = in.Profile
 	return nil
@@ -4610,6 +5338,17 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.Hooks = nil
 	}
+	if in.SystemdOverrides != nil {
+		in, out := &in.SystemdOverrides, &out.SystemdOverrides
+		*out = make([]kops.SystemdOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SystemdOverrides = nil
+	}
 	out.MaxPrice = in.MaxPrice
 	out.SpotDurationInMinutes = in.SpotDurationInMinutes
 	out.CPUCredits = in.CPUCredits
@@ -4685,6 +5424,24 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	out.SecurityGroupOverride = in.SecurityGroupOverride
 	out.InstanceProtection = in.InstanceProtection
 	out.SysctlParameters = in.SysctlParameters
+	if in.Hardening != nil {
+		in, out := &in.Hardening, &out.Hardening
+		*out = new(kops.HardeningSpec)
+		if err := Convert_v1alpha3_HardeningSpec_To_kops_HardeningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hardening = nil
+	}
+	if in.NodeTuning != nil {
+		in, out := &in.NodeTuning, &out.NodeTuning
+		*out = new(kops.NodeTuningSpec)
+		if err := Convert_v1alpha3_NodeTuningSpec_To_kops_NodeTuningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeTuning = nil
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(kops.RollingUpdate)
@@ -4715,6 +5472,7 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.WarmPool = nil
 	}
+	out.ImagePrePull = in.ImagePrePull
 	if in.Containerd != nil {
 		in, out := &in.Containerd, &out.Containerd
 		*out = new(kops.ContainerdConfig)
@@ -4799,6 +5557,17 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 	} else {
 		out.Hooks = nil
 	}
+	if in.SystemdOverrides != nil {
+		in, out := &in.SystemdOverrides, &out.SystemdOverrides
+		*out = make([]SystemdOverrideSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_SystemdOverrideSpec_To_v1alpha3_SystemdOverrideSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SystemdOverrides = nil
+	}
 	out.MaxPrice = in.MaxPrice
 	out.SpotDurationInMinutes = in.SpotDurationInMinutes
 	out.CPUCredits = in.CPUCredits
@@ -4874,6 +5643,24 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 	out.SecurityGroupOverride = in.SecurityGroupOverride
 	out.InstanceProtection = in.InstanceProtection
 	out.SysctlParameters = in.SysctlParameters
+	if in.Hardening != nil {
+		in, out := &in.Hardening, &out.Hardening
+		*out = new(HardeningSpec)
+		if err := Convert_kops_HardeningSpec_To_v1alpha3_HardeningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hardening = nil
+	}
+	if in.NodeTuning != nil {
+		in, out := &in.NodeTuning, &out.NodeTuning
+		*out = new(NodeTuningSpec)
+		if err := Convert_kops_NodeTuningSpec_To_v1alpha3_NodeTuningSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeTuning = nil
+	}
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
 		*out = new(RollingUpdate)
@@ -4904,6 +5691,7 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 	} else {
 		out.WarmPool = nil
 	}
+	out.ImagePrePull = in.ImagePrePull
 	if in.Containerd != nil {
 		in, out := &in.Containerd, &out.Containerd
 		*out = new(ContainerdConfig)
@@ -5075,6 +5863,28 @@ func Convert_kops_KarpenterConfig_To_v1alpha3_KarpenterConfig(in *kops.Karpenter
 	return autoConvert_kops_KarpenterConfig_To_v1alpha3_KarpenterConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(in *KeylessIdentitySpec, out *kops.KeylessIdentitySpec, s conversion.Scope) error {
+	out.Issuer = in.Issuer
+	out.Subject = in.Subject
+	return nil
+}
+
+// Convert_v1alpha3_KeylessIdentitySpec_To_kops_KeylessIdentitySpec is an autogenerated conversion function.
+func Convert_v1alpha3_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(in *KeylessIdentitySpec, out *kops.KeylessIdentitySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(in, out, s)
+}
+
+func autoConvert_kops_KeylessIdentitySpec_To_v1alpha3_KeylessIdentitySpec(in *kops.KeylessIdentitySpec, out *KeylessIdentitySpec, s conversion.Scope) error {
+	out.Issuer = in.Issuer
+	out.Subject = in.Subject
+	return nil
+}
+
+// Convert_kops_KeylessIdentitySpec_To_v1alpha3_KeylessIdentitySpec is an autogenerated conversion function.
+func Convert_kops_KeylessIdentitySpec_To_v1alpha3_KeylessIdentitySpec(in *kops.KeylessIdentitySpec, out *KeylessIdentitySpec, s conversion.Scope) error {
+	return autoConvert_kops_KeylessIdentitySpec_To_v1alpha3_KeylessIdentitySpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_Keyset_To_kops_Keyset(in *Keyset, out *kops.Keyset, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_KeysetSpec_To_kops_KeysetSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -5257,6 +6067,42 @@ func Convert_kops_KopeioNetworkingSpec_To_v1alpha3_KopeioNetworkingSpec(in *kops
 	return autoConvert_kops_KopeioNetworkingSpec_To_v1alpha3_KopeioNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_KopsControllerConfig_To_kops_KopsControllerConfig(in *KopsControllerConfig, out *kops.KopsControllerConfig, s conversion.Scope) error {
+	if in.BootstrapAdmission != nil {
+		in, out := &in.BootstrapAdmission, &out.BootstrapAdmission
+		*out = new(kops.BootstrapAdmissionSpec)
+		if err := Convert_v1alpha3_BootstrapAdmissionSpec_To_kops_BootstrapAdmissionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BootstrapAdmission = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_KopsControllerConfig_To_kops_KopsControllerConfig is an autogenerated conversion function.
+func Convert_v1alpha3_KopsControllerConfig_To_kops_KopsControllerConfig(in *KopsControllerConfig, out *kops.KopsControllerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_KopsControllerConfig_To_kops_KopsControllerConfig(in, out, s)
+}
+
+func autoConvert_kops_KopsControllerConfig_To_v1alpha3_KopsControllerConfig(in *kops.KopsControllerConfig, out *KopsControllerConfig, s conversion.Scope) error {
+	if in.BootstrapAdmission != nil {
+		in, out := &in.BootstrapAdmission, &out.BootstrapAdmission
+		*out = new(BootstrapAdmissionSpec)
+		if err := Convert_kops_BootstrapAdmissionSpec_To_v1alpha3_BootstrapAdmissionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BootstrapAdmission = nil
+	}
+	return nil
+}
+
+// Convert_kops_KopsControllerConfig_To_v1alpha3_KopsControllerConfig is an autogenerated conversion function.
+func Convert_kops_KopsControllerConfig_To_v1alpha3_KopsControllerConfig(in *kops.KopsControllerConfig, out *KopsControllerConfig, s conversion.Scope) error {
+	return autoConvert_kops_KopsControllerConfig_To_v1alpha3_KopsControllerConfig(in, out, s)
+}
+
 func autoConvert_v1alpha3_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *KubeAPIServerConfig, out *kops.KubeAPIServerConfig, s conversion.Scope) error {
 	out.Image = in.Image
 	out.DisableBasicAuth = in.DisableBasicAuth
@@ -5933,6 +6779,7 @@ func autoConvert_v1alpha3_KubeletConfigSpec_To_kops_KubeletConfigSpec(in *Kubele
 	out.RegistryPullQPS = in.RegistryPullQPS
 	out.RegistryBurst = in.RegistryBurst
 	out.TopologyManagerPolicy = in.TopologyManagerPolicy
+	out.ReservedSystemCPUs = in.ReservedSystemCPUs
 	out.RotateCertificates = in.RotateCertificates
 	out.ProtectKernelDefaults = in.ProtectKernelDefaults
 	out.CgroupDriver = in.CgroupDriver
@@ -6037,6 +6884,7 @@ func autoConvert_kops_KubeletConfigSpec_To_v1alpha3_KubeletConfigSpec(in *kops.K
 	out.RegistryPullQPS = in.RegistryPullQPS
 	out.RegistryBurst = in.RegistryBurst
 	out.TopologyManagerPolicy = in.TopologyManagerPolicy
+	out.ReservedSystemCPUs = in.ReservedSystemCPUs
 	out.RotateCertificates = in.RotateCertificates
 	out.ProtectKernelDefaults = in.ProtectKernelDefaults
 	out.CgroupDriver = in.CgroupDriver
@@ -6095,6 +6943,30 @@ func Convert_kops_KuberouterNetworkingSpec_To_v1alpha3_KuberouterNetworkingSpec(
 	return autoConvert_kops_KuberouterNetworkingSpec_To_v1alpha3_KuberouterNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(in *KustomizeAddonSpec, out *kops.KustomizeAddonSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Path = in.Path
+	out.Overlay = in.Overlay
+	return nil
+}
+
+// Convert_v1alpha3_KustomizeAddonSpec_To_kops_KustomizeAddonSpec is an autogenerated conversion function.
+func Convert_v1alpha3_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(in *KustomizeAddonSpec, out *kops.KustomizeAddonSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_KustomizeAddonSpec_To_kops_KustomizeAddonSpec(in, out, s)
+}
+
+func autoConvert_kops_KustomizeAddonSpec_To_v1alpha3_KustomizeAddonSpec(in *kops.KustomizeAddonSpec, out *KustomizeAddonSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Path = in.Path
+	out.Overlay = in.Overlay
+	return nil
+}
+
+// Convert_kops_KustomizeAddonSpec_To_v1alpha3_KustomizeAddonSpec is an autogenerated conversion function.
+func Convert_kops_KustomizeAddonSpec_To_v1alpha3_KustomizeAddonSpec(in *kops.KustomizeAddonSpec, out *KustomizeAddonSpec, s conversion.Scope) error {
+	return autoConvert_kops_KustomizeAddonSpec_To_v1alpha3_KustomizeAddonSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_LeaderElectionConfiguration_To_kops_LeaderElectionConfiguration(in *LeaderElectionConfiguration, out *kops.LeaderElectionConfiguration, s conversion.Scope) error {
 	out.LeaderElect = in.LeaderElect
 	out.LeaderElectLeaseDuration = in.LeaderElectLeaseDuration
@@ -6795,6 +7667,32 @@ func Convert_kops_NodeProblemDetectorConfig_To_v1alpha3_NodeProblemDetectorConfi
 	return autoConvert_kops_NodeProblemDetectorConfig_To_v1alpha3_NodeProblemDetectorConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_NodeSwapSpec_To_kops_NodeSwapSpec(in *NodeSwapSpec, out *kops.NodeSwapSpec, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Device = in.Device
+	out.Swappiness = in.Swappiness
+	out.Behavior = in.Behavior
+	return nil
+}
+
+// Convert_v1alpha3_NodeSwapSpec_To_kops_NodeSwapSpec is an autogenerated conversion function.
+func Convert_v1alpha3_NodeSwapSpec_To_kops_NodeSwapSpec(in *NodeSwapSpec, out *kops.NodeSwapSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NodeSwapSpec_To_kops_NodeSwapSpec(in, out, s)
+}
+
+func autoConvert_kops_NodeSwapSpec_To_v1alpha3_NodeSwapSpec(in *kops.NodeSwapSpec, out *NodeSwapSpec, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Device = in.Device
+	out.Swappiness = in.Swappiness
+	out.Behavior = in.Behavior
+	return nil
+}
+
+// Convert_kops_NodeSwapSpec_To_v1alpha3_NodeSwapSpec is an autogenerated conversion function.
+func Convert_kops_NodeSwapSpec_To_v1alpha3_NodeSwapSpec(in *kops.NodeSwapSpec, out *NodeSwapSpec, s conversion.Scope) error {
+	return autoConvert_kops_NodeSwapSpec_To_v1alpha3_NodeSwapSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_NodeTerminationHandlerSpec_To_kops_NodeTerminationHandlerSpec(in *NodeTerminationHandlerSpec, out *kops.NodeTerminationHandlerSpec, s conversion.Scope) error {
 	out.DeleteSQSMsgIfNodeNotFound = in.DeleteSQSMsgIfNodeNotFound
 	out.Enabled = in.Enabled
@@ -6849,6 +7747,82 @@ func Convert_kops_NodeTerminationHandlerSpec_To_v1alpha3_NodeTerminationHandlerS
 	return autoConvert_kops_NodeTerminationHandlerSpec_To_v1alpha3_NodeTerminationHandlerSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_NodeTuningSpec_To_kops_NodeTuningSpec(in *NodeTuningSpec, out *kops.NodeTuningSpec, s conversion.Scope) error {
+	if in.Swap != nil {
+		in, out := &in.Swap, &out.Swap
+		*out = new(kops.NodeSwapSpec)
+		if err := Convert_v1alpha3_NodeSwapSpec_To_kops_NodeSwapSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Swap = nil
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = make([]kops.HugePagesSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_HugePagesSpec_To_kops_HugePagesSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HugePages = nil
+	}
+	if in.CPUManager != nil {
+		in, out := &in.CPUManager, &out.CPUManager
+		*out = new(kops.CPUManagerSpec)
+		if err := Convert_v1alpha3_CPUManagerSpec_To_kops_CPUManagerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CPUManager = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_NodeTuningSpec_To_kops_NodeTuningSpec is an autogenerated conversion function.
+func Convert_v1alpha3_NodeTuningSpec_To_kops_NodeTuningSpec(in *NodeTuningSpec, out *kops.NodeTuningSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NodeTuningSpec_To_kops_NodeTuningSpec(in, out, s)
+}
+
+func autoConvert_kops_NodeTuningSpec_To_v1alpha3_NodeTuningSpec(in *kops.NodeTuningSpec, out *NodeTuningSpec, s conversion.Scope) error {
+	if in.Swap != nil {
+		in, out := &in.Swap, &out.Swap
+		*out = new(NodeSwapSpec)
+		if err := Convert_kops_NodeSwapSpec_To_v1alpha3_NodeSwapSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Swap = nil
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = make([]HugePagesSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_HugePagesSpec_To_v1alpha3_HugePagesSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HugePages = nil
+	}
+	if in.CPUManager != nil {
+		in, out := &in.CPUManager, &out.CPUManager
+		*out = new(CPUManagerSpec)
+		if err := Convert_kops_CPUManagerSpec_To_v1alpha3_CPUManagerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CPUManager = nil
+	}
+	return nil
+}
+
+// Convert_kops_NodeTuningSpec_To_v1alpha3_NodeTuningSpec is an autogenerated conversion function.
+func Convert_kops_NodeTuningSpec_To_v1alpha3_NodeTuningSpec(in *kops.NodeTuningSpec, out *NodeTuningSpec, s conversion.Scope) error {
+	return autoConvert_kops_NodeTuningSpec_To_v1alpha3_NodeTuningSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_NvidiaGPUConfig_To_kops_NvidiaGPUConfig(in *NvidiaGPUConfig, out *kops.NvidiaGPUConfig, s conversion.Scope) error {
 	out.DriverPackage = in.DriverPackage
 	out.Enabled = in.Enabled
@@ -7563,6 +8537,50 @@ func Convert_kops_ServiceAccountIssuerDiscoveryConfig_To_v1alpha3_ServiceAccount
 	return autoConvert_kops_ServiceAccountIssuerDiscoveryConfig_To_v1alpha3_ServiceAccountIssuerDiscoveryConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_SignaturePolicySpec_To_kops_SignaturePolicySpec(in *SignaturePolicySpec, out *kops.SignaturePolicySpec, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.PublicKeys = in.PublicKeys
+	if in.KeylessIdentities != nil {
+		in, out := &in.KeylessIdentities, &out.KeylessIdentities
+		*out = make([]kops.KeylessIdentitySpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_KeylessIdentitySpec_To_kops_KeylessIdentitySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.KeylessIdentities = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_SignaturePolicySpec_To_kops_SignaturePolicySpec is an autogenerated conversion function.
+func Convert_v1alpha3_SignaturePolicySpec_To_kops_SignaturePolicySpec(in *SignaturePolicySpec, out *kops.SignaturePolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_SignaturePolicySpec_To_kops_SignaturePolicySpec(in, out, s)
+}
+
+func autoConvert_kops_SignaturePolicySpec_To_v1alpha3_SignaturePolicySpec(in *kops.SignaturePolicySpec, out *SignaturePolicySpec, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.PublicKeys = in.PublicKeys
+	if in.KeylessIdentities != nil {
+		in, out := &in.KeylessIdentities, &out.KeylessIdentities
+		*out = make([]KeylessIdentitySpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_KeylessIdentitySpec_To_v1alpha3_KeylessIdentitySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.KeylessIdentities = nil
+	}
+	return nil
+}
+
+// Convert_kops_SignaturePolicySpec_To_v1alpha3_SignaturePolicySpec is an autogenerated conversion function.
+func Convert_kops_SignaturePolicySpec_To_v1alpha3_SignaturePolicySpec(in *kops.SignaturePolicySpec, out *SignaturePolicySpec, s conversion.Scope) error {
+	return autoConvert_kops_SignaturePolicySpec_To_v1alpha3_SignaturePolicySpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_SnapshotControllerConfig_To_kops_SnapshotControllerConfig(in *SnapshotControllerConfig, out *kops.SnapshotControllerConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.InstallDefaultClass = in.InstallDefaultClass
@@ -7585,6 +8603,48 @@ func Convert_kops_SnapshotControllerConfig_To_v1alpha3_SnapshotControllerConfig(
 	return autoConvert_kops_SnapshotControllerConfig_To_v1alpha3_SnapshotControllerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(in *SystemdOverrideSpec, out *kops.SystemdOverrideSpec, s conversion.Scope) error {
+	out.Unit = in.Unit
+	out.Name = in.Name
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]kops.InstanceGroupRole, len(*in))
+		for i := range *in {
+			(*out)[i] = kops.InstanceGroupRole((*in)[i])
+		}
+	} else {
+		out.Roles = nil
+	}
+	out.Manifest = in.Manifest
+	return nil
+}
+
+// Convert_v1alpha3_SystemdOverrideSpec_To_kops_SystemdOverrideSpec is an autogenerated conversion function.
+func Convert_v1alpha3_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(in *SystemdOverrideSpec, out *kops.SystemdOverrideSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_SystemdOverrideSpec_To_kops_SystemdOverrideSpec(in, out, s)
+}
+
+func autoConvert_kops_SystemdOverrideSpec_To_v1alpha3_SystemdOverrideSpec(in *kops.SystemdOverrideSpec, out *SystemdOverrideSpec, s conversion.Scope) error {
+	out.Unit = in.Unit
+	out.Name = in.Name
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]InstanceGroupRole, len(*in))
+		for i := range *in {
+			(*out)[i] = InstanceGroupRole((*in)[i])
+		}
+	} else {
+		out.Roles = nil
+	}
+	out.Manifest = in.Manifest
+	return nil
+}
+
+// Convert_kops_SystemdOverrideSpec_To_v1alpha3_SystemdOverrideSpec is an autogenerated conversion function.
+func Convert_kops_SystemdOverrideSpec_To_v1alpha3_SystemdOverrideSpec(in *kops.SystemdOverrideSpec, out *SystemdOverrideSpec, s conversion.Scope) error {
+	return autoConvert_kops_SystemdOverrideSpec_To_v1alpha3_SystemdOverrideSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_TargetSpec_To_kops_TargetSpec(in *TargetSpec, out *kops.TargetSpec, s conversion.Scope) error {
 	if in.Terraform != nil {
 		in, out := &in.Terraform, &out.Terraform
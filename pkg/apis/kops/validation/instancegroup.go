@@ -24,6 +24,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -110,6 +111,16 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, validateFileAssetSpec(&g.Spec.FileAssets[i], field.NewPath("spec", "fileAssets").Index(i))...)
 	}
 
+	// @check the images to pre-pull are image references
+	for i, image := range g.Spec.ImagePrePull {
+		fldPath := field.NewPath("spec", "imagePrePull").Index(i)
+		if image == "" {
+			allErrs = append(allErrs, field.Required(fldPath, ""))
+		} else if strings.ContainsAny(image, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(fldPath, image, "image must not contain whitespace"))
+		}
+	}
+
 	for _, UserDataInfo := range g.Spec.AdditionalUserData {
 		allErrs = append(allErrs, validateExtraUserData(&UserDataInfo)...)
 	}
@@ -151,6 +162,10 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		}
 	}
 
+	if g.Spec.Hardening != nil {
+		allErrs = append(allErrs, validateHardening(g.Spec.Hardening, field.NewPath("spec", "hardening"))...)
+	}
+
 	if g.Spec.RollingUpdate != nil {
 		allErrs = append(allErrs, validateRollingUpdate(g.Spec.RollingUpdate, field.NewPath("spec", "rollingUpdate"), g.Spec.Role == kops.InstanceGroupRoleControlPlane)...)
 	}
@@ -295,6 +310,79 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 		allErrs = append(allErrs, validateContainerdConfig(&cluster.Spec, g.Spec.Containerd, field.NewPath("spec", "containerd"), false)...)
 	}
 
+	// Drop-ins may override units of hooks defined on either the cluster or the instance group
+	hooks := append(append([]kops.HookSpec{}, cluster.Spec.Hooks...), g.Spec.Hooks...)
+	allErrs = append(allErrs, validateSystemdOverrides(g.Spec.SystemdOverrides, hooks, field.NewPath("spec", "systemdOverrides"))...)
+
+	if g.Spec.NodeTuning != nil {
+		allErrs = append(allErrs, validateNodeTuning(g.Spec.NodeTuning, cluster, field.NewPath("spec", "nodeTuning"))...)
+	}
+
+	return allErrs
+}
+
+func validateNodeTuning(tuning *kops.NodeTuningSpec, cluster *kops.Cluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if swap := tuning.Swap; swap != nil {
+		swapPath := fldPath.Child("swap")
+		if !cluster.IsKubernetesGTE("1.28") {
+			allErrs = append(allErrs, field.Forbidden(swapPath, "swap requires Kubernetes 1.28 or later"))
+		}
+		if swap.Behavior != "" {
+			allErrs = append(allErrs, IsValidValue(swapPath.Child("behavior"), &swap.Behavior, []string{kops.SwapBehaviorLimitedSwap, kops.SwapBehaviorUnlimitedSwap})...)
+			if swap.Behavior == kops.SwapBehaviorUnlimitedSwap && cluster.IsKubernetesGTE("1.30") {
+				allErrs = append(allErrs, field.Forbidden(swapPath.Child("behavior"), "UnlimitedSwap is not supported by Kubernetes 1.30 or later"))
+			}
+		}
+		if swap.Device != "" {
+			if !strings.HasPrefix(swap.Device, "/dev/") {
+				allErrs = append(allErrs, field.Invalid(swapPath.Child("device"), swap.Device, "swap device must be an absolute path under /dev"))
+			}
+			if swap.Size != nil {
+				allErrs = append(allErrs, field.Forbidden(swapPath.Child("size"), "size cannot be set together with device"))
+			}
+		} else if swap.Size == nil {
+			allErrs = append(allErrs, field.Required(swapPath.Child("size"), "either size or device must be set"))
+		} else if swap.Size.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(swapPath.Child("size"), swap.Size.String(), "swap size must be greater than zero"))
+		}
+		if swap.Swappiness != nil && (*swap.Swappiness < 0 || *swap.Swappiness > 200) {
+			allErrs = append(allErrs, field.Invalid(swapPath.Child("swappiness"), *swap.Swappiness, "swappiness must be between 0 and 200"))
+		}
+	}
+
+	pageSizes := make(map[string]bool)
+	for i, hugePages := range tuning.HugePages {
+		hugePagesPath := fldPath.Child("hugePages").Index(i)
+		pageSize := hugePages.PageSize.String()
+		switch {
+		case hugePages.PageSize.Cmp(resource.MustParse("2Mi")) == 0, hugePages.PageSize.Cmp(resource.MustParse("1Gi")) == 0:
+			if pageSizes[pageSize] {
+				allErrs = append(allErrs, field.Duplicate(hugePagesPath.Child("pageSize"), pageSize))
+			}
+			pageSizes[pageSize] = true
+		default:
+			allErrs = append(allErrs, field.NotSupported(hugePagesPath.Child("pageSize"), pageSize, []string{"2Mi", "1Gi"}))
+		}
+		if hugePages.Count <= 0 {
+			allErrs = append(allErrs, field.Invalid(hugePagesPath.Child("count"), hugePages.Count, "count must be greater than zero"))
+		}
+	}
+
+	if cpuManager := tuning.CPUManager; cpuManager != nil {
+		cpuManagerPath := fldPath.Child("cpuManager")
+		if cpuManager.Policy != "" {
+			allErrs = append(allErrs, IsValidValue(cpuManagerPath.Child("policy"), &cpuManager.Policy, []string{"none", "static"})...)
+		}
+		if cpuManager.ReservedCPUs != nil && *cpuManager.ReservedCPUs < 1 {
+			allErrs = append(allErrs, field.Invalid(cpuManagerPath.Child("reservedCPUs"), *cpuManager.ReservedCPUs, "at least one CPU must be reserved"))
+		}
+		if cpuManager.TopologyManagerPolicy != "" {
+			allErrs = append(allErrs, IsValidValue(cpuManagerPath.Child("topologyManagerPolicy"), &cpuManager.TopologyManagerPolicy, []string{"none", "best-effort", "restricted", "single-numa-node"})...)
+		}
+	}
+
 	return allErrs
 }
 
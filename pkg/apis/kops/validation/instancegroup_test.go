@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/kops/pkg/nodeidentity/aws"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/pkg/apis/kops"
@@ -384,6 +385,148 @@ func TestIGUpdatePolicy(t *testing.T) {
 	}
 }
 
+func TestIGImagePrePull(t *testing.T) {
+	for _, test := range []struct {
+		label    string
+		images   []string
+		expected []string
+	}{
+		{
+			label:  "valid",
+			images: []string{"registry.k8s.io/pause:3.9", "docker.io/library/busybox@sha256:3fbc632167424a6d997e74f52b878d7cc478225cffac6bc977eedfe51c7f4e79"},
+		},
+		{
+			label:    "empty",
+			images:   []string{"registry.k8s.io/pause:3.9", ""},
+			expected: []string{"Required value::spec.imagePrePull[1]"},
+		},
+		{
+			label:    "whitespace",
+			images:   []string{"registry.k8s.io/pause:3.9 busybox"},
+			expected: []string{"Invalid value::spec.imagePrePull[0]"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			ig.Spec.ImagePrePull = test.images
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGNodeTuning(t *testing.T) {
+	for _, test := range []struct {
+		label             string
+		kubernetesVersion string
+		tuning            *kops.NodeTuningSpec
+		expected          []string
+	}{
+		{
+			label: "valid",
+			tuning: &kops.NodeTuningSpec{
+				Swap: &kops.NodeSwapSpec{
+					Size:       resource.NewQuantity(4<<30, resource.BinarySI),
+					Swappiness: fi.PtrTo(int32(10)),
+				},
+				HugePages: []kops.HugePagesSpec{
+					{PageSize: resource.MustParse("2Mi"), Count: 512},
+					{PageSize: resource.MustParse("1Gi"), Count: 2},
+				},
+				CPUManager: &kops.CPUManagerSpec{
+					ReservedCPUs:          fi.PtrTo(int32(2)),
+					TopologyManagerPolicy: "single-numa-node",
+				},
+			},
+		},
+		{
+			label: "swap device",
+			tuning: &kops.NodeTuningSpec{
+				Swap: &kops.NodeSwapSpec{Device: "/dev/nvme1n1"},
+			},
+		},
+		{
+			label:             "swap before 1.28",
+			kubernetesVersion: "1.27.0",
+			tuning: &kops.NodeTuningSpec{
+				Swap: &kops.NodeSwapSpec{Size: resource.NewQuantity(1<<30, resource.BinarySI)},
+			},
+			expected: []string{"Forbidden::spec.nodeTuning.swap"},
+		},
+		{
+			label: "unlimited swap",
+			tuning: &kops.NodeTuningSpec{
+				Swap: &kops.NodeSwapSpec{Size: resource.NewQuantity(1<<30, resource.BinarySI), Behavior: kops.SwapBehaviorUnlimitedSwap},
+			},
+			expected: []string{"Forbidden::spec.nodeTuning.swap.behavior"},
+		},
+		{
+			label: "invalid swap",
+			tuning: &kops.NodeTuningSpec{
+				Swap: &kops.NodeSwapSpec{Device: "nvme1n1", Swappiness: fi.PtrTo(int32(300)), Behavior: "NoSwap"},
+			},
+			expected: []string{
+				"Unsupported value::spec.nodeTuning.swap.behavior",
+				"Invalid value::spec.nodeTuning.swap.device",
+				"Invalid value::spec.nodeTuning.swap.swappiness",
+			},
+		},
+		{
+			label: "missing swap size",
+			tuning: &kops.NodeTuningSpec{
+				Swap: &kops.NodeSwapSpec{},
+			},
+			expected: []string{"Required value::spec.nodeTuning.swap.size"},
+		},
+		{
+			label: "invalid huge pages",
+			tuning: &kops.NodeTuningSpec{
+				HugePages: []kops.HugePagesSpec{
+					{PageSize: resource.MustParse("2Mi"), Count: 512},
+					{PageSize: resource.MustParse("2048Ki"), Count: 0},
+					{PageSize: resource.MustParse("4Mi"), Count: 1},
+				},
+			},
+			expected: []string{
+				"Duplicate value::spec.nodeTuning.hugePages[1].pageSize",
+				"Invalid value::spec.nodeTuning.hugePages[1].count",
+				"Unsupported value::spec.nodeTuning.hugePages[2].pageSize",
+			},
+		},
+		{
+			label: "invalid cpu manager",
+			tuning: &kops.NodeTuningSpec{
+				CPUManager: &kops.CPUManagerSpec{
+					Policy:                "dynamic",
+					ReservedCPUs:          fi.PtrTo(int32(0)),
+					TopologyManagerPolicy: "numa",
+				},
+			},
+			expected: []string{
+				"Unsupported value::spec.nodeTuning.cpuManager.policy",
+				"Invalid value::spec.nodeTuning.cpuManager.reservedCPUs",
+				"Unsupported value::spec.nodeTuning.cpuManager.topologyManagerPolicy",
+			},
+		},
+	} {
+		t.Run(test.label, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					KubernetesVersion: "1.30.0",
+				},
+			}
+			if test.kubernetesVersion != "" {
+				cluster.Spec.KubernetesVersion = test.kubernetesVersion
+			}
+			ig := createMinimalInstanceGroup()
+			ig.Spec.NodeTuning = test.tuning
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
 func TestValidInstanceGroup(t *testing.T) {
 	grid := []struct {
 		IG             *kops.InstanceGroup
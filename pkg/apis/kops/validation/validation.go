@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -37,9 +38,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/pkg/util/subnet"
 
+	"k8s.io/kops/pkg/addonversions"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/assets/signatures"
+	"k8s.io/kops/pkg/featureflag"
+	"k8s.io/kops/pkg/hardening"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/pkg/systemd"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/utils"
 )
@@ -120,6 +127,8 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateHookSpec(&spec.Hooks[i], fieldPath.Child("hooks").Index(i))...)
 	}
 
+	allErrs = append(allErrs, validateSystemdOverrides(spec.SystemdOverrides, spec.Hooks, fieldPath.Child("systemdOverrides"))...)
+
 	if spec.FileAssets != nil {
 		for i, x := range spec.FileAssets {
 			allErrs = append(allErrs, validateFileAssetSpec(&x, fieldPath.Child("fileAssets").Index(i))...)
@@ -160,10 +169,18 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateClusterAutoscaler(c, spec.ClusterAutoscaler, fieldPath.Child("clusterAutoscaler"))...)
 	}
 
+	if spec.DNSProvider != "" {
+		allErrs = append(allErrs, validateDNSProvider(c, fieldPath.Child("dnsProvider"))...)
+	}
+
 	if spec.ExternalDNS != nil {
 		allErrs = append(allErrs, validateExternalDNS(c, spec.ExternalDNS, fieldPath.Child("externalDNS"))...)
 	}
 
+	if spec.GossipConfig != nil || spec.DNSControllerGossipConfig != nil {
+		allErrs = append(allErrs, validateGossipConfig(spec, fieldPath)...)
+	}
+
 	if spec.MetricsServer != nil {
 		allErrs = append(allErrs, validateMetricsServer(c, spec.MetricsServer, fieldPath.Child("metricsServer"))...)
 	}
@@ -212,6 +229,9 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		if spec.Assets.ContainerProxy != nil && spec.Assets.ContainerRegistry != nil {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("assets", "containerProxy"), "containerProxy cannot be used in conjunction with containerRegistry"))
 		}
+		if spec.Assets.Verification != nil {
+			allErrs = append(allErrs, validateAssetVerification(spec.Assets.Verification, fieldPath.Child("assets", "verification"))...)
+		}
 	}
 
 	for i, sysctlParameter := range spec.SysctlParameters {
@@ -220,6 +240,10 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		}
 	}
 
+	if spec.Hardening != nil {
+		allErrs = append(allErrs, validateHardening(spec.Hardening, fieldPath.Child("hardening"))...)
+	}
+
 	if spec.RollingUpdate != nil {
 		allErrs = append(allErrs, validateRollingUpdate(spec.RollingUpdate, fieldPath.Child("rollingUpdate"), false)...)
 	}
@@ -293,6 +317,325 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateCertManager(c, spec.CertManager, fieldPath.Child("certManager"))...)
 	}
 
+	if spec.KopsController != nil && spec.KopsController.BootstrapAdmission != nil {
+		allErrs = append(allErrs, validateBootstrapAdmission(spec.KopsController.BootstrapAdmission, featureflag.Metal.Enabled(), fieldPath.Child("kopsController", "bootstrapAdmission"))...)
+	}
+
+	if len(spec.Addons) > 0 {
+		allErrs = append(allErrs, validateAddons(spec.Addons, fieldPath.Child("addons"))...)
+	}
+
+	for i := range spec.AddonPatches {
+		allErrs = append(allErrs, validateAddonPatch(&spec.AddonPatches[i], fieldPath.Child("addonPatches").Index(i))...)
+	}
+
+	if len(spec.AddonVersions) > 0 {
+		allErrs = append(allErrs, validateAddonVersions(spec, fieldPath.Child("addonVersions"))...)
+	}
+
+	return allErrs
+}
+
+func validateAddons(addons []kops.AddonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Helm and kustomize addons are added to the bootstrap channel by name, so their names must be unique.
+	names := sets.New[string]()
+	for i, addon := range addons {
+		fldPath := fldPath.Index(i)
+		switch {
+		case addon.Helm != nil:
+			if addon.Manifest != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("manifest"), "manifest cannot be specified together with helm"))
+			}
+			if addon.Kustomize != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("kustomize"), "kustomize cannot be specified together with helm"))
+			}
+
+			allErrs = append(allErrs, validateHelmAddon(addon.Helm, fldPath.Child("helm"))...)
+			if names.Has(addon.Helm.Name) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("helm", "name"), addon.Helm.Name))
+			}
+			names.Insert(addon.Helm.Name)
+		case addon.Kustomize != nil:
+			if addon.Manifest != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("manifest"), "manifest cannot be specified together with kustomize"))
+			}
+
+			allErrs = append(allErrs, validateKustomizeAddon(addon.Kustomize, fldPath.Child("kustomize"))...)
+			if names.Has(addon.Kustomize.Name) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("kustomize", "name"), addon.Kustomize.Name))
+			}
+			names.Insert(addon.Kustomize.Name)
+		default:
+			if addon.Manifest == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("manifest"), "one of manifest, helm or kustomize must be specified"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateHelmAddon(spec *kops.HelmAddonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		for _, msg := range utilvalidation.IsDNS1123Label(spec.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), spec.Name, msg))
+		}
+	}
+
+	if spec.Namespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namespace"), ""))
+	} else {
+		for _, msg := range utilvalidation.IsDNS1123Label(spec.Namespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), spec.Namespace, msg))
+		}
+	}
+
+	if spec.Chart == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("chart"), ""))
+	} else if strings.HasPrefix(spec.Chart, "oci://") {
+		if spec.Repository != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("repository"), "repository cannot be specified for OCI charts"))
+		}
+	} else {
+		if strings.Contains(spec.Chart, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("chart"), spec.Chart, "must be a chart name or an oci:// reference"))
+		}
+		if spec.Repository == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("repository"), "repository is required for charts that are not OCI references"))
+		} else if u, err := url.Parse(spec.Repository); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("repository"), spec.Repository, "must be an http or https URL"))
+		}
+	}
+
+	if spec.Version == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("version"), ""))
+	}
+
+	if spec.Values != "" {
+		values := make(map[string]interface{})
+		if err := utils.YamlUnmarshal([]byte(spec.Values), &values); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("values"), spec.Values, fmt.Sprintf("must be a YAML map: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateKustomizeAddon(spec *kops.KustomizeAddonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		for _, msg := range utilvalidation.IsDNS1123Label(spec.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), spec.Name, msg))
+		}
+	}
+
+	if spec.Path == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), ""))
+	}
+
+	if spec.Overlay != "" && !filepath.IsLocal(filepath.FromSlash(spec.Overlay)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("overlay"), spec.Overlay, "must be a relative path within path"))
+	}
+
+	return allErrs
+}
+
+func validateAddonPatch(patch *kops.AddonPatchSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if patch.Addon == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("addon"), ""))
+	}
+	if patch.Target.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("target", "kind"), ""))
+	}
+	if patch.Target.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("target", "name"), ""))
+	}
+
+	if patch.Patch == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("patch"), ""))
+		return allErrs
+	}
+
+	switch patch.Type {
+	case "", kops.AddonPatchTypeStrategicMerge:
+		var values map[string]interface{}
+		if err := utils.YamlUnmarshal([]byte(patch.Patch), &values); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("patch"), patch.Patch, fmt.Sprintf("must be a YAML map: %v", err)))
+		}
+	case kops.AddonPatchTypeJSON6902:
+		var operations []map[string]interface{}
+		if err := utils.YamlUnmarshal([]byte(patch.Patch), &operations); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("patch"), patch.Patch, fmt.Sprintf("must be a YAML list of operations: %v", err)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), patch.Type, []kops.AddonPatchType{kops.AddonPatchTypeStrategicMerge, kops.AddonPatchTypeJSON6902}))
+	}
+
+	return allErrs
+}
+
+func validateAssetVerification(spec *kops.AssetVerificationSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	keyless := false
+	for _, policies := range []struct {
+		name     string
+		policies []kops.SignaturePolicySpec
+	}{
+		{name: "images", policies: spec.Images},
+		{name: "files", policies: spec.Files},
+	} {
+		seen := sets.New[string]()
+		for i, policy := range policies.policies {
+			fldPath := fldPath.Child(policies.name).Index(i)
+
+			if policy.Prefix == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("prefix"), ""))
+			} else if seen.Has(policy.Prefix) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("prefix"), policy.Prefix))
+			}
+			seen.Insert(policy.Prefix)
+
+			if len(policy.PublicKeys) == 0 && len(policy.KeylessIdentities) == 0 {
+				allErrs = append(allErrs, field.Required(fldPath, "publicKeys or keylessIdentities must be set"))
+			}
+			for j, key := range policy.PublicKeys {
+				if _, err := signatures.ParsePublicKey(key); err != nil {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("publicKeys").Index(j), key, fmt.Sprintf("not a PEM-encoded ECDSA or RSA public key: %v", err)))
+				}
+			}
+			for j, identity := range policy.KeylessIdentities {
+				keyless = true
+				if identity.Issuer == "" {
+					allErrs = append(allErrs, field.Required(fldPath.Child("keylessIdentities").Index(j).Child("issuer"), ""))
+				}
+				if identity.Subject == "" {
+					allErrs = append(allErrs, field.Required(fldPath.Child("keylessIdentities").Index(j).Child("subject"), ""))
+				}
+			}
+		}
+	}
+
+	if keyless && len(spec.FulcioRoots) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("fulcioRoots"), "keyless signatures require the certificates of the signing authority"))
+	}
+	for i, root := range spec.FulcioRoots {
+		if _, err := signatures.ParseCertificates([]byte(root)); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("fulcioRoots").Index(i), root, fmt.Sprintf("not PEM-encoded certificates: %v", err)))
+		}
+	}
+	if keyless && len(spec.RekorPublicKeys) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("rekorPublicKeys"), "keyless signatures require the public key of the transparency log"))
+	}
+	for i, key := range spec.RekorPublicKeys {
+		if _, err := signatures.ParsePublicKey(key); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("rekorPublicKeys").Index(i), key, fmt.Sprintf("not a PEM-encoded ECDSA or RSA public key: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAddonVersions(spec *kops.ClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	addons, err := addonversions.All()
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	var names []string
+	for _, addon := range addons {
+		names = append(names, addon.Name)
+	}
+
+	// An unparseable kubernetesVersion is reported elsewhere.
+	kubernetesVersion, _ := util.ParseKubernetesVersion(spec.KubernetesVersion)
+
+	seen := sets.New[string]()
+	for i, v := range spec.AddonVersions {
+		fldPath := fldPath.Index(i)
+
+		if v.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+		} else if seen.Has(v.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), v.Name))
+		}
+		seen.Insert(v.Name)
+
+		if v.Version == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("version"), ""))
+			continue
+		}
+		if v.Name == "" {
+			continue
+		}
+
+		var addon *addonversions.Addon
+		for _, a := range addons {
+			if a.Name == v.Name {
+				addon = a
+			}
+		}
+		if addon == nil {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("name"), v.Name, names))
+			continue
+		}
+
+		if _, err := addonversions.ParseVersion(v.Version); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), v.Version, err.Error()))
+			continue
+		}
+		if kubernetesVersion != nil {
+			if err := addon.CheckVersion(v.Version, *kubernetesVersion); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), v.Version, err.Error()))
+			}
+		}
+
+		if v.Name == "networking.cilium.io" && spec.Networking.Cilium != nil && spec.Networking.Cilium.Version != "" && spec.Networking.Cilium.Version != v.Version {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("version"), fmt.Sprintf("conflicts with spec.networking.cilium.version %q", spec.Networking.Cilium.Version)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateBootstrapAdmission(spec *kops.BootstrapAdmissionSpec, enrolledHosts bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name, max := range spec.MaxNodesPerInstanceGroup {
+		if max < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxNodesPerInstanceGroup").Key(name), max, "must not be negative"))
+		}
+	}
+
+	// Hosts enrolled with `kops toolbox enroll` have no machine type, so they would never be admitted.
+	if enrolledHosts && len(spec.AllowedInstanceTypes) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("allowedInstanceTypes"), "allowedInstanceTypes is not supported with bare-metal hosts"))
+	}
+
+	for i, instanceType := range spec.AllowedInstanceTypes {
+		if instanceType == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("allowedInstanceTypes").Index(i), ""))
+		}
+	}
+
+	for i, instanceID := range spec.DeniedInstanceIDs {
+		if instanceID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("deniedInstanceIDs").Index(i), ""))
+		}
+	}
+
 	return allErrs
 }
 
@@ -306,6 +649,24 @@ type cloudProviderConstraints struct {
 	requiresSubnetRegion          bool
 }
 
+func validateHardening(spec *kops.HardeningSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Profile == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("profile"), "a hardening profile must be specified"))
+	} else {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("profile"), &spec.Profile, kops.SupportedHardeningProfiles)...)
+	}
+
+	for i, id := range spec.ExcludedControls {
+		if hardening.FindControl(id) == nil {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("excludedControls").Index(i), id))
+		}
+	}
+
+	return allErrs
+}
+
 func validateCloudProvider(c *kops.Cluster, provider *kops.CloudProviderSpec, fieldSpec *field.Path) (allErrs field.ErrorList, constraints *cloudProviderConstraints) {
 	constraints = &cloudProviderConstraints{
 		requiresSubnets:               true,
@@ -684,6 +1045,73 @@ func validateFileAssetSpec(v *kops.FileAssetSpec, fieldPath *field.Path) field.E
 	return allErrs
 }
 
+// systemdOverrideUnits are the units managed by kOps that can be overridden with drop-ins
+var systemdOverrideUnits = sets.New(
+	"containerd.service",
+	"kubelet.service",
+	"protokube.service",
+	"kubernetes-iptables-setup.service",
+	"cni-iptables-setup.service",
+)
+
+var systemdDropInName = regexp.MustCompile(`^[A-Za-z0-9_.@-]+$`)
+
+// validateSystemdOverrides validates systemd drop-ins; the unit must be managed by kOps or be a named hook.
+func validateSystemdOverrides(overrides []kops.SystemdOverrideSpec, hooks []kops.HookSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	units := systemdOverrideUnits.Clone()
+	for _, hook := range hooks {
+		if hook.Name != "" && (hook.Enabled == nil || *hook.Enabled) {
+			units.Insert(ensureSystemdSuffix(hook.Name))
+		}
+	}
+
+	seen := sets.New[string]()
+	for i, override := range overrides {
+		fldPath := fieldPath.Index(i)
+
+		if override.Unit == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("unit"), ""))
+		} else if !units.Has(ensureSystemdSuffix(override.Unit)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("unit"), override.Unit, sets.List(units)))
+		}
+
+		name := override.Name
+		if name == "" {
+			name = kops.DefaultSystemdOverrideName
+		} else if !systemdDropInName.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), name, "name may only contain alphanumeric characters and '_', '.', '@' or '-'"))
+		} else if strings.HasPrefix(name, kops.ReservedSystemdOverridePrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), name, fmt.Sprintf("names starting with %q are reserved for drop-ins written by kOps", kops.ReservedSystemdOverridePrefix)))
+		}
+
+		key := ensureSystemdSuffix(override.Unit) + "/" + name
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), name))
+		}
+		seen.Insert(key)
+
+		if strings.TrimSpace(override.Manifest) == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("manifest"), ""))
+		}
+
+		for j, role := range override.Roles {
+			allErrs = append(allErrs, IsValidValue(fldPath.Child("roles").Index(j), &role, kops.AllInstanceGroupRoles)...)
+		}
+	}
+
+	return allErrs
+}
+
+// ensureSystemdSuffix adds ".service" to names without a systemd unit extension, as nodeup does
+func ensureSystemdSuffix(name string) string {
+	if !systemd.UnitFileExtensionValid(name) {
+		name += ".service"
+	}
+	return name
+}
+
 func validateHookSpec(v *kops.HookSpec, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -1768,6 +2196,74 @@ func validateContainerdConfig(spec *kops.ClusterSpec, config *kops.ContainerdCon
 		allErrs = append(allErrs, validateNvidiaConfig(spec, config.NvidiaGPU, fldPath.Child("nvidia"), inClusterConfig)...)
 	}
 
+	if len(config.Registries) > 0 {
+		// containerd refuses to start if both the legacy mirrors and the hosts directory are configured
+		if len(config.RegistryMirrors) > 0 || (!inClusterConfig && spec.Containerd != nil && len(spec.Containerd.RegistryMirrors) > 0) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("registries"), "registries cannot be used together with registryMirrors"))
+		}
+		allErrs = append(allErrs, validateContainerdRegistries(config.Registries, fldPath.Child("registries"))...)
+	}
+
+	return allErrs
+}
+
+var containerdRegistryName = regexp.MustCompile(`^(_default|[a-zA-Z0-9.-]+(:[0-9]+)?)$`)
+
+func validateContainerdRegistries(registries map[string]kops.ContainerdRegistryConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name, registry := range registries {
+		fldPath := fldPath.Key(name)
+
+		if !containerdRegistryName.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath, name, "registry must be a host, optionally with a port, or _default"))
+		}
+		if registry.Server != "" {
+			allErrs = append(allErrs, validateRegistryURL(registry.Server, fldPath.Child("server"))...)
+		}
+		if registry.CABundle != "" {
+			allErrs = append(allErrs, validateCABundle(registry.CABundle, fldPath.Child("caBundle"))...)
+		}
+
+		for i, mirror := range registry.Mirrors {
+			fldPath := fldPath.Child("mirrors").Index(i)
+			if mirror.Endpoint == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("endpoint"), ""))
+			} else {
+				allErrs = append(allErrs, validateRegistryURL(mirror.Endpoint, fldPath.Child("endpoint"))...)
+			}
+			for j := range mirror.Capabilities {
+				allErrs = append(allErrs, IsValidValue(fldPath.Child("capabilities").Index(j), &mirror.Capabilities[j], []string{"pull", "resolve", "push"})...)
+			}
+			if mirror.CABundle != "" {
+				allErrs = append(allErrs, validateCABundle(mirror.CABundle, fldPath.Child("caBundle"))...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateRegistryURL(s string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, s, fmt.Sprintf("cannot parse URL: %v", err)))
+	} else if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, s, "must be an http or https URL"))
+	}
+
+	return allErrs
+}
+
+func validateCABundle(bundle string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(bundle)) {
+		allErrs = append(allErrs, field.Invalid(fldPath, "...", "must contain PEM-encoded certificates"))
+	}
+
 	return allErrs
 }
 
@@ -1876,6 +2372,59 @@ func validateClusterAutoscaler(cluster *kops.Cluster, spec *kops.ClusterAutoscal
 	return allErrs
 }
 
+func validateDNSProvider(cluster *kops.Cluster, fldPath *field.Path) (allErrs field.ErrorList) {
+	allErrs = append(allErrs, IsValidValue(fldPath, &cluster.Spec.DNSProvider, []string{"powerdns", "rfc2136"})...)
+
+	if cluster.UsesLegacyGossip() || cluster.UsesNoneDNS() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "dnsProvider requires public or private DNS topology"))
+	}
+
+	return allErrs
+}
+
+func validateGossipConfig(spec *kops.ClusterSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	// The primary protocol defaults to mesh, and the secondary protocol to memberlist in protokube and to none in dns-controller
+	if gossip := spec.GossipConfig; gossip != nil {
+		allErrs = append(allErrs, validateGossipSecrets(fi.ValueOf(gossip.Protocol), "mesh", gossip.AdditionalSecrets, gossip.Sealing, fldPath.Child("gossipConfig"))...)
+		if secondary := gossip.Secondary; secondary != nil {
+			// The hosts can only be discovered from kops-controller by the instances, as the primary protocol
+			if fi.ValueOf(secondary.Protocol) == kops.GossipProtocolKopsController {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("gossipConfig", "secondary", "protocol"), "kops-controller can only be the primary gossip protocol"))
+			}
+			allErrs = append(allErrs, validateGossipSecrets(fi.ValueOf(secondary.Protocol), "memberlist", secondary.AdditionalSecrets, secondary.Sealing, fldPath.Child("gossipConfig", "secondary"))...)
+		}
+	}
+	if gossip := spec.DNSControllerGossipConfig; gossip != nil {
+		if fi.ValueOf(gossip.Protocol) == kops.GossipProtocolKopsController {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsControllerGossipConfig", "protocol"), "dns-controller does not support the kops-controller gossip protocol"))
+		}
+		allErrs = append(allErrs, validateGossipSecrets(fi.ValueOf(gossip.Protocol), "mesh", gossip.AdditionalSecrets, gossip.Sealing, fldPath.Child("dnsControllerGossipConfig"))...)
+		if secondary := gossip.Secondary; secondary != nil {
+			if fi.ValueOf(secondary.Protocol) == kops.GossipProtocolKopsController {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("dnsControllerGossipConfig", "secondary", "protocol"), "dns-controller does not support the kops-controller gossip protocol"))
+			}
+			allErrs = append(allErrs, validateGossipSecrets(fi.ValueOf(secondary.Protocol), "", secondary.AdditionalSecrets, secondary.Sealing, fldPath.Child("dnsControllerGossipConfig", "secondary"))...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateGossipSecrets validates the secret rotation settings of a gossip protocol, which defaults to defaultProtocol
+func validateGossipSecrets(protocol string, defaultProtocol string, additionalSecrets []string, sealing string, fldPath *field.Path) (allErrs field.ErrorList) {
+	if protocol == "" {
+		protocol = defaultProtocol
+	}
+	// mesh secures connections with a single secret
+	if protocol == "mesh" && len(additionalSecrets) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalSecrets"), "the mesh gossip protocol does not support additional secrets"))
+	}
+	if sealing != "" {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("sealing"), &sealing, []string{kops.GossipSealingEnforced, kops.GossipSealingPermissive, kops.GossipSealingDisabled})...)
+	}
+	return allErrs
+}
+
 func validateExternalDNS(cluster *kops.Cluster, spec *kops.ExternalDNSConfig, fldPath *field.Path) (allErrs field.ErrorList) {
 	allErrs = append(allErrs, IsValidValue(fldPath.Child("provider"), &spec.Provider, []kops.ExternalDNSProvider{"", kops.ExternalDNSProviderDNSController, kops.ExternalDNSProviderExternalDNS, kops.ExternalDNSProviderNone})...)
 
@@ -1889,6 +2438,15 @@ func validateExternalDNS(cluster *kops.Cluster, spec *kops.ExternalDNSConfig, fl
 		if cluster.UsesLegacyGossip() || cluster.UsesNoneDNS() {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("provider"), "external-dns requires public or private DNS topology"))
 		}
+		if spec.OwnerID != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ownerID"), "ownerID is only supported by dns-controller"))
+		}
+	}
+
+	if spec.OwnerID != "" {
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(spec.OwnerID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ownerID"), spec.OwnerID, msg))
+		}
 	}
 
 	return allErrs
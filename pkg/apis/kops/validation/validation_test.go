@@ -1772,3 +1772,694 @@ func Test_Validate_NriConfig(t *testing.T) {
 		testErrors(t, g.Input.Containerd, errs, g.ExpectedErrors)
 	}
 }
+
+func Test_Validate_Hardening(t *testing.T) {
+	grid := []struct {
+		Input          kops.HardeningSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.HardeningSpec{
+				Profile: kops.HardeningProfileCISLevel1,
+			},
+		},
+		{
+			Input: kops.HardeningSpec{
+				Profile:          kops.HardeningProfileCISLevel2,
+				ExcludedControls: []string{"audit-rules", "sshd-configuration"},
+			},
+		},
+		{
+			Input:          kops.HardeningSpec{},
+			ExpectedErrors: []string{"Required value::hardening.profile"},
+		},
+		{
+			Input: kops.HardeningSpec{
+				Profile: "cis-level3",
+			},
+			ExpectedErrors: []string{"Unsupported value::hardening.profile"},
+		},
+		{
+			Input: kops.HardeningSpec{
+				Profile:          kops.HardeningProfileCISLevel1,
+				ExcludedControls: []string{"kubelet-read-only-port", "no-such-control"},
+			},
+			ExpectedErrors: []string{"Not found::hardening.excludedControls[1]"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateHardening(&g.Input, field.NewPath("hardening"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_SystemdOverrides(t *testing.T) {
+	hooks := []kops.HookSpec{
+		{Name: "custom-hook", Manifest: "Type=oneshot"},
+		{Name: "disabled-hook", Enabled: fi.PtrTo(false)},
+	}
+	grid := []struct {
+		Input          []kops.SystemdOverrideSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: []kops.SystemdOverrideSpec{
+				{Unit: "kubelet.service", Manifest: "[Service]\nLimitNOFILE=1048576"},
+				{Unit: "kubelet.service", Name: "10-memory", Manifest: "[Service]\nMemoryHigh=2G"},
+				{Unit: "containerd", Manifest: "[Service]\nLimitNOFILE=1048576"},
+				{Unit: "custom-hook", Manifest: "[Service]\nTimeoutStartSec=600"},
+			},
+		},
+		{
+			Input: []kops.SystemdOverrideSpec{
+				{Manifest: "[Service]\nLimitNOFILE=1048576"},
+			},
+			ExpectedErrors: []string{"Required value::systemdOverrides[0].unit"},
+		},
+		{
+			Input: []kops.SystemdOverrideSpec{
+				{Unit: "sshd.service", Manifest: "[Service]\nLimitNOFILE=1048576"},
+				{Unit: "disabled-hook.service", Manifest: "[Service]\nLimitNOFILE=1048576"},
+			},
+			ExpectedErrors: []string{
+				"Unsupported value::systemdOverrides[0].unit",
+				"Unsupported value::systemdOverrides[1].unit",
+			},
+		},
+		{
+			Input: []kops.SystemdOverrideSpec{
+				{Unit: "kubelet.service", Name: "../evil", Manifest: "[Service]\nLimitNOFILE=1048576"},
+				{Unit: "kubelet.service", Manifest: " "},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::systemdOverrides[0].name",
+				"Required value::systemdOverrides[1].manifest",
+			},
+		},
+		{
+			Input: []kops.SystemdOverrideSpec{
+				{Unit: "kubelet.service", Name: "10-kops-node-tuning", Manifest: "[Service]\nLimitNOFILE=1048576"},
+			},
+			ExpectedErrors: []string{"Invalid value::systemdOverrides[0].name"},
+		},
+		{
+			Input: []kops.SystemdOverrideSpec{
+				{Unit: "kubelet.service", Manifest: "[Service]\nLimitNOFILE=1048576"},
+				{Unit: "kubelet", Name: kops.DefaultSystemdOverrideName, Manifest: "[Service]\nLimitNOFILE=65536"},
+			},
+			ExpectedErrors: []string{"Duplicate value::systemdOverrides[1].name"},
+		},
+		{
+			Input: []kops.SystemdOverrideSpec{
+				{Unit: "kubelet.service", Roles: []kops.InstanceGroupRole{"Bastion", "Worker"}, Manifest: "[Service]\nLimitNOFILE=1048576"},
+			},
+			ExpectedErrors: []string{"Unsupported value::systemdOverrides[0].roles[1]"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateSystemdOverrides(g.Input, hooks, field.NewPath("systemdOverrides"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_ExternalDNS_OwnerID(t *testing.T) {
+	grid := []struct {
+		Input          kops.ExternalDNSConfig
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ExternalDNSConfig{OwnerID: "minimal.example.com"},
+		},
+		{
+			Input: kops.ExternalDNSConfig{Provider: kops.ExternalDNSProviderDNSController, OwnerID: "cluster-a"},
+		},
+		{
+			Input:          kops.ExternalDNSConfig{OwnerID: "owner,\"a\""},
+			ExpectedErrors: []string{"Invalid value::externalDNS.ownerID"},
+		},
+		{
+			Input:          kops.ExternalDNSConfig{Provider: kops.ExternalDNSProviderExternalDNS, OwnerID: "cluster-a"},
+			ExpectedErrors: []string{"Forbidden::externalDNS.ownerID"},
+		},
+	}
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+	}
+	for _, g := range grid {
+		errs := validateExternalDNS(cluster, &g.Input, field.NewPath("externalDNS"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_GossipConfig(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterSpec{
+				GossipConfig: &kops.GossipConfig{
+					Protocol:  fi.PtrTo(kops.GossipProtocolKopsController),
+					Secondary: &kops.GossipConfigSecondary{Protocol: fi.PtrTo("memberlist")},
+				},
+				DNSControllerGossipConfig: &kops.DNSControllerGossipConfig{Protocol: fi.PtrTo("memberlist")},
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				GossipConfig: &kops.GossipConfig{
+					Protocol:  fi.PtrTo("memberlist"),
+					Secondary: &kops.GossipConfigSecondary{Protocol: fi.PtrTo(kops.GossipProtocolKopsController)},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.gossipConfig.secondary.protocol"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				DNSControllerGossipConfig: &kops.DNSControllerGossipConfig{
+					Protocol:  fi.PtrTo(kops.GossipProtocolKopsController),
+					Secondary: &kops.DNSControllerGossipConfigSecondary{Protocol: fi.PtrTo(kops.GossipProtocolKopsController)},
+				},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.dnsControllerGossipConfig.protocol",
+				"Forbidden::spec.dnsControllerGossipConfig.secondary.protocol",
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				GossipConfig: &kops.GossipConfig{
+					Protocol:          fi.PtrTo("memberlist"),
+					AdditionalSecrets: []string{"old-secret"},
+					Sealing:           kops.GossipSealingPermissive,
+					Secondary:         &kops.GossipConfigSecondary{AdditionalSecrets: []string{"old-secret"}},
+				},
+				DNSControllerGossipConfig: &kops.DNSControllerGossipConfig{
+					Protocol:          fi.PtrTo("memberlist"),
+					AdditionalSecrets: []string{"old-secret"},
+					Sealing:           kops.GossipSealingDisabled,
+				},
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				GossipConfig: &kops.GossipConfig{
+					AdditionalSecrets: []string{"old-secret"},
+					Sealing:           "Optional",
+					Secondary:         &kops.GossipConfigSecondary{Protocol: fi.PtrTo("mesh"), AdditionalSecrets: []string{"old-secret"}},
+				},
+				DNSControllerGossipConfig: &kops.DNSControllerGossipConfig{
+					Protocol:          fi.PtrTo("mesh"),
+					AdditionalSecrets: []string{"old-secret"},
+				},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.gossipConfig.additionalSecrets",
+				"Unsupported value::spec.gossipConfig.sealing",
+				"Forbidden::spec.gossipConfig.secondary.additionalSecrets",
+				"Forbidden::spec.dnsControllerGossipConfig.additionalSecrets",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateGossipConfig(&g.Input, field.NewPath("spec"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_DNSProvider(t *testing.T) {
+	grid := []struct {
+		DNSProvider    string
+		ClusterName    string
+		ExpectedErrors []string
+	}{
+		{
+			DNSProvider: "rfc2136",
+			ClusterName: "minimal.example.com",
+		},
+		{
+			DNSProvider: "powerdns",
+			ClusterName: "minimal.example.com",
+		},
+		{
+			DNSProvider:    "bind",
+			ClusterName:    "minimal.example.com",
+			ExpectedErrors: []string{"Unsupported value::spec.dnsProvider"},
+		},
+		{
+			DNSProvider:    "rfc2136",
+			ClusterName:    "minimal.k8s.local",
+			ExpectedErrors: []string{"Forbidden::spec.dnsProvider"},
+		},
+	}
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: g.ClusterName},
+			Spec: kops.ClusterSpec{
+				DNSProvider: g.DNSProvider,
+			},
+		}
+		errs := validateDNSProvider(cluster, field.NewPath("spec", "dnsProvider"))
+		testErrors(t, g.DNSProvider, errs, g.ExpectedErrors)
+	}
+}
+
+const testRegistryCABundle = `-----BEGIN CERTIFICATE-----
+MIIBgTCCASegAwIBAgIUIYrWDwtR12L5MBYseNr5zYs7mIMwCgYIKoZIzj0EAwIw
+FjEUMBIGA1UEAwwLcmVnaXN0cnktY2EwHhcNMjYxMDE4MjIxMjQ4WhcNMzYxMDE1
+MjIxMjQ4WjAWMRQwEgYDVQQDDAtyZWdpc3RyeS1jYTBZMBMGByqGSM49AgEGCCqG
+SM49AwEHA0IABFYUS3G/cv7/BjqC2aRzaIluXrabCLGhAQeDNEPd9z0lDf/jlU8v
+yFI/y8ZeDfMP2Fl6AhmEisDtkE0K2BIGYmOjUzBRMB0GA1UdDgQWBBSvqHKYluKD
+QZuMwTMSumu3nMAvpDAfBgNVHSMEGDAWgBSvqHKYluKDQZuMwTMSumu3nMAvpDAP
+BgNVHRMBAf8EBTADAQH/MAoGCCqGSM49BAMCA0gAMEUCIF3igjSQLbId32eA+Wz8
+pO3ngfSve8b4hiQ3eR3McX0+AiEA/Q1Ux4Mf82l9pVGrP4weWWkqEcB4hKPogtHO
+QWWqPHE=
+-----END CERTIFICATE-----
+`
+
+func Test_Validate_ContainerdRegistries(t *testing.T) {
+	grid := []struct {
+		Input          kops.ContainerdConfig
+		ClusterConfig  *kops.ContainerdConfig
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ContainerdConfig{
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {
+						Mirrors: []kops.ContainerdRegistryMirror{
+							{Endpoint: "https://mirror.example.com", CABundle: testRegistryCABundle},
+							{Endpoint: "http://10.0.0.10:5000/v2/docker.io", Capabilities: []string{"pull"}, OverridePath: true},
+						},
+						AuthFromDockerConfig: true,
+					},
+					"registry.example.com:5000": {
+						Server:   "https://registry.example.com:5000",
+						CABundle: testRegistryCABundle,
+					},
+					"_default": {
+						Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://cache.example.com"}},
+					},
+				},
+			},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				RegistryMirrors: map[string][]string{"docker.io": {"https://mirror.example.com"}},
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com"}}},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::containerd.registries"},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com"}}},
+				},
+			},
+			ClusterConfig: &kops.ContainerdConfig{
+				RegistryMirrors: map[string][]string{"docker.io": {"https://mirror.example.com"}},
+			},
+			ExpectedErrors: []string{"Forbidden::containerd.registries"},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"https://docker.io": {
+						Server:   "registry.example.com",
+						CABundle: "not a certificate",
+						Mirrors: []kops.ContainerdRegistryMirror{
+							{},
+							{Endpoint: "ftp://mirror.example.com", Capabilities: []string{"pull", "delete"}},
+						},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::containerd.registries[https://docker.io]",
+				"Invalid value::containerd.registries[https://docker.io].server",
+				"Invalid value::containerd.registries[https://docker.io].caBundle",
+				"Required value::containerd.registries[https://docker.io].mirrors[0].endpoint",
+				"Invalid value::containerd.registries[https://docker.io].mirrors[1].endpoint",
+				"Unsupported value::containerd.registries[https://docker.io].mirrors[1].capabilities[1]",
+			},
+		},
+	}
+	for _, g := range grid {
+		spec := &kops.ClusterSpec{Containerd: g.ClusterConfig}
+		errs := validateContainerdConfig(spec, &g.Input, field.NewPath("containerd"), g.ClusterConfig == nil)
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_BootstrapAdmission(t *testing.T) {
+	grid := []struct {
+		Input          kops.BootstrapAdmissionSpec
+		EnrolledHosts  bool
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.BootstrapAdmissionSpec{
+				MaxNodesPerInstanceGroup: map[string]int32{"nodes": 10},
+				AllowedInstanceTypes:     []string{"t3.medium"},
+				DeniedInstanceIDs:        []string{"i-0123456789abcdef0"},
+			},
+		},
+		{
+			Input: kops.BootstrapAdmissionSpec{
+				MaxNodesPerInstanceGroup: map[string]int32{"nodes": -1},
+			},
+			ExpectedErrors: []string{"Invalid value::bootstrapAdmission.maxNodesPerInstanceGroup[nodes]"},
+		},
+		{
+			Input: kops.BootstrapAdmissionSpec{
+				AllowedInstanceTypes: []string{"t3.medium", ""},
+				DeniedInstanceIDs:    []string{""},
+			},
+			ExpectedErrors: []string{
+				"Required value::bootstrapAdmission.allowedInstanceTypes[1]",
+				"Required value::bootstrapAdmission.deniedInstanceIDs[0]",
+			},
+		},
+		{
+			Input: kops.BootstrapAdmissionSpec{
+				MaxNodesPerInstanceGroup: map[string]int32{"nodes": 10},
+				DeniedInstanceIDs:        []string{"i-0123456789abcdef0"},
+			},
+			EnrolledHosts: true,
+		},
+		{
+			Input: kops.BootstrapAdmissionSpec{
+				AllowedInstanceTypes: []string{"t3.medium"},
+			},
+			EnrolledHosts:  true,
+			ExpectedErrors: []string{"Forbidden::bootstrapAdmission.allowedInstanceTypes"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateBootstrapAdmission(&g.Input, g.EnrolledHosts, field.NewPath("bootstrapAdmission"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_Addons(t *testing.T) {
+	grid := []struct {
+		Input          []kops.AddonSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: []kops.AddonSpec{
+				{Manifest: "s3://bucket/addons.yaml"},
+				{Helm: &kops.HelmAddonSpec{Name: "podinfo", Namespace: "podinfo", Chart: "podinfo", Repository: "https://stefanprodan.github.io/podinfo", Version: "6.7.0", Values: "replicaCount: 2"}},
+				{Helm: &kops.HelmAddonSpec{Name: "other", Namespace: "kube-system", Chart: "oci://ghcr.io/stefanprodan/charts/podinfo", Version: "6.7.0"}},
+			},
+		},
+		{
+			Input: []kops.AddonSpec{
+				{},
+			},
+			ExpectedErrors: []string{"Required value::addons[0].manifest"},
+		},
+		{
+			Input: []kops.AddonSpec{
+				{Manifest: "s3://bucket/addons.yaml", Helm: &kops.HelmAddonSpec{Name: "podinfo", Namespace: "podinfo", Chart: "oci://ghcr.io/stefanprodan/charts/podinfo", Version: "6.7.0"}},
+			},
+			ExpectedErrors: []string{"Forbidden::addons[0].manifest"},
+		},
+		{
+			Input: []kops.AddonSpec{
+				{Helm: &kops.HelmAddonSpec{}},
+			},
+			ExpectedErrors: []string{
+				"Required value::addons[0].helm.name",
+				"Required value::addons[0].helm.namespace",
+				"Required value::addons[0].helm.chart",
+				"Required value::addons[0].helm.version",
+			},
+		},
+		{
+			Input: []kops.AddonSpec{
+				{Helm: &kops.HelmAddonSpec{Name: "podinfo", Namespace: "podinfo", Chart: "podinfo", Version: "6.7.0"}},
+				{Helm: &kops.HelmAddonSpec{Name: "podinfo", Namespace: "podinfo", Chart: "oci://ghcr.io/stefanprodan/charts/podinfo", Repository: "https://example.com", Version: "6.7.0", Values: "- not a map"}},
+			},
+			ExpectedErrors: []string{
+				"Required value::addons[0].helm.repository",
+				"Forbidden::addons[1].helm.repository",
+				"Invalid value::addons[1].helm.values",
+				"Duplicate value::addons[1].helm.name",
+			},
+		},
+		{
+			Input: []kops.AddonSpec{
+				{Kustomize: &kops.KustomizeAddonSpec{Name: "widgets", Path: "s3://bucket/kustomize/widgets", Overlay: "overlays/production"}},
+				{Kustomize: &kops.KustomizeAddonSpec{Name: "gadgets", Path: "addons/gadgets"}},
+			},
+		},
+		{
+			Input: []kops.AddonSpec{
+				{Kustomize: &kops.KustomizeAddonSpec{}},
+			},
+			ExpectedErrors: []string{
+				"Required value::addons[0].kustomize.name",
+				"Required value::addons[0].kustomize.path",
+			},
+		},
+		{
+			Input: []kops.AddonSpec{
+				{Helm: &kops.HelmAddonSpec{Name: "widgets", Namespace: "widgets", Chart: "oci://ghcr.io/example/widgets", Version: "1.0.0"}},
+				{Manifest: "s3://bucket/addons.yaml", Kustomize: &kops.KustomizeAddonSpec{Name: "widgets", Path: "addons/widgets", Overlay: "../other"}},
+				{Helm: &kops.HelmAddonSpec{Name: "gadgets", Namespace: "gadgets", Chart: "oci://ghcr.io/example/gadgets", Version: "1.0.0"}, Kustomize: &kops.KustomizeAddonSpec{Name: "gadgets", Path: "addons/gadgets"}},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::addons[1].manifest",
+				"Invalid value::addons[1].kustomize.overlay",
+				"Duplicate value::addons[1].kustomize.name",
+				"Forbidden::addons[2].kustomize",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateAddons(g.Input, field.NewPath("addons"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_AddonPatch(t *testing.T) {
+	grid := []struct {
+		Input          kops.AddonPatchSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.AddonPatchSpec{
+				Addon:  "coredns.addons.k8s.io",
+				Target: kops.AddonPatchTarget{Group: "apps", Kind: "Deployment", Name: "coredns"},
+				Patch:  "spec:\n  replicas: 3\n",
+			},
+		},
+		{
+			Input: kops.AddonPatchSpec{
+				Addon:  "coredns.addons.k8s.io",
+				Target: kops.AddonPatchTarget{Kind: "Deployment", Name: "coredns"},
+				Type:   kops.AddonPatchTypeJSON6902,
+				Patch:  "- op: replace\n  path: /spec/replicas\n  value: 3\n",
+			},
+		},
+		{
+			Input: kops.AddonPatchSpec{},
+			ExpectedErrors: []string{
+				"Required value::addonPatches[0].addon",
+				"Required value::addonPatches[0].target.kind",
+				"Required value::addonPatches[0].target.name",
+				"Required value::addonPatches[0].patch",
+			},
+		},
+		{
+			Input: kops.AddonPatchSpec{
+				Addon:  "coredns.addons.k8s.io",
+				Target: kops.AddonPatchTarget{Kind: "Deployment", Name: "coredns"},
+				Patch:  "- op: replace\n",
+			},
+			ExpectedErrors: []string{"Invalid value::addonPatches[0].patch"},
+		},
+		{
+			Input: kops.AddonPatchSpec{
+				Addon:  "coredns.addons.k8s.io",
+				Target: kops.AddonPatchTarget{Kind: "Deployment", Name: "coredns"},
+				Type:   kops.AddonPatchTypeJSON6902,
+				Patch:  "spec:\n  replicas: 3\n",
+			},
+			ExpectedErrors: []string{"Invalid value::addonPatches[0].patch"},
+		},
+		{
+			Input: kops.AddonPatchSpec{
+				Addon:  "coredns.addons.k8s.io",
+				Target: kops.AddonPatchTarget{Kind: "Deployment", Name: "coredns"},
+				Type:   "Merge",
+				Patch:  "spec:\n  replicas: 3\n",
+			},
+			ExpectedErrors: []string{"Unsupported value::addonPatches[0].type"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateAddonPatch(&g.Input, field.NewPath("addonPatches").Index(0))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_AddonVersions(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+				AddonVersions: []kops.AddonVersionSpec{
+					{Name: "coredns.addons.k8s.io", Version: "v1.11.3"},
+					{Name: "metrics-server.addons.k8s.io", Version: "v0.7.2"},
+				},
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+				AddonVersions:     []kops.AddonVersionSpec{{}},
+			},
+			ExpectedErrors: []string{
+				"Required value::addonVersions[0].name",
+				"Required value::addonVersions[0].version",
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+				AddonVersions: []kops.AddonVersionSpec{
+					{Name: "coredns.addons.k8s.io", Version: "v1.11.3"},
+					{Name: "coredns.addons.k8s.io", Version: "v1.11.1"},
+				},
+			},
+			ExpectedErrors: []string{"Duplicate value::addonVersions[1].name"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+				AddonVersions:     []kops.AddonVersionSpec{{Name: "dns-controller.addons.k8s.io", Version: "v1.29.0"}},
+			},
+			ExpectedErrors: []string{"Unsupported value::addonVersions[0].name"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+				AddonVersions:     []kops.AddonVersionSpec{{Name: "coredns.addons.k8s.io", Version: "1.11.3"}},
+			},
+			ExpectedErrors: []string{"Invalid value::addonVersions[0].version"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+				AddonVersions:     []kops.AddonVersionSpec{{Name: "coredns.addons.k8s.io", Version: "v1.12.0"}},
+			},
+			ExpectedErrors: []string{"Invalid value::addonVersions[0].version"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+				Networking: kops.NetworkingSpec{
+					Cilium: &kops.CiliumNetworkingSpec{Version: "v1.15.6"},
+				},
+				AddonVersions: []kops.AddonVersionSpec{{Name: "networking.cilium.io", Version: "v1.15.7"}},
+			},
+			ExpectedErrors: []string{"Forbidden::addonVersions[0].version"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateAddonVersions(&g.Input, field.NewPath("addonVersions"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+const testPublicKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE05B3e6qZ9aqzdB18f0KE4sTGYm2O
+DkZv8Zd26wzwEaCsQ03uUoAb/M1syKEZLOPzXlfeuxeZoj1m90KdjfRiag==
+-----END PUBLIC KEY-----`
+
+const testCertificate = `-----BEGIN CERTIFICATE-----
+MIIBgTCCASegAwIBAgIUZQuFtLH1edljNyGPX1ZrvsyoWNkwCgYIKoZIzj0EAwIw
+FjEUMBIGA1UEAwwLdGVzdC1mdWxjaW8wHhcNMjYxMDE5MDYxNDU5WhcNMzYxMDE2
+MDYxNDU5WjAWMRQwEgYDVQQDDAt0ZXN0LWZ1bGNpbzBZMBMGByqGSM49AgEGCCqG
+SM49AwEHA0IABNOQd3uqmfWqs3QdfH9ChOLExmJtjg5Gb/GXdusM8BGgrENN7lKA
+G/zNbMihGSzj815X3rsXmaI9ZvdCnY30YmqjUzBRMB0GA1UdDgQWBBSBTxmQvxaa
+cq0+tcCs557y2F5dhTAfBgNVHSMEGDAWgBSBTxmQvxaacq0+tcCs557y2F5dhTAP
+BgNVHRMBAf8EBTADAQH/MAoGCCqGSM49BAMCA0gAMEUCIQDY2NZBL2q5Z/I1cHXp
+3PIV71ynQ5zKfjC3RLnS8V2fygIgX932dD2McjL7P/m4y+W+t3wuhfVkJS8GJbu3
+sJYhHi0=
+-----END CERTIFICATE-----`
+
+func Test_Validate_AssetVerification(t *testing.T) {
+	identity := kops.KeylessIdentitySpec{Issuer: "https://accounts.google.com", Subject: "krel-trust@k8s-releng-prod.iam.gserviceaccount.com"}
+
+	grid := []struct {
+		Input          kops.AssetVerificationSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.AssetVerificationSpec{
+				Images: []kops.SignaturePolicySpec{
+					{Prefix: "registry.k8s.io/", KeylessIdentities: []kops.KeylessIdentitySpec{identity}},
+					{Prefix: "registry.example.com/", PublicKeys: []string{testPublicKey}},
+				},
+				Files: []kops.SignaturePolicySpec{
+					{Prefix: "https://dl.k8s.io/", KeylessIdentities: []kops.KeylessIdentitySpec{identity}},
+				},
+				FulcioRoots:     []string{testCertificate},
+				RekorPublicKeys: []string{testPublicKey},
+			},
+		},
+		{
+			Input: kops.AssetVerificationSpec{
+				Images: []kops.SignaturePolicySpec{
+					{PublicKeys: []string{testPublicKey}},
+					{Prefix: "registry.example.com/"},
+					{Prefix: "registry.example.com/", PublicKeys: []string{"not a key"}},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::verification.images[0].prefix",
+				"Required value::verification.images[1]",
+				"Duplicate value::verification.images[2].prefix",
+				"Invalid value::verification.images[2].publicKeys[0]",
+			},
+		},
+		{
+			Input: kops.AssetVerificationSpec{
+				Files: []kops.SignaturePolicySpec{
+					{Prefix: "https://dl.k8s.io/", KeylessIdentities: []kops.KeylessIdentitySpec{{}}},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::verification.files[0].keylessIdentities[0].issuer",
+				"Required value::verification.files[0].keylessIdentities[0].subject",
+				"Required value::verification.fulcioRoots",
+				"Required value::verification.rekorPublicKeys",
+			},
+		},
+		{
+			Input: kops.AssetVerificationSpec{
+				Files: []kops.SignaturePolicySpec{
+					{Prefix: "https://dl.k8s.io/", KeylessIdentities: []kops.KeylessIdentitySpec{identity}},
+				},
+				FulcioRoots:     []string{testPublicKey},
+				RekorPublicKeys: []string{testCertificate},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::verification.fulcioRoots[0]",
+				"Invalid value::verification.rekorPublicKeys[0]",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateAssetVerification(&g.Input, field.NewPath("verification"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/model"
+	"k8s.io/kops/pkg/systemd"
 	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/reflectutils"
 )
@@ -33,6 +34,8 @@ type Config struct {
 	Assets map[architectures.Architecture][]string `json:",omitempty"`
 	// Images are a list of images we should preload
 	Images map[architectures.Architecture][]*Image `json:"images,omitempty"`
+	// AssetVerification is the policy for verifying the signatures of assets and preloaded images before they are used.
+	AssetVerification *AssetVerification `json:"assetVerification,omitempty"`
 	// ClusterName is the name of the cluster
 	ClusterName string `json:",omitempty"`
 	// Channels is a list of channels that we should apply
@@ -43,6 +46,8 @@ type Config struct {
 	KubernetesVersion string
 	// Packages specifies additional packages to be installed.
 	Packages []string `json:"packages,omitempty"`
+	// PrePullImages are the container images to pull while the instance boots.
+	PrePullImages []string `json:"prePullImages,omitempty"`
 
 	// ConfigStore configures the stores that nodes use to get their configuration when they don't use kops-controller.
 	ConfigStore *kops.ConfigStoreSpec `json:"configStore,omitempty"`
@@ -79,6 +84,10 @@ type Config struct {
 	ServiceNodePortRange string `json:",omitempty"`
 	// SysctlParameters will configure kernel parameters using sysctl(8).
 	SysctlParameters []string `json:",omitempty"`
+	// Hardening configures the OS and kubelet hardening profile.
+	Hardening *kops.HardeningSpec `json:"hardening,omitempty"`
+	// NodeTuning configures swap, huge pages and CPU management.
+	NodeTuning *kops.NodeTuningSpec `json:"nodeTuning,omitempty"`
 	// UpdatePolicy determines the policy for applying upgrades automatically.
 	UpdatePolicy string
 	// VolumeMounts are a collection of volume mounts.
@@ -88,6 +97,8 @@ type Config struct {
 	FileAssets []kops.FileAssetSpec `json:",omitempty"`
 	// Hooks are for custom actions, for example on first installation.
 	Hooks [][]kops.HookSpec
+	// SystemdOverrides are drop-ins for the systemd units managed by kOps.
+	SystemdOverrides []kops.SystemdOverrideSpec `json:",omitempty"`
 	// ContainerdConfig holds the configuration for containerd.
 	ContainerdConfig *kops.ContainerdConfig `json:"containerdConfig,omitempty"`
 
@@ -178,6 +189,15 @@ type Image struct {
 	Hash string `json:"hash,omitempty"`
 }
 
+// AssetVerification lists the assets and preloaded images whose signatures nodeup verifies, and the policy to verify them against.
+type AssetVerification struct {
+	// Policy is the verification policy of the cluster.
+	Policy *kops.AssetVerificationSpec `json:"policy,omitempty"`
+	// SignedFiles are the canonical URLs of the files that must be signed, keyed by the hex-encoded hash of the file.
+	// The canonical URL selects the policy for the file, wherever it is downloaded from.
+	SignedFiles map[string]string `json:"signedFiles,omitempty"`
+}
+
 // StaticManifest is a generic static manifest
 type StaticManifest struct {
 	// Key identifies the static manifest
@@ -232,6 +252,7 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		VolumeMounts:         instanceGroup.Spec.VolumeMounts,
 		FileAssets:           append(filterFileAssets(instanceGroup.Spec.FileAssets, role), filterFileAssets(cluster.Spec.FileAssets, role)...),
 		Hooks:                [][]kops.HookSpec{igHooks, clusterHooks},
+		SystemdOverrides:     mergeSystemdOverrides(cluster.Spec.SystemdOverrides, instanceGroup.Spec.SystemdOverrides, role),
 		UsesLegacyGossip:     cluster.UsesLegacyGossip(),
 		UsesNoneDNS:          cluster.UsesNoneDNS(),
 	}
@@ -396,6 +417,14 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		config.SysctlParameters = append(config.SysctlParameters, cluster.Spec.SysctlParameters...)
 	}
 
+	config.NodeTuning = instanceGroup.Spec.NodeTuning
+
+	if instanceGroup.Spec.Hardening != nil {
+		config.Hardening = instanceGroup.Spec.Hardening
+	} else {
+		config.Hardening = cluster.Spec.Hardening
+	}
+
 	return &config, &bootConfig
 }
 
@@ -466,6 +495,35 @@ func filterHooks(h []kops.HookSpec, role kops.InstanceGroupRole) []kops.HookSpec
 	return hooks
 }
 
+// mergeSystemdOverrides returns the drop-ins for the role, with the instance group
+// drop-ins replacing the cluster drop-ins for the same unit and name.
+func mergeSystemdOverrides(clusterOverrides, igOverrides []kops.SystemdOverrideSpec, role kops.InstanceGroupRole) []kops.SystemdOverrideSpec {
+	var overrides []kops.SystemdOverrideSpec
+	index := make(map[string]int)
+	for _, list := range [][]kops.SystemdOverrideSpec{clusterOverrides, igOverrides} {
+		for _, override := range list {
+			if len(override.Roles) > 0 && !containsRole(role, override.Roles) {
+				continue
+			}
+			override.Roles = nil
+			if !systemd.UnitFileExtensionValid(override.Unit) {
+				override.Unit += ".service"
+			}
+			if override.Name == "" {
+				override.Name = kops.DefaultSystemdOverrideName
+			}
+			key := override.Unit + "/" + override.Name
+			if i, found := index[key]; found {
+				overrides[i] = override
+				continue
+			}
+			index[key] = len(overrides)
+			overrides = append(overrides, override)
+		}
+	}
+	return overrides
+}
+
 func containsRole(v kops.InstanceGroupRole, list []kops.InstanceGroupRole) bool {
 	for _, x := range list {
 		if v == x {
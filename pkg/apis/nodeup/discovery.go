@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+const DiscoveryAPIVersion = "discovery.kops.k8s.io/v1alpha1"
+
+// DiscoveryHostsPath is the path on which kops-controller serves the DiscoveryHostsResponse.
+// Clients can watch for changes by passing the last seen resourceVersion, and a timeoutSeconds to wait for a change.
+const DiscoveryHostsPath = "/discovery/hosts"
+
+// DiscoveryHostsResponse holds the addresses of the internal hostnames of the cluster, as served by kops-controller.
+type DiscoveryHostsResponse struct {
+	// APIVersion defines the versioned schema of this representation of a response.
+	APIVersion string `json:"apiVersion"`
+	// ResourceVersion identifies the hosts; it changes whenever the hosts change.
+	ResourceVersion string `json:"resourceVersion"`
+	// Hosts are the internal hostnames and their addresses.
+	Hosts []DiscoveryHost `json:"hosts,omitempty"`
+}
+
+// DiscoveryHost is an internal hostname and its addresses.
+type DiscoveryHost struct {
+	// Name is the fully qualified hostname, for example api.internal.<clustername>.
+	Name string `json:"name"`
+	// Addresses are the IP addresses of the hostname.
+	Addresses []string `json:"addresses"`
+}
@@ -18,13 +18,14 @@ package applyset
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 )
@@ -36,9 +37,12 @@ import (
 // * We want to watch the objects we're applying / be notified of changes
 // * We want to know when the objects we apply are "healthy"
 // * We expose a "try once" method to better support running from a controller.
+// * We want to prune objects that we applied previously, but are no longer desired.
+//
+// Pruning follows the ApplySet specification (KEP-3659), as implemented by kubectl apply --prune --applyset:
+// objects are labeled as members of the set, and the kinds and namespaces of the members are recorded on a parent object.
 //
 // TODO: Pluggable health functions.
-// TODO: Pruning
 type ApplySet struct {
 	// client is the dynamic kubernetes client used to apply objects to the k8s cluster.
 	client dynamic.Interface
@@ -46,6 +50,8 @@ type ApplySet struct {
 	restMapper meta.RESTMapper
 	// patchOptions holds the options used when applying, in particular the fieldManager
 	patchOptions metav1.PatchOptions
+	// parent is the parent object of the ApplySet, if pruning is supported.
+	parent *Parent
 
 	// mutex guards trackers
 	mutex sync.Mutex
@@ -59,8 +65,12 @@ type Options struct {
 	Client dynamic.Interface
 	// RESTMapper is used to map object kind to resources, and to know if objects are cluster-scoped.
 	RESTMapper meta.RESTMapper
-	// PatchOptions holds the options used when applying, in particular the fieldManager
+	// PatchOptions holds the options used when applying, in particular the fieldManager.
+	// If Force is set, we first apply without forcing, so that conflicts can be reported.
 	PatchOptions metav1.PatchOptions
+	// Parent is the parent object of the ApplySet, which records its members so they can be pruned.
+	// If nil, objects are not labeled as members, and Prune and Adopt are not supported.
+	Parent *Parent
 }
 
 // New constructs a new ApplySet
@@ -69,6 +79,7 @@ func New(options Options) (*ApplySet, error) {
 		client:       options.Client,
 		restMapper:   options.RESTMapper,
 		patchOptions: options.PatchOptions,
+		parent:       options.Parent,
 	}
 	a.trackers = &objectTrackerList{}
 	return a, nil
@@ -100,13 +111,22 @@ func (a *ApplySet) ApplyOnce(ctx context.Context) (*ApplyResults, error) {
 	trackers := a.trackers
 	a.mutex.Unlock()
 
-	client := &UnstructuredClient{
-		client:     a.client,
-		restMapper: a.restMapper,
-	}
+	client := a.unstructuredClient()
 
 	results := &ApplyResults{total: len(trackers.items)}
 
+	// Record the kinds and namespaces we are about to apply on the parent first,
+	// so that we can find the objects to prune even if we are interrupted.
+	if a.parent != nil {
+		recorded, _, err := a.readParent(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.writeParent(ctx, client, recorded.union(desiredMembers(trackers))); err != nil {
+			return nil, err
+		}
+	}
+
 	for i := range trackers.items {
 		tracker := &trackers.items[i]
 		expectedObject := tracker.desired
@@ -138,14 +158,14 @@ func (a *ApplySet) ApplyOnce(ctx context.Context) (*ApplyResults, error) {
 			}
 		}
 
-		j, err := json.Marshal(expectedObject)
+		j, err := a.withPartOfLabel(expectedObject)
 		if err != nil {
 			// TODO: Differentiate between server-fixable vs client-fixable errors?
 			results.applyError(gvk, nn, fmt.Errorf("failed to marshal object to JSON: %w", err))
 			continue
 		}
 
-		lastApplied, err := client.Patch(ctx, gvk, nn, types.ApplyPatchType, j, a.patchOptions)
+		lastApplied, err := a.apply(ctx, client, gvk, nn, j, results)
 		if err != nil {
 			results.applyError(gvk, nn, fmt.Errorf("error from apply: %w", err))
 			continue
@@ -158,3 +178,63 @@ func (a *ApplySet) ApplyOnce(ctx context.Context) (*ApplyResults, error) {
 	}
 	return results, nil
 }
+
+// apply performs a server-side apply of the object.
+// When we are forcing, we first apply without forcing, so that we can report any conflicts that we override.
+func (a *ApplySet) apply(ctx context.Context, client *UnstructuredClient, gvk schema.GroupVersionKind, nn types.NamespacedName, data []byte, results *ApplyResults) (*unstructured.Unstructured, error) {
+	if a.patchOptions.Force == nil || !*a.patchOptions.Force {
+		return client.Patch(ctx, gvk, nn, types.ApplyPatchType, data, a.patchOptions)
+	}
+
+	patchOptions := a.patchOptions
+	patchOptions.Force = nil
+	applied, err := client.Patch(ctx, gvk, nn, types.ApplyPatchType, data, patchOptions)
+	if err == nil || !apierrors.IsConflict(err) {
+		return applied, err
+	}
+	results.applyConflict(gvk, nn, err)
+
+	return client.Patch(ctx, gvk, nn, types.ApplyPatchType, data, a.patchOptions)
+}
+
+// unstructuredClient returns an UnstructuredClient using our client and restMapper.
+func (a *ApplySet) unstructuredClient() *UnstructuredClient {
+	return &UnstructuredClient{
+		client:     a.client,
+		restMapper: a.restMapper,
+	}
+}
+
+// CheckHealth reads the desired objects back from the cluster and reports their health, without applying them.
+// Objects that do not exist are reported as unhealthy.
+func (a *ApplySet) CheckHealth(ctx context.Context) (*HealthResults, error) {
+	// snapshot the state
+	a.mutex.Lock()
+	trackers := a.trackers
+	a.mutex.Unlock()
+
+	client := a.unstructuredClient()
+
+	results := &HealthResults{}
+
+	for i := range trackers.items {
+		tracker := &trackers.items[i]
+		expectedObject := tracker.desired
+
+		gvk := expectedObject.GroupVersionKind()
+		nn := types.NamespacedName{Namespace: expectedObject.GetNamespace(), Name: expectedObject.GetName()}
+
+		currentObj, err := client.Get(ctx, gvk, nn)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error getting object %s %s: %w", gvk, nn, err)
+			}
+			results.reportHealth(gvk, nn, false)
+			continue
+		}
+
+		tracker.isHealthy = isHealthy(currentObj)
+		results.reportHealth(gvk, nn, tracker.isHealthy)
+	}
+	return results, nil
+}
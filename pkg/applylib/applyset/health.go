@@ -57,6 +57,19 @@ func isHealthy(u *unstructured.Unstructured) bool {
 		return true
 	}
 
+	switch gvk.GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"},
+		schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		if !isRolledOut(u) {
+			return false
+		}
+	case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+		if !isEstablished(u) {
+			return false
+		}
+	}
+
 	ready := true
 	statusConditions, found, err := unstructured.NestedFieldNoCopy(u.Object, "status", "conditions")
 	if err != nil || !found {
@@ -120,6 +133,77 @@ func isHealthy(u *unstructured.Unstructured) bool {
 	return ready
 }
 
+// isRolledOut reports whether a workload (DaemonSet, Deployment or StatefulSet) has rolled out its current spec,
+// i.e. the controller has observed the latest generation and all the desired pods are updated and available.
+func isRolledOut(u *unstructured.Unstructured) bool {
+	observedGeneration, _, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	if observedGeneration < u.GetGeneration() {
+		klog.Infof("object %s has not yet observed generation %d", humanName(u), u.GetGeneration())
+		return false
+	}
+
+	updateStrategy, _, _ := unstructured.NestedString(u.Object, "spec", "updateStrategy", "type")
+	onDelete := updateStrategy == "OnDelete"
+
+	var desired, updated, available int64
+	switch u.GetKind() {
+	case "DaemonSet":
+		desired, _, _ = unstructured.NestedInt64(u.Object, "status", "desiredNumberScheduled")
+		updated, _, _ = unstructured.NestedInt64(u.Object, "status", "updatedNumberScheduled")
+		available, _, _ = unstructured.NestedInt64(u.Object, "status", "numberAvailable")
+
+	case "Deployment", "StatefulSet":
+		replicas, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+		desired = replicas
+		updated, _, _ = unstructured.NestedInt64(u.Object, "status", "updatedReplicas")
+		if u.GetKind() == "Deployment" {
+			available, _, _ = unstructured.NestedInt64(u.Object, "status", "availableReplicas")
+
+			// Pods from the previous ReplicaSet are still running
+			current, _, _ := unstructured.NestedInt64(u.Object, "status", "replicas")
+			if current > updated {
+				klog.Infof("object %s has %d old replicas", humanName(u), current-updated)
+				return false
+			}
+		} else {
+			available, _, _ = unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+
+			// Pods below the partition are deliberately not updated
+			partition, _, _ := unstructured.NestedInt64(u.Object, "spec", "updateStrategy", "rollingUpdate", "partition")
+			updated += partition
+		}
+	}
+
+	if !onDelete && updated < desired {
+		klog.Infof("object %s has %d of %d replicas updated", humanName(u), updated, desired)
+		return false
+	}
+	if available < desired {
+		klog.Infof("object %s has %d of %d replicas available", humanName(u), available, desired)
+		return false
+	}
+	return true
+}
+
+// isEstablished reports whether a CustomResourceDefinition is being served; the conditions are missing until it is.
+func isEstablished(u *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if conditionMap["type"] == "Established" && conditionMap["status"] == "True" {
+			return true
+		}
+	}
+	klog.Infof("object %s is not yet established", humanName(u))
+	return false
+}
+
 // humanName returns an identifier for the object suitable for printing in log messages
 func humanName(u *unstructured.Unstructured) string {
 	gvk := u.GroupVersionKind()
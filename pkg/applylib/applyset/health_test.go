@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestIsHealthy(t *testing.T) {
+	grid := []struct {
+		name     string
+		object   string
+		expected bool
+	}{
+		{
+			name: "configmap",
+			object: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`,
+			expected: true,
+		},
+		{
+			name: "deployment rolled out",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  generation: 2
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 2
+  conditions:
+  - type: Available
+    status: "True"
+  - type: Progressing
+    status: "True"
+`,
+			expected: true,
+		},
+		{
+			name: "deployment generation not observed",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  generation: 3
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 2
+`,
+			expected: false,
+		},
+		{
+			name: "deployment with old replicas",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  generation: 2
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 3
+  updatedReplicas: 2
+  availableReplicas: 2
+`,
+			expected: false,
+		},
+		{
+			name: "deployment not available",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  generation: 1
+status:
+  observedGeneration: 1
+  replicas: 1
+  updatedReplicas: 1
+  conditions:
+  - type: Available
+    status: "False"
+`,
+			expected: false,
+		},
+		{
+			name: "daemonset rolled out",
+			object: `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  generation: 1
+status:
+  observedGeneration: 1
+  desiredNumberScheduled: 3
+  updatedNumberScheduled: 3
+  numberAvailable: 3
+`,
+			expected: true,
+		},
+		{
+			name: "daemonset rolling out",
+			object: `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  generation: 1
+status:
+  observedGeneration: 1
+  desiredNumberScheduled: 3
+  updatedNumberScheduled: 1
+  numberAvailable: 3
+`,
+			expected: false,
+		},
+		{
+			name: "daemonset with OnDelete strategy",
+			object: `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  generation: 1
+spec:
+  updateStrategy:
+    type: OnDelete
+status:
+  observedGeneration: 1
+  desiredNumberScheduled: 3
+  updatedNumberScheduled: 1
+  numberAvailable: 3
+`,
+			expected: true,
+		},
+		{
+			name: "statefulset with partition",
+			object: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  generation: 1
+spec:
+  replicas: 3
+  updateStrategy:
+    type: RollingUpdate
+    rollingUpdate:
+      partition: 2
+status:
+  observedGeneration: 1
+  updatedReplicas: 1
+  readyReplicas: 3
+`,
+			expected: true,
+		},
+		{
+			name: "statefulset not ready",
+			object: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  generation: 1
+spec:
+  replicas: 3
+status:
+  observedGeneration: 1
+  updatedReplicas: 3
+  readyReplicas: 2
+`,
+			expected: false,
+		},
+		{
+			name: "crd established",
+			object: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: certificates.cert-manager.io
+status:
+  conditions:
+  - type: NamesAccepted
+    status: "True"
+  - type: Established
+    status: "True"
+`,
+			expected: true,
+		},
+		{
+			name: "crd not yet established",
+			object: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: certificates.cert-manager.io
+`,
+			expected: false,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			j, err := yaml.YAMLToJSON([]byte(g.object))
+			if err != nil {
+				t.Fatalf("failed to convert object to JSON: %v", err)
+			}
+			u := &unstructured.Unstructured{}
+			if err := u.UnmarshalJSON(j); err != nil {
+				t.Fatalf("failed to parse object: %v", err)
+			}
+
+			actual := isHealthy(u)
+			if actual != g.expected {
+				t.Errorf("isHealthy returned %v, expected %v", actual, g.expected)
+			}
+		})
+	}
+}
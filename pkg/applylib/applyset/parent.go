@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// The labels and annotations of ApplySets, as defined by KEP-3659 and used by kubectl apply --prune --applyset.
+const (
+	// PartOfLabel is the label on member objects, holding the ID of the ApplySet.
+	PartOfLabel = "applyset.kubernetes.io/part-of"
+	// IDLabel is the label on the parent object, holding the ID of the ApplySet.
+	IDLabel = "applyset.kubernetes.io/id"
+	// ToolingAnnotation is the annotation on the parent object naming the tool that manages the ApplySet.
+	ToolingAnnotation = "applyset.kubernetes.io/tooling"
+	// ContainsGroupKindsAnnotation is the annotation on the parent object listing the kinds of the members.
+	ContainsGroupKindsAnnotation = "applyset.kubernetes.io/contains-group-kinds"
+	// AdditionalNamespacesAnnotation is the annotation on the parent object listing the namespaces of members,
+	// other than the namespace of the parent.
+	AdditionalNamespacesAnnotation = "applyset.kubernetes.io/additional-namespaces"
+
+	// AdoptedAnnotation is set to "true" on the parent object once Adopt has completed.
+	// It is specific to kOps, and not part of KEP-3659.
+	AdoptedAnnotation = "applyset.kops.k8s.io/adopted"
+)
+
+// Parent identifies the parent object of an ApplySet, which records the kinds and namespaces of its members.
+type Parent struct {
+	// GroupVersionKind is the kind of the parent; kubectl supports Secrets and ConfigMaps.
+	GroupVersionKind schema.GroupVersionKind
+	// Namespace is the namespace of the parent.
+	Namespace string
+	// Name is the name of the parent.
+	Name string
+	// Tooling identifies the tool that manages the ApplySet, in the form name/version.
+	Tooling string
+}
+
+// ID returns the ID of the ApplySet, which is derived from the identity of the parent.
+func (p *Parent) ID() string {
+	unencoded := strings.Join([]string{p.Name, p.Namespace, p.GroupVersionKind.Kind, p.GroupVersionKind.Group}, ".")
+	hashed := sha256.Sum256([]byte(unencoded))
+	return "applyset-" + base64.RawURLEncoding.EncodeToString(hashed[:]) + "-v1"
+}
+
+// memberSet is the set of kinds and namespaces recorded on the parent.
+type memberSet struct {
+	groupKinds sets.Set[schema.GroupKind]
+	namespaces sets.Set[string]
+
+	// adopted records that Adopt has completed.
+	adopted bool
+}
+
+func newMemberSet() *memberSet {
+	return &memberSet{
+		groupKinds: sets.New[schema.GroupKind](),
+		namespaces: sets.New[string](),
+	}
+}
+
+// union returns a memberSet containing the members of both sets.
+func (m *memberSet) union(o *memberSet) *memberSet {
+	return &memberSet{
+		groupKinds: m.groupKinds.Union(o.groupKinds),
+		namespaces: m.namespaces.Union(o.namespaces),
+		adopted:    m.adopted || o.adopted,
+	}
+}
+
+// desiredMembers returns the kinds and namespaces of the desired objects.
+func desiredMembers(trackers *objectTrackerList) *memberSet {
+	members := newMemberSet()
+	for i := range trackers.items {
+		obj := trackers.items[i].desired
+		members.groupKinds.Insert(obj.GroupVersionKind().GroupKind())
+		if ns := obj.GetNamespace(); ns != "" {
+			members.namespaces.Insert(ns)
+		}
+	}
+	return members
+}
+
+// IsAdopted returns true if Adopt has completed for the ApplySet.
+// Until then, Adopt should be called before applying, so that an interrupted adoption is retried.
+func (a *ApplySet) IsAdopted(ctx context.Context) (bool, error) {
+	if a.parent == nil {
+		return false, fmt.Errorf("ApplySet does not have a parent")
+	}
+	recorded, _, err := a.readParent(ctx, a.unstructuredClient())
+	if err != nil {
+		return false, err
+	}
+	return recorded.adopted, nil
+}
+
+// readParent reads the members recorded on the parent object; it returns an empty set if the parent does not exist.
+func (a *ApplySet) readParent(ctx context.Context, client *UnstructuredClient) (*memberSet, bool, error) {
+	members := newMemberSet()
+
+	nn := types.NamespacedName{Namespace: a.parent.Namespace, Name: a.parent.Name}
+	obj, err := client.Get(ctx, a.parent.GroupVersionKind, nn)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return members, false, nil
+		}
+		return nil, false, fmt.Errorf("error reading ApplySet parent %s: %w", nn, err)
+	}
+
+	annotations := obj.GetAnnotations()
+	for _, s := range strings.Split(annotations[ContainsGroupKindsAnnotation], ",") {
+		if s != "" {
+			members.groupKinds.Insert(schema.ParseGroupKind(s))
+		}
+	}
+	for _, s := range strings.Split(annotations[AdditionalNamespacesAnnotation], ",") {
+		if s != "" {
+			members.namespaces.Insert(s)
+		}
+	}
+	members.adopted = annotations[AdoptedAnnotation] == "true"
+	return members, true, nil
+}
+
+// writeParent records the members on the parent object, creating it if needed.
+func (a *ApplySet) writeParent(ctx context.Context, client *UnstructuredClient, members *memberSet) error {
+	var groupKinds []string
+	for gk := range members.groupKinds {
+		groupKinds = append(groupKinds, gk.String())
+	}
+	sort.Strings(groupKinds)
+
+	var namespaces []string
+	for ns := range members.namespaces {
+		if ns != a.parent.Namespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+
+	annotations := map[string]interface{}{
+		ToolingAnnotation:              a.parent.Tooling,
+		ContainsGroupKindsAnnotation:   strings.Join(groupKinds, ","),
+		AdditionalNamespacesAnnotation: strings.Join(namespaces, ","),
+	}
+	// We must keep writing the annotation, as server-side apply removes the fields we no longer apply.
+	if members.adopted {
+		annotations[AdoptedAnnotation] = "true"
+	}
+
+	parent := map[string]interface{}{
+		"apiVersion": a.parent.GroupVersionKind.GroupVersion().String(),
+		"kind":       a.parent.GroupVersionKind.Kind,
+		"metadata": map[string]interface{}{
+			"name":      a.parent.Name,
+			"namespace": a.parent.Namespace,
+			"labels": map[string]interface{}{
+				IDLabel: a.parent.ID(),
+			},
+			"annotations": annotations,
+		},
+	}
+	j, err := json.Marshal(parent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ApplySet parent to JSON: %w", err)
+	}
+
+	nn := types.NamespacedName{Namespace: a.parent.Namespace, Name: a.parent.Name}
+	if _, err := client.Patch(ctx, a.parent.GroupVersionKind, nn, types.ApplyPatchType, j, a.patchOptions); err != nil {
+		return fmt.Errorf("error writing ApplySet parent %s: %w", nn, err)
+	}
+	return nil
+}
+
+// withPartOfLabel returns the JSON of the object, with the label that makes it a member of the ApplySet.
+func (a *ApplySet) withPartOfLabel(obj ApplyableObject) ([]byte, error) {
+	j, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	if a.parent == nil {
+		return j, nil
+	}
+
+	u := make(map[string]interface{})
+	if err := json.Unmarshal(j, &u); err != nil {
+		return nil, err
+	}
+	metadata, _ := u["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+		u["metadata"] = metadata
+	}
+	labels, _ := metadata["labels"].(map[string]interface{})
+	if labels == nil {
+		labels = make(map[string]interface{})
+		metadata["labels"] = labels
+	}
+	labels[PartOfLabel] = a.parent.ID()
+	return json.Marshal(u)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// memberKey identifies a member of the ApplySet; unlike objectKey it ignores the version.
+type memberKey struct {
+	GroupKind schema.GroupKind
+	Namespace string
+	Name      string
+}
+
+// Prune deletes the members of the ApplySet that are not desired objects,
+// and then records the desired objects as the only members on the parent.
+// It should only be called once the desired objects have all been applied.
+func (a *ApplySet) Prune(ctx context.Context) (*PruneResults, error) {
+	if a.parent == nil {
+		return nil, fmt.Errorf("cannot prune an ApplySet without a parent")
+	}
+
+	// snapshot the state
+	a.mutex.Lock()
+	trackers := a.trackers
+	a.mutex.Unlock()
+
+	client := a.unstructuredClient()
+
+	desired := sets.New[memberKey]()
+	for i := range trackers.items {
+		obj := trackers.items[i].desired
+		desired.Insert(memberKey{
+			GroupKind: obj.GroupVersionKind().GroupKind(),
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		})
+	}
+	desiredMembers := desiredMembers(trackers)
+
+	recorded, _, err := a.readParent(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	members := recorded.union(desiredMembers)
+	members.namespaces.Insert(a.parent.Namespace)
+
+	// Sort the kinds, so we prune in a predictable order.
+	groupKinds := members.groupKinds.UnsortedList()
+	sort.Slice(groupKinds, func(i, j int) bool {
+		return groupKinds[i].String() < groupKinds[j].String()
+	})
+
+	results := &PruneResults{}
+	listOptions := metav1.ListOptions{LabelSelector: PartOfLabel + "=" + a.parent.ID()}
+	for _, gk := range groupKinds {
+		restMapping, err := a.restMapper.RESTMapping(gk)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				// The kind no longer exists (for example the CRD was removed), so neither do any of its objects.
+				klog.V(2).Infof("skipping pruning of kind %v, which is not known to the server", gk)
+				continue
+			}
+			return nil, fmt.Errorf("error getting rest mapping for %v: %w", gk, err)
+		}
+
+		var namespaces []string
+		if restMapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			namespaces = sets.List(members.namespaces)
+		} else {
+			namespaces = []string{""}
+		}
+
+		for _, ns := range namespaces {
+			objects, err := client.List(ctx, restMapping.GroupVersionKind, ns, listOptions)
+			if err != nil {
+				return nil, err
+			}
+			for i := range objects.Items {
+				obj := &objects.Items[i]
+				key := memberKey{GroupKind: gk, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+				if desired.Has(key) {
+					continue
+				}
+
+				nn := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+				klog.Infof("pruning %s %s", restMapping.GroupVersionKind, nn)
+				propagationPolicy := metav1.DeletePropagationBackground
+				if err := client.Delete(ctx, restMapping.GroupVersionKind, nn, metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}); err != nil {
+					if apierrors.IsNotFound(err) {
+						continue
+					}
+					return nil, fmt.Errorf("error pruning %s %s: %w", restMapping.GroupVersionKind, nn, err)
+				}
+				results.reportPruned(restMapping.GroupVersionKind, nn)
+			}
+		}
+	}
+
+	desiredMembers.adopted = recorded.adopted
+	if err := a.writeParent(ctx, client, desiredMembers); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Adopt makes existing objects members of the ApplySet, so that they are pruned unless they are desired objects.
+// This is used to take over objects that were applied before the ApplySet was created.
+// The field managers of the adopted and desired objects are migrated to server-side apply, as in ApplyOnce,
+// so that fields that were set with client-side apply or an Update by our field manager are removed when they are dropped from the manifest.
+// Once all the objects have been adopted, this is recorded on the parent; until then, Adopt should be retried.
+func (a *ApplySet) Adopt(ctx context.Context, objects []*unstructured.Unstructured) error {
+	if a.parent == nil {
+		return fmt.Errorf("cannot adopt objects into an ApplySet without a parent")
+	}
+
+	// snapshot the state
+	a.mutex.Lock()
+	trackers := a.trackers
+	a.mutex.Unlock()
+
+	client := a.unstructuredClient()
+
+	// The parent must list the kinds and namespaces of the adopted objects before they are labeled,
+	// otherwise we could lose track of them if we are interrupted.
+	adopted := newMemberSet()
+	for _, obj := range objects {
+		adopted.groupKinds.Insert(obj.GroupVersionKind().GroupKind())
+		if ns := obj.GetNamespace(); ns != "" {
+			adopted.namespaces.Insert(ns)
+		}
+	}
+	recorded, _, err := a.readParent(ctx, client)
+	if err != nil {
+		return err
+	}
+	members := recorded.union(adopted)
+	if len(objects) != 0 {
+		if err := a.writeParent(ctx, client, members); err != nil {
+			return err
+		}
+	}
+
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				PartOfLabel: a.parent.ID(),
+			},
+		},
+	}
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch to JSON: %w", err)
+	}
+
+	managedFields := &ManagedFieldsMigrator{
+		NewManager: a.patchOptions.FieldManager,
+		Client:     client,
+	}
+
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		nn := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		if err := managedFields.Migrate(ctx, obj); err != nil {
+			return fmt.Errorf("error migrating field managers of %s %s: %w", gvk, nn, err)
+		}
+		if obj.GetLabels()[PartOfLabel] == a.parent.ID() {
+			continue
+		}
+		klog.V(2).Infof("adopting %s %s into ApplySet %s", gvk, nn, a.parent.Name)
+		if _, err := client.Patch(ctx, gvk, nn, types.MergePatchType, patchJSON, metav1.PatchOptions{FieldManager: a.patchOptions.FieldManager}); err != nil {
+			return fmt.Errorf("error adopting %s %s: %w", gvk, nn, err)
+		}
+	}
+
+	for i := range trackers.items {
+		desired := trackers.items[i].desired
+		gvk := desired.GroupVersionKind()
+		nn := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
+		current, err := client.Get(ctx, gvk, nn)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("error reading %s %s: %w", gvk, nn, err)
+		}
+		if err := managedFields.Migrate(ctx, current); err != nil {
+			return fmt.Errorf("error migrating field managers of %s %s: %w", gvk, nn, err)
+		}
+	}
+
+	members.adopted = true
+	return a.writeParent(ctx, client, members)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyset
+
+import (
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kops/pkg/applylib/mocks"
+)
+
+func TestPrune(t *testing.T) {
+	h := mocks.NewHarness(t)
+
+	h.WithObjects()
+
+	parent := &Parent{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Secret"},
+		Namespace:        "kube-system",
+		Name:             "test-applyset",
+		Tooling:          "kops/v1.0.0",
+	}
+
+	client := h.DynamicClient()
+	configMaps := client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"})
+	secrets := client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"})
+
+	// legacy was applied before we used ApplySets, so is not a member.
+	existing := h.ParseObjects(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: legacy
+  namespace: ns1
+data:
+  foo: bar
+`)
+	for _, obj := range existing {
+		j, err := obj.MarshalJSON()
+		if err != nil {
+			t.Fatalf("error marshaling object: %v", err)
+		}
+		if _, err := configMaps.Namespace(obj.GetNamespace()).Patch(h.Ctx, obj.GetName(), types.ApplyPatchType, j, metav1.PatchOptions{FieldManager: "test"}); err != nil {
+			t.Fatalf("error creating object: %v", err)
+		}
+	}
+
+	apply := func(y string) *ApplySet {
+		s, err := New(Options{
+			RESTMapper:   h.RESTMapper(),
+			Client:       client,
+			PatchOptions: metav1.PatchOptions{FieldManager: "kops"},
+			Parent:       parent,
+		})
+		if err != nil {
+			t.Fatalf("error building applyset: %v", err)
+		}
+
+		var applyableObjects []ApplyableObject
+		for _, obj := range h.ParseObjects(y) {
+			applyableObjects = append(applyableObjects, obj)
+		}
+		if err := s.SetDesiredObjects(applyableObjects); err != nil {
+			t.Fatalf("error setting desired objects: %v", err)
+		}
+		return s
+	}
+
+	v1 := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+  namespace: ns1
+data:
+  foo: bar
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+  namespace: ns2
+data:
+  foo: bar
+`
+
+	isAdopted := func(s *ApplySet) bool {
+		t.Helper()
+		adopted, err := s.IsAdopted(h.Ctx)
+		if err != nil {
+			t.Fatalf("error checking for adoption: %v", err)
+		}
+		return adopted
+	}
+
+	// An apply that was interrupted before adopting does not record the adoption, so it is retried.
+	s := apply(v1)
+	if isAdopted(s) {
+		t.Fatalf("applyset adopted before first apply")
+	}
+	if _, err := s.ApplyOnce(h.Ctx); err != nil {
+		t.Fatalf("error applying objects: %v", err)
+	}
+	if isAdopted(s) {
+		t.Fatalf("applyset adopted without calling Adopt")
+	}
+
+	// The first apply adopts the legacy object, which is not in the manifest.
+	s = apply(v1)
+	if err := s.Adopt(h.Ctx, existing); err != nil {
+		t.Fatalf("error adopting objects: %v", err)
+	}
+	if !isAdopted(s) {
+		t.Fatalf("adoption was not recorded")
+	}
+	results, err := s.ApplyOnce(h.Ctx)
+	if err != nil {
+		t.Fatalf("error applying objects: %v", err)
+	}
+	if !results.AllApplied() {
+		t.Fatalf("not all objects were applied")
+	}
+	pruneResults, err := s.Prune(h.Ctx)
+	if err != nil {
+		t.Fatalf("error pruning objects: %v", err)
+	}
+	if got, want := pruneResults.Pruned(), []string{"ConfigMap:ns1/legacy"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected pruned objects; got %v, want %v", got, want)
+	}
+
+	a, err := configMaps.Namespace("ns1").Get(h.Ctx, "a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting configmap: %v", err)
+	}
+	if got := a.GetLabels()[PartOfLabel]; got != parent.ID() {
+		t.Errorf("unexpected %s label %q, want %q", PartOfLabel, got, parent.ID())
+	}
+
+	checkParent := func(wantGroupKinds, wantNamespaces string) {
+		t.Helper()
+		obj, err := secrets.Namespace(parent.Namespace).Get(h.Ctx, parent.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("error getting parent: %v", err)
+		}
+		if got := obj.GetLabels()[IDLabel]; got != parent.ID() {
+			t.Errorf("unexpected %s label %q, want %q", IDLabel, got, parent.ID())
+		}
+		if got := obj.GetAnnotations()[ContainsGroupKindsAnnotation]; got != wantGroupKinds {
+			t.Errorf("unexpected %s annotation %q, want %q", ContainsGroupKindsAnnotation, got, wantGroupKinds)
+		}
+		if got := obj.GetAnnotations()[AdditionalNamespacesAnnotation]; got != wantNamespaces {
+			t.Errorf("unexpected %s annotation %q, want %q", AdditionalNamespacesAnnotation, got, wantNamespaces)
+		}
+	}
+	checkParent("ConfigMap", "ns1,ns2")
+	if !isAdopted(s) {
+		t.Errorf("adoption was not kept after applying and pruning")
+	}
+
+	// Dropping an object from the manifest prunes it.
+	s = apply(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+  namespace: ns1
+data:
+  foo: baz
+`)
+	if _, err := s.ApplyOnce(h.Ctx); err != nil {
+		t.Fatalf("error applying objects: %v", err)
+	}
+	pruneResults, err = s.Prune(h.Ctx)
+	if err != nil {
+		t.Fatalf("error pruning objects: %v", err)
+	}
+	if got, want := pruneResults.Pruned(), []string{"ConfigMap:ns2/b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected pruned objects; got %v, want %v", got, want)
+	}
+	for _, nn := range []types.NamespacedName{{Namespace: "ns1", Name: "legacy"}, {Namespace: "ns2", Name: "b"}} {
+		_, err := configMaps.Namespace(nn.Namespace).Get(h.Ctx, nn.Name, metav1.GetOptions{})
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("expected configmap %s to be pruned, got error %v", nn, err)
+		}
+	}
+	checkParent("ConfigMap", "ns1")
+}
@@ -29,6 +29,7 @@ type ApplyResults struct {
 	applyFailCount    int
 	healthyCount      int
 	unhealthyCount    int
+	conflicts         []string
 }
 
 // AllApplied is true if the desired state has been successfully applied for all objects.
@@ -47,6 +48,11 @@ func (r *ApplyResults) AllHealthy() bool {
 	return r.unhealthyCount == 0
 }
 
+// Conflicts returns the names of the objects where we overrode changes made by another field manager.
+func (r *ApplyResults) Conflicts() []string {
+	return r.conflicts
+}
+
 // checkInvariants is an internal function that warns if the object doesn't match the expected invariants.
 func (r *ApplyResults) checkInvariants() {
 	if r.total != (r.applySuccessCount + r.applyFailCount) {
@@ -63,6 +69,12 @@ func (r *ApplyResults) applyError(gvk schema.GroupVersionKind, nn types.Namespac
 	klog.Warningf("error from apply on %s %s: %v", gvk, nn, err)
 }
 
+// applyConflict records that applying an object conflicted with another field manager, and that we are forcing the apply.
+func (r *ApplyResults) applyConflict(gvk schema.GroupVersionKind, nn types.NamespacedName, err error) {
+	r.conflicts = append(r.conflicts, objectName(gvk, nn))
+	klog.Warningf("overriding conflicting changes to %s %s: %v", gvk, nn, err)
+}
+
 // applySuccess records that an object was applied and this succeeded.
 func (r *ApplyResults) applySuccess(gvk schema.GroupVersionKind, nn types.NamespacedName) {
 	r.applySuccessCount++
@@ -76,3 +88,56 @@ func (r *ApplyResults) reportHealth(gvk schema.GroupVersionKind, nn types.Namesp
 		r.unhealthyCount++
 	}
 }
+
+// HealthResults contains the results of a CheckHealth operation.
+type HealthResults struct {
+	healthyCount int
+	unhealthy    []string
+}
+
+// AllHealthy is true if all the objects exist and have converged to a "ready" state.
+func (r *HealthResults) AllHealthy() bool {
+	return len(r.unhealthy) == 0
+}
+
+// Unhealthy returns the names of the objects that are not healthy, in the form used in log messages.
+func (r *HealthResults) Unhealthy() []string {
+	return r.unhealthy
+}
+
+// reportHealth records the health of an object.
+func (r *HealthResults) reportHealth(gvk schema.GroupVersionKind, nn types.NamespacedName, isHealthy bool) {
+	if isHealthy {
+		r.healthyCount++
+		return
+	}
+	r.unhealthy = append(r.unhealthy, objectName(gvk, nn))
+}
+
+// PruneResults contains the results of a Prune operation.
+type PruneResults struct {
+	pruned []string
+}
+
+// Pruned returns the names of the objects that were deleted, in the form used in log messages.
+func (r *PruneResults) Pruned() []string {
+	return r.pruned
+}
+
+// reportPruned records that an object was deleted.
+func (r *PruneResults) reportPruned(gvk schema.GroupVersionKind, nn types.NamespacedName) {
+	r.pruned = append(r.pruned, objectName(gvk, nn))
+}
+
+// objectName returns the name of an object for use in messages, in the form Kind.group:namespace/name.
+func objectName(gvk schema.GroupVersionKind, nn types.NamespacedName) string {
+	name := gvk.Kind
+	if gvk.Group != "" {
+		name += "." + gvk.Group
+	}
+	name += ":"
+	if nn.Namespace != "" {
+		name += nn.Namespace + "/"
+	}
+	return name + nn.Name
+}
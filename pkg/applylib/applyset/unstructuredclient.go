@@ -120,3 +120,30 @@ func (c *UnstructuredClient) Get(ctx context.Context, gvk schema.GroupVersionKin
 
 	return obj, nil
 }
+
+// List lists the objects of the kind in the namespace, or all the objects of a cluster-scoped kind if the namespace is empty.
+func (c *UnstructuredClient) List(ctx context.Context, gvk schema.GroupVersionKind, ns string, opt metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	dynamicResource, err := c.dynamicResource(ctx, gvk, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := dynamicResource.List(ctx, opt)
+	if err != nil {
+		return nil, fmt.Errorf("could not list objects: %w", err)
+	}
+	return objects, nil
+}
+
+// Delete deletes the specified object.
+func (c *UnstructuredClient) Delete(ctx context.Context, gvk schema.GroupVersionKind, nn types.NamespacedName, opt metav1.DeleteOptions) error {
+	dynamicResource, err := c.dynamicResource(ctx, gvk, nn.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := dynamicResource.Delete(ctx, nn.Name, opt); err != nil {
+		return fmt.Errorf("could not delete object: %w", err)
+	}
+	return nil
+}
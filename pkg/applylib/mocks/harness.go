@@ -23,7 +23,6 @@ import (
 	"io"
 	"strings"
 	"testing"
-	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -32,7 +31,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	yamlserializer "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
-	"k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
@@ -180,18 +180,12 @@ func (h *Harness) RESTConfig() *rest.Config {
 
 func (h *Harness) RESTMapper() *restmapper.DeferredDiscoveryRESTMapper {
 	if h.restMapper == nil {
-		// discoveryClient, err := discovery.NewDiscoveryClientForConfig(h.RESTConfig())
-		// if err != nil {
-		// 	h.Fatalf("error building discovery client: %")
-		// }
-
-		// TODO: Use memory cache or simplified rest mapper
-		discoveryClient, err := disk.NewCachedDiscoveryClientForConfig(h.RESTConfig(), "/home/justinsb/tmp/discovery", "", time.Minute)
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(h.RESTConfig())
 		if err != nil {
 			h.Fatalf("error building discovery client: %v", err)
 		}
 
-		restMapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+		restMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
 
 		h.restMapper = restMapper
 	}
@@ -137,6 +137,58 @@ func (s *MockKubeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	buildListRequest := func(common resourceRequestBase) {
+		switch r.Method {
+		case http.MethodGet:
+			req = &listResource{
+				resourceRequestBase: common,
+			}
+		}
+	}
+
+	if len(tokens) == 3 {
+		if tokens[0] == "api" {
+			buildListRequest(resourceRequestBase{
+				Group:    "",
+				Version:  tokens[1],
+				Resource: tokens[2],
+			})
+			matchedPath = true
+		}
+	}
+	if len(tokens) == 4 {
+		if tokens[0] == "apis" {
+			buildListRequest(resourceRequestBase{
+				Group:    tokens[1],
+				Version:  tokens[2],
+				Resource: tokens[3],
+			})
+			matchedPath = true
+		}
+	}
+	if len(tokens) == 5 {
+		if tokens[0] == "api" && tokens[2] == "namespaces" {
+			buildListRequest(resourceRequestBase{
+				Group:     "",
+				Version:   tokens[1],
+				Namespace: tokens[3],
+				Resource:  tokens[4],
+			})
+			matchedPath = true
+		}
+	}
+	if len(tokens) == 6 {
+		if tokens[0] == "apis" && tokens[3] == "namespaces" {
+			buildListRequest(resourceRequestBase{
+				Group:     tokens[1],
+				Version:   tokens[2],
+				Namespace: tokens[4],
+				Resource:  tokens[5],
+			})
+			matchedPath = true
+		}
+	}
+
 	buildObjectRequest := func(common resourceRequestBase) {
 		switch r.Method {
 		case http.MethodGet:
@@ -151,6 +203,10 @@ func (s *MockKubeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			req = &putResource{
 				resourceRequestBase: common,
 			}
+		case http.MethodDelete:
+			req = &deleteResource{
+				resourceRequestBase: common,
+			}
 		}
 	}
 
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockkubeapiserver
+
+import (
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// deleteResource is a request to delete a single resource
+type deleteResource struct {
+	resourceRequestBase
+}
+
+// Run serves the http request
+func (req *deleteResource) Run(s *MockKubeAPIServer) error {
+	gr := schema.GroupResource{Group: req.Group, Resource: req.Resource}
+
+	id := types.NamespacedName{Namespace: req.Namespace, Name: req.Name}
+	objects := s.objects[gr]
+	if objects == nil || objects.Objects[id] == nil {
+		return req.writeErrorResponse(http.StatusNotFound)
+	}
+	delete(objects.Objects, id)
+
+	// TODO: We don't implement finalizers or garbage collection
+	response := &metav1.Status{}
+	response.Kind = "Status"
+	response.APIVersion = "v1"
+	response.Status = metav1.StatusSuccess
+	return req.writeResponse(response)
+}
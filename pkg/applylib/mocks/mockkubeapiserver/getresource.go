@@ -20,7 +20,7 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
@@ -48,7 +48,7 @@ type getResource struct {
 func (req *getResource) Run(s *MockKubeAPIServer) error {
 	gr := schema.GroupResource{Group: req.Group, Resource: req.Resource}
 
-	var object runtime.Object
+	var object *unstructured.Unstructured
 	objects := s.objects[gr]
 	if objects != nil {
 		object = objects.Objects[types.NamespacedName{Namespace: req.Namespace, Name: req.Name}]
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockkubeapiserver
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// listResource is a request to list the resources of a kind, optionally in a single namespace
+type listResource struct {
+	resourceRequestBase
+}
+
+// Run serves the http request
+func (req *listResource) Run(s *MockKubeAPIServer) error {
+	gr := schema.GroupResource{Group: req.Group, Resource: req.Resource}
+
+	selector, err := labels.Parse(req.r.URL.Query().Get("labelSelector"))
+	if err != nil {
+		return fmt.Errorf("cannot parse labelSelector: %w", err)
+	}
+
+	kind := ""
+	for _, resource := range s.schema.resources {
+		if resource.Group == req.Group && resource.Version == req.Version && resource.Name == req.Resource {
+			kind = resource.Kind
+		}
+	}
+
+	response := &unstructured.UnstructuredList{}
+	response.SetAPIVersion(schema.GroupVersion{Group: req.Group, Version: req.Version}.String())
+	response.SetKind(kind + "List")
+
+	objects := s.objects[gr]
+	if objects != nil {
+		for id, obj := range objects.Objects {
+			if req.Namespace != "" && id.Namespace != req.Namespace {
+				continue
+			}
+			if !selector.Matches(labels.Set(obj.GetLabels())) {
+				continue
+			}
+			response.Items = append(response.Items, *obj)
+		}
+	}
+	sort.Slice(response.Items, func(i, j int) bool {
+		if response.Items[i].GetNamespace() != response.Items[j].GetNamespace() {
+			return response.Items[i].GetNamespace() < response.Items[j].GetNamespace()
+		}
+		return response.Items[i].GetName() < response.Items[j].GetName()
+	})
+
+	return req.writeResponse(response)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package mockkubeapiserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -48,8 +49,9 @@ func (req *patchResource) Run(s *MockKubeAPIServer) error {
 		return err
 	}
 
+	// Merge patches do not need to include the apiVersion and kind, so we don't parse as an unstructured object.
 	body := &unstructured.Unstructured{}
-	if err := body.UnmarshalJSON(bodyBytes); err != nil {
+	if err := json.Unmarshal(bodyBytes, &body.Object); err != nil {
 		return fmt.Errorf("failed to parse payload: %w", err)
 	}
 
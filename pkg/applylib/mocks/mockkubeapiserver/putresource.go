@@ -22,7 +22,6 @@ import (
 	"net/http"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
@@ -39,7 +38,7 @@ func (req *putResource) Run(s *MockKubeAPIServer) error {
 
 	id := types.NamespacedName{Namespace: req.Namespace, Name: req.Name}
 
-	var existing runtime.Object
+	var existing *unstructured.Unstructured
 	objects := s.objects[gr]
 	if objects != nil {
 		existing = objects.Objects[id]
@@ -65,7 +64,7 @@ func (req *putResource) Run(s *MockKubeAPIServer) error {
 	if req.SubResource == "" {
 		updated = body
 	} else if req.SubResource == "status" {
-		updated = existing.DeepCopyObject().(*unstructured.Unstructured)
+		updated = existing.DeepCopy()
 		newStatus := body.Object["status"]
 		if newStatus == nil {
 			// TODO: This might be allowed?
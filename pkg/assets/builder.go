@@ -17,6 +17,8 @@ limitations under the License.
 package assets
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -28,11 +30,13 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/assets/assetdata"
+	"k8s.io/kops/pkg/assets/signatures"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/values"
@@ -58,6 +62,13 @@ type AssetBuilder struct {
 	// StaticFiles records static files:
 	// * Configuration files supporting static pods
 	StaticFiles []*StaticFile
+
+	// verifier verifies images and files against the verification policy, if one is set.
+	verifier *signatures.Verifier
+	// verifiedImages records the digests of verified images, keyed by their canonical location.
+	verifiedImages map[string]string
+	// verificationReport collects the images that failed verification.
+	verificationReport *signatures.Report
 }
 
 type StaticFile struct {
@@ -116,6 +127,17 @@ func NewAssetBuilder(vfsContext *vfs.VFSContext, assets *kops.AssetsSpec, kubern
 	}
 	a.KubernetesVersion = *version
 
+	if assets != nil && assets.Verification != nil {
+		verifier, err := signatures.NewVerifier(assets.Verification, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+		if err != nil {
+			// This should have already been validated
+			klog.Fatalf("unexpected error building verifier for assets: %v", err)
+		}
+		a.verifier = verifier
+		a.verifiedImages = make(map[string]string)
+		a.verificationReport = &signatures.Report{}
+	}
+
 	return a
 }
 
@@ -219,6 +241,15 @@ func (a *AssetBuilder) RemapImage(image string) (string, error) {
 
 	a.ImageAssets = append(a.ImageAssets, asset)
 
+	if a.verifier != nil && a.verifier.HasImagePolicy(asset.CanonicalLocation) {
+		digest := a.verifyImage(asset.CanonicalLocation)
+		if digest == "" || strings.Contains(image, "@") {
+			return image, nil
+		}
+		// Pin the image to the verified digest, so that the image cannot be replaced after it was verified.
+		return image + "@" + digest, nil
+	}
+
 	if !featureflag.ImageDigest.Enabled() || os.Getenv("KOPS_BASE_URL") != "" {
 		return image, nil
 	}
@@ -236,6 +267,52 @@ func (a *AssetBuilder) RemapImage(image string) (string, error) {
 	return image + "@" + digest, nil
 }
 
+// verifyImage verifies the image against its policy, returning its digest.
+// Images that fail verification are recorded in the verification report, and an empty digest is returned.
+func (a *AssetBuilder) verifyImage(image string) string {
+	if digest, found := a.verifiedImages[image]; found {
+		return digest
+	}
+
+	digest, err := a.verifier.VerifyImage(context.TODO(), image)
+	if err != nil {
+		var failure *signatures.Failure
+		if !errors.As(err, &failure) {
+			failure = &signatures.Failure{Artifact: image, Reasons: []string{err.Error()}}
+		}
+		a.verificationReport.Add(failure)
+	}
+	a.verifiedImages[image] = digest
+	return digest
+}
+
+// VerificationError returns a report of the images that failed verification against the verification policy,
+// or nil if all images were verified.
+func (a *AssetBuilder) VerificationError() error {
+	if a.verificationReport == nil {
+		return nil
+	}
+	return a.verificationReport.Err()
+}
+
+// SignedFiles returns the canonical URLs of the file assets that must be signed according to the verification policy,
+// keyed by the hex-encoded hash of the file.
+func (a *AssetBuilder) SignedFiles() map[string]string {
+	signed := make(map[string]string)
+	if a.verifier == nil {
+		return signed
+	}
+	for _, fileAsset := range a.FileAssets {
+		if fileAsset.SHAValue == nil {
+			continue
+		}
+		if a.verifier.HasFilePolicy(fileAsset.CanonicalURL.String()) {
+			signed[fileAsset.SHAValue.Hex()] = fileAsset.CanonicalURL.String()
+		}
+	}
+	return signed
+}
+
 // RemapFile returns a remapped URL for the file, if AssetsLocation is defined.
 // It is returns in a FileAsset, alongside the SHA hash of the file.
 // The SHA hash is is knownHash is provided, and otherwise will be found first by
@@ -17,13 +17,35 @@ limitations under the License.
 package assets
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/assets/signatures"
 	"k8s.io/kops/pkg/testutils/golden"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 func buildAssetBuilder(t *testing.T) *AssetBuilder {
@@ -180,3 +202,99 @@ func TestRemapEmptySection(t *testing.T) {
 
 	golden.AssertMatchesFile(t, string(actual), expectedPath)
 }
+
+func TestRemapImage_Verification(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("marshaling public key: %v", err)
+	}
+
+	pushImage := func(repository string, signed bool) (string, string) {
+		img, err := random.Image(64, 1)
+		if err != nil {
+			t.Fatalf("building image: %v", err)
+		}
+		tag, err := name.NewTag(host + "/" + repository + ":v1.0.0")
+		if err != nil {
+			t.Fatalf("parsing tag: %v", err)
+		}
+		if err := remote.Write(tag, img); err != nil {
+			t.Fatalf("pushing image: %v", err)
+		}
+		digest, err := img.Digest()
+		if err != nil {
+			t.Fatalf("computing digest: %v", err)
+		}
+		if signed {
+			payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"}}`, tag.Repository.String(), digest.String()))
+			h := sha256.Sum256(payload)
+			sig, err := ecdsa.SignASN1(rand.Reader, key, h[:])
+			if err != nil {
+				t.Fatalf("signing: %v", err)
+			}
+			sigImage, err := mutate.Append(empty.Image, mutate.Addendum{
+				Layer:       static.NewLayer(payload, types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json")),
+				Annotations: map[string]string{"dev.cosignproject.cosign/signature": base64.StdEncoding.EncodeToString(sig)},
+			})
+			if err != nil {
+				t.Fatalf("building signature image: %v", err)
+			}
+			if err := remote.Write(signatures.SignatureTag(tag.Repository, digest), sigImage); err != nil {
+				t.Fatalf("pushing signature image: %v", err)
+			}
+		}
+		return tag.String(), digest.String()
+	}
+
+	signed, digest := pushImage("kops/signed", true)
+	unsigned, _ := pushImage("kops/unsigned", false)
+
+	builder := NewAssetBuilder(vfs.Context, &kops.AssetsSpec{
+		Verification: &kops.AssetVerificationSpec{
+			Images: []kops.SignaturePolicySpec{
+				{Prefix: host + "/kops/", PublicKeys: []string{string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))}},
+			},
+		},
+	}, "1.30.0", false)
+
+	remapped, err := builder.RemapImage(signed)
+	if err != nil {
+		t.Fatalf("error remapping image: %v", err)
+	}
+	if expected := signed + "@" + digest; remapped != expected {
+		t.Errorf("expected verified image to be pinned to %s, got %s", expected, remapped)
+	}
+
+	remapped, err = builder.RemapImage(unsigned)
+	if err != nil {
+		t.Fatalf("error remapping image: %v", err)
+	}
+	if remapped != unsigned {
+		t.Errorf("expected unverified image not to be pinned, got %s", remapped)
+	}
+
+	remapped, err = builder.RemapImage("registry.example.com/unverified:v1.0.0")
+	if err != nil {
+		t.Fatalf("error remapping image: %v", err)
+	}
+	if remapped != "registry.example.com/unverified:v1.0.0" {
+		t.Errorf("expected image without a policy to be unchanged, got %s", remapped)
+	}
+
+	err = builder.VerificationError()
+	if err == nil {
+		t.Fatalf("expected the unsigned image to fail verification")
+	}
+	expected := "signature verification failed for 1 artifact(s):\n  " + unsigned + " (policy \"" + host + "/kops/\")\n    - image is not signed"
+	if err.Error() != expected {
+		t.Errorf("unexpected report:\n%s\nexpected:\n%s", err, expected)
+	}
+}
@@ -0,0 +1,516 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/klog/v2"
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets/signatures"
+	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// BundleIndexFile is the name of the file listing the assets held in a bundle.
+	BundleIndexFile = "kops-bundle.yaml"
+
+	// annotationRefName is the OCI annotation recording the name of an image in an image layout.
+	annotationRefName = "org.opencontainers.image.ref.name"
+)
+
+// BundleIndex lists the assets held in a bundle.
+// A bundle is an OCI image layout holding the images of a cluster, with its files stored as blobs of the layout.
+type BundleIndex struct {
+	// KopsVersion is the version of kops that created the bundle.
+	KopsVersion string `json:"kopsVersion"`
+	// KubernetesVersion is the version of kubernetes of the cluster the bundle was created for.
+	KubernetesVersion string `json:"kubernetesVersion"`
+	// Images are the images held in the bundle.
+	Images []*BundleImage `json:"images,omitempty"`
+	// Files are the files held in the bundle.
+	Files []*BundleFile `json:"files,omitempty"`
+}
+
+// BundleImage is an image held in a bundle.
+type BundleImage struct {
+	// Name is the canonical location of the image.
+	Name string `json:"name"`
+	// Digest is the digest of the manifest of the image.
+	Digest string `json:"digest"`
+	// SignatureDigest is the digest of the manifest holding the cosign signatures of the image, if the image was verified.
+	SignatureDigest string `json:"signatureDigest,omitempty"`
+}
+
+// BundleFile is a file held in a bundle.
+type BundleFile struct {
+	// URL is the canonical location of the file.
+	URL string `json:"url"`
+	// SHA is the hash of the file, as used by the cluster.
+	SHA string `json:"sha"`
+	// Blob is the digest of the blob holding the file.
+	Blob string `json:"blob"`
+	// Signatures are the digests of the blobs holding the signatures of the file, keyed by the suffix of their URL.
+	Signatures map[string]string `json:"signatures,omitempty"`
+}
+
+// CreateBundle downloads the assets into a bundle in the directory.
+// Images and files are verified against the verification policy of the cluster, and their signatures are added to the bundle,
+// so that they can still be verified once the bundle is pushed; if any fails verification, a report of the failures is returned.
+func CreateBundle(dir string, imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VFSContext, cluster *kops.Cluster) (*BundleIndex, error) {
+	ctx := context.TODO()
+
+	var verifier *signatures.Verifier
+	report := &signatures.Report{}
+	if cluster.Spec.Assets != nil && cluster.Spec.Assets.Verification != nil {
+		v, err := signatures.NewVerifier(cluster.Spec.Assets.Verification, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+		if err != nil {
+			return nil, fmt.Errorf("building verifier for assets: %w", err)
+		}
+		verifier = v
+	}
+
+	p, err := layout.Write(dir, empty.Index)
+	if err != nil {
+		return nil, fmt.Errorf("creating image layout in %q: %w", dir, err)
+	}
+
+	index := &BundleIndex{
+		KopsVersion:       kopsbase.Version,
+		KubernetesVersion: cluster.Spec.KubernetesVersion,
+	}
+
+	images := make(map[string]bool)
+	for _, imageAsset := range imageAssets {
+		images[imageAsset.CanonicalLocation] = true
+	}
+	for _, image := range sortedKeys(images) {
+		bundleImage, err := addBundleImage(ctx, p, verifier, image)
+		if err != nil {
+			var failure *signatures.Failure
+			if !errors.As(err, &failure) {
+				return nil, fmt.Errorf("adding image %q to bundle: %w", image, err)
+			}
+			report.Add(failure)
+			continue
+		}
+		index.Images = append(index.Images, bundleImage)
+	}
+
+	files := make(map[string]*FileAsset)
+	for _, fileAsset := range fileAssets {
+		files[fileAsset.CanonicalURL.String()] = fileAsset
+	}
+	for _, fileURL := range sortedKeys(files) {
+		bundleFile, err := addBundleFile(ctx, p, vfsContext, verifier, files[fileURL])
+		if err != nil {
+			var failure *signatures.Failure
+			if !errors.As(err, &failure) {
+				return nil, fmt.Errorf("adding file %q to bundle: %w", fileURL, err)
+			}
+			report.Add(failure)
+			continue
+		}
+		index.Files = append(index.Files, bundleFile)
+	}
+
+	if err := report.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		return nil, fmt.Errorf("serializing bundle index: %w", err)
+	}
+	if err := p.WriteFile(BundleIndexFile, data, 0o644); err != nil {
+		return nil, fmt.Errorf("writing bundle index: %w", err)
+	}
+
+	return index, nil
+}
+
+// addBundleImage adds the image to the image layout, pinned to its verified digest if the verification policy applies to it.
+func addBundleImage(ctx context.Context, p layout.Path, verifier *signatures.Verifier, image string) (*BundleImage, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("parsing reference %q: %w", image, err)
+	}
+
+	verified := false
+	if verifier != nil && verifier.HasImagePolicy(image) {
+		digest, err := verifier.VerifyImage(ctx, image)
+		if err != nil {
+			return nil, err
+		}
+		ref = ref.Context().Digest(digest)
+		verified = true
+	}
+
+	klog.Infof("adding image %v to bundle", image)
+	options := []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}
+	desc, err := remote.Get(ref, options...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %q: %w", image, err)
+	}
+	if err := appendDescriptor(p, desc, image); err != nil {
+		return nil, err
+	}
+
+	bundleImage := &BundleImage{
+		Name:   image,
+		Digest: desc.Digest.String(),
+	}
+
+	if verified {
+		signatureRef := signatures.SignatureTag(ref.Context(), desc.Digest)
+		signatureDesc, err := remote.Get(signatureRef, options...)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %q: %w", signatureRef, err)
+		}
+		if err := appendDescriptor(p, signatureDesc, signatureRef.String()); err != nil {
+			return nil, err
+		}
+		bundleImage.SignatureDigest = signatureDesc.Digest.String()
+	}
+
+	return bundleImage, nil
+}
+
+func appendDescriptor(p layout.Path, desc *remote.Descriptor, refName string) error {
+	annotations := layout.WithAnnotations(map[string]string{annotationRefName: refName})
+	if desc.MediaType.IsIndex() {
+		idx, err := desc.ImageIndex()
+		if err != nil {
+			return err
+		}
+		return p.AppendIndex(idx, annotations)
+	}
+	// Assume anything else is an image, since some registries don't set mediaTypes properly.
+	img, err := desc.Image()
+	if err != nil {
+		return err
+	}
+	return p.AppendImage(img, annotations)
+}
+
+// addBundleFile adds the file, and its signatures if the verification policy applies to it, as blobs of the image layout.
+func addBundleFile(ctx context.Context, p layout.Path, vfsContext *vfs.VFSContext, verifier *signatures.Verifier, fileAsset *FileAsset) (*BundleFile, error) {
+	source := fileAsset.CanonicalURL.String()
+
+	// TODO drop file to disk, as vfs reads file into memory.
+	klog.Infof("adding file %q to bundle", source)
+	data, err := vfsContext.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading file %q: %w", source, err)
+	}
+
+	dataHash, err := fileAsset.SHAValue.Algorithm.Hash(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to hash file %q downloaded: %w", source, err)
+	}
+	if !fileAsset.SHAValue.Equal(dataHash) {
+		return nil, fmt.Errorf("the sha value %q does not match %q calculated value %q", fileAsset.SHAValue.Hex(), source, dataHash.Hex())
+	}
+
+	blob, err := writeBundleBlob(p, data)
+	if err != nil {
+		return nil, err
+	}
+	bundleFile := &BundleFile{
+		URL:  source,
+		SHA:  fileAsset.SHAValue.Hex(),
+		Blob: blob,
+	}
+
+	if verifier != nil && verifier.HasFilePolicy(source) {
+		fetch := func(ctx context.Context, u string) ([]byte, error) {
+			return vfsContext.ReadFile(u)
+		}
+		digest := sha256.Sum256(data)
+		if err := verifier.VerifyFile(ctx, source, digest[:], nil, fetch); err != nil {
+			return nil, err
+		}
+
+		bundleFile.Signatures = make(map[string]string)
+		for _, suffix := range []string{signatures.SignatureSuffix, signatures.BundleSuffix} {
+			sig, err := vfsContext.ReadFile(source + suffix)
+			if err != nil {
+				continue
+			}
+			blob, err := writeBundleBlob(p, sig)
+			if err != nil {
+				return nil, err
+			}
+			bundleFile.Signatures[suffix] = blob
+		}
+	}
+
+	return bundleFile, nil
+}
+
+func writeBundleBlob(p layout.Path, data []byte) (string, error) {
+	digest := sha256.Sum256(data)
+	h := v1.Hash{Algorithm: "sha256", Hex: hex.EncodeToString(digest[:])}
+	if err := p.WriteBlob(h, io.NopCloser(bytes.NewReader(data))); err != nil {
+		return "", fmt.Errorf("writing blob %v: %w", h, err)
+	}
+	return h.String(), nil
+}
+
+// ReadBundleIndex reads the index of the bundle in the directory.
+func ReadBundleIndex(dir string) (*BundleIndex, error) {
+	data, err := os.ReadFile(filepath.Join(dir, BundleIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle index: %w", err)
+	}
+	index := &BundleIndex{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("parsing bundle index: %w", err)
+	}
+	return index, nil
+}
+
+// PushBundle copies the assets of the bundle in the directory to the locations from which a cluster using the assets spec downloads them.
+func PushBundle(dir string, index *BundleIndex, assetsSpec *kops.AssetsSpec, vfsContext *vfs.VFSContext, cluster *kops.Cluster) error {
+	p, err := layout.FromPath(dir)
+	if err != nil {
+		return fmt.Errorf("reading image layout in %q: %w", dir, err)
+	}
+	ii, err := p.ImageIndex()
+	if err != nil {
+		return fmt.Errorf("reading image layout in %q: %w", dir, err)
+	}
+
+	// The assets are remapped as the cluster remaps them; the verification policy is left out,
+	// as the remapped images cannot be verified until they are pushed.
+	remapper := NewAssetBuilder(vfsContext, &kops.AssetsSpec{
+		ContainerRegistry: assetsSpec.ContainerRegistry,
+		FileRepository:    assetsSpec.FileRepository,
+	}, index.KubernetesVersion, true)
+
+	tasks := map[string]assetTask{}
+
+	for _, image := range index.Images {
+		if _, err := remapper.RemapImage(image.Name); err != nil {
+			return err
+		}
+		target := remapper.ImageAssets[len(remapper.ImageAssets)-1].DownloadLocation
+		if target == image.Name {
+			continue
+		}
+
+		digest, err := v1.NewHash(image.Digest)
+		if err != nil {
+			return fmt.Errorf("parsing digest of image %q: %w", image.Name, err)
+		}
+		tasks[target] = &pushImage{
+			Name:        target,
+			Index:       ii,
+			Digest:      digest,
+			TargetImage: target,
+		}
+
+		if image.SignatureDigest != "" {
+			signatureDigest, err := v1.NewHash(image.SignatureDigest)
+			if err != nil {
+				return fmt.Errorf("parsing signature digest of image %q: %w", image.Name, err)
+			}
+			targetRef, err := name.ParseReference(target)
+			if err != nil {
+				return fmt.Errorf("parsing reference %q: %w", target, err)
+			}
+			signatureTarget := signatures.SignatureTag(targetRef.Context(), digest).String()
+			tasks[signatureTarget] = &pushImage{
+				Name:        signatureTarget,
+				Index:       ii,
+				Digest:      signatureDigest,
+				TargetImage: signatureTarget,
+			}
+		}
+	}
+
+	for _, file := range index.Files {
+		canonicalURL, err := url.Parse(file.URL)
+		if err != nil {
+			return fmt.Errorf("parsing url %q: %w", file.URL, err)
+		}
+		hash, err := hashing.FromString(file.SHA)
+		if err != nil {
+			return fmt.Errorf("parsing hash of file %q: %w", file.URL, err)
+		}
+		fileAsset, err := remapper.RemapFile(canonicalURL, hash)
+		if err != nil {
+			return err
+		}
+		target := fileAsset.DownloadURL.String()
+		if target == file.URL {
+			continue
+		}
+
+		source, err := bundleBlobPath(dir, file.Blob)
+		if err != nil {
+			return fmt.Errorf("finding file %q in bundle: %w", file.URL, err)
+		}
+		tasks[file.URL] = &CopyFile{
+			Name:       file.URL,
+			SourceFile: source,
+			TargetFile: target,
+			SHA:        file.SHA,
+			VFSContext: vfsContext,
+			Cluster:    cluster,
+		}
+
+		for suffix, blob := range file.Signatures {
+			source, err := bundleBlobPath(dir, blob)
+			if err != nil {
+				return fmt.Errorf("finding signature of file %q in bundle: %w", file.URL, err)
+			}
+			tasks[file.URL+suffix] = &copySignature{
+				SourceFile: source,
+				TargetFile: target + suffix,
+				VFSContext: vfsContext,
+				Cluster:    cluster,
+			}
+		}
+	}
+
+	return runTasks(tasks, &signatures.Report{})
+}
+
+// bundleBlobPath returns the path of the blob with the digest in the image layout.
+func bundleBlobPath(dir string, digest string) (string, error) {
+	h, err := v1.NewHash(digest)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "blobs", h.Algorithm, h.Hex), nil
+}
+
+// pushImage pushes an image from the image layout of a bundle to a target registry.
+type pushImage struct {
+	Name        string
+	Index       v1.ImageIndex
+	Digest      v1.Hash
+	TargetImage string
+}
+
+func (e *pushImage) Run() error {
+	targetRef, err := name.ParseReference(e.TargetImage)
+	if err != nil {
+		return fmt.Errorf("parsing reference for %q: %w", e.TargetImage, err)
+	}
+
+	options := []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}
+
+	targetDesc, err := remote.Head(targetRef, options...)
+	if err == nil && targetDesc.Digest == e.Digest {
+		klog.Infof("no need to push image %v", targetRef)
+		return nil
+	}
+
+	manifest, err := e.Index.IndexManifest()
+	if err != nil {
+		return err
+	}
+	var desc *v1.Descriptor
+	for i := range manifest.Manifests {
+		if manifest.Manifests[i].Digest == e.Digest {
+			desc = &manifest.Manifests[i]
+			break
+		}
+	}
+	if desc == nil {
+		return fmt.Errorf("image %v not found in bundle", e.Digest)
+	}
+
+	klog.Infof("pushing image %v", targetRef)
+	if desc.MediaType.IsIndex() {
+		idx, err := e.Index.ImageIndex(e.Digest)
+		if err != nil {
+			return err
+		}
+		if err := remote.WriteIndex(targetRef, idx, options...); err != nil {
+			return fmt.Errorf("failed to push index: %w", err)
+		}
+		return nil
+	}
+	img, err := e.Index.Image(e.Digest)
+	if err != nil {
+		return err
+	}
+	if err := remote.Write(targetRef, img, options...); err != nil {
+		return fmt.Errorf("failed to push image: %w", err)
+	}
+	return nil
+}
+
+// copySignature copies the signature of a file from a bundle next to the file in the target repository.
+type copySignature struct {
+	SourceFile string
+	TargetFile string
+	VFSContext *vfs.VFSContext
+	Cluster    *kops.Cluster
+}
+
+func (e *copySignature) Run() error {
+	ctx := context.TODO()
+
+	data, err := e.VFSContext.ReadFile(e.SourceFile)
+	if err != nil {
+		return fmt.Errorf("error reading file %q: %w", e.SourceFile, err)
+	}
+
+	objectStore, err := buildVFSPath(e.TargetFile)
+	if err != nil {
+		return err
+	}
+	p, err := e.VFSContext.BuildVfsPath(objectStore)
+	if err != nil {
+		return fmt.Errorf("error building path %q: %w", objectStore, err)
+	}
+
+	klog.Infof("uploading signature to %q", objectStore)
+	return writeFile(ctx, e.Cluster, p, data)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestBundle(t *testing.T) {
+	sourceRegistry := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer sourceRegistry.Close()
+	sourceHost := strings.TrimPrefix(sourceRegistry.URL, "http://")
+
+	targetRegistry := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer targetRegistry.Close()
+	targetHost := strings.TrimPrefix(targetRegistry.URL, "http://")
+
+	img, err := random.Image(64, 1)
+	if err != nil {
+		t.Fatalf("building image: %v", err)
+	}
+	imgRef, err := name.ParseReference(sourceHost + "/kube-apiserver:v1.30.0")
+	if err != nil {
+		t.Fatalf("parsing reference: %v", err)
+	}
+	if err := remote.Write(imgRef, img); err != nil {
+		t.Fatalf("pushing image: %v", err)
+	}
+	imgDigest, err := img.Digest()
+	if err != nil {
+		t.Fatalf("computing digest: %v", err)
+	}
+
+	idx, err := random.Index(64, 1, 2)
+	if err != nil {
+		t.Fatalf("building index: %v", err)
+	}
+	idxRef, err := name.ParseReference(sourceHost + "/pause:3.9")
+	if err != nil {
+		t.Fatalf("parsing reference: %v", err)
+	}
+	if err := remote.WriteIndex(idxRef, idx); err != nil {
+		t.Fatalf("pushing index: %v", err)
+	}
+	idxDigest, err := idx.Digest()
+	if err != nil {
+		t.Fatalf("computing digest: %v", err)
+	}
+
+	kubelet := []byte("kubelet binary")
+	fileServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/release/v1.30.0/bin/linux/amd64/kubelet" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(kubelet)
+	}))
+	defer fileServer.Close()
+	kubeletURL, err := url.Parse(fileServer.URL + "/release/v1.30.0/bin/linux/amd64/kubelet")
+	if err != nil {
+		t.Fatalf("parsing url: %v", err)
+	}
+	kubeletHash, err := hashing.HashAlgorithmSHA256.Hash(bytes.NewReader(kubelet))
+	if err != nil {
+		t.Fatalf("hashing file: %v", err)
+	}
+
+	cluster := &kops.Cluster{}
+	cluster.Spec.KubernetesVersion = "1.30.0"
+
+	imageAssets := []*ImageAsset{
+		{CanonicalLocation: idxRef.String(), DownloadLocation: idxRef.String()},
+		{CanonicalLocation: imgRef.String(), DownloadLocation: imgRef.String()},
+		{CanonicalLocation: imgRef.String(), DownloadLocation: imgRef.String()},
+	}
+	fileAssets := []*FileAsset{
+		{CanonicalURL: kubeletURL, DownloadURL: kubeletURL, SHAValue: kubeletHash},
+	}
+
+	bundleDir := filepath.Join(t.TempDir(), "bundle")
+	index, err := CreateBundle(bundleDir, imageAssets, fileAssets, vfs.Context, cluster)
+	if err != nil {
+		t.Fatalf("creating bundle: %v", err)
+	}
+	if len(index.Images) != 2 {
+		t.Fatalf("expected 2 images in bundle, got %d", len(index.Images))
+	}
+	if index.Images[0].Name != imgRef.String() || index.Images[0].Digest != imgDigest.String() {
+		t.Errorf("unexpected image in bundle: %+v", index.Images[0])
+	}
+	if index.Images[1].Name != idxRef.String() || index.Images[1].Digest != idxDigest.String() {
+		t.Errorf("unexpected image in bundle: %+v", index.Images[1])
+	}
+	if len(index.Files) != 1 || index.Files[0].SHA != kubeletHash.Hex() || index.Files[0].Blob != "sha256:"+kubeletHash.Hex() {
+		t.Errorf("unexpected files in bundle: %+v", index.Files)
+	}
+
+	archive := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := WriteBundleArchive(bundleDir, archive); err != nil {
+		t.Fatalf("writing bundle archive: %v", err)
+	}
+	extractedDir := t.TempDir()
+	if err := ExtractBundleArchive(archive, extractedDir); err != nil {
+		t.Fatalf("extracting bundle archive: %v", err)
+	}
+	extracted, err := ReadBundleIndex(extractedDir)
+	if err != nil {
+		t.Fatalf("reading bundle index: %v", err)
+	}
+	if extracted.KubernetesVersion != "1.30.0" || len(extracted.Images) != 2 || len(extracted.Files) != 1 {
+		t.Fatalf("unexpected bundle index: %+v", extracted)
+	}
+
+	// Bundles are usually created from the well-known registries, which are remapped to the container registry by their path.
+	extracted.Images[0].Name = "registry.k8s.io/kube-apiserver:v1.30.0"
+	extracted.Images[1].Name = "registry.k8s.io/pause:3.9"
+
+	fileRepository := t.TempDir()
+	fileRepositoryURL := "file://" + fileRepository
+	assetsSpec := &kops.AssetsSpec{
+		ContainerRegistry: &targetHost,
+		FileRepository:    &fileRepositoryURL,
+	}
+	if err := PushBundle(extractedDir, extracted, assetsSpec, vfs.Context, cluster); err != nil {
+		t.Fatalf("pushing bundle: %v", err)
+	}
+
+	for image, digest := range map[string]string{
+		targetHost + "/kube-apiserver:v1.30.0": imgDigest.String(),
+		targetHost + "/pause:3.9":              idxDigest.String(),
+	} {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			t.Fatalf("parsing reference: %v", err)
+		}
+		desc, err := remote.Head(ref)
+		if err != nil {
+			t.Errorf("image %s was not pushed: %v", image, err)
+			continue
+		}
+		if desc.Digest.String() != digest {
+			t.Errorf("image %s was pushed with digest %s, expected %s", image, desc.Digest, digest)
+		}
+	}
+
+	pushed, err := os.ReadFile(filepath.Join(fileRepository, kubeletURL.Path))
+	if err != nil {
+		t.Fatalf("file was not pushed: %v", err)
+	}
+	if !bytes.Equal(pushed, kubelet) {
+		t.Errorf("file was pushed with contents %q, expected %q", pushed, kubelet)
+	}
+	pushedSHA, err := os.ReadFile(filepath.Join(fileRepository, kubeletURL.Path) + ".sha256")
+	if err != nil {
+		t.Fatalf("file hash was not pushed: %v", err)
+	}
+	if string(pushedSHA) != kubeletHash.Hex() {
+		t.Errorf("file hash was pushed as %q, expected %q", pushedSHA, kubeletHash.Hex())
+	}
+}
+
+func TestExtractBundleArchive_InvalidPath(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "bundle.tar")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatalf("creating bundle archive: %v", err)
+	}
+	tw := tar.NewWriter(f)
+	if err := tw.WriteHeader(&tar.Header{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0o644, Size: 4}); err != nil {
+		t.Fatalf("writing bundle archive: %v", err)
+	}
+	if _, err := tw.Write([]byte("evil")); err != nil {
+		t.Fatalf("writing bundle archive: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("writing bundle archive: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("writing bundle archive: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "bundle")
+	err = ExtractBundleArchive(archive, dir)
+	if err == nil || !strings.Contains(err.Error(), "invalid path") {
+		t.Errorf("expected invalid path error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "..", "evil")); !os.IsNotExist(err) {
+		t.Errorf("expected entry not to be extracted, got %v", err)
+	}
+}
@@ -17,11 +17,19 @@ limitations under the License.
 package assets
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets/signatures"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -29,21 +37,53 @@ type assetTask interface {
 	Run() error
 }
 
+// Copy copies the assets to the locations from which the cluster downloads them.
+// Images and files are verified against the verification policy of the cluster before they are copied,
+// together with their signatures; if any fails verification, a report of the failures is returned.
 func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VFSContext, cluster *kops.Cluster) error {
+	ctx := context.TODO()
+
 	tasks := map[string]assetTask{}
 
+	var verifier *signatures.Verifier
+	report := &signatures.Report{}
+	if cluster.Spec.Assets != nil && cluster.Spec.Assets.Verification != nil {
+		v, err := signatures.NewVerifier(cluster.Spec.Assets.Verification, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+		if err != nil {
+			return fmt.Errorf("building verifier for assets: %w", err)
+		}
+		verifier = v
+	}
+
+	// sources records the source of each image target, as the same image is usually remapped several times.
+	sources := map[string]string{}
 	for _, imageAsset := range imageAssets {
 		if imageAsset.DownloadLocation != imageAsset.CanonicalLocation {
+			if source, ok := sources[imageAsset.DownloadLocation]; ok {
+				if source != imageAsset.CanonicalLocation {
+					return fmt.Errorf("different sources for same image target %s: %s vs %s", imageAsset.DownloadLocation, imageAsset.CanonicalLocation, source)
+				}
+				continue
+			}
+			sources[imageAsset.DownloadLocation] = imageAsset.CanonicalLocation
+
 			copyImageTask := &CopyImage{
 				Name:        imageAsset.DownloadLocation,
 				SourceImage: imageAsset.CanonicalLocation,
 				TargetImage: imageAsset.DownloadLocation,
 			}
 
-			if existing, ok := tasks[copyImageTask.Name]; ok {
-				if existing.(*CopyImage).SourceImage != copyImageTask.SourceImage {
-					return fmt.Errorf("different sources for same image target %s: %s vs %s", copyImageTask.Name, copyImageTask.SourceImage, existing.(*CopyImage).SourceImage)
+			if verifier != nil && verifier.HasImagePolicy(imageAsset.CanonicalLocation) {
+				signatureTask, err := verifyImageCopy(ctx, verifier, copyImageTask)
+				if err != nil {
+					var failure *signatures.Failure
+					if !errors.As(err, &failure) {
+						return err
+					}
+					report.Add(failure)
+					continue
 				}
+				tasks[signatureTask.Name] = signatureTask
 			}
 
 			tasks[copyImageTask.Name] = copyImageTask
@@ -59,6 +99,7 @@ func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VF
 				SHA:        fileAsset.SHAValue.Hex(),
 				VFSContext: vfsContext,
 				Cluster:    cluster,
+				Verifier:   verifier,
 			}
 
 			if existing, ok := tasks[copyFileTask.Name]; ok {
@@ -78,6 +119,16 @@ func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VF
 		}
 	}
 
+	if err := report.Err(); err != nil {
+		return err
+	}
+
+	return runTasks(tasks, report)
+}
+
+// runTasks runs the tasks, a few at a time.
+// Verification failures are collected in the report, which is returned if any task failed verification.
+func runTasks(tasks map[string]assetTask, report *signatures.Report) error {
 	ch := make(chan error, 5)
 	for i := 0; i < cap(ch); i++ {
 		ch <- nil
@@ -92,14 +143,13 @@ func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VF
 	for _, name := range names {
 		task := tasks[name]
 		err := <-ch
-		if err != nil {
-			klog.Warning(err)
+		if err != nil && !addFailure(report, err) {
 			gotError = true
 		}
 		go func(n string, t assetTask) {
 			err := t.Run()
 			if err != nil {
-				err = fmt.Errorf("%s: %v", n, err)
+				err = fmt.Errorf("%s: %w", n, err)
 			}
 			ch <- err
 		}(name, task)
@@ -107,15 +157,62 @@ func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VF
 
 	for i := 0; i < cap(ch); i++ {
 		err := <-ch
-		if err != nil {
-			klog.Warning(err)
+		if err != nil && !addFailure(report, err) {
 			gotError = true
 		}
 	}
 
 	close(ch)
+	if err := report.Err(); err != nil {
+		return err
+	}
 	if gotError {
 		return fmt.Errorf("not all assets copied successfully")
 	}
 	return nil
 }
+
+// addFailure adds the error to the report if it is a verification failure, and otherwise logs it.
+// It returns true if the error was added to the report.
+func addFailure(report *signatures.Report, err error) bool {
+	var failure *signatures.Failure
+	if errors.As(err, &failure) {
+		report.Add(failure)
+		return true
+	}
+	klog.Warning(err)
+	return false
+}
+
+// verifyImageCopy verifies the source image of the copy task, pinning it to the verified digest,
+// and returns a task copying the signatures of the image alongside it.
+func verifyImageCopy(ctx context.Context, verifier *signatures.Verifier, copyImageTask *CopyImage) (*CopyImage, error) {
+	digest, err := verifier.VerifyImage(ctx, copyImageTask.SourceImage)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceRef, err := name.ParseReference(copyImageTask.SourceImage)
+	if err != nil {
+		return nil, fmt.Errorf("parsing reference %q: %w", copyImageTask.SourceImage, err)
+	}
+	targetRef, err := name.ParseReference(copyImageTask.TargetImage)
+	if err != nil {
+		return nil, fmt.Errorf("parsing reference %q: %w", copyImageTask.TargetImage, err)
+	}
+	hash, err := v1.NewHash(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(copyImageTask.SourceImage, "@") {
+		copyImageTask.SourceImage += "@" + digest
+	}
+
+	signatureTarget := signatures.SignatureTag(targetRef.Context(), hash).String()
+	return &CopyImage{
+		Name:        signatureTarget,
+		SourceImage: signatures.SignatureTag(sourceRef.Context(), hash).String(),
+		TargetImage: signatureTarget,
+	}, nil
+}
@@ -19,6 +19,7 @@ package assets
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"net/url"
 	"os"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets/signatures"
 	"k8s.io/kops/util/pkg/hashing"
 	"k8s.io/kops/util/pkg/vfs"
 )
@@ -40,6 +42,8 @@ type CopyFile struct {
 	SHA        string
 	VFSContext *vfs.VFSContext
 	Cluster    *kops.Cluster
+	// Verifier verifies the file against the verification policy of the cluster before it is copied, if set.
+	Verifier *signatures.Verifier
 }
 
 // fileExtensionForSHA returns the expected extension for the given hash
@@ -92,16 +96,16 @@ func (e *CopyFile) Run() error {
 
 	klog.V(2).Infof("copying bits from %q to %q", source, target)
 
-	if err := transferFile(ctx, e.VFSContext, e.Cluster, source, target, sourceSha); err != nil {
-		return fmt.Errorf("unable to transfer %q to %q: %v", source, target, err)
+	if err := transferFile(ctx, e.VFSContext, e.Cluster, e.Verifier, source, target, sourceSha); err != nil {
+		return fmt.Errorf("unable to transfer %q to %q: %w", source, target, err)
 	}
 
 	return nil
 }
 
-// transferFile downloads a file from the source location, validates the file matches the SHA,
-// and uploads the file to the target location.
-func transferFile(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster, source string, target string, sha string) error {
+// transferFile downloads a file from the source location, validates the file matches the SHA
+// and, if a verifier is given, its signature, and uploads the file to the target location.
+func transferFile(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster, verifier *signatures.Verifier, source string, target string, sha string) error {
 	// TODO drop file to disk, as vfs reads file into memory.  We load kubelet into memory for instance.
 	// TODO in s3 can we do a copy file ... would need to test
 
@@ -150,6 +154,12 @@ func transferFile(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops
 		return fmt.Errorf("the sha value in %q does not match %q calculated value %q", shaTarget, source, dataHash.String())
 	}
 
+	if verifier != nil && verifier.HasFilePolicy(source) {
+		if err := verifyFile(ctx, vfsContext, cluster, verifier, source, objectStore, data); err != nil {
+			return err
+		}
+	}
+
 	klog.Infof("uploading %q to %q", source, objectStore)
 	if err := writeFile(ctx, cluster, uploadVFS, data); err != nil {
 		return err
@@ -163,6 +173,34 @@ func transferFile(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops
 	return nil
 }
 
+// verifyFile verifies the signature of the file downloaded from the source location,
+// and uploads the signature next to the target location, so that it can be verified where the file is downloaded.
+func verifyFile(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster, verifier *signatures.Verifier, source string, target string, data []byte) error {
+	fetch := func(ctx context.Context, u string) ([]byte, error) {
+		return vfsContext.ReadFile(u)
+	}
+	digest := sha256.Sum256(data)
+	if err := verifier.VerifyFile(ctx, source, digest[:], nil, fetch); err != nil {
+		return err
+	}
+
+	for _, suffix := range []string{signatures.SignatureSuffix, signatures.BundleSuffix} {
+		sig, err := vfsContext.ReadFile(source + suffix)
+		if err != nil {
+			continue
+		}
+		sigVFS, err := vfsContext.BuildVfsPath(target + suffix)
+		if err != nil {
+			return fmt.Errorf("error building path %q: %v", target+suffix, err)
+		}
+		if err := writeFile(ctx, cluster, sigVFS, sig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func writeFile(ctx context.Context, cluster *kops.Cluster, p vfs.Path, data []byte) error {
 	acl, err := acls.GetACL(ctx, p, cluster)
 	if err != nil {
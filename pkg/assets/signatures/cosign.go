@@ -0,0 +1,273 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signatures
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+var (
+	// oidIssuer is the Fulcio certificate extension holding the OIDC issuer as raw bytes.
+	oidIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	// oidIssuerV2 is the Fulcio certificate extension holding the OIDC issuer as a DER-encoded UTF8String.
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// signature is a cosign signature of a payload.
+type signature struct {
+	// signature is the raw signature of the sha256 hash of the payload.
+	signature []byte
+	// certificate is the PEM-encoded keyless signing certificate, if the signature is keyless.
+	certificate []byte
+	// chain is the PEM-encoded chain of the signing certificate.
+	chain []byte
+	// bundle is the transparency log entry of the signature.
+	bundle *rekorBundle
+}
+
+// rekorBundle is the proof that a signature was recorded in a transparency log, as written by cosign.
+type rekorBundle struct {
+	SignedEntryTimestamp []byte       `json:"SignedEntryTimestamp"`
+	Payload              rekorPayload `json:"Payload"`
+}
+
+// rekorPayload is the transparency log entry signed by the log.
+// The fields are ordered as in the canonical JSON encoding the log signs.
+type rekorPayload struct {
+	// Body is the base64-encoded entry.
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// hashedRekord is the part of a hashedrekord transparency log entry that identifies the signature.
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Signature struct {
+			Content   string `json:"content"`
+			PublicKey struct {
+				Content string `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+	} `json:"spec"`
+}
+
+// verifySignature verifies that the signature of the sha256 digest satisfies the policy.
+func (v *Verifier) verifySignature(p *policy, sig *signature, digest []byte) error {
+	if sig.certificate == nil {
+		if len(p.keys) == 0 {
+			return fmt.Errorf("signature was made with a key, but the policy only accepts keyless signatures")
+		}
+		for _, key := range p.keys {
+			if verifyDigest(key, digest, sig.signature) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("signature does not match any of the public keys of the policy")
+	}
+
+	if len(p.identities) == 0 {
+		return fmt.Errorf("signature is keyless, but the policy only accepts signatures made with its public keys")
+	}
+	return v.verifyKeyless(p, sig, digest)
+}
+
+// verifyKeyless verifies a signature made with a keyless signing certificate.
+// As the certificate is short-lived, it is verified at the time the transparency log recorded the signature.
+func (v *Verifier) verifyKeyless(p *policy, sig *signature, digest []byte) error {
+	if sig.bundle == nil {
+		return fmt.Errorf("keyless signature has no transparency log entry")
+	}
+	integratedTime, err := v.verifyBundle(sig.bundle)
+	if err != nil {
+		return err
+	}
+
+	certs, err := ParseCertificates(sig.certificate)
+	if err != nil {
+		return fmt.Errorf("parsing signing certificate: %w", err)
+	}
+	cert := certs[0]
+
+	intermediates := v.intermediates.Clone()
+	if sig.chain != nil {
+		chain, err := ParseCertificates(sig.chain)
+		if err != nil {
+			return fmt.Errorf("parsing certificate chain: %w", err)
+		}
+		for _, c := range chain {
+			intermediates.AddCert(c)
+		}
+	}
+
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   integratedTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("verifying signing certificate: %w", err)
+	}
+
+	issuer, err := certificateIssuer(cert)
+	if err != nil {
+		return err
+	}
+	subjects := certificateSubjects(cert)
+	if !matchesIdentity(p, issuer, subjects) {
+		return fmt.Errorf("signing certificate of %v issued by %q does not match any identity of the policy", subjects, issuer)
+	}
+
+	if err := verifyDigest(cert.PublicKey, digest, sig.signature); err != nil {
+		return fmt.Errorf("signature does not match the signing certificate: %w", err)
+	}
+
+	if err := verifyEntry(sig.bundle, cert, sig.signature, digest); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// verifyBundle verifies the signed entry timestamp of the bundle, returning the time the entry was recorded.
+func (v *Verifier) verifyBundle(bundle *rekorBundle) (time.Time, error) {
+	key := v.rekorKeys[bundle.Payload.LogID]
+	if key == nil {
+		return time.Time{}, fmt.Errorf("transparency log entry is from unknown log %q", bundle.Payload.LogID)
+	}
+
+	canonical, err := json.Marshal(bundle.Payload)
+	if err != nil {
+		return time.Time{}, err
+	}
+	h := sha256.Sum256(canonical)
+	if err := verifyDigest(key, h[:], bundle.SignedEntryTimestamp); err != nil {
+		return time.Time{}, fmt.Errorf("verifying signed entry timestamp of transparency log entry: %w", err)
+	}
+
+	return time.Unix(bundle.Payload.IntegratedTime, 0), nil
+}
+
+// verifyEntry checks that the transparency log entry records the signature.
+func verifyEntry(bundle *rekorBundle, cert *x509.Certificate, sig []byte, digest []byte) error {
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return fmt.Errorf("decoding transparency log entry: %w", err)
+	}
+	entry := &hashedRekord{}
+	if err := json.Unmarshal(body, entry); err != nil {
+		return fmt.Errorf("parsing transparency log entry: %w", err)
+	}
+	if entry.Kind != "hashedrekord" {
+		return fmt.Errorf("unsupported transparency log entry kind %q", entry.Kind)
+	}
+
+	entrySignature, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.Content)
+	if err != nil || !bytes.Equal(entrySignature, sig) {
+		return fmt.Errorf("transparency log entry is for a different signature")
+	}
+	if entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(digest) {
+		return fmt.Errorf("transparency log entry is for a different artifact")
+	}
+	entryCertificate, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.PublicKey.Content)
+	if err != nil {
+		return fmt.Errorf("decoding certificate of transparency log entry: %w", err)
+	}
+	certs, err := ParseCertificates(entryCertificate)
+	if err != nil || !certs[0].Equal(cert) {
+		return fmt.Errorf("transparency log entry is for a different signing certificate")
+	}
+
+	return nil
+}
+
+// certificateIssuer returns the OIDC issuer recorded in a keyless signing certificate.
+func certificateIssuer(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err != nil {
+				return "", fmt.Errorf("parsing issuer of signing certificate: %w", err)
+			}
+			return issuer, nil
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuer) {
+			return string(ext.Value), nil
+		}
+	}
+	return "", fmt.Errorf("signing certificate does not record its issuer")
+}
+
+// certificateSubjects returns the email addresses and URIs of a keyless signing certificate.
+func certificateSubjects(cert *x509.Certificate) []string {
+	var subjects []string
+	subjects = append(subjects, cert.EmailAddresses...)
+	for _, u := range cert.URIs {
+		subjects = append(subjects, u.String())
+	}
+	return subjects
+}
+
+func matchesIdentity(p *policy, issuer string, subjects []string) bool {
+	for _, identity := range p.identities {
+		if identity.Issuer != issuer {
+			continue
+		}
+		for _, subject := range subjects {
+			if identity.Subject == subject {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyDigest verifies the signature of a sha256 digest.
+func verifyDigest(key crypto.PublicKey, digest []byte, sig []byte) error {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, sig) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig)
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signatures
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SignatureSuffix is appended to the URL of a file to find its signature, as written by "cosign sign-blob --output-signature".
+	SignatureSuffix = ".sig"
+	// BundleSuffix is appended to the URL of a file to find its keyless signature, as written by "cosign sign-blob --bundle".
+	BundleSuffix = ".bundle"
+)
+
+// Fetcher reads the file at a URL.
+type Fetcher func(ctx context.Context, url string) ([]byte, error)
+
+// fileBundle is a keyless signature of a file, as written by "cosign sign-blob --bundle".
+type fileBundle struct {
+	Base64Signature string       `json:"base64Signature"`
+	Cert            string       `json:"cert"`
+	RekorBundle     *rekorBundle `json:"rekorBundle"`
+}
+
+// SignatureURLs returns the URLs at which the signatures of the file at the URL are stored.
+func SignatureURLs(fileURL string) []string {
+	return []string{fileURL + SignatureSuffix, fileURL + BundleSuffix}
+}
+
+// VerifyFile verifies that the file is signed as required by the policy of its canonical URL.
+// digest is the sha256 hash of the file. The signatures are fetched from next to the file at each of the locations,
+// and then from next to the file at its canonical URL.
+// It returns a *Failure if the file is not signed as required, and nil if no policy applies to the file.
+func (v *Verifier) VerifyFile(ctx context.Context, canonicalURL string, digest []byte, locations []string, fetch Fetcher) error {
+	p := findPolicy(v.files, canonicalURL)
+	if p == nil {
+		return nil
+	}
+
+	sigs, reasons := fetchFileSignatures(ctx, canonicalURL, locations, fetch)
+	if len(sigs) == 0 && len(reasons) == 0 {
+		reasons = append(reasons, "file is not signed")
+	}
+	for _, sig := range sigs {
+		err := v.verifySignature(p, sig.signature, digest)
+		if err == nil {
+			return nil
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %v", sig.url, err))
+	}
+	return &Failure{Artifact: canonicalURL, Policy: p.prefix, Reasons: reasons}
+}
+
+type fileSignature struct {
+	url       string
+	signature *signature
+}
+
+// fetchFileSignatures fetches the signatures of a file from the first location that has any.
+// Signatures that cannot be parsed are reported as reasons.
+func fetchFileSignatures(ctx context.Context, canonicalURL string, locations []string, fetch Fetcher) ([]*fileSignature, []string) {
+	var reasons []string
+	for _, location := range append(append([]string(nil), locations...), canonicalURL) {
+		var sigs []*fileSignature
+		for _, u := range SignatureURLs(location) {
+			data, err := fetch(ctx, u)
+			if err != nil {
+				continue
+			}
+			sig, err := parseFileSignature(u, data)
+			if err != nil {
+				reasons = append(reasons, fmt.Sprintf("%s: %v", u, err))
+				continue
+			}
+			sigs = append(sigs, &fileSignature{url: u, signature: sig})
+		}
+		if len(sigs) != 0 || len(reasons) != 0 {
+			return sigs, reasons
+		}
+	}
+	return nil, nil
+}
+
+func parseFileSignature(u string, data []byte) (*signature, error) {
+	if strings.HasSuffix(u, BundleSuffix) {
+		bundle := &fileBundle{}
+		if err := json.Unmarshal(data, bundle); err != nil {
+			return nil, fmt.Errorf("parsing bundle: %w", err)
+		}
+		sig, err := base64.StdEncoding.DecodeString(bundle.Base64Signature)
+		if err != nil {
+			return nil, fmt.Errorf("decoding signature: %w", err)
+		}
+		cert, err := base64.StdEncoding.DecodeString(bundle.Cert)
+		if err != nil {
+			return nil, fmt.Errorf("decoding certificate: %w", err)
+		}
+		s := &signature{signature: sig, bundle: bundle.RekorBundle}
+		if len(cert) != 0 {
+			s.certificate = cert
+		}
+		return s, nil
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+	return &signature{signature: sig}, nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signatures
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+)
+
+// Annotations of the layers of a cosign signature image.
+const (
+	annotationSignature   = "dev.cosignproject.cosign/signature"
+	annotationCertificate = "dev.sigstore.cosign/certificate"
+	annotationChain       = "dev.sigstore.cosign/chain"
+	annotationBundle      = "dev.sigstore.cosign/bundle"
+)
+
+// maxPayloadSize limits the size of the signed payloads read from a registry.
+const maxPayloadSize = 1 << 20
+
+// simpleSigning is the payload cosign signs for an image.
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// SignatureTag returns the tag under which cosign stores the signatures of the image with the digest.
+func SignatureTag(repository name.Repository, digest v1.Hash) name.Tag {
+	return repository.Tag(digest.Algorithm + "-" + digest.Hex + ".sig")
+}
+
+// VerifyImage verifies that the image is signed as required by its policy, returning the digest of the image.
+// It returns a *Failure if the image is not signed as required, and an empty digest if no policy applies to the image.
+func (v *Verifier) VerifyImage(ctx context.Context, image string) (string, error) {
+	p := findPolicy(v.images, image)
+	if p == nil {
+		return "", nil
+	}
+
+	digest, reasons := v.verifyImage(ctx, p, image)
+	if reasons != nil {
+		return "", &Failure{Artifact: image, Policy: p.prefix, Reasons: reasons}
+	}
+	return digest.String(), nil
+}
+
+func (v *Verifier) verifyImage(ctx context.Context, p *policy, image string) (v1.Hash, []string) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return v1.Hash{}, []string{fmt.Sprintf("parsing image: %v", err)}
+	}
+
+	options := append([]remote.Option{remote.WithContext(ctx)}, v.remoteOptions...)
+	desc, err := remote.Head(ref, options...)
+	if err != nil {
+		// Some registries do not support HEAD requests for manifests.
+		d, getErr := remote.Get(ref, options...)
+		if getErr != nil {
+			return v1.Hash{}, []string{fmt.Sprintf("resolving image: %v", getErr)}
+		}
+		desc = &d.Descriptor
+	}
+	if digest, ok := ref.(name.Digest); ok && digest.DigestStr() != desc.Digest.String() {
+		return v1.Hash{}, []string{fmt.Sprintf("registry returned digest %s", desc.Digest)}
+	}
+
+	sigImage, err := remote.Image(SignatureTag(ref.Context(), desc.Digest), options...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return v1.Hash{}, []string{"image is not signed"}
+		}
+		return v1.Hash{}, []string{fmt.Sprintf("fetching signatures: %v", err)}
+	}
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return v1.Hash{}, []string{fmt.Sprintf("fetching signatures: %v", err)}
+	}
+	if len(manifest.Layers) == 0 {
+		return v1.Hash{}, []string{"image is not signed"}
+	}
+
+	var reasons []string
+	for i, layer := range manifest.Layers {
+		if err := v.verifyImageSignature(p, sigImage, layer, desc.Digest); err != nil {
+			reasons = append(reasons, fmt.Sprintf("signature %d: %v", i+1, err))
+			continue
+		}
+		return desc.Digest, nil
+	}
+	return v1.Hash{}, reasons
+}
+
+// verifyImageSignature verifies a layer of a cosign signature image, which holds a signed payload naming the image digest.
+func (v *Verifier) verifyImageSignature(p *policy, sigImage v1.Image, layer v1.Descriptor, digest v1.Hash) error {
+	sig := &signature{}
+
+	encoded, found := layer.Annotations[annotationSignature]
+	if !found {
+		return fmt.Errorf("layer has no signature annotation")
+	}
+	var err error
+	if sig.signature, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	if s, found := layer.Annotations[annotationCertificate]; found && s != "" {
+		sig.certificate = []byte(s)
+	}
+	if s, found := layer.Annotations[annotationChain]; found && s != "" {
+		sig.chain = []byte(s)
+	}
+	if s, found := layer.Annotations[annotationBundle]; found && s != "" {
+		sig.bundle = &rekorBundle{}
+		if err := json.Unmarshal([]byte(s), sig.bundle); err != nil {
+			return fmt.Errorf("parsing transparency log bundle: %w", err)
+		}
+	}
+
+	if layer.Size > maxPayloadSize {
+		return fmt.Errorf("payload of %d bytes is too large", layer.Size)
+	}
+	l, err := sigImage.LayerByDigest(layer.Digest)
+	if err != nil {
+		return fmt.Errorf("fetching payload: %w", err)
+	}
+	r, err := l.Compressed()
+	if err != nil {
+		return fmt.Errorf("fetching payload: %w", err)
+	}
+	defer r.Close()
+	payload, err := io.ReadAll(io.LimitReader(r, maxPayloadSize))
+	if err != nil {
+		return fmt.Errorf("fetching payload: %w", err)
+	}
+
+	h := sha256.Sum256(payload)
+	if err := v.verifySignature(p, sig, h[:]); err != nil {
+		return err
+	}
+
+	// The payload is only trusted once its signature is verified.
+	ss := &simpleSigning{}
+	if err := json.Unmarshal(payload, ss); err != nil {
+		return fmt.Errorf("parsing payload: %w", err)
+	}
+	if ss.Critical.Image.DockerManifestDigest != digest.String() {
+		return fmt.Errorf("signature is for image %s", ss.Critical.Image.DockerManifestDigest)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signatures
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Failure records why an artifact does not satisfy its signature policy.
+type Failure struct {
+	// Artifact is the image, or the URL of the file.
+	Artifact string
+	// Policy is the prefix of the policy that applies to the artifact.
+	Policy string
+	// Reasons explain why each signature of the artifact was rejected, or why no signature was found.
+	Reasons []string
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("%s does not satisfy signature policy %q: %s", f.Artifact, f.Policy, strings.Join(f.Reasons, "; "))
+}
+
+// Report collects the artifacts that failed verification, so that all of them can be reported at once.
+// It is safe for concurrent use.
+type Report struct {
+	mutex    sync.Mutex
+	failures []*Failure
+}
+
+// Add adds a failure to the report.
+func (r *Report) Add(failure *Failure) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, f := range r.failures {
+		if f.Artifact == failure.Artifact {
+			return
+		}
+	}
+	r.failures = append(r.failures, failure)
+}
+
+// Err returns the report as an error, or nil if no artifact failed verification.
+func (r *Report) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.failures) == 0 {
+		return nil
+	}
+	return &ReportError{Failures: append([]*Failure(nil), r.failures...)}
+}
+
+// ReportError is returned when artifacts fail verification.
+type ReportError struct {
+	Failures []*Failure
+}
+
+func (e *ReportError) Error() string {
+	failures := append([]*Failure(nil), e.Failures...)
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Artifact < failures[j].Artifact
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "signature verification failed for %d artifact(s):\n", len(failures))
+	for _, f := range failures {
+		fmt.Fprintf(&b, "  %s (policy %q)\n", f.Artifact, f.Policy)
+		for _, reason := range f.Reasons {
+			fmt.Fprintf(&b, "    - %s\n", reason)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
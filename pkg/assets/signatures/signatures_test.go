@@ -0,0 +1,463 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signatures
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// signer signs payloads as cosign would, with a key or with a keyless signing certificate.
+type signer struct {
+	key *ecdsa.PrivateKey
+	// certificate is the PEM-encoded keyless signing certificate of the key, if the signer is keyless.
+	certificate []byte
+	// rekor is the key of the transparency log recording keyless signatures.
+	rekor *ecdsa.PrivateKey
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	return key
+}
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("marshaling public key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// keylessAuthority issues keyless signing certificates and records their signatures in a transparency log.
+type keylessAuthority struct {
+	root     *x509.Certificate
+	rootKey  *ecdsa.PrivateKey
+	rootPEM  string
+	rekorKey *ecdsa.PrivateKey
+}
+
+func newKeylessAuthority(t *testing.T) *keylessAuthority {
+	rootKey := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-fulcio"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, rootKey.Public(), rootKey)
+	if err != nil {
+		t.Fatalf("creating root certificate: %v", err)
+	}
+	root, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing root certificate: %v", err)
+	}
+	return &keylessAuthority{
+		root:     root,
+		rootKey:  rootKey,
+		rootPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		rekorKey: newKey(t),
+	}
+}
+
+// newSigner issues a short-lived signing certificate for the identity, which expired an hour ago.
+func (a *keylessAuthority) newSigner(t *testing.T, issuer, subject string) *signer {
+	key := newKey(t)
+	issuerValue, err := asn1.Marshal(issuer)
+	if err != nil {
+		t.Fatalf("encoding issuer: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		EmailAddresses: []string{subject},
+		NotBefore:      time.Now().Add(-2 * time.Hour),
+		NotAfter:       time.Now().Add(-time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{
+			{Id: oidIssuerV2, Value: issuerValue},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.root, key.Public(), a.rootKey)
+	if err != nil {
+		t.Fatalf("creating signing certificate: %v", err)
+	}
+	return &signer{
+		key:         key,
+		certificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		rekor:       a.rekorKey,
+	}
+}
+
+func (a *keylessAuthority) spec(t *testing.T) kops.AssetVerificationSpec {
+	return kops.AssetVerificationSpec{
+		FulcioRoots:     []string{a.rootPEM},
+		RekorPublicKeys: []string{publicKeyPEM(t, a.rekorKey)},
+	}
+}
+
+// sign returns the signature of the payload and, for a keyless signer, its transparency log bundle.
+func (s *signer) sign(t *testing.T, payload []byte) ([]byte, *rekorBundle) {
+	h := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, h[:])
+	if err != nil {
+		t.Fatalf("signing: %v", err)
+	}
+	if s.certificate == nil {
+		return sig, nil
+	}
+
+	entry := &hashedRekord{Kind: "hashedrekord"}
+	entry.Spec.Signature.Content = base64.StdEncoding.EncodeToString(sig)
+	entry.Spec.Signature.PublicKey.Content = base64.StdEncoding.EncodeToString(s.certificate)
+	entry.Spec.Data.Hash.Algorithm = "sha256"
+	entry.Spec.Data.Hash.Value = hex.EncodeToString(h[:])
+	body, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("encoding entry: %v", err)
+	}
+	id, err := logID(s.rekor.Public())
+	if err != nil {
+		t.Fatalf("computing log ID: %v", err)
+	}
+	bundle := &rekorBundle{
+		Payload: rekorPayload{
+			Body:           base64.StdEncoding.EncodeToString(body),
+			IntegratedTime: time.Now().Add(-90 * time.Minute).Unix(),
+			LogID:          id,
+			LogIndex:       42,
+		},
+	}
+	canonical, err := json.Marshal(bundle.Payload)
+	if err != nil {
+		t.Fatalf("encoding payload: %v", err)
+	}
+	ch := sha256.Sum256(canonical)
+	if bundle.SignedEntryTimestamp, err = ecdsa.SignASN1(rand.Reader, s.rekor, ch[:]); err != nil {
+		t.Fatalf("signing entry: %v", err)
+	}
+	return sig, bundle
+}
+
+// signImage pushes a cosign signature of the image with the digest to the repository.
+func (s *signer) signImage(t *testing.T, repository name.Repository, digest v1.Hash) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, repository.String(), digest.String()))
+	sig, bundle := s.sign(t, payload)
+
+	annotations := map[string]string{
+		annotationSignature: base64.StdEncoding.EncodeToString(sig),
+	}
+	if s.certificate != nil {
+		annotations[annotationCertificate] = string(s.certificate)
+		b, err := json.Marshal(bundle)
+		if err != nil {
+			t.Fatalf("encoding bundle: %v", err)
+		}
+		annotations[annotationBundle] = string(b)
+	}
+
+	layer := static.NewLayer(payload, types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json"))
+	img, err := mutate.Append(empty.Image, mutate.Addendum{Layer: layer, Annotations: annotations})
+	if err != nil {
+		t.Fatalf("building signature image: %v", err)
+	}
+	if err := remote.Write(SignatureTag(repository, digest), img); err != nil {
+		t.Fatalf("pushing signature image: %v", err)
+	}
+}
+
+// pushImage pushes a random image to the registry, returning its name and digest.
+func pushImage(t *testing.T, host string, repository string) (name.Tag, v1.Hash) {
+	img, err := random.Image(64, 1)
+	if err != nil {
+		t.Fatalf("building image: %v", err)
+	}
+	tag, err := name.NewTag(host + "/" + repository + ":v1.0.0")
+	if err != nil {
+		t.Fatalf("parsing tag: %v", err)
+	}
+	if err := remote.Write(tag, img); err != nil {
+		t.Fatalf("pushing image: %v", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatalf("computing digest: %v", err)
+	}
+	return tag, digest
+}
+
+func newRegistry(t *testing.T) string {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parsing registry URL: %v", err)
+	}
+	return u.Host
+}
+
+func checkFailure(t *testing.T, err error, reason string) {
+	t.Helper()
+	var failure *Failure
+	if !errors.As(err, &failure) {
+		t.Fatalf("expected a verification failure, got %v", err)
+	}
+	if !strings.Contains(strings.Join(failure.Reasons, "; "), reason) {
+		t.Errorf("expected reason %q, got %v", reason, failure.Reasons)
+	}
+}
+
+func TestVerifyImageWithKey(t *testing.T) {
+	ctx := context.Background()
+	host := newRegistry(t)
+
+	key := newKey(t)
+	otherKey := newKey(t)
+
+	signed, signedDigest := pushImage(t, host, "kops/signed")
+	(&signer{key: key}).signImage(t, signed.Context(), signedDigest)
+
+	wrongKey, wrongKeyDigest := pushImage(t, host, "kops/wrong-key")
+	(&signer{key: otherKey}).signImage(t, wrongKey.Context(), wrongKeyDigest)
+
+	unsigned, _ := pushImage(t, host, "kops/unsigned")
+
+	mismatched, _ := pushImage(t, host, "kops/mismatched")
+	other, otherDigest := pushImage(t, host, "kops/other")
+	// Copy the signature of another image into the repository, as if the image had been replaced after signing.
+	mismatchedDesc, err := remote.Head(mismatched)
+	if err != nil {
+		t.Fatalf("resolving image: %v", err)
+	}
+	(&signer{key: key}).signImage(t, other.Context(), otherDigest)
+	sigImage, err := remote.Image(SignatureTag(other.Context(), otherDigest))
+	if err != nil {
+		t.Fatalf("fetching signature: %v", err)
+	}
+	if err := remote.Write(SignatureTag(mismatched.Context(), mismatchedDesc.Digest), sigImage); err != nil {
+		t.Fatalf("pushing signature: %v", err)
+	}
+
+	verifier, err := NewVerifier(&kops.AssetVerificationSpec{
+		Images: []kops.SignaturePolicySpec{
+			{Prefix: host + "/kops/", PublicKeys: []string{publicKeyPEM(t, key)}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("building verifier: %v", err)
+	}
+
+	digest, err := verifier.VerifyImage(ctx, signed.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if digest != signedDigest.String() {
+		t.Errorf("expected digest %s, got %s", signedDigest, digest)
+	}
+
+	_, err = verifier.VerifyImage(ctx, wrongKey.String())
+	checkFailure(t, err, "signature does not match any of the public keys of the policy")
+
+	_, err = verifier.VerifyImage(ctx, unsigned.String())
+	checkFailure(t, err, "image is not signed")
+
+	_, err = verifier.VerifyImage(ctx, mismatched.String())
+	checkFailure(t, err, "signature is for image "+otherDigest.String())
+
+	digest, err = verifier.VerifyImage(ctx, "registry.example.com/unverified:v1.0.0")
+	if err != nil || digest != "" {
+		t.Errorf("expected images without a policy to be skipped, got %q, %v", digest, err)
+	}
+}
+
+func TestVerifyImageKeyless(t *testing.T) {
+	ctx := context.Background()
+	host := newRegistry(t)
+	authority := newKeylessAuthority(t)
+
+	const issuer = "https://accounts.example.com"
+	image, digest := pushImage(t, host, "kops/keyless")
+	authority.newSigner(t, issuer, "release@example.com").signImage(t, image.Context(), digest)
+
+	other, otherDigest := pushImage(t, host, "kops/other-identity")
+	authority.newSigner(t, issuer, "someone@example.com").signImage(t, other.Context(), otherDigest)
+
+	spec := authority.spec(t)
+	spec.Images = []kops.SignaturePolicySpec{
+		{
+			Prefix:            host + "/kops/",
+			KeylessIdentities: []kops.KeylessIdentitySpec{{Issuer: issuer, Subject: "release@example.com"}},
+		},
+	}
+	verifier, err := NewVerifier(&spec)
+	if err != nil {
+		t.Fatalf("building verifier: %v", err)
+	}
+
+	if _, err := verifier.VerifyImage(ctx, image.String()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = verifier.VerifyImage(ctx, other.String())
+	checkFailure(t, err, `signing certificate of [someone@example.com] issued by "https://accounts.example.com" does not match any identity of the policy`)
+
+	// Without the transparency log key, the signing time cannot be trusted.
+	spec.RekorPublicKeys = []string{publicKeyPEM(t, newKey(t))}
+	verifier, err = NewVerifier(&spec)
+	if err != nil {
+		t.Fatalf("building verifier: %v", err)
+	}
+	_, err = verifier.VerifyImage(ctx, image.String())
+	checkFailure(t, err, "transparency log entry is from unknown log")
+}
+
+func TestVerifyFile(t *testing.T) {
+	ctx := context.Background()
+	authority := newKeylessAuthority(t)
+	key := newKey(t)
+
+	const issuer = "https://accounts.example.com"
+	data := []byte("kubelet")
+	digest := sha256.Sum256(data)
+
+	sig, _ := (&signer{key: key}).sign(t, data)
+	keylessSigner := authority.newSigner(t, issuer, "release@example.com")
+	keylessSig, bundle := keylessSigner.sign(t, data)
+	fileBundleJSON, err := json.Marshal(&fileBundle{
+		Base64Signature: base64.StdEncoding.EncodeToString(keylessSig),
+		Cert:            base64.StdEncoding.EncodeToString(keylessSigner.certificate),
+		RekorBundle:     bundle,
+	})
+	if err != nil {
+		t.Fatalf("encoding bundle: %v", err)
+	}
+
+	files := map[string][]byte{
+		"https://mirror.example.com/release/v1.30.0/kubelet.sig": []byte(base64.StdEncoding.EncodeToString(sig) + "\n"),
+		"https://dl.example.com/keyless/v1.30.0/kubelet.bundle":  fileBundleJSON,
+		"https://dl.example.com/corrupted/v1.30.0/kubelet.sig":   []byte("not base64!"),
+		"https://dl.example.com/wrong-key/v1.30.0/kubelet.sig":   []byte(base64.StdEncoding.EncodeToString(keylessSig)),
+	}
+	fetch := func(ctx context.Context, u string) ([]byte, error) {
+		data, found := files[u]
+		if !found {
+			return nil, os.ErrNotExist
+		}
+		return data, nil
+	}
+
+	spec := authority.spec(t)
+	spec.Files = []kops.SignaturePolicySpec{
+		{Prefix: "https://dl.example.com/", PublicKeys: []string{publicKeyPEM(t, key)}},
+		{
+			Prefix:            "https://dl.example.com/keyless/",
+			KeylessIdentities: []kops.KeylessIdentitySpec{{Issuer: issuer, Subject: "release@example.com"}},
+		},
+	}
+	verifier, err := NewVerifier(&spec)
+	if err != nil {
+		t.Fatalf("building verifier: %v", err)
+	}
+
+	// The signature is fetched from the mirror the file was downloaded from.
+	err = verifier.VerifyFile(ctx, "https://dl.example.com/release/v1.30.0/kubelet", digest[:], []string{"https://mirror.example.com/release/v1.30.0/kubelet"}, fetch)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = verifier.VerifyFile(ctx, "https://dl.example.com/keyless/v1.30.0/kubelet", digest[:], nil, fetch)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = verifier.VerifyFile(ctx, "https://dl.example.com/release/v1.30.0/kubectl", digest[:], nil, fetch)
+	checkFailure(t, err, "file is not signed")
+
+	err = verifier.VerifyFile(ctx, "https://dl.example.com/corrupted/v1.30.0/kubelet", digest[:], nil, fetch)
+	checkFailure(t, err, "decoding signature")
+
+	err = verifier.VerifyFile(ctx, "https://dl.example.com/wrong-key/v1.30.0/kubelet", digest[:], nil, fetch)
+	checkFailure(t, err, "signature does not match any of the public keys of the policy")
+
+	otherDigest := sha256.Sum256([]byte("tampered"))
+	err = verifier.VerifyFile(ctx, "https://dl.example.com/keyless/v1.30.0/kubelet", otherDigest[:], nil, fetch)
+	checkFailure(t, err, "signature does not match the signing certificate")
+
+	if err := verifier.VerifyFile(ctx, "https://other.example.com/kubelet", digest[:], nil, fetch); err != nil {
+		t.Errorf("expected files without a policy to be skipped, got %v", err)
+	}
+}
+
+func TestReport(t *testing.T) {
+	report := &Report{}
+	if err := report.Err(); err != nil {
+		t.Fatalf("expected no error from an empty report, got %v", err)
+	}
+
+	report.Add(&Failure{Artifact: "registry.k8s.io/pause:3.9", Policy: "registry.k8s.io/", Reasons: []string{"image is not signed"}})
+	report.Add(&Failure{Artifact: "https://dl.k8s.io/release/v1.30.0/bin/linux/amd64/kubelet", Policy: "https://dl.k8s.io/", Reasons: []string{"a", "b"}})
+	report.Add(&Failure{Artifact: "registry.k8s.io/pause:3.9", Policy: "registry.k8s.io/", Reasons: []string{"duplicate"}})
+
+	expected := `signature verification failed for 2 artifact(s):
+  https://dl.k8s.io/release/v1.30.0/bin/linux/amd64/kubelet (policy "https://dl.k8s.io/")
+    - a
+    - b
+  registry.k8s.io/pause:3.9 (policy "registry.k8s.io/")
+    - image is not signed`
+	if actual := report.Err().Error(); actual != expected {
+		t.Errorf("unexpected report:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signatures verifies the cosign signatures of images and files against the verification policy of a cluster.
+package signatures
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// Verifier verifies images and files against a verification policy.
+type Verifier struct {
+	images []*policy
+	files  []*policy
+
+	// roots are the certificate authorities issuing keyless signing certificates.
+	roots *x509.CertPool
+	// intermediates are the intermediate certificates of the certificate authorities.
+	intermediates *x509.CertPool
+	// rekorKeys are the public keys of the transparency logs, keyed by their log ID.
+	rekorKeys map[string]crypto.PublicKey
+
+	remoteOptions []remote.Option
+}
+
+// policy is a parsed kops.SignaturePolicySpec.
+type policy struct {
+	prefix     string
+	keys       []crypto.PublicKey
+	identities []kops.KeylessIdentitySpec
+}
+
+// NewVerifier builds a Verifier for the policy.
+// The options are used to access registries when verifying images.
+func NewVerifier(spec *kops.AssetVerificationSpec, options ...remote.Option) (*Verifier, error) {
+	v := &Verifier{
+		roots:         x509.NewCertPool(),
+		intermediates: x509.NewCertPool(),
+		rekorKeys:     make(map[string]crypto.PublicKey),
+		remoteOptions: options,
+	}
+
+	var err error
+	if v.images, err = parsePolicies(spec.Images); err != nil {
+		return nil, fmt.Errorf("parsing image policies: %w", err)
+	}
+	if v.files, err = parsePolicies(spec.Files); err != nil {
+		return nil, fmt.Errorf("parsing file policies: %w", err)
+	}
+
+	for i, data := range spec.FulcioRoots {
+		certs, err := ParseCertificates([]byte(data))
+		if err != nil {
+			return nil, fmt.Errorf("parsing fulcioRoots[%d]: %w", i, err)
+		}
+		for _, cert := range certs {
+			if isSelfSigned(cert) {
+				v.roots.AddCert(cert)
+			} else {
+				v.intermediates.AddCert(cert)
+			}
+		}
+	}
+
+	for i, data := range spec.RekorPublicKeys {
+		key, err := ParsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing rekorPublicKeys[%d]: %w", i, err)
+		}
+		id, err := logID(key)
+		if err != nil {
+			return nil, fmt.Errorf("parsing rekorPublicKeys[%d]: %w", i, err)
+		}
+		v.rekorKeys[id] = key
+	}
+
+	return v, nil
+}
+
+func parsePolicies(specs []kops.SignaturePolicySpec) ([]*policy, error) {
+	var policies []*policy
+	for _, spec := range specs {
+		p := &policy{
+			prefix:     spec.Prefix,
+			identities: spec.KeylessIdentities,
+		}
+		for i, data := range spec.PublicKeys {
+			key, err := ParsePublicKey(data)
+			if err != nil {
+				return nil, fmt.Errorf("policy %q: parsing publicKeys[%d]: %w", spec.Prefix, i, err)
+			}
+			p.keys = append(p.keys, key)
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// ParsePublicKey parses a PEM-encoded ECDSA or RSA public key.
+func ParsePublicKey(data string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// logID returns the ID of the transparency log with the public key, which is the hex-encoded sha256 hash of the key.
+func logID(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(der)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// ParseCertificates parses the PEM-encoded certificates in data.
+func ParseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found")
+	}
+	return certs, nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(cert) == nil
+}
+
+// findPolicy returns the policy with the longest prefix of name, or nil if none applies.
+func findPolicy(policies []*policy, name string) *policy {
+	var found *policy
+	for _, p := range policies {
+		if !strings.HasPrefix(name, p.prefix) {
+			continue
+		}
+		if found == nil || len(p.prefix) > len(found.prefix) {
+			found = p
+		}
+	}
+	return found
+}
+
+// HasImagePolicy returns true if a policy applies to the image.
+func (v *Verifier) HasImagePolicy(image string) bool {
+	return findPolicy(v.images, image) != nil
+}
+
+// HasFilePolicy returns true if a policy applies to the file with the canonical URL.
+func (v *Verifier) HasFilePolicy(canonicalURL string) bool {
+	return findPolicy(v.files, canonicalURL) != nil
+}
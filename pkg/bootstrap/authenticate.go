@@ -37,6 +37,12 @@ type VerifyResult struct {
 	// InstanceGroupName is the name of the kops InstanceGroup this node is a member of.
 	InstanceGroupName string
 
+	// InstanceID is the cloud identifier of the instance, if known.
+	InstanceID string
+
+	// InstanceType is the machine type of the instance, if known.
+	InstanceType string
+
 	// CertificateNames is the alternate names the node is authorized to use for certificates.
 	CertificateNames []string
 
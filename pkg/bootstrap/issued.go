@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// IssuedCertificatesNamespace is the namespace of the ConfigMaps recording the certificates issued to nodes.
+// It is separate from kube-system, so that kops-controller does not need access to the other ConfigMaps.
+const IssuedCertificatesNamespace = "kops-system"
+
+// IssuedCertificatesConfigMapName returns the name of the ConfigMap in which kops-controller
+// records the certificates it issued to the named node.
+func IssuedCertificatesConfigMapName(nodeName string) string {
+	return "kops-issued-certificates." + nodeName
+}
+
+// IssuedCertificate records a certificate issued to a node, so that it can later be revoked.
+// Issued certificates are stored in the node's ConfigMap keyed by serial number.
+type IssuedCertificate struct {
+	// Name is the name of the certificate in the bootstrap request, e.g. "kubelet".
+	Name string `json:"name"`
+	// Signer is the name of the CA keyset that signed the certificate.
+	Signer string `json:"signer"`
+	// KeypairID is the ID of the keypair of the keyset that signed the certificate.
+	KeypairID string `json:"keypairID,omitempty"`
+	// NotAfter is the expiry time of the certificate.
+	NotAfter time.Time `json:"notAfter"`
+}
+
+// AddIssuedCertificate adds the certificate with the given serial number to the ConfigMap data,
+// and removes any certificates that have expired.
+func AddIssuedCertificate(data map[string]string, serial string, cert *IssuedCertificate, now time.Time) (map[string]string, error) {
+	existing, err := ParseIssuedCertificates(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	for k, v := range existing {
+		if v.NotAfter.Before(now) {
+			continue
+		}
+		result[k] = data[k]
+	}
+
+	b, err := json.Marshal(cert)
+	if err != nil {
+		return nil, fmt.Errorf("encoding issued certificate: %w", err)
+	}
+	result[serial] = string(b)
+	return result, nil
+}
+
+// AllIssuedCertificatesExpired returns true if all the certificates in the record have expired,
+// in which case there is nothing left to revoke and the record can be deleted.
+func AllIssuedCertificatesExpired(issued map[string]*IssuedCertificate, now time.Time) bool {
+	for _, cert := range issued {
+		if !cert.NotAfter.Before(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseIssuedCertificates parses the ConfigMap data recording the certificates issued to a node.
+func ParseIssuedCertificates(data map[string]string) (map[string]*IssuedCertificate, error) {
+	issued := make(map[string]*IssuedCertificate, len(data))
+	for serial, v := range data {
+		cert := &IssuedCertificate{}
+		if err := json.Unmarshal([]byte(v), cert); err != nil {
+			return nil, fmt.Errorf("parsing issued certificate %q: %w", serial, err)
+		}
+		issued[serial] = cert
+	}
+	return issued, nil
+}
@@ -61,7 +61,7 @@ var _ bootstrap.Verifier = &verifier{}
 // TODO: Dedup with gce
 func (v *verifier) parseTokenData(tokenPrefix string, authToken string, body []byte) (*AuthToken, *AuthTokenData, error) {
 	if !strings.HasPrefix(authToken, tokenPrefix) {
-		return nil, nil, fmt.Errorf("incorrect authorization type")
+		return nil, nil, bootstrap.ErrNotThisVerifier
 	}
 	authToken = strings.TrimPrefix(authToken, tokenPrefix)
 
@@ -141,6 +141,11 @@ func (v *verifier) getSigningKey(ctx context.Context, tokenData *AuthTokenData)
 
 	// TODO: Check instance-group matches request (does it matter?)
 
+	// Hosts enrolled with a TPM must prove possession of the TPM, not just a key that could have been copied.
+	if host.Spec.TPM != nil {
+		return nil, nil, fmt.Errorf("host %v was enrolled with a TPM and must use TPM attestation", id)
+	}
+
 	if host.Spec.PublicKey == "" {
 		return nil, nil, fmt.Errorf("host %v did not have public-key", id)
 	}
@@ -158,6 +163,7 @@ func (v *verifier) getSigningKey(ctx context.Context, tokenData *AuthTokenData)
 	result := &bootstrap.VerifyResult{
 		NodeName:          nodeName,
 		InstanceGroupName: instanceGroup,
+		InstanceID:        nodeName,
 		CertificateNames:  sans,
 	}
 
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpmbootstrap
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/legacy/tpm2/credactivation"
+	kops "k8s.io/kops/pkg/apis/kops/v1alpha2"
+)
+
+// attestationKeyAttributes are the attributes an attestation key must have.
+// A restricted signing key cannot sign data that looks like a TPM-generated structure,
+// so a quote signed by such a key must have been produced by the TPM itself.
+const attestationKeyAttributes = tpm2.FlagFixedTPM | tpm2.FlagFixedParent | tpm2.FlagRestricted | tpm2.FlagSign
+
+// credentialBlobMagic and credentialBlobVersion are the header of the credential file format
+// understood by tpm2_activatecredential.
+const (
+	credentialBlobMagic   = 0xBADCC0DE
+	credentialBlobVersion = 1
+)
+
+// UnmarshalTPM2B returns the contents of a size-prefixed TPM2B structure,
+// as written by the tpm2-tools.
+func UnmarshalTPM2B(b []byte) ([]byte, error) {
+	if len(b) < 2 {
+		return nil, fmt.Errorf("TPM2B structure too short")
+	}
+	n := int(binary.BigEndian.Uint16(b))
+	if len(b) != n+2 {
+		return nil, fmt.Errorf("TPM2B structure has size %d, but %d bytes of data", n, len(b)-2)
+	}
+	return b[2:], nil
+}
+
+// DecodeAttestationKey parses an attestation key as stored in HostTPMSpec, checking it is a restricted signing key.
+func DecodeAttestationKey(s string) (tpm2.Public, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return tpm2.Public{}, fmt.Errorf("decoding attestation key: %w", err)
+	}
+	return decodeAttestationKey(b)
+}
+
+func decodeAttestationKey(b []byte) (tpm2.Public, error) {
+	pub, err := tpm2.DecodePublic(b)
+	if err != nil {
+		return tpm2.Public{}, fmt.Errorf("parsing attestation key: %w", err)
+	}
+	if pub.Attributes&attestationKeyAttributes != attestationKeyAttributes {
+		return tpm2.Public{}, fmt.Errorf("attestation key has attributes %v, must include fixedTPM, fixedParent, restricted and sign", pub.Attributes)
+	}
+	return pub, nil
+}
+
+// PCRSelection builds the SHA-256 PCR selection we quote.
+func PCRSelection(pcrs []int) tpm2.PCRSelection {
+	sel := tpm2.PCRSelection{Hash: tpm2.AlgSHA256}
+	sel.PCRs = append(sel.PCRs, pcrs...)
+	sort.Ints(sel.PCRs)
+	return sel
+}
+
+// ParsePCRValues splits the output of `tpm2_pcrread -o` for the given PCRs into HostPCRValues.
+// tpm2_pcrread writes the digests in ascending PCR order.
+func ParsePCRValues(pcrs []int, raw []byte) ([]kops.HostPCRValue, error) {
+	sel := PCRSelection(pcrs)
+	if len(raw) != len(sel.PCRs)*sha256.Size {
+		return nil, fmt.Errorf("expected %d bytes of PCR values for PCRs %v, got %d", len(sel.PCRs)*sha256.Size, sel.PCRs, len(raw))
+	}
+	var values []kops.HostPCRValue
+	for i, pcr := range sel.PCRs {
+		values = append(values, kops.HostPCRValue{
+			Index: pcr,
+			Value: hex.EncodeToString(raw[i*sha256.Size : (i+1)*sha256.Size]),
+		})
+	}
+	return values, nil
+}
+
+// expectedPCRDigest computes the selection and digest that a quote over the expected PCR values must contain.
+func expectedPCRDigest(values []kops.HostPCRValue) (tpm2.PCRSelection, []byte, error) {
+	sorted := make([]kops.HostPCRValue, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	sel := tpm2.PCRSelection{Hash: tpm2.AlgSHA256}
+	hasher := sha256.New()
+	for i, v := range sorted {
+		if i > 0 && sorted[i-1].Index == v.Index {
+			return sel, nil, fmt.Errorf("PCR %d specified more than once", v.Index)
+		}
+		b, err := hex.DecodeString(v.Value)
+		if err != nil {
+			return sel, nil, fmt.Errorf("decoding value of PCR %d: %w", v.Index, err)
+		}
+		if len(b) != sha256.Size {
+			return sel, nil, fmt.Errorf("value of PCR %d is not a SHA-256 digest", v.Index)
+		}
+		sel.PCRs = append(sel.PCRs, v.Index)
+		hasher.Write(b)
+	}
+	return sel, hasher.Sum(nil), nil
+}
+
+// CredentialChallenge is a secret encrypted such that it can only be recovered by a TPM
+// that holds both the endorsement key and the attestation key.
+type CredentialChallenge struct {
+	// Secret is the value that the host must return to prove it activated the credential.
+	Secret []byte
+	// Blob is the encrypted credential, in the format read by tpm2_activatecredential.
+	Blob []byte
+}
+
+// NewCredentialChallenge builds a credential-activation challenge binding the attestation key
+// (the TPM2B_PUBLIC as written by tpm2_createak) to the PEM encoded endorsement key.
+// It returns the challenge and the attestation key encoded for HostTPMSpec.
+func NewCredentialChallenge(endorsementKey []byte, attestationKey []byte) (*CredentialChallenge, string, error) {
+	block, _ := pem.Decode(endorsementKey)
+	if block == nil {
+		return nil, "", fmt.Errorf("endorsement key is not PEM encoded")
+	}
+	ek, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing endorsement key: %w", err)
+	}
+
+	akBytes, err := UnmarshalTPM2B(attestationKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading attestation key: %w", err)
+	}
+	ak, err := decodeAttestationKey(akBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	akName, err := ak.Name()
+	if err != nil {
+		return nil, "", fmt.Errorf("computing attestation key name: %w", err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("generating secret: %w", err)
+	}
+
+	// The EK templates use AES-128 as the symmetric algorithm.
+	idObject, encSecret, err := credactivation.Generate(akName.Digest, ek, 16, secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("generating credential: %w", err)
+	}
+
+	var blob bytes.Buffer
+	_ = binary.Write(&blob, binary.BigEndian, uint32(credentialBlobMagic))
+	_ = binary.Write(&blob, binary.BigEndian, uint32(credentialBlobVersion))
+	blob.Write(idObject)
+	blob.Write(encSecret)
+
+	return &CredentialChallenge{Secret: secret, Blob: blob.Bytes()}, base64.StdEncoding.EncodeToString(akBytes), nil
+}
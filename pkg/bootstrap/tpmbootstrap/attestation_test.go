@@ -0,0 +1,409 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpmbootstrap
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kops "k8s.io/kops/pkg/apis/kops/v1alpha2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// quoteFixture is a quote recorded from the TPM simulator, so the verifier is tested without it.
+// It was made by the testHost with seed 1, enrolled with PCRs 0 and 7, for a token for node-1 with a request body of `{}`;
+// the other attestation key is from the testHost with seed 2.
+type quoteFixture struct {
+	// Host is the TPM identity recorded at enrollment.
+	Host *kops.HostTPMSpec `json:"host"`
+	// OtherAttestationKey is the attestation key of another TPM.
+	OtherAttestationKey string `json:"otherAttestationKey"`
+	// Token is the token created by the enrolled host.
+	Token *AuthToken `json:"token"`
+}
+
+func loadQuoteFixture(t *testing.T) *quoteFixture {
+	b, err := os.ReadFile("testdata/quote.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	fixture := &quoteFixture{}
+	if err := json.Unmarshal(b, fixture); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+	return fixture
+}
+
+func TestVerifyQuote(t *testing.T) {
+	grid := []struct {
+		name     string
+		mutate   func(t *testing.T, fixture *quoteFixture)
+		expected string
+	}{
+		{
+			name:   "recorded quote",
+			mutate: func(t *testing.T, fixture *quoteFixture) {},
+		},
+		{
+			name: "PCR value changed after enrollment",
+			mutate: func(t *testing.T, fixture *quoteFixture) {
+				fixture.Host.PCRs[1].Value = hex.EncodeToString(bytes.Repeat([]byte{0xaa}, sha256.Size))
+			},
+			expected: "PCR values did not match",
+		},
+		{
+			name: "fewer PCRs than quoted",
+			mutate: func(t *testing.T, fixture *quoteFixture) {
+				fixture.Host.PCRs = fixture.Host.PCRs[1:]
+			},
+			expected: "quote covers PCRs",
+		},
+		{
+			name: "PCR recorded twice",
+			mutate: func(t *testing.T, fixture *quoteFixture) {
+				fixture.Host.PCRs[0].Index = 7
+			},
+			expected: "specified more than once",
+		},
+		{
+			name: "token data replaced",
+			mutate: func(t *testing.T, fixture *quoteFixture) {
+				fixture.Token.Data = bytes.Replace(fixture.Token.Data, []byte("node-1"), []byte("node-2"), 1)
+			},
+			expected: "not bound to token data",
+		},
+		{
+			name: "quote tampered with",
+			mutate: func(t *testing.T, fixture *quoteFixture) {
+				fixture.Token.Quote[len(fixture.Token.Quote)-1] ^= 0xff
+			},
+			expected: "signature did not verify",
+		},
+		{
+			name: "signature tampered with",
+			mutate: func(t *testing.T, fixture *quoteFixture) {
+				fixture.Token.Signature[len(fixture.Token.Signature)-1] ^= 0xff
+			},
+			expected: "signature did not verify",
+		},
+		{
+			name: "attestation key of another TPM",
+			mutate: func(t *testing.T, fixture *quoteFixture) {
+				fixture.Host.AttestationKey = fixture.OtherAttestationKey
+			},
+			expected: "signature did not verify",
+		},
+		{
+			name: "attestation key is not restricted",
+			mutate: func(t *testing.T, fixture *quoteFixture) {
+				fixture.Host.AttestationKey = encodeUnrestricted(t, fixture.Host.AttestationKey)
+			},
+			expected: "must include fixedTPM, fixedParent, restricted and sign",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			fixture := loadQuoteFixture(t)
+			g.mutate(t, fixture)
+
+			err := verifyQuote(fixture.Host, fixture.Token)
+			if g.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), g.expected) {
+				t.Fatalf("expected error containing %q, got %v", g.expected, err)
+			}
+		})
+	}
+}
+
+func TestVerifyTokenRecorded(t *testing.T) {
+	ctx := context.Background()
+	fixture := loadQuoteFixture(t)
+
+	host := &kops.Host{}
+	host.Namespace = "kops-system"
+	host.Name = "node-1"
+	host.Spec.InstanceGroup = "nodes"
+	host.Spec.TPM = fixture.Host
+
+	b, err := json.Marshal(fixture.Token)
+	if err != nil {
+		t.Fatalf("failed to marshal token: %v", err)
+	}
+	token := AuthenticationTokenPrefix + base64.StdEncoding.EncodeToString(b)
+	body := []byte(`{}`)
+
+	// The recorded token is too old to be accepted, so it cannot be replayed.
+	if _, err := newTestVerifier(host).VerifyToken(ctx, nil, token, body); err == nil || !strings.Contains(err.Error(), "incorrect Timestamp") {
+		t.Fatalf("expected error for recorded timestamp, got %v", err)
+	}
+
+	v := newTestVerifier(host)
+	v.opt.MaxTimeSkew = math.MaxInt64
+	result, err := v.VerifyToken(ctx, nil, token, body)
+	if err != nil {
+		t.Fatalf("VerifyToken failed: %v", err)
+	}
+	if result.NodeName != "node-1" || result.InstanceGroupName != "nodes" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if _, err := v.VerifyToken(ctx, nil, token, []byte(`{"a":1}`)); err == nil {
+		t.Errorf("expected error when body does not match token")
+	}
+
+	other := host.DeepCopy()
+	other.Name = "node-2"
+	if _, err := newTestVerifier(other).VerifyToken(ctx, nil, token, body); err == nil {
+		t.Errorf("expected error for host that is not enrolled")
+	}
+}
+
+func TestNewCredentialChallenge(t *testing.T) {
+	fixture := loadQuoteFixture(t)
+	akBytes, err := base64.StdEncoding.DecodeString(fixture.Host.AttestationKey)
+	if err != nil {
+		t.Fatalf("failed to decode attestation key: %v", err)
+	}
+	attestationKey := marshalU16(t, akBytes)
+
+	ek, ekPEM := newEndorsementKey(t)
+	challenge, encoded, err := NewCredentialChallenge(ekPEM, attestationKey)
+	if err != nil {
+		t.Fatalf("NewCredentialChallenge failed: %v", err)
+	}
+	if encoded != fixture.Host.AttestationKey {
+		t.Errorf("unexpected encoded attestation key %q", encoded)
+	}
+
+	ak, err := DecodeAttestationKey(encoded)
+	if err != nil {
+		t.Fatalf("DecodeAttestationKey failed: %v", err)
+	}
+	akName, err := ak.Name()
+	if err != nil {
+		t.Fatalf("failed to compute attestation key name: %v", err)
+	}
+
+	secret, err := activateCredential(ek, akName.Digest, challenge.Blob)
+	if err != nil {
+		t.Fatalf("failed to activate credential: %v", err)
+	}
+	if !bytes.Equal(secret, challenge.Secret) {
+		t.Fatalf("activated credential did not match secret")
+	}
+
+	// The credential can only be activated by the TPM holding the endorsement key...
+	otherEK, _ := newEndorsementKey(t)
+	if _, err := activateCredential(otherEK, akName.Digest, challenge.Blob); err == nil {
+		t.Errorf("credential was activated with another endorsement key")
+	}
+
+	// ... for the attestation key it was issued to.
+	otherAK, err := DecodeAttestationKey(fixture.OtherAttestationKey)
+	if err != nil {
+		t.Fatalf("DecodeAttestationKey failed: %v", err)
+	}
+	otherName, err := otherAK.Name()
+	if err != nil {
+		t.Fatalf("failed to compute attestation key name: %v", err)
+	}
+	if _, err := activateCredential(ek, otherName.Digest, challenge.Blob); err == nil {
+		t.Errorf("credential was activated for another attestation key")
+	}
+
+	unrestricted, err := base64.StdEncoding.DecodeString(encodeUnrestricted(t, fixture.Host.AttestationKey))
+	if err != nil {
+		t.Fatalf("failed to decode attestation key: %v", err)
+	}
+	if _, _, err := NewCredentialChallenge(ekPEM, marshalU16(t, unrestricted)); err == nil {
+		t.Errorf("expected error for attestation key that is not restricted")
+	}
+}
+
+func TestParsePCRValues(t *testing.T) {
+	raw := append(bytes.Repeat([]byte{0xaa}, sha256.Size), bytes.Repeat([]byte{0xbb}, sha256.Size)...)
+	values, err := ParsePCRValues([]int{7, 0}, raw)
+	if err != nil {
+		t.Fatalf("ParsePCRValues failed: %v", err)
+	}
+	if len(values) != 2 || values[0].Index != 0 || values[1].Index != 7 {
+		t.Fatalf("unexpected values %+v", values)
+	}
+	if values[1].Value != hex.EncodeToString(bytes.Repeat([]byte{0xbb}, sha256.Size)) {
+		t.Errorf("unexpected value for PCR 7: %v", values[1].Value)
+	}
+
+	if _, err := ParsePCRValues([]int{7}, raw); err == nil {
+		t.Errorf("expected error for mismatched length")
+	}
+}
+
+// encodeUnrestricted returns the attestation key with the restricted attribute cleared.
+func encodeUnrestricted(t *testing.T, attestationKey string) string {
+	b, err := base64.StdEncoding.DecodeString(attestationKey)
+	if err != nil {
+		t.Fatalf("failed to decode attestation key: %v", err)
+	}
+	pub, err := tpm2.DecodePublic(b)
+	if err != nil {
+		t.Fatalf("failed to parse attestation key: %v", err)
+	}
+	pub.Attributes &^= tpm2.FlagRestricted
+	encoded, err := pub.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode attestation key: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(encoded)
+}
+
+// newEndorsementKey generates an RSA key standing in for the endorsement key of a TPM.
+func newEndorsementKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// activateCredential recovers the secret from a credential blob as TPM2_ActivateCredential does,
+// with the private part of the endorsement key in memory rather than in a TPM.
+func activateCredential(ek *rsa.PrivateKey, akName *tpm2.HashValue, blob []byte) ([]byte, error) {
+	var magic, version uint32
+	var idObject, encSecret tpmutil.U16Bytes
+	if _, err := tpmutil.Unpack(blob, &magic, &version, &idObject, &encSecret); err != nil {
+		return nil, fmt.Errorf("parsing credential blob: %w", err)
+	}
+	if magic != credentialBlobMagic || version != credentialBlobVersion {
+		return nil, fmt.Errorf("unexpected credential blob header %x/%d", magic, version)
+	}
+
+	seed, err := rsa.DecryptOAEP(sha256.New(), nil, ek, encSecret, []byte("IDENTITY\x00"))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting seed: %w", err)
+	}
+
+	if len(idObject) < 2 {
+		return nil, fmt.Errorf("credential too short")
+	}
+	n := int(binary.BigEndian.Uint16(idObject))
+	if len(idObject) < 2+n {
+		return nil, fmt.Errorf("credential too short")
+	}
+	integrityHMAC, encIdentity := idObject[2:2+n], idObject[2+n:]
+
+	name, err := akName.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("encoding name: %w", err)
+	}
+	macKey, err := tpm2.KDFa(tpm2.AlgSHA256, seed, "INTEGRITY", nil, nil, sha256.Size*8)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(encIdentity)
+	mac.Write(name)
+	if !hmac.Equal(mac.Sum(nil), integrityHMAC) {
+		return nil, fmt.Errorf("integrity check failed")
+	}
+
+	symKey, err := tpm2.KDFa(tpm2.AlgSHA256, seed, "STORAGE", name, nil, 128)
+	if err != nil {
+		return nil, err
+	}
+	c, err := aes.NewCipher(symKey)
+	if err != nil {
+		return nil, err
+	}
+	cv := make([]byte, len(encIdentity))
+	cipher.NewCFBDecrypter(c, make([]byte, aes.BlockSize)).XORKeyStream(cv, encIdentity)
+	var secret tpmutil.U16Bytes
+	if _, err := tpmutil.Unpack(cv, &secret); err != nil {
+		return nil, fmt.Errorf("parsing credential: %w", err)
+	}
+	return secret, nil
+}
+
+func marshalU16(t *testing.T, b []byte) []byte {
+	packed, err := tpmutil.Pack(tpmutil.U16Bytes(b))
+	if err != nil {
+		t.Fatalf("failed to pack: %v", err)
+	}
+	return packed
+}
+
+// fakeClient serves Hosts from memory.
+type fakeClient struct {
+	crclient.Client
+	hosts map[string]*kops.Host
+}
+
+func (c *fakeClient) Get(ctx context.Context, key crclient.ObjectKey, obj crclient.Object, opts ...crclient.GetOption) error {
+	host := c.hosts[key.Name]
+	if host == nil || key.Namespace != host.Namespace {
+		return apierrors.NewNotFound(schema.GroupResource{Group: "kops.k8s.io", Resource: "hosts"}, key.Name)
+	}
+	host.DeepCopyInto(obj.(*kops.Host))
+	return nil
+}
+
+func newTestVerifier(hosts ...*kops.Host) *verifier {
+	c := &fakeClient{hosts: make(map[string]*kops.Host)}
+	for _, host := range hosts {
+		c.hosts[host.Name] = host
+	}
+	v, _ := NewVerifier(&Options{}, c)
+	return v.(*verifier)
+}
+
+func mustUnmarshalTPM2B(t *testing.T, b []byte) []byte {
+	out, err := UnmarshalTPM2B(b)
+	if err != nil {
+		t.Fatalf("UnmarshalTPM2B failed: %v", err)
+	}
+	return out
+}
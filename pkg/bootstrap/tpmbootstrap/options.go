@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpmbootstrap
+
+// Options describes how we authenticate bare-metal instances with TPM attestation.
+type Options struct {
+	// MaxTimeSkew is the maximum time skew to allow (in seconds)
+	MaxTimeSkew int64 `json:"MaxTimeSkew,omitempty"`
+}
+
+// AuthenticationTokenPrefix is the prefix used for authentication using a TPM quote
+const AuthenticationTokenPrefix = "x-metal-tpm "
+
+// MachineDir is the directory on the host where enrollment stores the TPM key material.
+const MachineDir = "/etc/kubernetes/kops/pki/machine/tpm"
+
+// DefaultPCRs are the PCRs included in the quote unless others are requested at enrollment.
+// PCR 7 records the secure boot policy, which is stable across kernel and firmware updates.
+var DefaultPCRs = []int{7}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpmbootstrap
+
+// AuthToken describes the authentication header data when using metal TPM authentication.
+type AuthToken struct {
+	// Data is the data we are attesting.
+	// It is a JSON encoded form of AuthTokenData.
+	Data []byte `json:"data,omitempty"`
+
+	// Quote is the TPMS_ATTEST structure produced by the TPM, with extraData set to the hash of Data.
+	Quote []byte `json:"quote,omitempty"`
+
+	// Signature is the TPMT_SIGNATURE over Quote, made with the attestation key.
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// AuthTokenData is the code data that is quoted as part of the header.
+type AuthTokenData struct {
+	// Instance is the name/id of the instance we are claiming
+	Instance string `json:"instance,omitempty"`
+
+	// RequestHash is the hash of the request
+	RequestHash []byte `json:"requestHash,omitempty"`
+
+	// Timestamp is the time of this request (to help prevent replay attacks)
+	Timestamp int64 `json:"timestamp,omitempty"`
+
+	// Audience is the audience for this request (to help prevent replay attacks)
+	Audience string `json:"audience,omitempty"`
+}
+
+// AudienceNodeAuthentication is used in case we have multiple audiences using the TPM in future
+const AudienceNodeAuthentication = "kops.k8s.io/node-bootstrap"
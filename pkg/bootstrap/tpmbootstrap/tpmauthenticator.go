@@ -0,0 +1,268 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpmbootstrap
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/bootstrap"
+)
+
+type tpmAuthenticator struct {
+	hostname string
+
+	// akPublic and akPrivate are the attestation key, wrapped by the endorsement key.
+	akPublic  []byte
+	akPrivate []byte
+
+	pcrs tpm2.PCRSelection
+
+	openTPM func() (io.ReadWriteCloser, error)
+}
+
+var _ bootstrap.Authenticator = &tpmAuthenticator{}
+
+// IsEnrolled returns true if the host was enrolled with a TPM identity.
+func IsEnrolled() bool {
+	_, err := os.Stat(filepath.Join(MachineDir, "ak.priv"))
+	return err == nil
+}
+
+// NewAuthenticator builds an authenticator from the attestation key created during enrollment.
+func NewAuthenticator() (bootstrap.Authenticator, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't determine hostname: %w", err)
+	}
+
+	var blobs [][]byte
+	for _, name := range []string{"ak.pub", "ak.priv"} {
+		p := filepath.Join(MachineDir, name)
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %q: %w", p, err)
+		}
+		b, err = UnmarshalTPM2B(b)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %q: %w", p, err)
+		}
+		blobs = append(blobs, b)
+	}
+
+	pcrsPath := filepath.Join(MachineDir, "pcrs")
+	pcrsBytes, err := os.ReadFile(pcrsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q: %w", pcrsPath, err)
+	}
+	pcrs, err := ParsePCRList(string(pcrsBytes))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %q: %w", pcrsPath, err)
+	}
+
+	return newAuthenticator(hostname, blobs[0], blobs[1], pcrs, openTPM), nil
+}
+
+func newAuthenticator(hostname string, akPublic, akPrivate []byte, pcrs []int, openTPM func() (io.ReadWriteCloser, error)) *tpmAuthenticator {
+	return &tpmAuthenticator{
+		hostname:  hostname,
+		akPublic:  akPublic,
+		akPrivate: akPrivate,
+		pcrs:      PCRSelection(pcrs),
+		openTPM:   openTPM,
+	}
+}
+
+// ParsePCRList parses a comma separated list of PCR indexes.
+func ParsePCRList(s string) ([]int, error) {
+	var pcrs []int
+	for _, field := range strings.Split(strings.TrimSpace(s), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		pcr, err := strconv.Atoi(field)
+		if err != nil || pcr < 0 || pcr > 23 {
+			return nil, fmt.Errorf("invalid PCR %q", field)
+		}
+		pcrs = append(pcrs, pcr)
+	}
+	if len(pcrs) == 0 {
+		return nil, fmt.Errorf("no PCRs specified")
+	}
+	return pcrs, nil
+}
+
+func (a *tpmAuthenticator) CreateToken(body []byte) (string, error) {
+	requestHash := sha256.Sum256(body)
+
+	data := AuthTokenData{
+		Timestamp:   time.Now().Unix(),
+		Audience:    AudienceNodeAuthentication,
+		RequestHash: requestHash[:],
+		Instance:    a.hostname,
+	}
+
+	payload, err := json.Marshal(&data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token data: %w", err)
+	}
+
+	quote, signature, err := a.quote(payload)
+	if err != nil {
+		return "", err
+	}
+
+	token := &AuthToken{
+		Data:      payload,
+		Quote:     quote,
+		Signature: signature,
+	}
+
+	b, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token: %w", err)
+	}
+	return AuthenticationTokenPrefix + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// quote loads the attestation key under the endorsement key and quotes the PCRs, binding the quote to payload.
+func (a *tpmAuthenticator) quote(payload []byte) ([]byte, []byte, error) {
+	tpmStart := time.Now()
+
+	rw, err := a.openTPM()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open TPM: %w", err)
+	}
+	defer rw.Close()
+
+	ekHandle, flushEK, err := loadEndorsementKey(rw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get endorsement key from TPM: %w", err)
+	}
+	if flushEK {
+		defer func() {
+			if err := tpm2.FlushContext(rw, ekHandle); err != nil {
+				klog.Warningf("failed to flush endorsement key: %v", err)
+			}
+		}()
+	}
+
+	session, auth, err := endorsementKeyAuth(rw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to authorize endorsement key session: %w", err)
+	}
+	defer func() {
+		if err := tpm2.FlushContext(rw, session); err != nil {
+			klog.Warningf("failed to flush endorsement key session: %v", err)
+		}
+	}()
+
+	akHandle, _, err := tpm2.LoadUsingAuth(rw, ekHandle, auth, a.akPublic, a.akPrivate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load attestation key (was the host reinstalled?): %w", err)
+	}
+	defer func() {
+		if err := tpm2.FlushContext(rw, akHandle); err != nil {
+			klog.Warningf("failed to flush attestation key: %v", err)
+		}
+	}()
+
+	extraData := sha256.Sum256(payload)
+	quote, signature, err := tpm2.QuoteRaw(rw, akHandle, "", "", extraData[:], a.pcrs, tpm2.AlgNull)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to quote PCRs %v: %w", a.pcrs.PCRs, err)
+	}
+
+	klog.Infof("TPM quote took %v", time.Since(tpmStart))
+
+	return quote, signature, nil
+}
+
+// endorsementKeyHandle is the persistent handle reserved for the RSA endorsement key by the TCG provisioning guidance.
+const endorsementKeyHandle = tpmutil.Handle(0x81010001)
+
+// endorsementKeyTemplate returns the default RSA endorsement key template of the TCG EK Credential Profile,
+// which is the key tpm2_createek creates.
+// We don't use go-tpm-tools/client here: it registers command line flags for confidential computing
+// devices in every binary that imports it, and this package is imported by the kops CLI and kops-controller.
+func endorsementKeyTemplate() tpm2.Public {
+	// The authPolicy is PolicySecret(TPM_RH_ENDORSEMENT).
+	policy, err := tpmutil.Pack(tpm2.CmdPolicySecret, tpm2.HandleEndorsement)
+	if err != nil {
+		panic(err)
+	}
+	digest := sha256.Sum256(append(make([]byte, sha256.Size), policy...))
+	digest = sha256.Sum256(digest[:])
+
+	return tpm2.Public{
+		Type:       tpm2.AlgRSA,
+		NameAlg:    tpm2.AlgSHA256,
+		Attributes: tpm2.FlagFixedTPM | tpm2.FlagFixedParent | tpm2.FlagSensitiveDataOrigin | tpm2.FlagAdminWithPolicy | tpm2.FlagRestricted | tpm2.FlagDecrypt,
+		AuthPolicy: digest[:],
+		RSAParameters: &tpm2.RSAParams{
+			Symmetric: &tpm2.SymScheme{
+				Alg:     tpm2.AlgAES,
+				KeyBits: 128,
+				Mode:    tpm2.AlgCFB,
+			},
+			KeyBits:    2048,
+			ModulusRaw: make([]byte, 256),
+		},
+	}
+}
+
+// loadEndorsementKey returns the handle of the endorsement key, and whether the caller must flush it.
+func loadEndorsementKey(rw io.ReadWriter) (tpmutil.Handle, bool, error) {
+	template := endorsementKeyTemplate()
+	if public, _, _, err := tpm2.ReadPublic(rw, endorsementKeyHandle); err == nil && public.MatchesTemplate(template) {
+		return endorsementKeyHandle, false, nil
+	}
+
+	handle, _, err := tpm2.CreatePrimary(rw, tpm2.HandleEndorsement, tpm2.PCRSelection{}, "", "", template)
+	if err != nil {
+		return 0, false, err
+	}
+	return handle, true, nil
+}
+
+// endorsementKeyAuth starts a policy session satisfying the endorsement key's authPolicy.
+// The caller must flush the returned session.
+func endorsementKeyAuth(rw io.ReadWriter) (tpmutil.Handle, tpm2.AuthCommand, error) {
+	session, _, err := tpm2.StartAuthSession(rw, tpm2.HandleNull, tpm2.HandleNull, make([]byte, sha256.Size), nil, tpm2.SessionPolicy, tpm2.AlgNull, tpm2.AlgSHA256)
+	if err != nil {
+		return 0, tpm2.AuthCommand{}, err
+	}
+
+	nullAuth := tpm2.AuthCommand{Session: tpm2.HandlePasswordSession, Attributes: tpm2.AttrContinueSession}
+	if _, _, err := tpm2.PolicySecret(rw, tpm2.HandleEndorsement, nullAuth, session, nil, nil, nil, 0); err != nil {
+		_ = tpm2.FlushContext(rw, session)
+		return 0, tpm2.AuthCommand{}, err
+	}
+	return session, tpm2.AuthCommand{Session: session, Attributes: tpm2.AttrContinueSession}, nil
+}
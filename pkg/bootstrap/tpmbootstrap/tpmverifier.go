@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpmbootstrap
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	kops "k8s.io/kops/pkg/apis/kops/v1alpha2"
+	"k8s.io/kops/pkg/bootstrap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type verifier struct {
+	opt    Options
+	client client.Client
+}
+
+// NewVerifier constructs a new verifier for hosts enrolled with a TPM identity.
+func NewVerifier(options *Options, client client.Client) (bootstrap.Verifier, error) {
+	opt := *options
+	if opt.MaxTimeSkew == 0 {
+		opt.MaxTimeSkew = 300
+	}
+	return &verifier{
+		opt:    opt,
+		client: client,
+	}, nil
+}
+
+var _ bootstrap.Verifier = &verifier{}
+
+func (v *verifier) VerifyToken(ctx context.Context, rawRequest *http.Request, authToken string, body []byte) (*bootstrap.VerifyResult, error) {
+	// Reminder: we shouldn't trust any data we get from the client until we've checked the quote (and even then...)
+
+	if !strings.HasPrefix(authToken, AuthenticationTokenPrefix) {
+		return nil, bootstrap.ErrNotThisVerifier
+	}
+	authToken = strings.TrimPrefix(authToken, AuthenticationTokenPrefix)
+
+	tokenBytes, err := base64.StdEncoding.DecodeString(authToken)
+	if err != nil {
+		return nil, fmt.Errorf("decoding authorization token: %w", err)
+	}
+
+	token := &AuthToken{}
+	if err = json.Unmarshal(tokenBytes, token); err != nil {
+		return nil, fmt.Errorf("unmarshalling authorization token: %w", err)
+	}
+
+	tokenData := &AuthTokenData{}
+	if err := json.Unmarshal(token.Data, tokenData); err != nil {
+		return nil, fmt.Errorf("unmarshalling authorization token data: %w", err)
+	}
+
+	// Guard against replay attacks
+	if tokenData.Audience != AudienceNodeAuthentication {
+		return nil, fmt.Errorf("incorrect Audience")
+	}
+	timeSkew := math.Abs(time.Since(time.Unix(tokenData.Timestamp, 0)).Seconds())
+	if timeSkew > float64(v.opt.MaxTimeSkew) {
+		return nil, fmt.Errorf("incorrect Timestamp %v", tokenData.Timestamp)
+	}
+
+	// Verify the token has signed the body content.
+	requestHash := sha256.Sum256(body)
+	if !bytes.Equal(requestHash[:], tokenData.RequestHash) {
+		return nil, fmt.Errorf("incorrect RequestHash")
+	}
+
+	host, err := v.getHost(ctx, tokenData.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyQuote(host.Spec.TPM, token); err != nil {
+		return nil, fmt.Errorf("failed to verify TPM quote for host %q: %w", host.Name, err)
+	}
+
+	return &bootstrap.VerifyResult{
+		NodeName:          host.Name,
+		InstanceGroupName: host.Spec.InstanceGroup,
+		InstanceID:        host.Name,
+	}, nil
+}
+
+func (v *verifier) getHost(ctx context.Context, nodeName string) (*kops.Host, error) {
+	id := types.NamespacedName{
+		Namespace: "kops-system",
+		Name:      nodeName,
+	}
+	host := &kops.Host{}
+	if err := v.client.Get(ctx, id, host); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("host not found for %v", id)
+		}
+		return nil, fmt.Errorf("error getting host %v: %w", id, err)
+	}
+
+	if host.Spec.TPM == nil || host.Spec.TPM.AttestationKey == "" {
+		return nil, fmt.Errorf("host %v was not enrolled with a TPM", id)
+	}
+	if host.Spec.InstanceGroup == "" {
+		return nil, fmt.Errorf("host %v did not have spec.instanceGroup", id)
+	}
+	return host, nil
+}
+
+// verifyQuote checks that the quote was signed by the host's attestation key,
+// is bound to the token data, and covers the expected PCR values.
+func verifyQuote(spec *kops.HostTPMSpec, token *AuthToken) error {
+	ak, err := DecodeAttestationKey(spec.AttestationKey)
+	if err != nil {
+		return err
+	}
+	akKey, err := ak.Key()
+	if err != nil {
+		return fmt.Errorf("getting attestation public key: %w", err)
+	}
+
+	signature, err := tpm2.DecodeSignature(bytes.NewBuffer(token.Signature))
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	if !verifySignature(akKey, signature, token.Quote) {
+		return fmt.Errorf("quote signature did not verify with attestation key")
+	}
+
+	attestation, err := tpm2.DecodeAttestationData(token.Quote)
+	if err != nil {
+		return fmt.Errorf("decoding quote: %w", err)
+	}
+	if attestation.Type != tpm2.TagAttestQuote || attestation.AttestedQuoteInfo == nil {
+		return fmt.Errorf("attestation was not a quote")
+	}
+
+	extraData := sha256.Sum256(token.Data)
+	if subtle.ConstantTimeCompare(attestation.ExtraData, extraData[:]) != 1 {
+		return fmt.Errorf("quote was not bound to token data")
+	}
+
+	sel, digest, err := expectedPCRDigest(spec.PCRs)
+	if err != nil {
+		return err
+	}
+	quoted := attestation.AttestedQuoteInfo
+	if quoted.PCRSelection.Hash != sel.Hash || !reflect.DeepEqual(PCRSelection(quoted.PCRSelection.PCRs).PCRs, sel.PCRs) {
+		return fmt.Errorf("quote covers PCRs %v, expected %v", quoted.PCRSelection.PCRs, sel.PCRs)
+	}
+	if !bytes.Equal(quoted.PCRDigest, digest) {
+		return fmt.Errorf("PCR values did not match the values recorded at enrollment")
+	}
+
+	return nil
+}
+
+func verifySignature(key crypto.PublicKey, signature *tpm2.Signature, quote []byte) bool {
+	digest := sha256.Sum256(quote)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if signature.Alg != tpm2.AlgECDSA || signature.ECC == nil || signature.ECC.HashAlg != tpm2.AlgSHA256 {
+			return false
+		}
+		return ecdsa.Verify(key, digest[:], signature.ECC.R, signature.ECC.S)
+
+	case *rsa.PublicKey:
+		if signature.Alg != tpm2.AlgRSASSA || signature.RSA == nil || signature.RSA.HashAlg != tpm2.AlgSHA256 {
+			return false
+		}
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature.RSA.Signature) == nil
+
+	default:
+		return false
+	}
+}
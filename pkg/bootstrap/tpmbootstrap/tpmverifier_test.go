@@ -0,0 +1,317 @@
+//go:build cgo && tpmsimulator
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpmbootstrap
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"testing"
+
+	"github.com/google/go-tpm-tools/client"
+	"github.com/google/go-tpm-tools/simulator"
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+	kops "k8s.io/kops/pkg/apis/kops/v1alpha2"
+)
+
+// testHost is a host with a simulated TPM, enrolled as `kops toolbox enroll --tpm` would do.
+// The simulator is built from C sources that are not vendored, so these tests only run with `-tags tpmsimulator -mod=mod`;
+// attestation_test.go covers the verifier with fixtures recorded from the simulator.
+type testHost struct {
+	sim *simulator.Simulator
+
+	// ekPEM, akPublic and akPrivate are in the formats written by tpm2_createek and tpm2_createak.
+	ekPEM     []byte
+	akPublic  []byte
+	akPrivate []byte
+}
+
+// newTestHost starts a simulated TPM; the simulator is a global resource, so only one testHost can be open at a time.
+func newTestHost(t *testing.T, seed int64) *testHost {
+	sim, err := simulator.GetWithFixedSeedInsecure(seed)
+	if err != nil {
+		t.Fatalf("failed to start TPM simulator: %v", err)
+	}
+	t.Cleanup(func() {
+		if !sim.IsClosed() {
+			sim.Close()
+		}
+	})
+
+	ek, err := client.EndorsementKeyRSA(sim)
+	if err != nil {
+		t.Fatalf("failed to create endorsement key: %v", err)
+	}
+	defer ek.Close()
+
+	ekDER, err := x509.MarshalPKIXPublicKey(ek.PublicKey())
+	if err != nil {
+		t.Fatalf("failed to marshal endorsement key: %v", err)
+	}
+
+	// Mirrors `tpm2_createak -G ecc -g sha256 -s ecdsa`
+	akTemplate := tpm2.Public{
+		Type:       tpm2.AlgECC,
+		NameAlg:    tpm2.AlgSHA256,
+		Attributes: tpm2.FlagFixedTPM | tpm2.FlagFixedParent | tpm2.FlagSensitiveDataOrigin | tpm2.FlagUserWithAuth | tpm2.FlagRestricted | tpm2.FlagSign,
+		ECCParameters: &tpm2.ECCParams{
+			Sign:    &tpm2.SigScheme{Alg: tpm2.AlgECDSA, Hash: tpm2.AlgSHA256},
+			CurveID: tpm2.CurveNISTP256,
+		},
+	}
+	session, err := client.NewEKSession(sim)
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	defer session.Close()
+	auth, err := session.Auth()
+	if err != nil {
+		t.Fatalf("failed to authorize session: %v", err)
+	}
+	akPrivate, akPublic, _, _, _, err := tpm2.CreateKeyUsingAuth(sim, ek.Handle(), tpm2.PCRSelection{}, auth, "", akTemplate)
+	if err != nil {
+		t.Fatalf("failed to create attestation key: %v", err)
+	}
+
+	return &testHost{
+		sim:       sim,
+		ekPEM:     pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ekDER}),
+		akPublic:  marshalTPM2B(t, akPublic),
+		akPrivate: marshalTPM2B(t, akPrivate),
+	}
+}
+
+func marshalTPM2B(t *testing.T, b []byte) []byte {
+	packed, err := tpmutil.Pack(tpmutil.U16Bytes(b))
+	if err != nil {
+		t.Fatalf("failed to pack: %v", err)
+	}
+	return packed
+}
+
+// openTPM returns the simulator, without closing it when the caller is done.
+func (h *testHost) openTPM() (io.ReadWriteCloser, error) {
+	return struct {
+		io.ReadWriter
+		io.Closer
+	}{h.sim, io.NopCloser(nil)}, nil
+}
+
+// activateCredential performs the equivalent of tpm2_activatecredential.
+func (h *testHost) activateCredential(t *testing.T, blob []byte) ([]byte, error) {
+	var magic, version uint32
+	var idObject, encSecret tpmutil.U16Bytes
+	if _, err := tpmutil.Unpack(blob, &magic, &version, &idObject, &encSecret); err != nil {
+		t.Fatalf("failed to parse credential blob: %v", err)
+	}
+	if magic != credentialBlobMagic || version != credentialBlobVersion {
+		t.Fatalf("unexpected credential blob header %x/%d", magic, version)
+	}
+
+	ek, err := client.EndorsementKeyRSA(h.sim)
+	if err != nil {
+		t.Fatalf("failed to create endorsement key: %v", err)
+	}
+	defer ek.Close()
+
+	akPublic, _ := UnmarshalTPM2B(h.akPublic)
+	akPrivate, _ := UnmarshalTPM2B(h.akPrivate)
+	loadSession, err := client.NewEKSession(h.sim)
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	defer loadSession.Close()
+	loadAuth, err := loadSession.Auth()
+	if err != nil {
+		t.Fatalf("failed to authorize session: %v", err)
+	}
+	akHandle, _, err := tpm2.LoadUsingAuth(h.sim, ek.Handle(), loadAuth, akPublic, akPrivate)
+	if err != nil {
+		t.Fatalf("failed to load attestation key: %v", err)
+	}
+	defer tpm2.FlushContext(h.sim, akHandle)
+
+	session, err := client.NewEKSession(h.sim)
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	defer session.Close()
+	ekAuth, err := session.Auth()
+	if err != nil {
+		t.Fatalf("failed to authorize session: %v", err)
+	}
+	akAuth := tpm2.AuthCommand{Session: tpm2.HandlePasswordSession, Attributes: tpm2.AttrContinueSession}
+
+	return tpm2.ActivateCredentialUsingAuth(h.sim, []tpm2.AuthCommand{akAuth, ekAuth}, akHandle, ek.Handle(), idObject, encSecret)
+}
+
+// enroll performs credential activation and records the host, as `kops toolbox enroll --tpm` does.
+func (h *testHost) enroll(t *testing.T, name string, pcrs []int) *kops.Host {
+	challenge, attestationKey, err := NewCredentialChallenge(h.ekPEM, h.akPublic)
+	if err != nil {
+		t.Fatalf("NewCredentialChallenge failed: %v", err)
+	}
+	secret, err := h.activateCredential(t, challenge.Blob)
+	if err != nil {
+		t.Fatalf("failed to activate credential: %v", err)
+	}
+	if !bytes.Equal(secret, challenge.Secret) {
+		t.Fatalf("activated credential did not match secret")
+	}
+
+	pcrValues, err := client.ReadPCRs(h.sim, PCRSelection(pcrs))
+	if err != nil {
+		t.Fatalf("failed to read PCRs: %v", err)
+	}
+	var raw []byte
+	for _, pcr := range PCRSelection(pcrs).PCRs {
+		raw = append(raw, pcrValues.GetPcrs()[uint32(pcr)]...)
+	}
+	values, err := ParsePCRValues(pcrs, raw)
+	if err != nil {
+		t.Fatalf("ParsePCRValues failed: %v", err)
+	}
+
+	host := &kops.Host{}
+	host.Namespace = "kops-system"
+	host.Name = name
+	host.Spec.InstanceGroup = "nodes"
+	host.Spec.TPM = &kops.HostTPMSpec{
+		EndorsementKey: string(h.ekPEM),
+		AttestationKey: attestationKey,
+		PCRs:           values,
+	}
+	return host
+}
+
+func (h *testHost) authenticator(t *testing.T, name string, pcrs []int) *tpmAuthenticator {
+	akPublic, err := UnmarshalTPM2B(h.akPublic)
+	if err != nil {
+		t.Fatalf("failed to parse attestation key: %v", err)
+	}
+	akPrivate, err := UnmarshalTPM2B(h.akPrivate)
+	if err != nil {
+		t.Fatalf("failed to parse attestation key: %v", err)
+	}
+	return newAuthenticator(name, akPublic, akPrivate, pcrs, h.openTPM)
+}
+
+func TestVerifyToken(t *testing.T) {
+	ctx := context.Background()
+	pcrs := []int{0, 7}
+
+	h := newTestHost(t, 1)
+	host := h.enroll(t, "node-1", pcrs)
+	v := newTestVerifier(host)
+	a := h.authenticator(t, "node-1", pcrs)
+
+	body := []byte(`{"apiVersion":"bootstrap.kops.k8s.io/v1alpha1"}`)
+	token, err := a.CreateToken(body)
+	if err != nil {
+		t.Fatalf("CreateToken failed: %v", err)
+	}
+
+	result, err := v.VerifyToken(ctx, nil, token, body)
+	if err != nil {
+		t.Fatalf("VerifyToken failed: %v", err)
+	}
+	if result.NodeName != "node-1" || result.InstanceGroupName != "nodes" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if _, err := v.VerifyToken(ctx, nil, token, []byte(`{}`)); err == nil {
+		t.Errorf("expected error when body does not match token")
+	}
+
+	if _, err := v.VerifyToken(ctx, nil, "x-pki-tpm "+token, body); err == nil {
+		t.Errorf("expected error for token intended for another verifier")
+	}
+}
+
+func TestVerifyTokenRejectsChangedPCRs(t *testing.T) {
+	ctx := context.Background()
+	pcrs := []int{7}
+
+	h := newTestHost(t, 1)
+	v := newTestVerifier(h.enroll(t, "node-1", pcrs))
+	a := h.authenticator(t, "node-1", pcrs)
+
+	// Simulate a change to the boot chain after enrollment.
+	measurement := sha256.Sum256([]byte("unexpected bootloader"))
+	if err := tpm2.PCRExtend(h.sim, tpmutil.Handle(7), tpm2.AlgSHA256, measurement[:], ""); err != nil {
+		t.Fatalf("failed to extend PCR: %v", err)
+	}
+
+	body := []byte(`{}`)
+	token, err := a.CreateToken(body)
+	if err != nil {
+		t.Fatalf("CreateToken failed: %v", err)
+	}
+	if _, err := v.VerifyToken(ctx, nil, token, body); err == nil {
+		t.Fatalf("expected error when PCR values changed after enrollment")
+	}
+}
+
+func TestVerifyTokenRejectsOtherTPM(t *testing.T) {
+	ctx := context.Background()
+	pcrs := []int{7}
+
+	enrolled := newTestHost(t, 1)
+	host := enrolled.enroll(t, "node-1", pcrs)
+	if _, _, err := enrolled.authenticator(t, "node-1", pcrs).quote([]byte("data")); err != nil {
+		t.Fatalf("quote with the enrolled TPM failed: %v", err)
+	}
+	enrolled.sim.Close()
+
+	// A machine claiming to be node-1, with its own TPM.
+	impostor := newTestHost(t, 2)
+	body := []byte(`{}`)
+	token, err := impostor.authenticator(t, "node-1", pcrs).CreateToken(body)
+	if err != nil {
+		t.Fatalf("CreateToken failed: %v", err)
+	}
+	if _, err := newTestVerifier(host).VerifyToken(ctx, nil, token, body); err == nil {
+		t.Fatalf("expected error for quote from a different TPM")
+	}
+
+	// The enrolled attestation key cannot be loaded into another TPM.
+	copied := newAuthenticator("node-1", mustUnmarshalTPM2B(t, enrolled.akPublic), mustUnmarshalTPM2B(t, enrolled.akPrivate), pcrs, impostor.openTPM)
+	if _, err := copied.CreateToken(body); err == nil {
+		t.Fatalf("expected error loading attestation key into a different TPM")
+	}
+}
+
+func TestNewCredentialChallengeRejectsOtherEndorsementKey(t *testing.T) {
+	other := newTestHost(t, 2)
+	other.sim.Close()
+
+	// A challenge for the other TPM's endorsement key cannot be activated with our TPM.
+	enrolled := newTestHost(t, 1)
+	challenge, _, err := NewCredentialChallenge(other.ekPEM, enrolled.akPublic)
+	if err != nil {
+		t.Fatalf("NewCredentialChallenge failed: %v", err)
+	}
+	if secret, err := enrolled.activateCredential(t, challenge.Blob); err == nil && bytes.Equal(secret, challenge.Secret) {
+		t.Fatalf("credential for another endorsement key was activated")
+	}
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/util"
+)
+
+// AddonCatalogEntry describes an addon that kOps can install into a cluster.
+type AddonCatalogEntry struct {
+	// Name is the name of the addon, which is also the name of its directory under upup/models/cloudup/resources/addons.
+	Name string `json:"name"`
+	// Description is a short description of the addon.
+	Description string `json:"description"`
+	// Clouds are the cloud providers that support the addon; empty means all of them.
+	Clouds []kops.CloudProviderID `json:"clouds,omitempty"`
+	// KubernetesVersion is a semver range of the Kubernetes versions that support the addon; empty means all of them.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Field is the path of the cluster spec field that configures the addon, if the addon is optional.
+	// Addons without a Field are installed by kOps based on other settings, such as the cloud provider or networking.
+	Field string `json:"field,omitempty"`
+}
+
+// Optional returns true if the addon can be enabled and disabled.
+func (e *AddonCatalogEntry) Optional() bool {
+	return e.Field != ""
+}
+
+// AddonCatalog lists the addons that kOps can install, in order of name.
+var AddonCatalog = []*AddonCatalogEntry{
+	{
+		Name:        "authentication.aws",
+		Description: "AWS IAM Authenticator, for authenticating to the API server with AWS IAM.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+	},
+	{
+		Name:        "authentication.kope.io",
+		Description: "kopeio authentication, for authenticating to the API server.",
+	},
+	{
+		Name:        "aws-cloud-controller.addons.k8s.io",
+		Description: "AWS cloud controller manager.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+	},
+	{
+		Name:        "aws-ebs-csi-driver.addons.k8s.io",
+		Description: "AWS EBS CSI driver, for persistent volumes backed by EBS.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+	},
+	{
+		Name:        "aws-load-balancer-controller.addons.k8s.io",
+		Description: "AWS Load Balancer Controller, for Ingresses and Services backed by ALBs and NLBs.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+		Field:       "spec.cloudProvider.aws.loadBalancerController",
+	},
+	{
+		Name:        "certmanager.io",
+		Description: "cert-manager, for issuing and renewing certificates.",
+		Field:       "spec.certManager",
+	},
+	{
+		Name:        "cluster-autoscaler.addons.k8s.io",
+		Description: "Cluster Autoscaler, for resizing instance groups based on pending pods.",
+		Field:       "spec.clusterAutoscaler",
+	},
+	{
+		Name:        "coredns.addons.k8s.io",
+		Description: "CoreDNS, for cluster DNS.",
+	},
+	{
+		Name:        "digitalocean-cloud-controller.addons.k8s.io",
+		Description: "DigitalOcean cloud controller manager.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderDO},
+	},
+	{
+		Name:        "digitalocean-csi-driver.addons.k8s.io",
+		Description: "DigitalOcean CSI driver, for persistent volumes backed by block storage.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderDO},
+	},
+	{
+		Name:        "dns-controller.addons.k8s.io",
+		Description: "dns-controller, for publishing DNS records of the cluster.",
+	},
+	{
+		Name:        "eks-pod-identity-webhook.addons.k8s.io",
+		Description: "Pod identity webhook, for injecting AWS IAM credentials into pods.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+		Field:       "spec.cloudProvider.aws.podIdentityWebhook",
+	},
+	{
+		Name:        "external-dns.addons.k8s.io",
+		Description: "ExternalDNS, for publishing DNS records of the cluster.",
+	},
+	{
+		Name:        "gcp-cloud-controller.addons.k8s.io",
+		Description: "GCP cloud controller manager.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderGCE},
+	},
+	{
+		Name:        "gcp-pd-csi-driver.addons.k8s.io",
+		Description: "GCP Persistent Disk CSI driver, for persistent volumes backed by persistent disks.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderGCE},
+		Field:       "spec.cloudProvider.gce.pdCSIDriver",
+	},
+	{
+		Name:        "hcloud-cloud-controller.addons.k8s.io",
+		Description: "Hetzner cloud controller manager.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderHetzner},
+	},
+	{
+		Name:        "hcloud-csi-driver.addons.k8s.io",
+		Description: "Hetzner CSI driver, for persistent volumes backed by Hetzner volumes.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderHetzner},
+	},
+	{
+		Name:        "karpenter.sh",
+		Description: "Karpenter, for provisioning nodes based on pending pods.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+		Field:       "spec.karpenter",
+	},
+	{
+		Name:        "kops-controller.addons.k8s.io",
+		Description: "kops-controller, for bootstrapping nodes and labeling them.",
+	},
+	{
+		Name:        "kube-dns.addons.k8s.io",
+		Description: "kube-dns, for cluster DNS.",
+	},
+	{
+		Name:        "kubelet-api.rbac.addons.k8s.io",
+		Description: "RBAC rules allowing the API server to access the kubelet API.",
+	},
+	{
+		Name:              "leader-migration.rbac.addons.k8s.io",
+		Description:       "RBAC rules for migrating cloud controllers from kube-controller-manager to the cloud controller manager.",
+		Clouds:            []kops.CloudProviderID{kops.CloudProviderAWS, kops.CloudProviderGCE},
+		KubernetesVersion: "<1.26.0",
+	},
+	{
+		Name:        "limit-range.addons.k8s.io",
+		Description: "Default LimitRange for the default namespace.",
+	},
+	{
+		Name:              "metadata-proxy.addons.k8s.io",
+		Description:       "Metadata proxy, for concealing node metadata from pods.",
+		Clouds:            []kops.CloudProviderID{kops.CloudProviderGCE},
+		KubernetesVersion: "<1.29.0",
+	},
+	{
+		Name:        "metrics-server.addons.k8s.io",
+		Description: "Metrics Server, for the resource metrics API used by kubectl top and autoscalers.",
+		Field:       "spec.metricsServer",
+	},
+	{
+		Name:        "networking.amazon-vpc-routed-eni",
+		Description: "Amazon VPC CNI networking.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+	},
+	{
+		Name:        "networking.cilium.io",
+		Description: "Cilium networking.",
+	},
+	{
+		Name:        "networking.flannel",
+		Description: "Flannel networking.",
+	},
+	{
+		Name:        "networking.kope.io",
+		Description: "kopeio networking.",
+	},
+	{
+		Name:        "networking.kuberouter",
+		Description: "kube-router networking.",
+	},
+	{
+		Name:        "networking.projectcalico.org",
+		Description: "Calico networking.",
+	},
+	{
+		Name:        "networking.projectcalico.org.canal",
+		Description: "Canal networking.",
+	},
+	{
+		Name:        "node-problem-detector.addons.k8s.io",
+		Description: "Node Problem Detector, for reporting node problems as conditions and events.",
+		Field:       "spec.nodeProblemDetector",
+	},
+	{
+		Name:        "node-termination-handler.aws",
+		Description: "AWS Node Termination Handler, for draining nodes before they are terminated.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+		Field:       "spec.cloudProvider.aws.nodeTerminationHandler",
+	},
+	{
+		Name:        "nodelocaldns.addons.k8s.io",
+		Description: "NodeLocal DNSCache, for caching DNS queries on each node.",
+		Field:       "spec.kubeDNS.nodeLocalDNS",
+	},
+	{
+		Name:        "nvidia.addons.k8s.io",
+		Description: "NVIDIA device plugin, for scheduling pods on GPUs.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS, kops.CloudProviderOpenstack},
+		Field:       "spec.containerd.nvidiaGPU",
+	},
+	{
+		Name:        "openstack.addons.k8s.io",
+		Description: "OpenStack cloud controller manager.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderOpenstack},
+	},
+	{
+		Name:        "podsecuritypolicy.addons.k8s.io",
+		Description: "Default PodSecurityPolicies.",
+	},
+	{
+		Name:        "scaleway-cloud-controller.addons.k8s.io",
+		Description: "Scaleway cloud controller manager.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderScaleway},
+	},
+	{
+		Name:        "scaleway-csi-driver.addons.k8s.io",
+		Description: "Scaleway CSI driver, for persistent volumes backed by block storage.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderScaleway},
+	},
+	{
+		Name:        "snapshot-controller.addons.k8s.io",
+		Description: "CSI snapshot controller, for volume snapshots.",
+		Field:       "spec.snapshotController",
+	},
+	{
+		Name:        "spotinst-kubernetes-cluster-controller.addons.k8s.io",
+		Description: "Spotinst controller, for instance groups managed by Spot.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+	},
+	{
+		Name:        "storage-aws.addons.k8s.io",
+		Description: "Default StorageClasses for AWS.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderAWS},
+	},
+	{
+		Name:        "storage-gce.addons.k8s.io",
+		Description: "Default StorageClasses for GCE.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderGCE},
+	},
+	{
+		Name:        "storage-openstack.addons.k8s.io",
+		Description: "OpenStack Cinder CSI driver and default StorageClasses.",
+		Clouds:      []kops.CloudProviderID{kops.CloudProviderOpenstack},
+	},
+}
+
+// FindAddonCatalogEntry returns the catalog entry of the named addon.
+func FindAddonCatalogEntry(name string) (*AddonCatalogEntry, error) {
+	for _, entry := range AddonCatalog {
+		if entry.Name == name {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("addon %q not found in the addon catalog", name)
+}
+
+// checkSupported returns an error if the addon is not optional or does not support the cluster.
+func (e *AddonCatalogEntry) checkSupported(cluster *kops.Cluster) error {
+	if !e.Optional() {
+		return fmt.Errorf("addon %q is managed by kOps and cannot be enabled or disabled", e.Name)
+	}
+
+	if len(e.Clouds) != 0 {
+		cloudProvider := cluster.Spec.GetCloudProvider()
+		supported := false
+		for _, cloud := range e.Clouds {
+			if cloud == cloudProvider {
+				supported = true
+			}
+		}
+		if !supported {
+			return fmt.Errorf("addon %q is not supported on cloud provider %q", e.Name, cloudProvider)
+		}
+	}
+
+	if e.KubernetesVersion != "" {
+		versionRange, err := semver.ParseRange(e.KubernetesVersion)
+		if err != nil {
+			return fmt.Errorf("cannot parse KubernetesVersion %q of addon %q: %w", e.KubernetesVersion, e.Name, err)
+		}
+		// The kubernetes version may not be resolved yet, in which case it is validated later.
+		if version, err := util.ParseKubernetesVersion(cluster.Spec.KubernetesVersion); err == nil && !versionRange(*version) {
+			return fmt.Errorf("addon %q is not supported on Kubernetes %s", e.Name, version)
+		}
+	}
+
+	return nil
+}
+
+// EnableAddon enables an optional addon in the cluster spec.
+// sets and unsets are fields of the addon configuration, relative to its spec field, to set or unset;
+// sets are of the form key=value.
+func EnableAddon(cluster *kops.Cluster, name string, sets []string, unsets []string) error {
+	entry, err := FindAddonCatalogEntry(name)
+	if err != nil {
+		return err
+	}
+	if err := entry.checkSupported(cluster); err != nil {
+		return err
+	}
+
+	var fields []string
+	for _, field := range unsets {
+		fields = append(fields, entry.Field+"."+field)
+	}
+	if err := UnsetClusterFields(fields, cluster); err != nil {
+		return err
+	}
+
+	fields = nil
+	for _, field := range sets {
+		if !strings.Contains(field, "=") {
+			return fmt.Errorf("unhandled field: %q", field)
+		}
+		fields = append(fields, entry.Field+"."+field)
+	}
+	fields = append(fields, entry.Field+".enabled=true")
+	return SetClusterFields(fields, cluster)
+}
+
+// DisableAddon disables an optional addon in the cluster spec, keeping the rest of its configuration.
+func DisableAddon(cluster *kops.Cluster, name string) error {
+	entry, err := FindAddonCatalogEntry(name)
+	if err != nil {
+		return err
+	}
+	if !entry.Optional() {
+		return fmt.Errorf("addon %q is managed by kOps and cannot be enabled or disabled", entry.Name)
+	}
+
+	return SetClusterFields([]string{entry.Field + ".enabled=false"}, cluster)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/models"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestAddonCatalogCoversAddons(t *testing.T) {
+	paths, err := models.NewAssetPath("cloudup/resources/addons").ReadDir()
+	if err != nil {
+		t.Fatalf("error reading addons: %v", err)
+	}
+	var addons []string
+	for _, p := range paths {
+		if p.Base() == "OWNERS" {
+			continue
+		}
+		addons = append(addons, p.Base())
+	}
+	sort.Strings(addons)
+
+	var catalog []string
+	for _, entry := range AddonCatalog {
+		catalog = append(catalog, entry.Name)
+
+		if entry.KubernetesVersion != "" {
+			if _, err := semver.ParseRange(entry.KubernetesVersion); err != nil {
+				t.Errorf("addon %q has invalid KubernetesVersion %q: %v", entry.Name, entry.KubernetesVersion, err)
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(catalog, addons) {
+		t.Errorf("addon catalog does not match the addons in the models; got %v, want %v", catalog, addons)
+	}
+}
+
+func TestEnableAddon(t *testing.T) {
+	grid := []struct {
+		Name   string
+		Sets   []string
+		Unsets []string
+		Input  kops.Cluster
+		Output kops.Cluster
+		Error  string
+	}{
+		{
+			Name: "metrics-server.addons.k8s.io",
+			Sets: []string{"insecure=false"},
+			Output: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					MetricsServer: &kops.MetricsServerConfig{
+						Enabled:  fi.PtrTo(true),
+						Insecure: fi.PtrTo(false),
+					},
+				},
+			},
+		},
+		{
+			Name:   "cluster-autoscaler.addons.k8s.io",
+			Unsets: []string{"expander"},
+			Input: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					ClusterAutoscaler: &kops.ClusterAutoscalerConfig{
+						Expander: "price",
+					},
+				},
+			},
+			Output: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					ClusterAutoscaler: &kops.ClusterAutoscalerConfig{
+						Enabled: fi.PtrTo(true),
+					},
+				},
+			},
+		},
+		{
+			Name: "aws-load-balancer-controller.addons.k8s.io",
+			Input: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{},
+					},
+				},
+			},
+			Output: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{
+							LoadBalancerController: &kops.LoadBalancerControllerSpec{
+								Enabled: fi.PtrTo(true),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "karpenter.sh",
+			Input: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						GCE: &kops.GCESpec{},
+					},
+				},
+			},
+			Error: `addon "karpenter.sh" is not supported on cloud provider "gce"`,
+		},
+		{
+			Name:  "coredns.addons.k8s.io",
+			Error: `addon "coredns.addons.k8s.io" is managed by kOps and cannot be enabled or disabled`,
+		},
+		{
+			Name:  "unknown",
+			Error: `addon "unknown" not found in the addon catalog`,
+		},
+		{
+			Name:  "metrics-server.addons.k8s.io",
+			Sets:  []string{"insecure"},
+			Error: `unhandled field: "insecure"`,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			c := g.Input
+
+			err := EnableAddon(&c, g.Name, g.Sets, g.Unsets)
+			if g.Error != "" {
+				if err == nil || !strings.Contains(err.Error(), g.Error) {
+					t.Fatalf("expected error %q, got %v", g.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error from EnableAddon: %v", err)
+			}
+
+			if !reflect.DeepEqual(c, g.Output) {
+				t.Errorf("unexpected output from EnableAddon.\nexpected=%v\nactual=%v", g.Output, c)
+			}
+		})
+	}
+}
+
+func TestDisableAddon(t *testing.T) {
+	c := kops.Cluster{
+		Spec: kops.ClusterSpec{
+			KubeDNS: &kops.KubeDNSConfig{
+				NodeLocalDNS: &kops.NodeLocalDNSConfig{
+					Enabled:          fi.PtrTo(true),
+					LocalIP:          "169.254.20.10",
+					ForwardToKubeDNS: fi.PtrTo(true),
+				},
+			},
+		},
+	}
+	if err := DisableAddon(&c, "nodelocaldns.addons.k8s.io"); err != nil {
+		t.Fatalf("unexpected error from DisableAddon: %v", err)
+	}
+
+	expected := kops.Cluster{
+		Spec: kops.ClusterSpec{
+			KubeDNS: &kops.KubeDNSConfig{
+				NodeLocalDNS: &kops.NodeLocalDNSConfig{
+					Enabled:          fi.PtrTo(false),
+					LocalIP:          "169.254.20.10",
+					ForwardToKubeDNS: fi.PtrTo(true),
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("unexpected output from DisableAddon.\nexpected=%v\nactual=%v", expected, c)
+	}
+
+	if err := DisableAddon(&c, "kops-controller.addons.k8s.io"); err == nil {
+		t.Errorf("expected error disabling a managed addon")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	cryptorand "crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -44,6 +45,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/v1alpha2"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/bootstrap/tpmbootstrap"
 	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/featureflag"
@@ -66,11 +68,17 @@ type ToolboxEnrollOptions struct {
 
 	SSHUser string
 	SSHPort int
+
+	// TPM enrolls the host with an attestation key in its TPM, instead of a key on disk.
+	TPM bool
+	// TPMPCRs are the PCRs whose current values the host must attest to when bootstrapping.
+	TPMPCRs []int
 }
 
 func (o *ToolboxEnrollOptions) InitDefaults() {
 	o.SSHUser = "root"
 	o.SSHPort = 22
+	o.TPMPCRs = tpmbootstrap.DefaultPCRs
 }
 
 func RunToolboxEnroll(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxEnrollOptions) error {
@@ -181,37 +189,26 @@ func enrollHost(ctx context.Context, options *ToolboxEnrollOptions, nodeupScript
 	}
 	defer host.Close()
 
-	publicKeyPath := "/etc/kubernetes/kops/pki/machine/public.pem"
-
-	publicKeyBytes, err := host.readFile(ctx, publicKeyPath)
-	if err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			publicKeyBytes = nil
-		} else {
-			return fmt.Errorf("error reading public key %q: %w", publicKeyPath, err)
-		}
-	}
-
-	publicKeyBytes = bytes.TrimSpace(publicKeyBytes)
-	if len(publicKeyBytes) == 0 {
-		if _, err := host.runScript(ctx, scriptCreateKey, ExecOptions{Sudo: sudo, Echo: true}); err != nil {
+	var publicKeyBytes []byte
+	var hostTPM *v1alpha2.HostTPMSpec
+	if options.TPM {
+		hostTPM, err = enrollTPM(ctx, host, options.TPMPCRs, sudo)
+		if err != nil {
 			return err
 		}
-
-		b, err := host.readFile(ctx, publicKeyPath)
+	} else {
+		publicKeyBytes, err = getOrCreatePublicKey(ctx, host, sudo)
 		if err != nil {
-			return fmt.Errorf("error reading public key %q (after creation): %w", publicKeyPath, err)
+			return err
 		}
-		publicKeyBytes = b
 	}
-	klog.Infof("public key is %s", string(publicKeyBytes))
 
 	hostname, err := host.getHostname(ctx)
 	if err != nil {
 		return err
 	}
 
-	if err := createHost(ctx, options, hostname, publicKeyBytes, kubeClient); err != nil {
+	if err := createHost(ctx, options, hostname, publicKeyBytes, hostTPM, kubeClient); err != nil {
 		return err
 	}
 
@@ -223,12 +220,13 @@ func enrollHost(ctx context.Context, options *ToolboxEnrollOptions, nodeupScript
 	return nil
 }
 
-func createHost(ctx context.Context, options *ToolboxEnrollOptions, nodeName string, publicKey []byte, client client.Client) error {
+func createHost(ctx context.Context, options *ToolboxEnrollOptions, nodeName string, publicKey []byte, hostTPM *v1alpha2.HostTPMSpec, client client.Client) error {
 	host := &v1alpha2.Host{}
 	host.Namespace = "kops-system"
 	host.Name = nodeName
 	host.Spec.InstanceGroup = options.InstanceGroup
 	host.Spec.PublicKey = string(publicKey)
+	host.Spec.TPM = hostTPM
 
 	if err := client.Create(ctx, host); err != nil {
 		return fmt.Errorf("failed to create host %s/%s: %w", host.Namespace, host.Name, err)
@@ -237,6 +235,104 @@ func createHost(ctx context.Context, options *ToolboxEnrollOptions, nodeName str
 	return nil
 }
 
+// getOrCreatePublicKey reads the public key of the machine key on the host, creating the key if needed.
+func getOrCreatePublicKey(ctx context.Context, host *SSHHost, sudo bool) ([]byte, error) {
+	publicKeyPath := "/etc/kubernetes/kops/pki/machine/public.pem"
+
+	publicKeyBytes, err := host.readFile(ctx, publicKeyPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			publicKeyBytes = nil
+		} else {
+			return nil, fmt.Errorf("error reading public key %q: %w", publicKeyPath, err)
+		}
+	}
+
+	publicKeyBytes = bytes.TrimSpace(publicKeyBytes)
+	if len(publicKeyBytes) == 0 {
+		if _, err := host.runScript(ctx, scriptCreateKey, ExecOptions{Sudo: sudo, Echo: true}); err != nil {
+			return nil, err
+		}
+
+		b, err := host.readFile(ctx, publicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key %q (after creation): %w", publicKeyPath, err)
+		}
+		publicKeyBytes = b
+	}
+	klog.Infof("public key is %s", string(publicKeyBytes))
+
+	return publicKeyBytes, nil
+}
+
+// enrollTPM creates an attestation key in the host's TPM and proves, by credential activation,
+// that it is resident in the same TPM as the endorsement key.
+// It returns the TPM identity to record on the Host.
+func enrollTPM(ctx context.Context, host *SSHHost, pcrs []int, sudo bool) (*v1alpha2.HostTPMSpec, error) {
+	if len(pcrs) == 0 {
+		return nil, fmt.Errorf("at least one PCR is required for TPM enrollment")
+	}
+	var pcrList []string
+	for _, pcr := range pcrs {
+		pcrList = append(pcrList, strconv.Itoa(pcr))
+	}
+	if _, err := tpmbootstrap.ParsePCRList(strings.Join(pcrList, ",")); err != nil {
+		return nil, err
+	}
+
+	script := strings.ReplaceAll(scriptCreateTPMKeys, "{{PCRS}}", strings.Join(pcrList, ","))
+	if _, err := host.runScript(ctx, script, ExecOptions{Sudo: sudo, Echo: true}); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, name := range []string{"ek.pem", "ak.pub", "pcrs.bin"} {
+		p := path.Join(tpmbootstrap.MachineDir, name)
+		b, err := host.readFile(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %q: %w", p, err)
+		}
+		files[name] = b
+	}
+
+	challenge, attestationKey, err := tpmbootstrap.NewCredentialChallenge(files["ek.pem"], files["ak.pub"])
+	if err != nil {
+		return nil, err
+	}
+
+	credentialPath := path.Join(tpmbootstrap.MachineDir, "credential.blob")
+	if err := host.writeFile(ctx, credentialPath, challenge.Blob); err != nil {
+		return nil, fmt.Errorf("error writing %q: %w", credentialPath, err)
+	}
+	if _, err := host.runScript(ctx, scriptActivateCredential, ExecOptions{Sudo: sudo, Echo: true}); err != nil {
+		return nil, fmt.Errorf("error activating TPM credential: %w", err)
+	}
+
+	secretPath := path.Join(tpmbootstrap.MachineDir, "credential.secret")
+	secret, err := host.readFile(ctx, secretPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q: %w", secretPath, err)
+	}
+	if _, err := host.runCommand(ctx, "rm -f "+secretPath, ExecOptions{Sudo: sudo, Echo: false}); err != nil {
+		klog.Warningf("error removing %q: %v", secretPath, err)
+	}
+	if subtle.ConstantTimeCompare(secret, challenge.Secret) != 1 {
+		return nil, fmt.Errorf("TPM credential activation returned the wrong secret; attestation key is not bound to the endorsement key")
+	}
+
+	pcrValues, err := tpmbootstrap.ParsePCRValues(pcrs, files["pcrs.bin"])
+	if err != nil {
+		return nil, err
+	}
+	klog.Infof("enrolled TPM attestation key, with PCRs %v", pcrList)
+
+	return &v1alpha2.HostTPMSpec{
+		EndorsementKey: string(files["ek.pem"]),
+		AttestationKey: attestationKey,
+		PCRs:           pcrValues,
+	}, nil
+}
+
 const scriptCreateKey = `
 #!/bin/bash
 set -o errexit
@@ -257,6 +353,63 @@ if [[ ! -f "${DIR}/public.pem" ]]; then
 fi
 `
 
+// scriptCreateTPMKeys creates the attestation key and records the PCR values; it requires tpm2-tools.
+// The attestation key is wrapped by the endorsement key and stored on disk, so it is lost if the host is reinstalled.
+const scriptCreateTPMKeys = `
+#!/bin/bash
+set -o errexit
+set -o nounset
+set -o pipefail
+
+set -x
+
+PCRS="{{PCRS}}"
+
+DIR=/etc/kubernetes/kops/pki/machine/tpm/
+mkdir -p ${DIR}
+cd ${DIR}
+
+tpm2_createek -c ek.ctx -G rsa -u ek.pem -f pem
+
+if [[ ! -f ak.priv ]]; then
+  tpm2_createak -C ek.ctx -c ak.ctx -G ecc -g sha256 -s ecdsa -u ak.pub -r ak.priv
+fi
+
+echo "${PCRS}" > pcrs
+tpm2_pcrread -o pcrs.bin "sha256:${PCRS}"
+
+tpm2_flushcontext -t
+rm -f ek.ctx ak.ctx
+`
+
+// scriptActivateCredential decrypts credential.blob, which can only succeed if the attestation key
+// and the endorsement key are resident in the same TPM.
+const scriptActivateCredential = `
+#!/bin/bash
+set -o errexit
+set -o nounset
+set -o pipefail
+
+set -x
+
+cd /etc/kubernetes/kops/pki/machine/tpm/
+
+tpm2_createek -c ek.ctx -G rsa
+
+tpm2_startauthsession --policy-session -S session.ctx
+tpm2_policysecret -S session.ctx -c e
+tpm2_load -C ek.ctx -u ak.pub -r ak.priv -c ak.ctx -P session:session.ctx
+tpm2_flushcontext session.ctx
+
+tpm2_startauthsession --policy-session -S session.ctx
+tpm2_policysecret -S session.ctx -c e
+tpm2_activatecredential -c ak.ctx -C ek.ctx -i credential.blob -o credential.secret -P session:session.ctx
+tpm2_flushcontext session.ctx
+
+tpm2_flushcontext -t
+rm -f credential.blob session.ctx ek.ctx ak.ctx
+`
+
 // SSHHost is a wrapper around an SSH connection to a host machine.
 type SSHHost struct {
 	hostname  string
@@ -317,6 +470,12 @@ func (s *SSHHost) readFile(ctx context.Context, path string) ([]byte, error) {
 	return p.ReadFile(ctx)
 }
 
+func (s *SSHHost) writeFile(ctx context.Context, path string, data []byte) error {
+	p := vfs.NewSSHPath(s.sshClient, s.hostname, path, s.sudo)
+
+	return p.WriteFile(ctx, bytes.NewReader(data), nil)
+}
+
 func (s *SSHHost) runScript(ctx context.Context, script string, options ExecOptions) (*CommandOutput, error) {
 	var tempDir string
 	{
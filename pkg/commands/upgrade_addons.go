@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/addonversions"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// AddonUpgrade is a newer version of an addon that is available in the channel.
+type AddonUpgrade struct {
+	// Name is the name of the addon.
+	Name string
+	// Current is the version of the addon the cluster runs: the pinned version, or the default version of this kOps release.
+	Current string
+	// Available is the newest version of the addon in the channel that is supported by this kOps release.
+	Available string
+}
+
+// FindAddonUpgrades returns the addons of the cluster for which the channel has a newer supported version.
+func FindAddonUpgrades(cluster *kops.Cluster, channel *kops.AddonChannel) ([]*AddonUpgrade, error) {
+	kubernetesVersion, err := util.ParseKubernetesVersion(cluster.Spec.KubernetesVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %q: %w", cluster.Spec.KubernetesVersion, err)
+	}
+
+	addons, err := addonversions.All()
+	if err != nil {
+		return nil, err
+	}
+
+	var upgrades []*AddonUpgrade
+	for _, addon := range addons {
+		if !addonInstalled(&cluster.Spec, addon.Name) {
+			continue
+		}
+
+		current := currentAddonVersion(&cluster.Spec, addon)
+		currentVersion, err := addonversions.ParseVersion(current)
+		if err != nil {
+			return nil, fmt.Errorf("addon %q: %w", addon.Name, err)
+		}
+
+		versions, err := channel.GetAddonVersions(addon.Name, kubernetesVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		var available string
+		var availableVersion semver.Version
+		for _, version := range versions {
+			if err := addon.CheckVersion(version, *kubernetesVersion); err != nil {
+				klog.V(2).Infof("ignoring version of addon in channel: %v", err)
+				continue
+			}
+			sv, err := addonversions.ParseVersion(version)
+			if err != nil {
+				return nil, err
+			}
+			if sv.GT(currentVersion) && (available == "" || sv.GT(availableVersion)) {
+				available = version
+				availableVersion = sv
+			}
+		}
+
+		if available != "" {
+			upgrades = append(upgrades, &AddonUpgrade{
+				Name:      addon.Name,
+				Current:   current,
+				Available: available,
+			})
+		}
+	}
+
+	return upgrades, nil
+}
+
+// SetAddonVersion pins the version of the addon in the cluster spec.
+func SetAddonVersion(cluster *kops.Cluster, name string, version string) {
+	// The version of cilium can also be set in its own configuration, which must agree with the pinned version.
+	if name == "networking.cilium.io" && cluster.Spec.Networking.Cilium != nil && cluster.Spec.Networking.Cilium.Version != "" {
+		cluster.Spec.Networking.Cilium.Version = version
+	}
+
+	for i := range cluster.Spec.AddonVersions {
+		if cluster.Spec.AddonVersions[i].Name == name {
+			cluster.Spec.AddonVersions[i].Version = version
+			return
+		}
+	}
+	cluster.Spec.AddonVersions = append(cluster.Spec.AddonVersions, kops.AddonVersionSpec{
+		Name:    name,
+		Version: version,
+	})
+}
+
+// currentAddonVersion returns the version of the addon installed by the cluster spec.
+func currentAddonVersion(spec *kops.ClusterSpec, addon *addonversions.Addon) string {
+	if version := spec.AddonVersion(addon.Name); version != "" {
+		return version
+	}
+	if addon.Name == "networking.cilium.io" && spec.Networking.Cilium != nil && spec.Networking.Cilium.Version != "" {
+		return spec.Networking.Cilium.Version
+	}
+	return addon.DefaultVersion
+}
+
+// addonInstalled returns true if the cluster spec installs the addon.
+func addonInstalled(spec *kops.ClusterSpec, name string) bool {
+	switch name {
+	case "certmanager.io":
+		return spec.CertManager != nil && fi.ValueOf(spec.CertManager.Enabled)
+	case "coredns.addons.k8s.io":
+		return spec.KubeDNS == nil || spec.KubeDNS.Provider == "" || spec.KubeDNS.Provider == "CoreDNS"
+	case "metrics-server.addons.k8s.io":
+		return spec.MetricsServer != nil && fi.ValueOf(spec.MetricsServer.Enabled)
+	case "networking.cilium.io":
+		return spec.Networking.Cilium != nil
+	case "snapshot-controller.addons.k8s.io":
+		return spec.SnapshotController != nil && fi.ValueOf(spec.SnapshotController.Enabled)
+	default:
+		return true
+	}
+}
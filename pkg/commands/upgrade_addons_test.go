@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestFindAddonUpgrades(t *testing.T) {
+	channel := &kops.AddonChannel{
+		Spec: kops.AddonChannelSpec{
+			Addons: []kops.PackageVersionSpec{
+				{Name: "coredns.addons.k8s.io", Version: "v1.11.2"},
+				{Name: "coredns.addons.k8s.io", Version: "v1.11.3"},
+				{Name: "coredns.addons.k8s.io", Version: "v1.12.0"},
+				{Name: "coredns.addons.k8s.io", Version: "v1.11.4", KubernetesVersion: "<1.29.0"},
+				{Name: "metrics-server.addons.k8s.io", Version: "v0.7.2"},
+				{Name: "networking.cilium.io", Version: "v1.15.7"},
+			},
+		},
+	}
+
+	grid := []struct {
+		Name     string
+		Spec     kops.ClusterSpec
+		Expected []*AddonUpgrade
+	}{
+		{
+			Name: "defaults",
+			Spec: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+			},
+			Expected: []*AddonUpgrade{
+				{Name: "coredns.addons.k8s.io", Current: "v1.11.1", Available: "v1.11.3"},
+			},
+		},
+		{
+			Name: "pinned",
+			Spec: kops.ClusterSpec{
+				KubernetesVersion: "1.29.0",
+				AddonVersions: []kops.AddonVersionSpec{
+					{Name: "coredns.addons.k8s.io", Version: "v1.11.3"},
+				},
+				MetricsServer: &kops.MetricsServerConfig{Enabled: fi.PtrTo(true)},
+			},
+			Expected: []*AddonUpgrade{
+				{Name: "metrics-server.addons.k8s.io", Current: "v0.7.1", Available: "v0.7.2"},
+			},
+		},
+		{
+			Name: "cilium",
+			Spec: kops.ClusterSpec{
+				KubernetesVersion: "1.28.0",
+				KubeDNS:           &kops.KubeDNSConfig{Provider: "KubeDNS"},
+				Networking: kops.NetworkingSpec{
+					Cilium: &kops.CiliumNetworkingSpec{Version: "v1.15.5"},
+				},
+			},
+			Expected: []*AddonUpgrade{
+				{Name: "networking.cilium.io", Current: "v1.15.5", Available: "v1.15.7"},
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			cluster := &kops.Cluster{Spec: g.Spec}
+			upgrades, err := FindAddonUpgrades(cluster, channel)
+			if err != nil {
+				t.Fatalf("FindAddonUpgrades failed: %v", err)
+			}
+			if !reflect.DeepEqual(upgrades, g.Expected) {
+				t.Errorf("unexpected upgrades; got %+v, want %+v", upgrades, g.Expected)
+			}
+		})
+	}
+}
+
+func TestSetAddonVersion(t *testing.T) {
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			AddonVersions: []kops.AddonVersionSpec{
+				{Name: "coredns.addons.k8s.io", Version: "v1.11.1"},
+			},
+			Networking: kops.NetworkingSpec{
+				Cilium: &kops.CiliumNetworkingSpec{Version: "v1.15.5"},
+			},
+		},
+	}
+
+	SetAddonVersion(cluster, "coredns.addons.k8s.io", "v1.11.3")
+	SetAddonVersion(cluster, "networking.cilium.io", "v1.15.7")
+
+	expected := []kops.AddonVersionSpec{
+		{Name: "coredns.addons.k8s.io", Version: "v1.11.3"},
+		{Name: "networking.cilium.io", Version: "v1.15.7"},
+	}
+	if !reflect.DeepEqual(cluster.Spec.AddonVersions, expected) {
+		t.Errorf("unexpected addonVersions; got %+v, want %+v", cluster.Spec.AddonVersions, expected)
+	}
+	if cluster.Spec.Networking.Cilium.Version != "v1.15.7" {
+		t.Errorf("unexpected cilium version %q", cluster.Spec.Networking.Cilium.Version)
+	}
+}
@@ -264,6 +264,21 @@ func (d *logDumper) dumpNode(ctx context.Context, name string, ip string, useBas
 	return nil
 }
 
+// RunCommand connects to a node and runs a command, writing its output to stdout.
+func (d *logDumper) RunCommand(ctx context.Context, host string, useBastion bool, command string, stdout io.Writer) error {
+	client, err := d.sshClientFactory.Dial(ctx, host, useBastion)
+	if err != nil {
+		return fmt.Errorf("unable to SSH to %q: %v", host, err)
+	}
+	defer client.Close()
+
+	var stderr bytes.Buffer
+	if err := client.ExecPiped(ctx, command, stdout, &stderr); err != nil {
+		return fmt.Errorf("running %q on %q: %w (stderr: %s)", command, host, err, stderr.String())
+	}
+	return nil
+}
+
 // sshClient is an interface abstracting *ssh.Client, which allows us to test it
 type sshClient interface {
 	io.Closer
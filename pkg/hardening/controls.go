@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hardening
+
+import (
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// Control is a single hardening control that nodeup knows how to apply.
+type Control struct {
+	// ID uniquely identifies the control; it is the value used in HardeningSpec.ExcludedControls.
+	ID string
+	// Description is a short human-readable summary of the control.
+	Description string
+	// Reference is the benchmark recommendation implemented by the control.
+	Reference string
+	// Level is the lowest profile level that includes the control.
+	Level int
+	// Roles restricts the control to nodes with the given roles; it applies to all nodes if empty.
+	Roles []kops.InstanceGroupRole
+
+	// Sysctls are kernel parameters, in sysctl.conf form, that the control sets.
+	Sysctls []string
+	// DisabledKernelModules are kernel modules that the control prevents from being loaded.
+	DisabledKernelModules []string
+	// AuditRules are auditctl rules that the control installs.
+	AuditRules []string
+	// SSHDSettings are sshd_config directives that the control sets.
+	SSHDSettings []string
+	// FilePermissions are the maximum permissions that the control enforces on files.
+	FilePermissions []FilePermission
+	// Kubelet applies the control to the kubelet configuration.
+	Kubelet func(kubelet *kops.KubeletConfigSpec)
+}
+
+// FilePermission is the maximum mode allowed for a file.
+type FilePermission struct {
+	// Path is the path of the file, which may be a glob pattern.
+	Path string
+	// Unit is the name of a systemd unit, for controls that apply to the unit file.
+	Unit string
+	// Mode is the most permissive mode allowed for the file.
+	Mode string
+}
+
+// AppliesToRole returns true if the control should be applied to nodes with the given role.
+func (c *Control) AppliesToRole(role kops.InstanceGroupRole) bool {
+	if len(c.Roles) == 0 {
+		return true
+	}
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// strongTLSCipherSuites are the cipher suites recommended by the CIS Kubernetes Benchmark.
+var strongTLSCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+}
+
+// controls is the catalogue of known controls, in the order they are reported.
+// Kubelet controls only set values that the user has not configured explicitly.
+var controls = []Control{
+	{
+		ID:          "kubelet-service-file-permissions",
+		Description: "Restrict the kubelet service file permissions to 600",
+		Reference:   "CIS Kubernetes Benchmark 4.1.1",
+		Level:       1,
+		FilePermissions: []FilePermission{
+			{Unit: "kubelet.service", Mode: "0600"},
+		},
+	},
+	{
+		ID:          "kubelet-kubeconfig-file-permissions",
+		Description: "Restrict the kubelet kubeconfig file permissions to 600",
+		Reference:   "CIS Kubernetes Benchmark 4.1.5",
+		Level:       1,
+		FilePermissions: []FilePermission{
+			{Path: "/var/lib/kubelet/kubeconfig", Mode: "0600"},
+		},
+	},
+	{
+		ID:          "client-ca-file-permissions",
+		Description: "Restrict the client certificate authorities file permissions to 600",
+		Reference:   "CIS Kubernetes Benchmark 4.1.7",
+		Level:       1,
+		Roles:       []kops.InstanceGroupRole{kops.InstanceGroupRoleNode},
+		FilePermissions: []FilePermission{
+			{Path: "/srv/kubernetes/ca.crt", Mode: "0600"},
+		},
+	},
+	{
+		ID:          "kubelet-config-file-permissions",
+		Description: "Restrict the kubelet configuration file permissions to 600",
+		Reference:   "CIS Kubernetes Benchmark 4.1.9",
+		Level:       1,
+		FilePermissions: []FilePermission{
+			{Path: "/var/lib/kubelet/kubelet.conf", Mode: "0600"},
+		},
+	},
+	{
+		ID:          "static-pod-manifest-permissions",
+		Description: "Restrict the control plane static pod manifest permissions to 600",
+		Reference:   "CIS Kubernetes Benchmark 1.1.1-1.1.8",
+		Level:       1,
+		Roles:       []kops.InstanceGroupRole{kops.InstanceGroupRoleControlPlane},
+		FilePermissions: []FilePermission{
+			{Path: "/etc/kubernetes/manifests/*.manifest", Mode: "0600"},
+		},
+	},
+	{
+		ID:          "kubelet-anonymous-auth",
+		Description: "Disable anonymous requests to the kubelet",
+		Reference:   "CIS Kubernetes Benchmark 4.2.1",
+		Level:       1,
+		Kubelet: func(kubelet *kops.KubeletConfigSpec) {
+			if kubelet.AnonymousAuth == nil {
+				kubelet.AnonymousAuth = fi.PtrTo(false)
+			}
+		},
+	},
+	{
+		ID:          "kubelet-read-only-port",
+		Description: "Disable the kubelet read-only port",
+		Reference:   "CIS Kubernetes Benchmark 4.2.4",
+		Level:       1,
+		Kubelet: func(kubelet *kops.KubeletConfigSpec) {
+			if kubelet.ReadOnlyPort == nil {
+				kubelet.ReadOnlyPort = fi.PtrTo(int32(0))
+			}
+		},
+	},
+	{
+		ID:          "kubelet-protect-kernel-defaults",
+		Description: "Make the kubelet fail if kernel parameters differ from its defaults",
+		Reference:   "CIS Kubernetes Benchmark 4.2.6",
+		Level:       1,
+		Sysctls: []string{
+			"vm.overcommit_memory = 1",
+			"vm.panic_on_oom = 0",
+			"kernel.panic = 10",
+			"kernel.panic_on_oops = 1",
+			"kernel.keys.root_maxkeys = 1000000",
+			"kernel.keys.root_maxbytes = 25000000",
+		},
+		Kubelet: func(kubelet *kops.KubeletConfigSpec) {
+			if kubelet.ProtectKernelDefaults == nil {
+				kubelet.ProtectKernelDefaults = fi.PtrTo(true)
+			}
+		},
+	},
+	{
+		ID:          "kubelet-strong-tls-ciphers",
+		Description: "Only allow strong TLS cipher suites and TLS 1.2 or later on the kubelet",
+		Reference:   "CIS Kubernetes Benchmark 4.2.12",
+		Level:       1,
+		Kubelet: func(kubelet *kops.KubeletConfigSpec) {
+			if len(kubelet.TLSCipherSuites) == 0 {
+				kubelet.TLSCipherSuites = append([]string(nil), strongTLSCipherSuites...)
+			}
+			if kubelet.TLSMinVersion == "" {
+				kubelet.TLSMinVersion = "VersionTLS12"
+			}
+		},
+	},
+	{
+		ID:          "kernel-network-parameters",
+		Description: "Disable ICMP redirects and source routing, and log martian packets",
+		Reference:   "CIS Distribution Independent Linux Benchmark 3.2",
+		Level:       1,
+		Sysctls: []string{
+			"net.ipv4.conf.all.send_redirects = 0",
+			"net.ipv4.conf.default.send_redirects = 0",
+			"net.ipv4.conf.all.accept_redirects = 0",
+			"net.ipv4.conf.default.accept_redirects = 0",
+			"net.ipv4.conf.all.secure_redirects = 0",
+			"net.ipv4.conf.default.secure_redirects = 0",
+			"net.ipv4.conf.all.accept_source_route = 0",
+			"net.ipv4.conf.default.accept_source_route = 0",
+			"net.ipv4.conf.all.log_martians = 1",
+			"net.ipv4.conf.default.log_martians = 1",
+			"net.ipv4.icmp_echo_ignore_broadcasts = 1",
+			"net.ipv4.icmp_ignore_bogus_error_responses = 1",
+			"net.ipv4.tcp_syncookies = 1",
+			"net.ipv6.conf.all.accept_redirects = 0",
+			"net.ipv6.conf.default.accept_redirects = 0",
+		},
+	},
+	{
+		ID:          "kernel-memory-protection",
+		Description: "Enable address space layout randomization and disable core dumps of setuid programs",
+		Reference:   "CIS Distribution Independent Linux Benchmark 1.5",
+		Level:       1,
+		Sysctls: []string{
+			"kernel.randomize_va_space = 2",
+			"fs.suid_dumpable = 0",
+		},
+	},
+	{
+		ID:                    "disable-unused-filesystems",
+		Description:           "Prevent mounting of uncommon filesystem types",
+		Reference:             "CIS Distribution Independent Linux Benchmark 1.1.1",
+		Level:                 1,
+		DisabledKernelModules: []string{"cramfs", "freevxfs", "jffs2", "hfs", "hfsplus"},
+	},
+	{
+		ID:          "sshd-configuration",
+		Description: "Disable root and password-less SSH logins and limit SSH sessions",
+		Reference:   "CIS Distribution Independent Linux Benchmark 5.2",
+		Level:       1,
+		SSHDSettings: []string{
+			"PermitRootLogin no",
+			"PermitEmptyPasswords no",
+			"HostbasedAuthentication no",
+			"IgnoreRhosts yes",
+			"X11Forwarding no",
+			"MaxAuthTries 4",
+			"LoginGraceTime 60",
+			"ClientAliveInterval 300",
+			"ClientAliveCountMax 3",
+		},
+	},
+	{
+		ID:                    "disable-uncommon-network-protocols",
+		Description:           "Prevent loading of uncommon network protocols",
+		Reference:             "CIS Distribution Independent Linux Benchmark 3.4",
+		Level:                 2,
+		DisabledKernelModules: []string{"dccp", "rds", "tipc"},
+	},
+	{
+		ID:                    "disable-udf-filesystem",
+		Description:           "Prevent mounting of UDF filesystems",
+		Reference:             "CIS Distribution Independent Linux Benchmark 1.1.1.7",
+		Level:                 2,
+		DisabledKernelModules: []string{"udf"},
+	},
+	{
+		ID:          "sshd-disable-forwarding",
+		Description: "Disable SSH TCP and agent forwarding",
+		Reference:   "CIS Distribution Independent Linux Benchmark 5.2",
+		Level:       2,
+		SSHDSettings: []string{
+			"AllowTcpForwarding no",
+			"AllowAgentForwarding no",
+		},
+	},
+	{
+		ID:          "audit-rules",
+		Description: "Audit changes to Kubernetes configuration, credentials, identities and kernel modules",
+		Reference:   "CIS Distribution Independent Linux Benchmark 4.1",
+		Level:       2,
+		AuditRules: []string{
+			"-w /etc/kubernetes/ -p wa -k kubernetes",
+			"-w /srv/kubernetes/ -p wa -k kubernetes-pki",
+			"-w /var/lib/kubelet/kubeconfig -p wa -k kubelet",
+			"-w /var/lib/kubelet/kubelet.conf -p wa -k kubelet",
+			"-w /etc/containerd/ -p wa -k containerd",
+			"-w /etc/sudoers -p wa -k scope",
+			"-w /etc/sudoers.d/ -p wa -k scope",
+			"-w /etc/passwd -p wa -k identity",
+			"-w /etc/group -p wa -k identity",
+			"-w /etc/shadow -p wa -k identity",
+			"-w /etc/gshadow -p wa -k identity",
+			"-a always,exit -F arch=b64 -S init_module,finit_module,delete_module -k modules",
+		},
+	},
+	{
+		ID:          "kubelet-seccomp-default",
+		Description: "Use the RuntimeDefault seccomp profile for all workloads",
+		Reference:   "CIS Kubernetes Benchmark 5.7.2",
+		Level:       2,
+		Kubelet: func(kubelet *kops.KubeletConfigSpec) {
+			if kubelet.SeccompDefault == nil {
+				kubelet.SeccompDefault = fi.PtrTo(true)
+			}
+		},
+	},
+}
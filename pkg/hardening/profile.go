@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hardening
+
+import (
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// Status records what happened to a control on a node.
+type Status string
+
+const (
+	// StatusApplied means the control was applied.
+	StatusApplied Status = "Applied"
+	// StatusExcluded means the control was listed in HardeningSpec.ExcludedControls.
+	StatusExcluded Status = "Excluded"
+	// StatusNotApplicable means the control does not apply to the node.
+	StatusNotApplicable Status = "NotApplicable"
+)
+
+// Result is the outcome of evaluating a control for a node.
+type Result struct {
+	// ID is the ID of the control.
+	ID string `json:"id"`
+	// Description is the description of the control.
+	Description string `json:"description"`
+	// Reference is the benchmark recommendation implemented by the control.
+	Reference string `json:"reference,omitempty"`
+	// Status records whether the control was applied.
+	Status Status `json:"status"`
+	// Reason explains why a control was not applied.
+	Reason string `json:"reason,omitempty"`
+
+	// Control is the evaluated control.
+	Control *Control `json:"-"`
+}
+
+// ProfileLevel returns the benchmark level of the named profile.
+func ProfileLevel(profile string) (int, error) {
+	switch profile {
+	case kops.HardeningProfileCISLevel1:
+		return 1, nil
+	case kops.HardeningProfileCISLevel2:
+		return 2, nil
+	default:
+		return 0, fmt.Errorf("unknown hardening profile %q", profile)
+	}
+}
+
+// FindControl returns the control with the given ID, or nil if there is no such control.
+func FindControl(id string) *Control {
+	for i := range controls {
+		if controls[i].ID == id {
+			return &controls[i]
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the results of applying the profile in spec to a node with the given role.
+// Only controls that are part of the profile are returned.
+func Evaluate(spec *kops.HardeningSpec, role kops.InstanceGroupRole) ([]*Result, error) {
+	level, err := ProfileLevel(spec.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]bool)
+	for _, id := range spec.ExcludedControls {
+		if FindControl(id) == nil {
+			return nil, fmt.Errorf("unknown hardening control %q", id)
+		}
+		excluded[id] = true
+	}
+
+	var results []*Result
+	for i := range controls {
+		control := &controls[i]
+		if control.Level > level {
+			continue
+		}
+
+		result := &Result{
+			ID:          control.ID,
+			Description: control.Description,
+			Reference:   control.Reference,
+			Control:     control,
+		}
+		switch {
+		case excluded[control.ID]:
+			result.Status = StatusExcluded
+			result.Reason = "listed in excludedControls"
+		case !control.AppliesToRole(role):
+			result.Status = StatusNotApplicable
+			result.Reason = fmt.Sprintf("not applicable to role %s", role)
+		default:
+			result.Status = StatusApplied
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// AppliedControls returns the controls of the profile in spec that apply to a node with the given role.
+func AppliedControls(spec *kops.HardeningSpec, role kops.InstanceGroupRole) ([]*Control, error) {
+	results, err := Evaluate(spec, role)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []*Control
+	for _, result := range results {
+		if result.Status == StatusApplied {
+			applied = append(applied, result.Control)
+		}
+	}
+	return applied, nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hardening
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestControlIDsAreUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, control := range controls {
+		if seen[control.ID] {
+			t.Errorf("duplicate control ID %q", control.ID)
+		}
+		seen[control.ID] = true
+		if control.Level < 1 || control.Level > 2 {
+			t.Errorf("control %q has unexpected level %d", control.ID, control.Level)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	grid := []struct {
+		name     string
+		spec     kops.HardeningSpec
+		role     kops.InstanceGroupRole
+		expected map[string]Status
+		absent   []string
+	}{
+		{
+			name: "level1 node",
+			spec: kops.HardeningSpec{Profile: kops.HardeningProfileCISLevel1},
+			role: kops.InstanceGroupRoleNode,
+			expected: map[string]Status{
+				"kubelet-read-only-port":          StatusApplied,
+				"client-ca-file-permissions":      StatusApplied,
+				"static-pod-manifest-permissions": StatusNotApplicable,
+			},
+			absent: []string{"audit-rules", "kubelet-seccomp-default"},
+		},
+		{
+			name: "level2 control plane with exclusions",
+			spec: kops.HardeningSpec{
+				Profile:          kops.HardeningProfileCISLevel2,
+				ExcludedControls: []string{"sshd-configuration"},
+			},
+			role: kops.InstanceGroupRoleControlPlane,
+			expected: map[string]Status{
+				"audit-rules":                     StatusApplied,
+				"sshd-configuration":              StatusExcluded,
+				"client-ca-file-permissions":      StatusNotApplicable,
+				"static-pod-manifest-permissions": StatusApplied,
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			results, err := Evaluate(&g.spec, g.role)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actual := make(map[string]Status)
+			for _, result := range results {
+				actual[result.ID] = result.Status
+			}
+			for id, status := range g.expected {
+				if actual[id] != status {
+					t.Errorf("control %q: expected status %q, got %q", id, status, actual[id])
+				}
+			}
+			for _, id := range g.absent {
+				if _, found := actual[id]; found {
+					t.Errorf("control %q should not be part of profile %q", id, g.spec.Profile)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	if _, err := Evaluate(&kops.HardeningSpec{Profile: "cis-level3"}, kops.InstanceGroupRoleNode); err == nil {
+		t.Errorf("expected error for unknown profile")
+	}
+	if _, err := Evaluate(&kops.HardeningSpec{Profile: kops.HardeningProfileCISLevel1, ExcludedControls: []string{"no-such-control"}}, kops.InstanceGroupRoleNode); err == nil {
+		t.Errorf("expected error for unknown control")
+	}
+}
+
+func TestKubeletControlsRespectUserSettings(t *testing.T) {
+	kubelet := &kops.KubeletConfigSpec{
+		ReadOnlyPort: fi.PtrTo(int32(10255)),
+	}
+	applied, err := AppliedControls(&kops.HardeningSpec{Profile: kops.HardeningProfileCISLevel1}, kops.InstanceGroupRoleNode)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, control := range applied {
+		if control.Kubelet != nil {
+			control.Kubelet(kubelet)
+		}
+	}
+	if *kubelet.ReadOnlyPort != 10255 {
+		t.Errorf("expected user-specified readOnlyPort to be kept, got %d", *kubelet.ReadOnlyPort)
+	}
+	if kubelet.AnonymousAuth == nil || *kubelet.AnonymousAuth {
+		t.Errorf("expected anonymousAuth to be disabled")
+	}
+	if kubelet.ProtectKernelDefaults == nil || !*kubelet.ProtectKernelDefaults {
+		t.Errorf("expected protectKernelDefaults to be enabled")
+	}
+}
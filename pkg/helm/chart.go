@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/registry"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"sigs.k8s.io/yaml"
+)
+
+// CacheDirEnvVar is the environment variable that overrides the location of the chart cache.
+const CacheDirEnvVar = "KOPS_HELM_CHART_CACHE"
+
+// ociPrefix is the prefix of charts stored in OCI registries.
+const ociPrefix = "oci://"
+
+// DefaultCacheDir returns the directory in which downloaded charts are cached.
+func DefaultCacheDir() (string, error) {
+	if dir := os.Getenv(CacheDirEnvVar); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("finding cache directory (set %s to override): %w", CacheDirEnvVar, err)
+	}
+	return filepath.Join(dir, "kops", "helm-charts"), nil
+}
+
+// fetchChart returns the packaged chart, from the cache if present, otherwise downloading it into the cache.
+func (r *Renderer) fetchChart(ctx context.Context, spec *kops.HelmAddonSpec) ([]byte, error) {
+	cachePath, err := r.cachePath(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(cachePath)
+	if err == nil {
+		klog.V(2).Infof("using cached chart %s", cachePath)
+		return data, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading cached chart %s: %w", cachePath, err)
+	}
+
+	if strings.HasPrefix(spec.Chart, ociPrefix) {
+		data, err = r.pullOCIChart(spec)
+	} else {
+		data, err = r.downloadRepositoryChart(ctx, spec)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return nil, fmt.Errorf("creating chart cache directory: %w", err)
+	}
+	// Write to a temporary file first, so that an interrupted download is never mistaken for a cached chart.
+	tmpPath := cachePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return nil, fmt.Errorf("writing chart to cache: %w", err)
+	}
+	if err := os.Rename(tmpPath, cachePath); err != nil {
+		return nil, fmt.Errorf("writing chart to cache: %w", err)
+	}
+
+	return data, nil
+}
+
+// cachePath returns the location of the chart in the cache,
+// which is <cache>/<repository host>/<repository path>/<chart>-<version>.tgz.
+func (r *Renderer) cachePath(spec *kops.HelmAddonSpec) (string, error) {
+	var location, name string
+	if strings.HasPrefix(spec.Chart, ociPrefix) {
+		location, name = path.Split(strings.TrimPrefix(spec.Chart, ociPrefix))
+	} else {
+		u, err := url.Parse(spec.Repository)
+		if err != nil {
+			return "", fmt.Errorf("parsing chart repository %q: %w", spec.Repository, err)
+		}
+		location = u.Host + "/" + u.Path
+		name = spec.Chart
+	}
+
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.ContainsAny(spec.Version, `/\`) {
+		return "", fmt.Errorf("invalid chart %q version %q", spec.Chart, spec.Version)
+	}
+
+	// Cleaning an absolute path removes any ".." elements, so the result stays within the cache.
+	location = strings.ReplaceAll(path.Clean("/"+location), ":", "_")
+	return filepath.Join(r.CacheDir, filepath.FromSlash(location), name+"-"+spec.Version+".tgz"), nil
+}
+
+// repositoryIndex is the subset of a chart repository's index.yaml that we need.
+type repositoryIndex struct {
+	Entries map[string][]struct {
+		Version string   `json:"version"`
+		URLs    []string `json:"urls"`
+		Digest  string   `json:"digest"`
+	} `json:"entries"`
+}
+
+// downloadRepositoryChart downloads a chart from a chart repository, verifying it against the digest in the index.
+func (r *Renderer) downloadRepositoryChart(ctx context.Context, spec *kops.HelmAddonSpec) ([]byte, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(spec.Repository, "/") + "/")
+	if err != nil {
+		return nil, fmt.Errorf("parsing chart repository %q: %w", spec.Repository, err)
+	}
+
+	indexURL := baseURL.ResolveReference(&url.URL{Path: "index.yaml"})
+	indexData, err := r.httpGet(ctx, indexURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	index := &repositoryIndex{}
+	if err := yaml.Unmarshal(indexData, index); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", indexURL, err)
+	}
+
+	for _, entry := range index.Entries[spec.Chart] {
+		if entry.Version != spec.Version {
+			continue
+		}
+		if len(entry.URLs) == 0 {
+			return nil, fmt.Errorf("chart %s version %s in %s has no URLs", spec.Chart, spec.Version, spec.Repository)
+		}
+
+		chartURL, err := baseURL.Parse(entry.URLs[0])
+		if err != nil {
+			return nil, fmt.Errorf("parsing URL of chart %s version %s: %w", spec.Chart, spec.Version, err)
+		}
+
+		data, err := r.httpGet(ctx, chartURL.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.Digest != "" {
+			hash := sha256.Sum256(data)
+			if actual := hex.EncodeToString(hash[:]); actual != strings.TrimPrefix(entry.Digest, "sha256:") {
+				return nil, fmt.Errorf("chart %s has digest %s, expected %s", chartURL, actual, entry.Digest)
+			}
+		}
+
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("chart %s version %s not found in %s", spec.Chart, spec.Version, spec.Repository)
+}
+
+// pullOCIChart pulls a chart from an OCI registry, verifying it against the digest in the image manifest.
+func (r *Renderer) pullOCIChart(spec *kops.HelmAddonSpec) ([]byte, error) {
+	client, err := registry.NewClient()
+	if err != nil {
+		return nil, fmt.Errorf("creating registry client: %w", err)
+	}
+
+	ref := strings.TrimPrefix(spec.Chart, ociPrefix) + ":" + spec.Version
+	result, err := client.Pull(ref, registry.PullOptWithChart(true))
+	if err != nil {
+		return nil, fmt.Errorf("pulling chart %s: %w", ref, err)
+	}
+
+	hash := sha256.Sum256(result.Chart.Data)
+	if actual := "sha256:" + hex.EncodeToString(hash[:]); actual != result.Chart.Digest {
+		return nil, fmt.Errorf("chart %s has digest %s, expected %s", ref, actual, result.Chart.Digest)
+	}
+
+	return result.Chart.Data, nil
+}
+
+func (r *Renderer) httpGet(ctx context.Context, u string) ([]byte, error) {
+	klog.V(2).Infof("downloading %s", u)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request for %s: %w", u, err)
+	}
+
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", u, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: unexpected status %q", u, response.Status)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", u, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// Renderer renders Helm charts into manifests, as "helm template" would.
+type Renderer struct {
+	// CacheDir is the directory in which downloaded charts are cached.
+	// Charts found in the cache are used without contacting the repository.
+	CacheDir string
+	// HTTPClient is used to download charts from chart repositories; http.DefaultClient is used if nil.
+	HTTPClient *http.Client
+}
+
+// NewRenderer builds a Renderer that uses the default chart cache.
+func NewRenderer() (*Renderer, error) {
+	cacheDir, err := DefaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Renderer{CacheDir: cacheDir}, nil
+}
+
+// RenderedChart is the result of rendering a chart.
+type RenderedChart struct {
+	// Manifest is the rendered objects, as a multi-document YAML file.
+	Manifest []byte
+	// ChartDigest is the sha256 digest of the packaged chart.
+	ChartDigest string
+}
+
+// builtinNamespaces are the namespaces that always exist in a cluster.
+var builtinNamespaces = map[string]bool{
+	"default":         true,
+	"kube-node-lease": true,
+	"kube-public":     true,
+	"kube-system":     true,
+}
+
+// clusterScopedKinds are the well-known kinds that are not namespaced.
+// The scope of custom resources is taken from the CRDs in the chart.
+var clusterScopedKinds = map[schema.GroupKind]bool{
+	{Group: "", Kind: "Namespace"}:        true,
+	{Group: "", Kind: "Node"}:             true,
+	{Group: "", Kind: "PersistentVolume"}: true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:     true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"}:        true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"}: true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}:   true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:                 true,
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:                             true,
+	{Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"}:                       true,
+	{Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"}:       true,
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                                true,
+	{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                      true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                         true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                  true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                               true,
+	{Group: "snapshot.storage.k8s.io", Kind: "VolumeSnapshotClass"}:                   true,
+	{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                      true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                   true,
+	{Group: "storage.k8s.io", Kind: "VolumeAttachment"}:                               true,
+}
+
+// Render renders the chart with the values, for a cluster running kubernetesVersion.
+// CRDs from the chart are included ahead of the templates; chart tests and NOTES.txt are omitted.
+// The release namespace is included, unless it is a built-in namespace or the chart creates it.
+func (r *Renderer) Render(ctx context.Context, spec *kops.HelmAddonSpec, values map[string]interface{}, kubernetesVersion string) (*RenderedChart, error) {
+	data, err := r.fetchChart(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+
+	chrt, err := loader.LoadArchive(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("loading chart %s: %w", spec.Chart, err)
+	}
+
+	kubeVersion, err := chartutil.ParseKubeVersion(kubernetesVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %q: %w", kubernetesVersion, err)
+	}
+	if chrt.Metadata.KubeVersion != "" && !chartutil.IsCompatibleRange(chrt.Metadata.KubeVersion, kubeVersion.String()) {
+		return nil, fmt.Errorf("chart %s requires kubernetes %s, cluster is %s", spec.Chart, chrt.Metadata.KubeVersion, kubeVersion)
+	}
+
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	if err := chartutil.ProcessDependencies(chrt, values); err != nil {
+		return nil, fmt.Errorf("processing dependencies of chart %s: %w", spec.Chart, err)
+	}
+
+	caps := chartutil.DefaultCapabilities.Copy()
+	caps.KubeVersion = *kubeVersion
+	options := chartutil.ReleaseOptions{
+		Name:      spec.Name,
+		Namespace: spec.Namespace,
+		IsInstall: true,
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, values, options, caps)
+	if err != nil {
+		return nil, fmt.Errorf("building values for chart %s: %w", spec.Chart, err)
+	}
+
+	rendered, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("rendering chart %s: %w", spec.Chart, err)
+	}
+
+	var documents []string
+	for _, crd := range chrt.CRDObjects() {
+		documents = append(documents, string(crd.File.Data))
+	}
+
+	var names []string
+	for name := range rendered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if path.Base(name) == "NOTES.txt" {
+			continue
+		}
+		documents = append(documents, rendered[name])
+	}
+
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(strings.Join(documents, "\n---\n")))
+	if err != nil {
+		return nil, fmt.Errorf("parsing output of chart %s: %w", spec.Chart, err)
+	}
+
+	crdClusterScoped, err := crdScopes(objects)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CRDs of chart %s: %w", spec.Chart, err)
+	}
+
+	var kept kubemanifest.ObjectList
+	createNamespace := !builtinNamespaces[spec.Namespace]
+	for _, object := range objects {
+		meta := &metav1.ObjectMeta{}
+		if err := object.Reparse(meta, "metadata"); err != nil {
+			return nil, fmt.Errorf("parsing output of chart %s: %w", spec.Chart, err)
+		}
+
+		// Chart tests are run on demand by "helm test"; they are not part of the release.
+		if strings.Contains(meta.Annotations["helm.sh/hook"], "test") {
+			continue
+		}
+
+		gk := object.GroupVersionKind().GroupKind()
+		clusterScoped, found := crdClusterScoped[gk]
+		if !found {
+			clusterScoped = clusterScopedKinds[gk]
+		}
+		if meta.Namespace == "" && !clusterScoped {
+			if err := object.Set(spec.Namespace, "metadata", "namespace"); err != nil {
+				return nil, fmt.Errorf("setting namespace of %s %s: %w", object.Kind(), meta.Name, err)
+			}
+		}
+
+		if object.GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Namespace"}) && meta.Name == spec.Namespace {
+			createNamespace = false
+		}
+
+		kept = append(kept, object)
+	}
+
+	// As with "helm install --create-namespace", we create the release namespace unless the chart does.
+	if createNamespace {
+		namespace := kubemanifest.NewObject(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": spec.Namespace,
+			},
+		})
+		kept = append(kubemanifest.ObjectList{namespace}, kept...)
+	}
+
+	manifest, err := kept.ToYAML()
+	if err != nil {
+		return nil, fmt.Errorf("serializing output of chart %s: %w", spec.Chart, err)
+	}
+
+	return &RenderedChart{
+		Manifest:    manifest,
+		ChartDigest: "sha256:" + hex.EncodeToString(hash[:]),
+	}, nil
+}
+
+// crdSpec is the subset of the spec of a CustomResourceDefinition that we need.
+type crdSpec struct {
+	Group string `json:"group"`
+	Names struct {
+		Kind string `json:"kind"`
+	} `json:"names"`
+	Scope string `json:"scope"`
+}
+
+// crdScopes returns whether the kinds defined by the CRDs among the objects are cluster-scoped.
+func crdScopes(objects kubemanifest.ObjectList) (map[schema.GroupKind]bool, error) {
+	scopes := make(map[schema.GroupKind]bool)
+	for _, object := range objects {
+		if object.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+			continue
+		}
+
+		crd := &crdSpec{}
+		if err := object.Reparse(crd, "spec"); err != nil {
+			return nil, err
+		}
+		scopes[schema.GroupKind{Group: crd.Group, Kind: crd.Names.Kind}] = crd.Scope == "Cluster"
+	}
+	return scopes, nil
+}
+
+// BuildValues returns the values for the chart: the values from ValuesFile, overridden by the inline Values.
+// A ValuesFile that is not a URL is relative to configBase.
+func BuildValues(ctx context.Context, vfsContext *vfs.VFSContext, spec *kops.HelmAddonSpec, configBase vfs.Path) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	if spec.Values != "" {
+		if err := utils.YamlUnmarshal([]byte(spec.Values), &values); err != nil {
+			return nil, fmt.Errorf("parsing values of addon %q: %w", spec.Name, err)
+		}
+	}
+
+	if spec.ValuesFile != "" {
+		var p vfs.Path
+		if strings.Contains(spec.ValuesFile, "://") {
+			var err error
+			p, err = vfsContext.BuildVfsPath(spec.ValuesFile)
+			if err != nil {
+				return nil, fmt.Errorf("parsing valuesFile %q: %w", spec.ValuesFile, err)
+			}
+		} else {
+			p = configBase.Join(spec.ValuesFile)
+		}
+
+		data, err := p.ReadFile(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reading values of addon %q: %w", spec.Name, err)
+		}
+
+		fileValues := map[string]interface{}{}
+		if err := utils.YamlUnmarshal(data, &fileValues); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", p, err)
+		}
+		values = chartutil.CoalesceTables(values, fileValues)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/testutils/golden"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// packageChart packages the chart in dir into outDir, returning the path of the archive.
+func packageChart(t *testing.T, dir string, outDir string) string {
+	t.Helper()
+
+	chrt, err := loader.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("loading chart %s: %v", dir, err)
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		t.Fatalf("creating %s: %v", outDir, err)
+	}
+	p, err := chartutil.Save(chrt, outDir)
+	if err != nil {
+		t.Fatalf("packaging chart %s: %v", dir, err)
+	}
+	return p
+}
+
+func TestRenderFromCache(t *testing.T) {
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	packageChart(t, "testdata/example", filepath.Join(cacheDir, "charts.example.com", "stable"))
+
+	r := &Renderer{CacheDir: cacheDir}
+	spec := &kops.HelmAddonSpec{
+		Name:       "widgets",
+		Namespace:  "widget-system",
+		Chart:      "example",
+		Repository: "https://charts.example.com/stable",
+		Version:    "1.2.3",
+	}
+	values := map[string]interface{}{"replicaCount": 2}
+
+	rendered, err := r.Render(ctx, spec, values, "1.30.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	golden.AssertMatchesFile(t, string(rendered.Manifest), "testdata/example.yaml")
+
+	if _, err := r.Render(ctx, spec, values, "1.25.0"); err == nil {
+		t.Errorf("expected error rendering chart for an unsupported kubernetes version")
+	}
+}
+
+func TestRenderDownloadsFromRepository(t *testing.T) {
+	ctx := context.Background()
+	archive := packageChart(t, "testdata/example", t.TempDir())
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatalf("reading %s: %v", archive, err)
+	}
+	hash := sha256.Sum256(data)
+	digest := hex.EncodeToString(hash[:])
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		switch req.URL.Path {
+		case "/stable/index.yaml":
+			fmt.Fprintf(w, "apiVersion: v1\nentries:\n  example:\n  - version: 1.2.3\n    digest: %s\n    urls:\n    - charts/example-1.2.3.tgz\n", digest)
+		case "/stable/charts/example-1.2.3.tgz":
+			_, _ = w.Write(data)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+
+	r := &Renderer{CacheDir: t.TempDir()}
+	spec := &kops.HelmAddonSpec{
+		Name:       "widgets",
+		Namespace:  "widget-system",
+		Chart:      "example",
+		Repository: server.URL + "/stable",
+		Version:    "1.2.3",
+	}
+
+	first, err := r.Render(ctx, spec, nil, "1.30.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.ChartDigest != "sha256:"+digest {
+		t.Errorf("unexpected chart digest %q, expected sha256:%s", first.ChartDigest, digest)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+
+	// The chart is now cached, so rendering again must not download it.
+	second, err := r.Render(ctx, spec, nil, "1.30.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected cached chart to be used, got %d requests", requests)
+	}
+	if string(first.Manifest) != string(second.Manifest) {
+		t.Errorf("rendering from the cache gave different output")
+	}
+
+	missing := *spec
+	missing.Version = "9.9.9"
+	if _, err := r.Render(ctx, &missing, nil, "1.30.2"); err == nil {
+		t.Errorf("expected error rendering a missing chart version")
+	}
+}
+
+func TestBuildValues(t *testing.T) {
+	ctx := context.Background()
+	vfs.Context.ResetMemfsContext(true)
+	configBase, err := vfs.Context.BuildVfsPath("memfs://tests/cluster.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := configBase.Join("values", "widgets.yaml").WriteFile(ctx, bytes.NewReader([]byte("replicaCount: 3\nimage:\n  tag: \"3.10\"\n")), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spec := &kops.HelmAddonSpec{
+		Name:       "widgets",
+		Values:     "replicaCount: 5\n",
+		ValuesFile: "values/widgets.yaml",
+	}
+	values, err := BuildValues(ctx, vfs.Context, spec, configBase)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := fmt.Sprintf("%v", values)
+	expected := "map[image:map[tag:3.10] replicaCount:5]"
+	if actual != expected {
+		t.Errorf("unexpected values %s, expected %s", actual, expected)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// CacheDirEnvVar is the environment variable that overrides the location of the build cache.
+const CacheDirEnvVar = "KOPS_KUSTOMIZE_CACHE"
+
+// DefaultCacheDir returns the directory in which build output is cached.
+func DefaultCacheDir() (string, error) {
+	if dir := os.Getenv(CacheDirEnvVar); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("finding cache directory (set %s to override): %w", CacheDirEnvVar, err)
+	}
+	return filepath.Join(dir, "kops", "kustomize"), nil
+}
+
+// Builder builds kustomizations into manifests, as "kustomize build" would.
+type Builder struct {
+	// CacheDir is the directory in which build output is cached, keyed by the hash of the kustomization's files.
+	// Output found in the cache is used without building the kustomization.
+	CacheDir string
+}
+
+// NewBuilder builds a Builder that uses the default build cache.
+func NewBuilder() (*Builder, error) {
+	cacheDir, err := DefaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Builder{CacheDir: cacheDir}, nil
+}
+
+// Source holds the files of a directory containing kustomizations, keyed by their slash-separated path relative to the directory.
+type Source map[string][]byte
+
+// ReadSource reads the files under dir.
+// Hidden files and directories, such as .git, are skipped.
+func ReadSource(ctx context.Context, dir vfs.Path) (Source, error) {
+	paths, err := dir.ReadTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", dir, err)
+	}
+
+	prefix := path.Clean(dir.Path()) + "/"
+	source := Source{}
+	for _, p := range paths {
+		name, found := strings.CutPrefix(path.Clean(p.Path()), prefix)
+		if !found {
+			return nil, fmt.Errorf("file %s is not under %s", p, dir)
+		}
+		if isHidden(name) {
+			continue
+		}
+
+		data, err := p.ReadFile(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+		source[name] = data
+	}
+
+	if len(source) == 0 {
+		return nil, fmt.Errorf("no files found in %s", dir)
+	}
+	return source, nil
+}
+
+// isHidden returns true if any element of the slash-separated path starts with a dot.
+func isHidden(name string) bool {
+	for _, element := range strings.Split(name, "/") {
+		if strings.HasPrefix(element, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns the paths of the files of the source, sorted.
+func (s Source) Names() []string {
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Hash returns the sha256 hash of the paths and contents of the files of the source.
+func (s Source) Hash() string {
+	h := sha256.New()
+	for _, name := range s.Names() {
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(s[name]))
+		h.Write(s[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Build builds the kustomization in the overlay directory of the source, which is the root of the source if empty.
+// The output is cached by the hash of the source, the overlay and the version of kOps, so an unchanged kustomization is not rebuilt.
+func (b *Builder) Build(source Source, overlay string) ([]byte, error) {
+	h := sha256.Sum256([]byte(source.Hash() + "\x00" + path.Clean(overlay) + "\x00" + kopsbase.Version))
+	cachePath := filepath.Join(b.CacheDir, hex.EncodeToString(h[:])+".yaml")
+
+	manifest, err := os.ReadFile(cachePath)
+	if err == nil {
+		klog.V(2).Infof("using cached kustomize output %s", cachePath)
+		return manifest, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading cached kustomize output %s: %w", cachePath, err)
+	}
+
+	manifest, err = build(source, overlay)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(b.CacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating kustomize cache directory: %w", err)
+	}
+	// Write to a temporary file first, so that an interrupted write is never mistaken for cached output.
+	tmpPath := cachePath + ".tmp"
+	if err := os.WriteFile(tmpPath, manifest, 0o644); err != nil {
+		return nil, fmt.Errorf("writing kustomize output to cache: %w", err)
+	}
+	if err := os.Rename(tmpPath, cachePath); err != nil {
+		return nil, fmt.Errorf("writing kustomize output to cache: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// build runs kustomize on an in-memory copy of the source, so that only the files of the source can be loaded.
+func build(source Source, overlay string) ([]byte, error) {
+	const root = "/source"
+
+	fs := filesys.MakeFsInMemory()
+	for name, data := range source {
+		if err := fs.WriteFile(path.Join(root, name), data); err != nil {
+			return nil, fmt.Errorf("copying %s: %w", name, err)
+		}
+	}
+
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resources, err := kustomizer.Run(fs, path.Join(root, overlay))
+	if err != nil {
+		return nil, fmt.Errorf("building kustomization %q: %w", overlay, err)
+	}
+
+	manifest, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("serializing output of kustomization %q: %w", overlay, err)
+	}
+	return manifest, nil
+}
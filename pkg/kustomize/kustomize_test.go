@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/pkg/testutils/golden"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestReadSource(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for name, contents := range map[string]string{
+		"kustomization.yaml":    "resources: []\n",
+		"nested/patch.yaml":     "kind: Deployment\n",
+		".git/HEAD":             "ref: refs/heads/main\n",
+		"nested/.hidden.yaml":   "kind: Secret\n",
+		"nested/.dir/file.yaml": "kind: Secret\n",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("creating %s: %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0o644); err != nil {
+			t.Fatalf("writing %s: %v", p, err)
+		}
+	}
+
+	source, err := ReadSource(ctx, vfs.NewFSPath(dir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"kustomization.yaml", "nested/patch.yaml"}
+	if !reflect.DeepEqual(source.Names(), expected) {
+		t.Errorf("unexpected files %v, expected %v", source.Names(), expected)
+	}
+
+	if _, err := ReadSource(ctx, vfs.NewFSPath(filepath.Join(dir, "nested", ".dir", "missing"))); err == nil {
+		t.Errorf("expected error reading a missing directory")
+	}
+}
+
+func TestSourceHash(t *testing.T) {
+	source := Source{"kustomization.yaml": []byte("resources: []\n")}
+	hash := source.Hash()
+
+	if (Source{"kustomization.yaml": []byte("resources: []\n")}).Hash() != hash {
+		t.Errorf("hash of identical sources differs")
+	}
+	if (Source{"kustomization.yml": []byte("resources: []\n")}).Hash() == hash {
+		t.Errorf("hash does not change when a file is renamed")
+	}
+	if (Source{"kustomization.yaml": []byte("resources: [a.yaml]\n")}).Hash() == hash {
+		t.Errorf("hash does not change when a file changes")
+	}
+}
+
+func TestBuild(t *testing.T) {
+	ctx := context.Background()
+	source, err := ReadSource(ctx, vfs.NewFSPath("testdata/example"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cacheDir := t.TempDir()
+	b := &Builder{CacheDir: cacheDir}
+	manifest, err := b.Build(source, "overlays/production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	golden.AssertMatchesFile(t, string(manifest), "testdata/example.yaml")
+
+	// A second build of the same source is served from the cache.
+	cached, err := filepath.Glob(filepath.Join(cacheDir, "*.yaml"))
+	if err != nil {
+		t.Fatalf("listing cache: %v", err)
+	}
+	if len(cached) != 1 {
+		t.Fatalf("expected one cached build, found %v", cached)
+	}
+	if err := os.WriteFile(cached[0], []byte("cached"), 0o644); err != nil {
+		t.Fatalf("writing cache: %v", err)
+	}
+	manifest, err = b.Build(source, "overlays/production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(manifest) != "cached" {
+		t.Errorf("expected build to be served from the cache, got %q", manifest)
+	}
+
+	if _, err := b.Build(source, "overlays/missing"); err == nil {
+		t.Errorf("expected error building a missing overlay")
+	}
+}
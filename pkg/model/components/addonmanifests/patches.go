@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonmanifests
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/kubemanifest"
+	"sigs.k8s.io/yaml"
+)
+
+// applyPatches applies the cluster's addonPatches for the named addon to the manifest.
+// Images added by a patch are remapped, so they are included in the cluster's assets.
+func applyPatches(name string, patches []kops.AddonPatchSpec, assetBuilder *assets.AssetBuilder, manifest []byte) ([]byte, error) {
+	var objects kubemanifest.ObjectList
+	for i := range patches {
+		patch := &patches[i]
+		if patch.Addon != name {
+			continue
+		}
+
+		if objects == nil {
+			var err error
+			objects, err = kubemanifest.LoadObjectsFrom(manifest)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		matched := false
+		for j, object := range objects {
+			if !matchesPatchTarget(object, &patch.Target) {
+				continue
+			}
+			matched = true
+
+			patched, err := patchObject(object, patch)
+			if err != nil {
+				return nil, fmt.Errorf("error applying addonPatches[%d] to %s %s/%s: %w", i, object.Kind(), object.GetNamespace(), object.GetName(), err)
+			}
+			if err := remapPatchedImages(object, patched, assetBuilder); err != nil {
+				return nil, err
+			}
+			objects[j] = patched
+		}
+		if !matched {
+			return nil, fmt.Errorf("addonPatches[%d] did not match any object in addon %q", i, name)
+		}
+	}
+
+	if objects == nil {
+		return manifest, nil
+	}
+	return objects.ToYAML()
+}
+
+// matchesPatchTarget returns true if the object is selected by the target; fields of the target that are not set match any value.
+func matchesPatchTarget(object *kubemanifest.Object, target *kops.AddonPatchTarget) bool {
+	gvk := object.GroupVersionKind()
+	if target.Group != "" && target.Group != gvk.Group {
+		return false
+	}
+	if target.Kind != "" && target.Kind != gvk.Kind {
+		return false
+	}
+	if target.Namespace != "" && target.Namespace != object.GetNamespace() {
+		return false
+	}
+	if target.Name != "" && target.Name != object.GetName() {
+		return false
+	}
+	return true
+}
+
+func patchObject(object *kubemanifest.Object, patch *kops.AddonPatchSpec) (*kubemanifest.Object, error) {
+	original, err := object.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing patch: %w", err)
+	}
+
+	var patched []byte
+	switch patch.Type {
+	case "", kops.AddonPatchTypeStrategicMerge:
+		if dataStruct, err := scheme.Scheme.New(object.GroupVersionKind()); err == nil {
+			patched, err = strategicpatch.StrategicMergePatch(original, patchJSON, dataStruct)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// As with kustomize, kinds without a known schema (such as custom resources) get a JSON merge patch.
+			patched, err = jsonpatch.MergePatch(original, patchJSON)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case kops.AddonPatchTypeJSON6902:
+		jsonPatch, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing patch: %w", err)
+		}
+		patched, err = jsonPatch.Apply(original)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", patch.Type)
+	}
+
+	data := make(map[string]interface{})
+	if err := json.Unmarshal(patched, &data); err != nil {
+		return nil, fmt.Errorf("error parsing patched object: %w", err)
+	}
+	return kubemanifest.NewObject(data), nil
+}
+
+// remapPatchedImages remaps the images of the patched object that were not in the original object,
+// which has already been remapped.
+func remapPatchedImages(original *kubemanifest.Object, patched *kubemanifest.Object, assetBuilder *assets.AssetBuilder) error {
+	existing := sets.New[string]()
+	if err := original.RemapImages(func(image string) (string, error) {
+		existing.Insert(image)
+		return image, nil
+	}); err != nil {
+		return err
+	}
+
+	if err := patched.RemapImages(func(image string) (string, error) {
+		if existing.Has(image) {
+			return image, nil
+		}
+		return assetBuilder.RemapImage(image)
+	}); err != nil {
+		return fmt.Errorf("error remapping images: %w", err)
+	}
+	return nil
+}
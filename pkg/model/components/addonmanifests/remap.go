@@ -51,6 +51,11 @@ func RemapAddonManifest(addon *addonsapi.AddonSpec, context *model.KopsModelCont
 			}
 		}
 
+		err = pinAddonVersion(name, &context.Cluster.Spec, objects)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pin version of %q: %w", name, err)
+		}
+
 		err = addLabels(addon, objects)
 		if err != nil {
 			return nil, fmt.Errorf("failed to annotate %q: %w", name, err)
@@ -76,6 +81,15 @@ func RemapAddonManifest(addon *addonsapi.AddonSpec, context *model.KopsModelCont
 		manifest = remapped
 	}
 
+	// Patches from the cluster spec are applied last, so they take precedence over anything kOps sets.
+	{
+		patched, err := applyPatches(name, context.Cluster.Spec.AddonPatches, assetBuilder, manifest)
+		if err != nil {
+			return nil, fmt.Errorf("error patching manifest %s: %w", name, err)
+		}
+		manifest = patched
+	}
+
 	return manifest, nil
 }
 
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addonmanifests
+
+import (
+	"fmt"
+
+	"k8s.io/kops/pkg/addonversions"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+// pinAddonVersion sets the tag of the images of the named addon to the version pinned in the cluster's addonVersions.
+func pinAddonVersion(name string, spec *kops.ClusterSpec, objects kubemanifest.ObjectList) error {
+	version := spec.AddonVersion(name)
+	if version == "" {
+		return nil
+	}
+
+	addon, err := addonversions.Find(name)
+	if err != nil {
+		return err
+	}
+	if addon == nil {
+		return fmt.Errorf("the version of addon %q cannot be pinned", name)
+	}
+
+	for _, object := range objects {
+		err := object.RemapImages(func(image string) (string, error) {
+			return addon.SetImageVersion(image, version), nil
+		})
+		if err != nil {
+			return fmt.Errorf("error pinning images of %s %s/%s: %w", object.Kind(), object.GetNamespace(), object.GetName(), err)
+		}
+	}
+	return nil
+}
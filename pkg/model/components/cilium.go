@@ -39,6 +39,9 @@ func (b *CiliumOptionsBuilder) BuildOptions(o interface{}) error {
 		return nil
 	}
 
+	if c.Version == "" {
+		c.Version = clusterSpec.AddonVersion("networking.cilium.io")
+	}
 	if c.Version == "" {
 		c.Version = "v1.15.6"
 	}
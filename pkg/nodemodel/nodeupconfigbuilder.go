@@ -69,6 +69,10 @@ func NewNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBui
 	}
 
 	for i := range cluster.Spec.Addons {
+		// Addons rendered from Helm charts or built from kustomizations are part of the bootstrap channel.
+		if cluster.Spec.Addons[i].Manifest == "" {
+			continue
+		}
 		channels = append(channels, cluster.Spec.Addons[i].Manifest)
 	}
 
@@ -443,9 +447,62 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 	config.Packages = append(config.Packages, cluster.Spec.Packages...)
 	config.Packages = append(config.Packages, ig.Spec.Packages...)
 
+	for _, image := range ig.Spec.ImagePrePull {
+		// Pull through the configured container registry mirror
+		remapped, err := n.assetBuilder.RemapImage(image)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to remap image %q: %w", image, err)
+		}
+		config.PrePullImages = append(config.PrePullImages, remapped)
+	}
+
+	config.AssetVerification = n.buildAssetVerification(config)
+
 	return config, bootConfig, nil
 }
 
+// buildAssetVerification returns the policy nodeup enforces on the assets and preloaded images of the config,
+// or nil if none of them must be signed.
+// Container images are not verified by nodeup, as they are verified and pinned to their digest when remapped.
+func (n *nodeUpConfigBuilder) buildAssetVerification(config *nodeup.Config) *nodeup.AssetVerification {
+	if n.cluster.Spec.Assets == nil || n.cluster.Spec.Assets.Verification == nil {
+		return nil
+	}
+
+	signedFiles := n.assetBuilder.SignedFiles()
+	verification := &nodeup.AssetVerification{
+		SignedFiles: make(map[string]string),
+	}
+	addSignedFile := func(hash string) {
+		if canonicalURL, found := signedFiles[hash]; found {
+			verification.SignedFiles[hash] = canonicalURL
+		}
+	}
+	for _, archAssets := range config.Assets {
+		for _, asset := range archAssets {
+			if hash, _, found := strings.Cut(asset, "@"); found {
+				addSignedFile(hash)
+			}
+		}
+	}
+	for _, archImages := range config.Images {
+		for _, image := range archImages {
+			addSignedFile(image.Hash)
+		}
+	}
+	if len(verification.SignedFiles) == 0 {
+		return nil
+	}
+
+	policy := n.cluster.Spec.Assets.Verification
+	verification.Policy = &kops.AssetVerificationSpec{
+		Files:           policy.Files,
+		FulcioRoots:     policy.FulcioRoots,
+		RekorPublicKeys: policy.RekorPublicKeys,
+	}
+	return verification
+}
+
 func loadCertificates(keysets map[string]*fi.Keyset, name string, config *nodeup.Config, includeKeypairID bool) error {
 	keyset := keysets[name]
 	if keyset == nil {
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// RevocationListPath returns the path of the certificate revocation list for the keypair of the named keyset,
+// relative to the cluster's configuration base.
+func RevocationListPath(signer string, keypairID string) string {
+	return "pki/crl/" + signer + "/" + keypairID + ".crl"
+}
+
+// RevokedCertificatesPath returns the path of the record of the certificates revoked for the keypair of the named keyset,
+// relative to the cluster's configuration base. The record is the source of the certificate revocation list.
+func RevokedCertificatesPath(signer string, keypairID string) string {
+	return "pki/crl/" + signer + "/" + keypairID + ".json"
+}
+
+// RevokedCertificates records the certificates revoked for a keypair.
+type RevokedCertificates struct {
+	// Number is the number of the last certificate revocation list built from the record.
+	Number *big.Int `json:"number"`
+	// Certificates are the revoked certificates that have not yet expired.
+	Certificates []*RevokedCertificate `json:"certificates"`
+}
+
+// RevokedCertificate is a certificate in a certificate revocation list.
+type RevokedCertificate struct {
+	// SerialNumber is the serial number of the certificate.
+	SerialNumber *big.Int `json:"serialNumber"`
+	// RevocationTime is the time the certificate was revoked.
+	RevocationTime time.Time `json:"revocationTime"`
+	// NotAfter is the expiry time of the certificate, after which it is dropped from the list.
+	NotAfter time.Time `json:"notAfter"`
+}
+
+// ParsePEMRevocationList parses a PEM-encoded certificate revocation list.
+func ParsePEMRevocationList(pemData []byte) (*x509.RevocationList, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("could not parse certificate revocation list")
+	}
+	if block.Type != "X509 CRL" {
+		return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+	}
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing certificate revocation list: %v", err)
+	}
+	return crl, nil
+}
+
+// RevokeCertificates adds the certificates to the record of the certificates revoked for the CA keypair (if previous is not nil),
+// drops the certificates that have expired, and builds a PEM-encoded certificate revocation list signed by the CA keypair.
+// Certificates with an unknown expiry time are kept until the CA certificate expires.
+// The list stays valid until the CA certificate expires; it is replaced whenever another certificate is revoked.
+func RevokeCertificates(ca *Certificate, caKey *PrivateKey, previous *RevokedCertificates, certificates []*RevokedCertificate, now time.Time) (*RevokedCertificates, []byte, error) {
+	if ca == nil || ca.Certificate == nil {
+		return nil, nil, fmt.Errorf("CA certificate is required")
+	}
+	if caKey == nil || caKey.Key == nil {
+		return nil, nil, fmt.Errorf("CA private key is required")
+	}
+
+	record := &RevokedCertificates{
+		Number: big.NewInt(1),
+	}
+	revoked := make(map[string]bool)
+	add := func(cert *RevokedCertificate) {
+		if revoked[cert.SerialNumber.String()] {
+			return
+		}
+		revoked[cert.SerialNumber.String()] = true
+		if cert.NotAfter.IsZero() || cert.NotAfter.After(ca.Certificate.NotAfter) {
+			cert.NotAfter = ca.Certificate.NotAfter
+		}
+		if cert.NotAfter.Before(now) {
+			return
+		}
+		record.Certificates = append(record.Certificates, cert)
+	}
+
+	if previous != nil {
+		if previous.Number != nil {
+			record.Number = new(big.Int).Add(previous.Number, big.NewInt(1))
+		}
+		for _, cert := range previous.Certificates {
+			add(&RevokedCertificate{
+				SerialNumber:   cert.SerialNumber,
+				RevocationTime: cert.RevocationTime,
+				NotAfter:       cert.NotAfter,
+			})
+		}
+	}
+	for _, cert := range certificates {
+		add(&RevokedCertificate{
+			SerialNumber:   cert.SerialNumber,
+			RevocationTime: now.UTC(),
+			NotAfter:       cert.NotAfter,
+		})
+	}
+
+	template := &x509.RevocationList{
+		Number:     record.Number,
+		ThisUpdate: now.UTC(),
+		NextUpdate: ca.Certificate.NotAfter,
+	}
+	for _, cert := range record.Certificates {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   cert.SerialNumber,
+			RevocationTime: cert.RevocationTime,
+		})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.Certificate, caKey.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error signing certificate revocation list: %v", err)
+	}
+
+	return record, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}), nil
+}
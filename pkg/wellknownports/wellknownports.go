@@ -20,6 +20,12 @@ const (
 	// KubeAPIServer is the port where kube-apiserver listens.
 	KubeAPIServer = 443
 
+	// ProtokubeGossipStatus is the port where protokube serves the gossip status.
+	ProtokubeGossipStatus = 3985
+
+	// DNSControllerHealthCheck is the port where dns-controller serves metrics and health checks.
+	DNSControllerHealthCheck = 3986
+
 	// NodeupChallenge is the port where nodeup listens for challenges.
 	NodeupChallenge = 3987
 
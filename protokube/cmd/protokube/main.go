@@ -17,17 +17,22 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/wellknownports"
 	gossiputils "k8s.io/kops/protokube/pkg/gossip"
 	gossipdns "k8s.io/kops/protokube/pkg/gossip/dns"
+	"k8s.io/kops/protokube/pkg/gossip/kopscontroller"
 	_ "k8s.io/kops/protokube/pkg/gossip/memberlist"
 	_ "k8s.io/kops/protokube/pkg/gossip/mesh"
 	"k8s.io/kops/protokube/pkg/protokube"
@@ -42,6 +47,14 @@ var (
 func main() {
 	klog.InitFlags(nil)
 
+	if len(os.Args) > 2 && os.Args[1] == "gossip" && os.Args[2] == "status" {
+		if err := runGossipStatus(os.Args[3:]); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	fmt.Printf("protokube version %s\n", BuildVersion)
 
 	if err := run(); err != nil {
@@ -56,6 +69,10 @@ func run() error {
 	var zones []string
 	var containerized, master, gossip bool
 	var cloud, clusterID, dnsInternalSuffix, gossipSecret, gossipListen, gossipProtocol, gossipSecretSecondary, gossipListenSecondary, gossipProtocolSecondary string
+	var gossipAdditionalSecrets, gossipAdditionalSecretsSecondary []string
+	var gossipSealing, gossipSealingSecondary string
+	var gossipStatusListen string
+	var kopsControllerCAFile string
 	var flagChannels string
 	var dnsUpdateInterval int
 
@@ -67,12 +84,18 @@ func run() error {
 	flag.StringVar(&dnsInternalSuffix, "dns-internal-suffix", dnsInternalSuffix, "DNS suffix for internal domain names")
 	flags.IntVar(&dnsUpdateInterval, "dns-update-interval", 5, "Configure interval at which to update DNS records.")
 	flag.StringVar(&flagChannels, "channels", flagChannels, "channels to install")
-	flag.StringVar(&gossipProtocol, "gossip-protocol", "mesh", "mesh/memberlist")
+	flag.StringVar(&gossipProtocol, "gossip-protocol", "mesh", "mesh/memberlist/kops-controller")
 	flag.StringVar(&gossipListen, "gossip-listen", fmt.Sprintf("0.0.0.0:%d", wellknownports.ProtokubeGossipWeaveMesh), "address:port on which to bind for gossip")
 	flags.StringVar(&gossipSecret, "gossip-secret", gossipSecret, "Secret to use to secure gossip")
+	flags.StringArrayVar(&gossipAdditionalSecrets, "gossip-additional-secrets", nil, "Additional secrets to accept on gossip, so that the gossip secret can be rotated")
+	flags.StringVar(&gossipSealing, "gossip-sealing", "", "Sealing of the memberlist gossip state with the secret: Enforced, Permissive or Disabled")
 	flag.StringVar(&gossipProtocolSecondary, "gossip-protocol-secondary", "memberlist", "mesh/memberlist")
 	flag.StringVar(&gossipListenSecondary, "gossip-listen-secondary", fmt.Sprintf("0.0.0.0:%d", wellknownports.ProtokubeGossipMemberlist), "address:port on which to bind for gossip")
 	flags.StringVar(&gossipSecretSecondary, "gossip-secret-secondary", gossipSecret, "Secret to use to secure gossip")
+	flags.StringArrayVar(&gossipAdditionalSecretsSecondary, "gossip-additional-secrets-secondary", nil, "Additional secrets to accept on the secondary gossip, so that the gossip secret can be rotated")
+	flags.StringVar(&gossipSealingSecondary, "gossip-sealing-secondary", "", "Sealing of the secondary memberlist gossip state with the secret: Enforced, Permissive or Disabled")
+	flag.StringVar(&gossipStatusListen, "gossip-status-listen", fmt.Sprintf("127.0.0.1:%d", wellknownports.ProtokubeGossipStatus), "address:port on which to serve the gossip status, or empty to disable")
+	flag.StringVar(&kopsControllerCAFile, "kops-controller-ca-file", "/srv/kubernetes/ca.crt", "CA certificate to verify kops-controller with, when discovering hosts from kops-controller")
 	flags.StringSliceVarP(&zones, "zone", "z", []string{}, "Configure permitted zones and their mappings")
 
 	bootstrapMasterNodeLabels := false
@@ -193,14 +216,29 @@ func run() error {
 		}
 
 		channelName := "dns"
-		gossipState, err := gossiputils.GetGossipState(gossipProtocol, gossipListen, channelName, gossipName, []byte(gossipSecret), gossipSeeds)
-		if err != nil {
-			klog.Errorf("error initializing gossip: %v", err)
-			os.Exit(1)
+		var gossipState gossiputils.GossipState
+		if gossipProtocol == kopscontroller.Protocol {
+			// The hosts are discovered from kops-controller, instead of by gossiping with the other instances
+			caCertificates, err := os.ReadFile(path.Join(rootfs, kopsControllerCAFile))
+			if err != nil {
+				klog.Errorf("error reading kops-controller CA certificate: %v", err)
+				os.Exit(1)
+			}
+			gossipState, err = kopscontroller.NewKopsControllerState(gossipName, clusterID, caCertificates, gossipSeeds)
+			if err != nil {
+				klog.Errorf("error initializing kops-controller discovery: %v", err)
+				os.Exit(1)
+			}
+		} else {
+			gossipState, err = gossiputils.GetGossipState(gossipProtocol, gossipListen, channelName, gossipName, gossiputils.NewKeyring(gossipSecret, gossipAdditionalSecrets, gossipSealing), gossipSeeds)
+			if err != nil {
+				klog.Errorf("error initializing gossip: %v", err)
+				os.Exit(1)
+			}
 		}
 
 		if gossipProtocolSecondary != "" {
-			secondaryGossipState, err := gossiputils.GetGossipState(gossipProtocolSecondary, gossipListenSecondary, channelName, gossipName, []byte(gossipSecretSecondary), gossipSeeds)
+			secondaryGossipState, err := gossiputils.GetGossipState(gossipProtocolSecondary, gossipListenSecondary, channelName, gossipName, gossiputils.NewKeyring(gossipSecretSecondary, gossipAdditionalSecretsSecondary, gossipSealingSecondary), gossipSeeds)
 			if err != nil {
 				klog.Errorf("error initializing secondary gossip: %v", err)
 				os.Exit(1)
@@ -232,6 +270,15 @@ func run() error {
 			gossipdns.RunDNSUpdates(dnsTarget, dnsView)
 			klog.Fatalf("RunDNSUpdates exited unexpectedly")
 		}()
+
+		if gossipStatusListen != "" {
+			go func() {
+				mux := http.NewServeMux()
+				mux.Handle(gossipdns.StatusPath, gossipdns.NewStatusHandler(gossipState, dnsView))
+				err := http.ListenAndServe(gossipStatusListen, mux)
+				klog.Errorf("gossip status server exited: %v", err)
+			}()
+		}
 	}
 
 	var channels []string
@@ -253,3 +300,27 @@ func run() error {
 
 	return fmt.Errorf("Unexpected exit")
 }
+
+// runGossipStatus prints the status of the gossip cluster and DNS records, as served by a running protokube
+func runGossipStatus(args []string) error {
+	statusFlags := pflag.NewFlagSet("gossip status", pflag.ContinueOnError)
+	address := statusFlags.String("address", fmt.Sprintf("127.0.0.1:%d", wellknownports.ProtokubeGossipStatus), "address:port of the protokube gossip status endpoint")
+	if err := statusFlags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	status, err := gossipdns.GetStatus(ctx, *address)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error formatting gossip status: %w", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -189,9 +189,9 @@ func buildTagKey(zone DNSZoneInfo, record *DNSRecord) (string, error) {
 }
 
 type DNSRecord struct {
-	Name    string
-	Rrdatas []string
-	RrsType string
+	Name    string   `json:"name"`
+	Rrdatas []string `json:"rrdatas"`
+	RrsType string   `json:"type"`
 }
 
 type DNSZoneInfo struct {
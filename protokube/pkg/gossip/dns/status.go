@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/protokube/pkg/gossip"
+)
+
+// StatusPath is the path of the gossip status endpoint
+const StatusPath = "/gossip/status"
+
+// Status is the response of the gossip status endpoint
+type Status struct {
+	// Gossip is the membership of the gossip cluster
+	Gossip *gossip.GossipStatus `json:"gossip"`
+	// DNS is the DNS view built from the gossip state
+	DNS DNSStatus `json:"dns"`
+}
+
+// DNSStatus describes a DNSViewSnapshot
+type DNSStatus struct {
+	// Version is the version of the gossip state the snapshot was built from
+	Version uint64 `json:"version"`
+	// Zones are the zones in the snapshot, with their records
+	Zones []DNSZoneStatus `json:"zones"`
+}
+
+// DNSZoneStatus describes a zone in a DNSViewSnapshot
+type DNSZoneStatus struct {
+	Name    string      `json:"name"`
+	Records []DNSRecord `json:"records"`
+}
+
+// Status describes the snapshot, with zones and records in a stable order
+func (s *DNSViewSnapshot) Status() DNSStatus {
+	status := DNSStatus{
+		Version: s.version,
+	}
+	for _, zone := range s.ListZones() {
+		records := s.RecordsForZone(zone)
+		for i := range records {
+			// The snapshot is shared, so we sort a copy of the values
+			rrdatas := append([]string(nil), records[i].Rrdatas...)
+			sort.Strings(rrdatas)
+			records[i].Rrdatas = rrdatas
+		}
+		sort.Slice(records, func(i, j int) bool {
+			if records[i].Name != records[j].Name {
+				return records[i].Name < records[j].Name
+			}
+			return records[i].RrsType < records[j].RrsType
+		})
+		status.Zones = append(status.Zones, DNSZoneStatus{
+			Name:    zone.Name,
+			Records: records,
+		})
+	}
+	sort.Slice(status.Zones, func(i, j int) bool {
+		return status.Zones[i].Name < status.Zones[j].Name
+	})
+	return status
+}
+
+// NewStatusHandler serves the Status of the gossip state and the DNS view built from it
+func NewStatusHandler(gossipState gossip.GossipState, view *DNSView) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := &Status{
+			Gossip: gossipState.Status(),
+			DNS:    view.Snapshot().Status(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			klog.Warningf("error writing gossip status: %v", err)
+		}
+	})
+}
+
+// GetStatus queries the gossip status endpoint at the address
+func GetStatus(ctx context.Context, address string) (*Status, error) {
+	url := "http://" + address + StatusPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request for %q: %w", url, err)
+	}
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying %q: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from %q: %s", url, response.Status)
+	}
+
+	status := &Status{}
+	if err := json.NewDecoder(response.Body).Decode(status); err != nil {
+		return nil, fmt.Errorf("parsing response from %q: %w", url, err)
+	}
+	return status, nil
+}
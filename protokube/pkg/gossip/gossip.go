@@ -30,6 +30,8 @@ type GossipState interface {
 	Snapshot() *GossipStateSnapshot
 	UpdateValues(removeKeys []string, putKeys map[string]string) error
 	Start() error
+	// Status returns the membership of the gossip cluster, for debugging
+	Status() *GossipStatus
 }
 
 // MultiGossipState enables ramping between gossip mechanisms. This will replicaet
@@ -50,6 +52,12 @@ func (m *MultiGossipState) UpdateValues(removeKeys []string, putKeys map[string]
 	return err
 }
 
+func (m *MultiGossipState) Status() *GossipStatus {
+	status := m.Primary.Status()
+	status.Secondary = m.Secondary.Status()
+	return status
+}
+
 func (m *MultiGossipState) Start() error {
 	errCh := make(chan error, 2)
 
@@ -64,7 +72,7 @@ func (m *MultiGossipState) Start() error {
 	return <-errCh
 }
 
-type newGossipFunc func(listen, channelName, gossipName string, gossipSecret []byte, gossipSeeds SeedProvider) (GossipState, error)
+type newGossipFunc func(listen, channelName, gossipName string, gossipSecrets *Keyring, gossipSeeds SeedProvider) (GossipState, error)
 
 var (
 	gossipMap      = make(map[string]newGossipFunc)
@@ -81,7 +89,7 @@ func Register(name string, f newGossipFunc) {
 	gossipMap[name] = f
 }
 
-func GetGossipState(protocol, listen, channelName, gossipName string, gossipSecret []byte, gossipSeeds SeedProvider) (GossipState, error) {
+func GetGossipState(protocol, listen, channelName, gossipName string, gossipSecrets *Keyring, gossipSeeds SeedProvider) (GossipState, error) {
 	gossipMapMutex.Lock()
 	f, ok := gossipMap[protocol]
 	gossipMapMutex.Unlock()
@@ -89,5 +97,5 @@ func GetGossipState(protocol, listen, channelName, gossipName string, gossipSecr
 		return nil, fmt.Errorf("Unknown gossip protocol: %s", protocol)
 	}
 
-	return f(listen, channelName, gossipName, gossipSecret, gossipSeeds)
+	return f(listen, channelName, gossipName, gossipSecrets, gossipSeeds)
 }
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gossip
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// sealedPrefix marks gossip data that was sealed with a secret from the Keyring
+var sealedPrefix = []byte("kops-gossip-sealed-v1:")
+
+// Sealing controls whether data is sealed with the secret, so that instances that do not seal data can be replaced
+// without partitioning the cluster.
+type Sealing string
+
+const (
+	// SealingEnforced seals the data we send, and only accepts sealed data
+	SealingEnforced Sealing = "Enforced"
+	// SealingPermissive seals the data we send, but also accepts data that was not sealed
+	SealingPermissive Sealing = "Permissive"
+	// SealingDisabled does not seal the data we send, but accepts sealed data
+	SealingDisabled Sealing = "Disabled"
+)
+
+// Keyring holds the shared secrets that secure gossip.
+// Data we send is secured with the Primary secret, but data secured with any of the secrets is accepted,
+// so that the secret can be rotated without partitioning the cluster.
+type Keyring struct {
+	// Primary is the secret used to secure the data we send
+	Primary []byte
+	// Additional are secrets that are only accepted on the data we receive
+	Additional [][]byte
+	// Sealing controls whether we seal the data we send, and accept data that was not sealed
+	Sealing Sealing
+}
+
+// NewKeyring builds a Keyring from the primary secret, the additional accepted secrets and the sealing mode,
+// which defaults to SealingPermissive
+func NewKeyring(primary string, additional []string, sealing string) *Keyring {
+	k := &Keyring{
+		Primary: []byte(primary),
+		Sealing: Sealing(sealing),
+	}
+	if k.Sealing == "" {
+		// TODO: Default to SealingEnforced once all supported kOps versions seal the data
+		k.Sealing = SealingPermissive
+	}
+	for _, secret := range additional {
+		if secret == "" || secret == primary {
+			continue
+		}
+		k.Additional = append(k.Additional, []byte(secret))
+	}
+	return k
+}
+
+// IsSecure returns true if the keyring has a secret to secure data with
+func (k *Keyring) IsSecure() bool {
+	return k != nil && len(k.Primary) != 0
+}
+
+// Seal encrypts and authenticates data with the primary secret.
+// If there is no primary secret, or sealing is disabled, the data is returned unchanged.
+func (k *Keyring) Seal(data []byte) ([]byte, error) {
+	if !k.IsSecure() || k.Sealing == SealingDisabled {
+		return data, nil
+	}
+
+	aead, err := newAEAD(k.Primary)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	sealed := make([]byte, 0, len(sealedPrefix)+len(nonce)+len(data)+aead.Overhead())
+	sealed = append(sealed, sealedPrefix...)
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, data, sealedPrefix), nil
+}
+
+// Open verifies and decrypts data sealed with any of the secrets.
+// If there is no primary secret, or sealing is not enforced, data that was not sealed is returned unchanged.
+func (k *Keyring) Open(sealed []byte) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, sealedPrefix) {
+		if k.IsSecure() && k.Sealing != SealingPermissive && k.Sealing != SealingDisabled {
+			return nil, fmt.Errorf("rejecting gossip data that was not sealed with a secret")
+		}
+		return sealed, nil
+	}
+	if k == nil {
+		return nil, fmt.Errorf("cannot open sealed gossip data without a secret")
+	}
+
+	secrets := append([][]byte{k.Primary}, k.Additional...)
+	for _, secret := range secrets {
+		if len(secret) == 0 {
+			continue
+		}
+		aead, err := newAEAD(secret)
+		if err != nil {
+			return nil, err
+		}
+		b := sealed[len(sealedPrefix):]
+		if len(b) < aead.NonceSize() {
+			return nil, fmt.Errorf("sealed gossip data is truncated")
+		}
+		data, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], sealedPrefix)
+		if err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("gossip data was not sealed with any of our secrets")
+}
+
+// newAEAD builds the cipher for a secret; AES-256 needs a 32 byte key, so we use the hash of the secret
+func newAEAD(secret []byte) (cipher.AEAD, error) {
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("error building cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
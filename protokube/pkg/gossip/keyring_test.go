@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gossip
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	data := []byte("dns/local/A/api.internal.example.k8s.local")
+
+	enforced := string(SealingEnforced)
+	old := NewKeyring("old", nil, enforced)
+	// During the rotation, nodes send with the new secret but still accept the old one
+	rotating := NewKeyring("new", []string{"old"}, enforced)
+	rotated := NewKeyring("new", nil, enforced)
+	other := NewKeyring("other", nil, enforced)
+
+	sealedOld, err := old.Seal(data)
+	if err != nil {
+		t.Fatalf("unexpected error sealing: %v", err)
+	}
+	if bytes.Contains(sealedOld, data) {
+		t.Errorf("sealed data contains the plaintext")
+	}
+	sealedNew, err := rotating.Seal(data)
+	if err != nil {
+		t.Fatalf("unexpected error sealing: %v", err)
+	}
+
+	grid := []struct {
+		name    string
+		keyring *Keyring
+		sealed  []byte
+		valid   bool
+	}{
+		{name: "rotating node accepts old secret", keyring: rotating, sealed: sealedOld, valid: true},
+		{name: "rotating node accepts new secret", keyring: rotating, sealed: sealedNew, valid: true},
+		{name: "rotated node accepts new secret", keyring: rotated, sealed: sealedNew, valid: true},
+		{name: "rotated node rejects old secret", keyring: rotated, sealed: sealedOld},
+		{name: "other secret is rejected", keyring: other, sealed: sealedNew},
+		{name: "unsealed data is rejected", keyring: rotating, sealed: data},
+		{name: "node without secret rejects sealed data", keyring: NewKeyring("", nil, ""), sealed: sealedNew},
+		{name: "node without secret accepts unsealed data", keyring: NewKeyring("", nil, ""), sealed: data, valid: true},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			opened, err := g.keyring.Open(g.sealed)
+			if !g.valid {
+				if err == nil {
+					t.Errorf("expected error opening data")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error opening data: %v", err)
+			}
+			if !bytes.Equal(opened, data) {
+				t.Errorf("unexpected data %q, expected %q", opened, data)
+			}
+		})
+	}
+}
+
+func TestKeyringTamperedData(t *testing.T) {
+	k := NewKeyring("secret", nil, "")
+	sealed, err := k.Seal([]byte("value"))
+	if err != nil {
+		t.Fatalf("unexpected error sealing: %v", err)
+	}
+	sealed[len(sealed)-1] ^= 0xff
+	if _, err := k.Open(sealed); err == nil {
+		t.Errorf("expected error opening tampered data")
+	}
+}
+
+func TestKeyringSealingTransition(t *testing.T) {
+	data := []byte("dns/local/A/api.internal.example.k8s.local")
+
+	// Nodes of kOps versions that do not seal the data send it as is, and do not open sealed data
+	unsealed := data
+	disabled := NewKeyring("secret", nil, string(SealingDisabled))
+	permissive := NewKeyring("secret", nil, string(SealingPermissive))
+	enforced := NewKeyring("secret", nil, string(SealingEnforced))
+	defaulted := NewKeyring("secret", nil, "")
+
+	sealedDisabled, err := disabled.Seal(data)
+	if err != nil {
+		t.Fatalf("unexpected error sealing: %v", err)
+	}
+	if !bytes.Equal(sealedDisabled, data) {
+		t.Errorf("data was sealed with sealing disabled")
+	}
+	sealedPermissive, err := permissive.Seal(data)
+	if err != nil {
+		t.Fatalf("unexpected error sealing: %v", err)
+	}
+	if bytes.Equal(sealedPermissive, data) {
+		t.Errorf("data was not sealed with permissive sealing")
+	}
+
+	grid := []struct {
+		name    string
+		keyring *Keyring
+		sealed  []byte
+		valid   bool
+	}{
+		{name: "disabled accepts unsealed data", keyring: disabled, sealed: unsealed, valid: true},
+		{name: "disabled accepts sealed data", keyring: disabled, sealed: sealedPermissive, valid: true},
+		{name: "permissive accepts unsealed data", keyring: permissive, sealed: sealedDisabled, valid: true},
+		{name: "permissive accepts sealed data", keyring: permissive, sealed: sealedPermissive, valid: true},
+		{name: "enforced accepts sealed data", keyring: enforced, sealed: sealedPermissive, valid: true},
+		{name: "enforced rejects unsealed data", keyring: enforced, sealed: sealedDisabled},
+		{name: "default accepts sealed data", keyring: defaulted, sealed: sealedPermissive, valid: true},
+		{name: "default accepts unsealed data", keyring: defaulted, sealed: sealedDisabled, valid: true},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			opened, err := g.keyring.Open(g.sealed)
+			if !g.valid {
+				if err == nil {
+					t.Errorf("expected error opening data")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error opening data: %v", err)
+			}
+			if !bytes.Equal(opened, data) {
+				t.Errorf("unexpected data %q, expected %q", opened, data)
+			}
+		})
+	}
+}
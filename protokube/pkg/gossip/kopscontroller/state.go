@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kopscontroller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/wellknownports"
+	"k8s.io/kops/protokube/pkg/gossip"
+	gossipdns "k8s.io/kops/protokube/pkg/gossip/dns"
+)
+
+// Protocol is the name of the gossip protocol that discovers the hosts from kops-controller instead of gossiping
+const Protocol = "kops-controller"
+
+const (
+	// watchTimeout is how long we ask kops-controller to wait for the hosts to change
+	watchTimeout = 5 * time.Minute
+	// dialTimeout is how long we wait to connect, so that unreachable seeds are skipped quickly
+	dialTimeout = 10 * time.Second
+	// retryInterval is how long we wait after failing to reach any kops-controller
+	retryInterval = 10 * time.Second
+)
+
+// KopsControllerState implements gossip.GossipState by watching the hosts served by kops-controller.
+// The state is read-only: kops-controller is the source of truth for the hosts.
+type KopsControllerState struct {
+	name        string
+	clusterName string
+	httpClient  *http.Client
+	seeds       *gossip.SeedRecorder
+
+	mutex           sync.Mutex
+	snapshot        *gossip.GossipStateSnapshot
+	resourceVersion string
+	// server is the kops-controller we last watched, and lastError the error from watching it
+	server    string
+	lastError string
+}
+
+var _ gossip.GossipState = &KopsControllerState{}
+
+// NewKopsControllerState builds a KopsControllerState.
+// kops-controller is found through its internal hostname, or else by trying the seeds,
+// and its serving certificate is verified against caCertificates.
+func NewKopsControllerState(name, clusterName string, caCertificates []byte, seeds gossip.SeedProvider) (*KopsControllerState, error) {
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCertificates) {
+		return nil, fmt.Errorf("no CA certificates found for kops-controller")
+	}
+
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: dialTimeout,
+		}).DialContext,
+		TLSClientConfig: &tls.Config{
+			RootCAs:    certPool,
+			MinVersion: tls.VersionTLS12,
+			// We might connect to a seed by IP address, so we always verify the internal hostname
+			ServerName: "kops-controller.internal." + clusterName,
+		},
+		Proxy: nil,
+	}
+
+	s := &KopsControllerState{
+		name:        name,
+		clusterName: clusterName,
+		httpClient: &http.Client{
+			Timeout:   watchTimeout + time.Minute,
+			Transport: transport,
+		},
+		snapshot: &gossip.GossipStateSnapshot{
+			Values: make(map[string]string),
+		},
+	}
+	if seeds != nil {
+		s.seeds = gossip.NewSeedRecorder(seeds)
+	}
+	return s, nil
+}
+
+// Snapshot implements gossip.GossipState
+func (s *KopsControllerState) Snapshot() *gossip.GossipStateSnapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.snapshot
+}
+
+// UpdateValues implements gossip.GossipState; the hosts can only be changed through kops-controller
+func (s *KopsControllerState) UpdateValues(removeKeys []string, putKeys map[string]string) error {
+	return fmt.Errorf("the hosts discovered from kops-controller are read-only")
+}
+
+// Start implements gossip.GossipState, watching kops-controller for changes to the hosts
+func (s *KopsControllerState) Start() error {
+	ctx := context.Background()
+
+	for {
+		for _, server := range s.servers() {
+			for {
+				err := s.watch(ctx, server)
+				s.recordResult(server, err)
+				if err != nil {
+					klog.Warningf("error watching hosts from kops-controller at %s: %v", server, err)
+					break
+				}
+			}
+		}
+
+		time.Sleep(retryInterval)
+	}
+}
+
+// servers returns the addresses at which we can try to reach kops-controller
+func (s *KopsControllerState) servers() []string {
+	servers := []string{"kops-controller.internal." + s.clusterName}
+
+	if s.seeds == nil {
+		return servers
+	}
+	seeds, err := s.seeds.GetSeeds()
+	if err != nil {
+		klog.Warningf("error finding seeds for kops-controller: %v", err)
+	}
+	for _, seed := range seeds {
+		// The seeds might include a port, which is for gossip
+		if host, _, err := net.SplitHostPort(seed); err == nil {
+			seed = host
+		}
+		servers = append(servers, seed)
+	}
+	return servers
+}
+
+// watch waits for the hosts served by kops-controller to change, and updates the snapshot
+func (s *KopsControllerState) watch(ctx context.Context, server string) error {
+	s.mutex.Lock()
+	resourceVersion := s.resourceVersion
+	s.mutex.Unlock()
+
+	u := url.URL{
+		Scheme: "https",
+		Host:   net.JoinHostPort(server, strconv.Itoa(wellknownports.KopsControllerPort)),
+		Path:   nodeup.DiscoveryHostsPath,
+	}
+	if resourceVersion != "" {
+		query := url.Values{}
+		query.Set("resourceVersion", resourceVersion)
+		query.Set("timeoutSeconds", strconv.Itoa(int(watchTimeout.Seconds())))
+		u.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	response := &nodeup.DiscoveryHostsResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+	if response.APIVersion != nodeup.DiscoveryAPIVersion {
+		return fmt.Errorf("unexpected apiVersion %q", response.APIVersion)
+	}
+
+	s.update(response)
+	return nil
+}
+
+// update replaces the snapshot with the hosts from kops-controller, as records in the gossip DNS format
+func (s *KopsControllerState) update(response *nodeup.DiscoveryHostsResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if response.ResourceVersion == s.resourceVersion {
+		return
+	}
+
+	values := make(map[string]string)
+	for _, host := range response.Hosts {
+		var ipv4, ipv6 []string
+		for _, address := range host.Addresses {
+			ip := net.ParseIP(address)
+			if ip == nil {
+				klog.Warningf("ignoring invalid address %q for %q", address, host.Name)
+				continue
+			}
+			if ip.To4() != nil {
+				ipv4 = append(ipv4, address)
+			} else {
+				ipv6 = append(ipv6, address)
+			}
+		}
+		if len(ipv4) != 0 {
+			values["dns/"+gossipdns.DefaultZoneName+"/A/"+host.Name] = strings.Join(ipv4, ",")
+		}
+		if len(ipv6) != 0 {
+			values["dns/"+gossipdns.DefaultZoneName+"/AAAA/"+host.Name] = strings.Join(ipv6, ",")
+		}
+	}
+
+	klog.Infof("discovered hosts from kops-controller at version %s", response.ResourceVersion)
+	s.resourceVersion = response.ResourceVersion
+	s.snapshot = &gossip.GossipStateSnapshot{
+		Values:  values,
+		Version: s.snapshot.Version + 1,
+	}
+}
+
+func (s *KopsControllerState) recordResult(server string, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.server = server
+	if err != nil {
+		s.lastError = err.Error()
+	} else {
+		s.lastError = ""
+	}
+}
+
+// Status implements gossip.GossipState, reporting the kops-controller we are watching as the only member
+func (s *KopsControllerState) Status() *gossip.GossipStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	status := &gossip.GossipStatus{
+		Protocol: Protocol,
+		Self:     s.name,
+		Secure:   true,
+	}
+	if s.seeds != nil {
+		status.Seeds = s.seeds.Status()
+	}
+	if s.server != "" {
+		state := "watching"
+		if s.lastError != "" {
+			state = "error: " + s.lastError
+		}
+		status.Members = append(status.Members, gossip.GossipMember{
+			Name:    "kops-controller",
+			Address: s.server,
+			State:   state,
+		})
+	}
+	return status
+}
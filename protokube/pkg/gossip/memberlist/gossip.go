@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/memberlist"
 	cluster "github.com/jacksontj/memberlistmesh"
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/klog/v2"
@@ -31,21 +32,21 @@ import (
 )
 
 func init() {
-	gossip.Register("memberlist", func(listen, channelName, gossipName string, gossipSecret []byte, gossipSeeds gossip.SeedProvider) (gossip.GossipState, error) {
-		return NewMemberlistGossiper(listen, channelName, gossipName, gossipSecret, gossipSeeds)
+	gossip.Register("memberlist", func(listen, channelName, gossipName string, gossipSecrets *gossip.Keyring, gossipSeeds gossip.SeedProvider) (gossip.GossipState, error) {
+		return NewMemberlistGossiper(listen, channelName, gossipName, gossipSecrets, gossipSeeds)
 	})
 }
 
 type MemberlistGossiper struct {
 	peer       *cluster.Peer
-	seeds      gossip.SeedProvider
+	seeds      *gossip.SeedRecorder
 	listenPort int
 
 	state *state
 	bcast func([]byte)
 }
 
-func NewMemberlistGossiper(listen string, channelName string, nodeName string, password []byte, seeds gossip.SeedProvider) (*MemberlistGossiper, error) {
+func NewMemberlistGossiper(listen string, channelName string, nodeName string, secrets *gossip.Keyring, seedProvider gossip.SeedProvider) (*MemberlistGossiper, error) {
 	_, portString, err := net.SplitHostPort(listen)
 	if err != nil {
 		return nil, fmt.Errorf("cannot parse -listen flag: %v", listen)
@@ -55,6 +56,7 @@ func NewMemberlistGossiper(listen string, channelName string, nodeName string, p
 		return nil, fmt.Errorf("cannot parse -listen flag: %v", listen)
 	}
 
+	seeds := gossip.NewSeedRecorder(seedProvider)
 	initialPeers, err := seeds.GetSeeds()
 	if err != nil {
 		return nil, err
@@ -82,7 +84,8 @@ func NewMemberlistGossiper(listen string, channelName string, nodeName string, p
 		return nil, err
 	}
 
-	s := &state{}
+	// memberlist does not let us configure its encryption, so we seal the state we gossip instead
+	s := &state{keyring: secrets}
 
 	return &MemberlistGossiper{
 		peer:       peer,
@@ -157,3 +160,35 @@ func (g *MemberlistGossiper) UpdateValues(removeKeys []string, putKeys map[strin
 	g.bcast(b)
 	return nil
 }
+
+func (g *MemberlistGossiper) Status() *gossip.GossipStatus {
+	status := &gossip.GossipStatus{
+		Protocol: "memberlist",
+		Self:     g.peer.Name(),
+		Secure:   g.state.keyring.IsSecure(),
+		Seeds:    g.seeds.Status(),
+	}
+	for _, node := range g.peer.Peers() {
+		status.Members = append(status.Members, gossip.GossipMember{
+			Name:    node.Name,
+			Address: node.Address(),
+			State:   nodeState(node.State),
+		})
+	}
+	return status
+}
+
+func nodeState(state memberlist.NodeStateType) string {
+	switch state {
+	case memberlist.StateAlive:
+		return "alive"
+	case memberlist.StateSuspect:
+		return "suspect"
+	case memberlist.StateDead:
+		return "dead"
+	case memberlist.StateLeft:
+		return "left"
+	default:
+		return fmt.Sprintf("unknown(%d)", state)
+	}
+}
@@ -30,6 +30,9 @@ type state struct {
 	mtx  sync.RWMutex
 	data mesh.KVState
 
+	// keyring seals the state we send, and opens the state we receive
+	keyring *gossip.Keyring
+
 	lastSnapshot *gossip.GossipStateSnapshot
 	version      uint64
 }
@@ -38,13 +41,22 @@ func (s *state) MarshalBinary() ([]byte, error) {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
 	klog.V(4).Infof("Gossip => %v", s.data)
-	return proto.Marshal(&s.data)
+	b, err := proto.Marshal(&s.data)
+	if err != nil {
+		return nil, err
+	}
+	return s.keyring.Seal(b)
 }
 
 func (s *state) Merge(b []byte) error {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
+	b, err := s.keyring.Open(b)
+	if err != nil {
+		return err
+	}
+
 	var other mesh.KVState
 	if err := proto.Unmarshal(b, &other); err != nil {
 		return err
@@ -29,13 +29,13 @@ import (
 )
 
 func init() {
-	gossip.Register("mesh", func(listen, channelName, gossipName string, gossipSecret []byte, gossipSeeds gossip.SeedProvider) (gossip.GossipState, error) {
-		return NewMeshGossiper(listen, channelName, gossipName, gossipSecret, gossipSeeds)
+	gossip.Register("mesh", func(listen, channelName, gossipName string, gossipSecrets *gossip.Keyring, gossipSeeds gossip.SeedProvider) (gossip.GossipState, error) {
+		return NewMeshGossiper(listen, channelName, gossipName, gossipSecrets, gossipSeeds)
 	})
 }
 
 type MeshGossiper struct {
-	seeds gossip.SeedProvider
+	seeds *gossip.SeedRecorder
 
 	router *mesh.Router
 	peer   *peer
@@ -43,7 +43,7 @@ type MeshGossiper struct {
 	// version uint64
 }
 
-func NewMeshGossiper(listen string, channelName string, nodeName string, password []byte, seeds gossip.SeedProvider) (*MeshGossiper, error) {
+func NewMeshGossiper(listen string, channelName string, nodeName string, secrets *gossip.Keyring, seeds gossip.SeedProvider) (*MeshGossiper, error) {
 	connLimit := 0 // 0 means no limit
 	gossipDnsConnLimit := os.Getenv("GOSSIP_DNS_CONN_LIMIT")
 	if gossipDnsConnLimit != "" {
@@ -58,6 +58,17 @@ func NewMeshGossiper(listen string, channelName string, nodeName string, passwor
 
 	klog.Infof("gossip dns connection limit is:%d", connLimit)
 
+	seedRecorder := gossip.NewSeedRecorder(seeds)
+
+	// mesh secures connections with a single password, so it cannot accept additional secrets
+	var password []byte
+	if secrets != nil {
+		password = secrets.Primary
+		if len(secrets.Additional) != 0 {
+			klog.Warningf("mesh gossip does not support additional secrets; use memberlist gossip to rotate the gossip secret")
+		}
+	}
+
 	meshConfig := mesh.Config{
 		ProtocolMinVersion: mesh.ProtocolMinVersion,
 		Password:           password,
@@ -99,7 +110,7 @@ func NewMeshGossiper(listen string, channelName string, nodeName string, passwor
 	peer.register(gossip)
 
 	gossiper := &MeshGossiper{
-		seeds:  seeds,
+		seeds:  seedRecorder,
 		router: router,
 		peer:   peer,
 	}
@@ -162,3 +173,41 @@ func (g *MeshGossiper) UpdateValues(removeKeys []string, putEntries map[string]s
 	klog.V(2).Infof("UpdateValues: remove=%s, put=%s", removeKeys, putEntries)
 	return g.peer.updateValues(removeKeys, putEntries)
 }
+
+func (g *MeshGossiper) Status() *gossip.GossipStatus {
+	status := mesh.NewStatus(g.router)
+
+	// We only know the addresses of the peers we are connected to
+	addresses := make(map[string]string)
+	for _, peer := range status.Peers {
+		if peer.Name != status.Name {
+			continue
+		}
+		for _, connection := range peer.Connections {
+			if connection.Established {
+				addresses[connection.Name] = connection.Address
+			}
+		}
+	}
+
+	s := &gossip.GossipStatus{
+		Protocol: "mesh",
+		Self:     status.NickName,
+		Secure:   status.Encryption,
+		Seeds:    g.seeds.Status(),
+	}
+	for _, peer := range status.Peers {
+		member := gossip.GossipMember{
+			Name:    peer.NickName,
+			Address: addresses[peer.Name],
+			State:   "known",
+		}
+		if peer.Name == status.Name {
+			member.State = "self"
+		} else if member.Address != "" {
+			member.State = "connected"
+		}
+		s.Members = append(s.Members, member)
+	}
+	return s
+}
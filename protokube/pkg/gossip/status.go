@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gossip
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// GossipStatus describes the membership of a gossip node, for debugging
+type GossipStatus struct {
+	// Protocol is the gossip protocol (mesh or memberlist)
+	Protocol string `json:"protocol"`
+	// Self is the name of this member
+	Self string `json:"self"`
+	// Secure is true if the gossip is secured with a secret
+	Secure bool `json:"secure"`
+	// Members are the members of the gossip cluster that we know of, including ourselves
+	Members []GossipMember `json:"members"`
+	// Seeds describes where we found the other members
+	Seeds SeedStatus `json:"seeds"`
+	// Secondary is the status of the secondary gossip, when gossip is being migrated between protocols
+	Secondary *GossipStatus `json:"secondary,omitempty"`
+}
+
+// GossipMember describes a member of the gossip cluster
+type GossipMember struct {
+	// Name is the name of the member, usually the instance id
+	Name string `json:"name"`
+	// Address is the address of the member, if known
+	Address string `json:"address,omitempty"`
+	// State is the state of the member as seen by us, for example alive or connected
+	State string `json:"state"`
+}
+
+// SeedStatus describes the results of the last query of a SeedProvider
+type SeedStatus struct {
+	// Provider is the type of the SeedProvider
+	Provider string `json:"provider"`
+	// Seeds are the seeds last returned by the provider
+	Seeds []string `json:"seeds,omitempty"`
+	// Error is the error from the last query, if it failed
+	Error string `json:"error,omitempty"`
+	// LastQueried is when the provider was last queried
+	LastQueried *time.Time `json:"lastQueried,omitempty"`
+}
+
+// SeedRecorder wraps a SeedProvider, recording the results of the last query for the GossipStatus
+type SeedRecorder struct {
+	provider SeedProvider
+
+	mutex  sync.Mutex
+	status SeedStatus
+}
+
+var _ SeedProvider = &SeedRecorder{}
+
+// NewSeedRecorder builds a SeedRecorder for the provider
+func NewSeedRecorder(provider SeedProvider) *SeedRecorder {
+	return &SeedRecorder{
+		provider: provider,
+		status: SeedStatus{
+			Provider: fmt.Sprintf("%T", provider),
+		},
+	}
+}
+
+// GetSeeds implements SeedProvider
+func (r *SeedRecorder) GetSeeds() ([]string, error) {
+	seeds, err := r.provider.GetSeeds()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	r.status.LastQueried = &now
+	if err != nil {
+		r.status.Error = err.Error()
+	} else {
+		r.status.Error = ""
+		r.status.Seeds = append([]string(nil), seeds...)
+	}
+	return seeds, err
+}
+
+// Status returns the results of the last query
+func (r *SeedRecorder) Status() SeedStatus {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.status
+}
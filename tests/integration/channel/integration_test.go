@@ -425,3 +425,24 @@ func TestChannelImages(t *testing.T) {
 		})
 	}
 }
+
+// The addon versions of each channel should parse
+func TestAddonChannels(t *testing.T) {
+	for _, channel := range []string{"stable", "alpha"} {
+		t.Run(channel+"-channel", func(t *testing.T) {
+			sourcePath := "../../../channels/" + channel + kops.AddonChannelSuffix
+			sourceBytes, err := os.ReadFile(sourcePath)
+			if err != nil {
+				t.Fatalf("unexpected error reading sourcePath %q: %v", sourcePath, err)
+			}
+
+			addonChannel := &kops.AddonChannel{}
+			if err := kops.ParseRawYaml(sourceBytes, addonChannel); err != nil {
+				t.Fatalf("failed to parse addon channel: %v", err)
+			}
+			if len(addonChannel.Spec.Addons) == 0 {
+				t.Errorf("expected addon versions in %q", sourcePath)
+			}
+		})
+	}
+}
@@ -90,9 +90,16 @@ func (r *assetResource) GetSource() *Source {
 	return r.Asset.source
 }
 
+// AssetVerifier verifies an asset after it is downloaded, before it is used.
+type AssetVerifier interface {
+	// VerifyAsset verifies the asset with the hash, downloaded from one of the urls to localFile.
+	VerifyAsset(hash *hashing.Hash, urls []string, localFile string) error
+}
+
 type AssetStore struct {
 	cacheDir string
 	assets   []*asset
+	verifier AssetVerifier
 }
 
 func NewAssetStore(cacheDir string) *AssetStore {
@@ -102,6 +109,11 @@ func NewAssetStore(cacheDir string) *AssetStore {
 	return a
 }
 
+// SetVerifier sets the verifier for the assets that are added to the store.
+func (a *AssetStore) SetVerifier(verifier AssetVerifier) {
+	a.verifier = verifier
+}
+
 func (a *AssetStore) FindMatches(expr *regexp.Regexp) map[string]Resource {
 	matches := make(map[string]Resource)
 
@@ -258,6 +270,12 @@ func (a *AssetStore) addURLs(urls []string, hash *hashing.Hash) error {
 		return err
 	}
 
+	if a.verifier != nil {
+		if err := a.verifier.VerifyAsset(hash, urls, localFile); err != nil {
+			return err
+		}
+	}
+
 	assetPath := primaryURL
 	r := NewFileResource(localFile)
 
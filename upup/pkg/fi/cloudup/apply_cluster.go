@@ -380,6 +380,19 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		}
 	}
 
+	if c.Cluster.Spec.DNSProvider != "" {
+		secret, err := secretStore.FindSecret(DNSProviderSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("could not load the %s secret: %w", DNSProviderSecretName, err)
+		}
+		if secret == nil {
+			fmt.Println("")
+			fmt.Println("You have dnsProvider set, but no dnsprovider secret has been set.")
+			fmt.Println("See `kops create secret dnsprovider -h`")
+			return nil, fmt.Errorf("could not find %s secret", DNSProviderSecretName)
+		}
+	}
+
 	fileAssets := &nodemodel.FileAssets{Cluster: cluster}
 	if err := fileAssets.AddFileAssets(assetBuilder); err != nil {
 		return nil, err
@@ -490,7 +503,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 	modelContext.Region = cloud.Region()
 
 	if cluster.PublishesDNSRecords() {
-		err = validateDNS(cluster, cloud)
+		err = validateDNS(cluster, cloud, secretStore)
 		if err != nil {
 			return nil, err
 		}
@@ -695,6 +708,11 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		return nil, fmt.Errorf("error building tasks: %v", err)
 	}
 
+	// All images have been remapped by now; refuse to use any that failed verification.
+	if err := assetBuilder.VerificationError(); err != nil {
+		return nil, err
+	}
+
 	var target fi.CloudupTarget
 	shouldPrecreateDNS := true
 
@@ -796,7 +814,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 	}
 
 	if shouldPrecreateDNS && clusterLifecycle != fi.LifecycleIgnore {
-		if err := precreateDNS(ctx, cluster, cloud); err != nil {
+		if err := precreateDNS(ctx, cluster, cloud, secretStore); err != nil {
 			klog.Warningf("unable to pre-create DNS records - cluster startup may be slower: %v", err)
 		}
 	}
@@ -328,6 +328,8 @@ func (a awsVerifier) verifyCallerIdentity(ctx context.Context, callerIdentity *G
 
 	result := &bootstrap.VerifyResult{
 		NodeName:          addrs[0],
+		InstanceID:        instanceID,
+		InstanceType:      string(instance.InstanceType),
 		CertificateNames:  addrs,
 		ChallengeEndpoint: challengeEndpoints[0],
 	}
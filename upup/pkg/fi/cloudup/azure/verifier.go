@@ -114,9 +114,13 @@ func (a azureVerifier) VerifyToken(ctx context.Context, rawRequest *http.Request
 	result := &bootstrap.VerifyResult{
 		NodeName:          nodeName,
 		InstanceGroupName: igName,
+		InstanceID:        vmId,
 		CertificateNames:  addrs,
 		ChallengeEndpoint: challengeEndpoints[0],
 	}
+	if vm.SKU != nil && vm.SKU.Name != nil {
+		result.InstanceType = *vm.SKU.Name
+	}
 
 	return result, nil
 }
@@ -22,6 +22,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	channelsapi "k8s.io/kops/channels/pkg/api"
 	"k8s.io/kops/pkg/apis/kops"
@@ -213,6 +214,19 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 		}
 	}
 
+	if err := b.buildHelmAddons(c, addons, serviceAccounts); err != nil {
+		return err
+	}
+
+	// The addons built so far are the ones that addonPatches are applied to
+	if err := b.validateAddonPatches(addons); err != nil {
+		return err
+	}
+
+	if err := b.buildKustomizeAddons(c, addons, serviceAccounts); err != nil {
+		return err
+	}
+
 	// Not all objects in ClusterAddons should be applied to the cluster - although most should.
 	// However, there are a handful of well-known exceptions:
 	// e.g. configuration objects which are instead configured via files on the nodes.
@@ -268,13 +282,18 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 			Name:      fi.PtrTo(name),
 		})
 
-		addons.Add(a)
+		addon := addons.Add(a)
+		addon.ManifestData = manifestBytes
 	}
 
 	if err := b.addPruneDirectives(addons); err != nil {
 		return err
 	}
 
+	if err := b.addDependencies(addons); err != nil {
+		return err
+	}
+
 	addonsObject := &channelsapi.Addons{}
 	addonsObject.Kind = "Addons"
 	addonsObject.ObjectMeta.Name = "bootstrap"
@@ -303,6 +322,21 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 	return nil
 }
 
+// validateAddonPatches returns an error if an addonPatches entry names an addon that is not in the bootstrap channel,
+// as the patch would otherwise be silently ignored.
+func (b *BootstrapChannelBuilder) validateAddonPatches(addons *AddonList) error {
+	names := sets.New[string]()
+	for _, addon := range addons.Items {
+		names.Insert(fi.ValueOf(addon.Spec.Name))
+	}
+	for i, patch := range b.Cluster.Spec.AddonPatches {
+		if !names.Has(patch.Addon) {
+			return fmt.Errorf("addonPatches[%d] names addon %q, which is not in the bootstrap channel (addons: %s)", i, patch.Addon, strings.Join(sets.List(names), ", "))
+		}
+	}
+	return nil
+}
+
 type AddonList struct {
 	Items []*Addon
 }
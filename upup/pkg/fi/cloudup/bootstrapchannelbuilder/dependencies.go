@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapchannelbuilder
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+// pkiRequiredCRDs are the cert-manager CRDs used by addons with NeedsPKI:
+// channels creates an Issuer for the addon, and the addon requests Certificates from it.
+var pkiRequiredCRDs = []string{
+	"certificates.cert-manager.io",
+	"issuers.cert-manager.io",
+}
+
+// addDependencies makes each addon depend on the addons that define the CRDs of its objects,
+// so that channels applies them first and waits for the CRDs (and any webhooks) to be ready.
+func (b *BootstrapChannelBuilder) addDependencies(addons *AddonList) error {
+	objectsByAddon := make(map[*Addon]kubemanifest.ObjectList)
+	crdOwners := make(map[schema.GroupKind]string)
+	for _, addon := range addons.Items {
+		objects, err := kubemanifest.LoadObjectsFrom(addon.ManifestData)
+		if err != nil {
+			return fmt.Errorf("failed to parse manifest of %s: %w", *addon.Spec.Name, err)
+		}
+		objectsByAddon[addon] = objects
+
+		for _, object := range objects {
+			if object.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+				continue
+			}
+			u := object.ToUnstructured()
+			var gk schema.GroupKind
+			gk.Group, _, _ = unstructured.NestedString(u.Object, "spec", "group")
+			gk.Kind, _, _ = unstructured.NestedString(u.Object, "spec", "names", "kind")
+			crdOwners[gk] = *addon.Spec.Name
+		}
+	}
+
+	for _, addon := range addons.Items {
+		name := *addon.Spec.Name
+
+		dependsOn := sets.New(addon.Spec.DependsOn...)
+		for _, object := range objectsByAddon[addon] {
+			owner, found := crdOwners[object.GroupVersionKind().GroupKind()]
+			if found && owner != name {
+				dependsOn.Insert(owner)
+			}
+		}
+
+		if addon.Spec.NeedsPKI {
+			requiredCRDs := sets.New(addon.Spec.RequiredCRDs...)
+			requiredCRDs.Insert(pkiRequiredCRDs...)
+			addon.Spec.RequiredCRDs = sets.List(requiredCRDs)
+		}
+
+		if dependsOn.Len() != 0 {
+			addon.Spec.DependsOn = sets.List(dependsOn)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapchannelbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/helm"
+	"k8s.io/kops/pkg/model/components/addonmanifests"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// buildHelmAddons renders the cluster's Helm chart addons and adds them to the bootstrap channel.
+func (b *BootstrapChannelBuilder) buildHelmAddons(c *fi.CloudupModelBuilderContext, addons *AddonList, serviceAccounts map[types.NamespacedName]iam.Subject) error {
+	var renderer *helm.Renderer
+	var configBase vfs.Path
+
+	for _, clusterAddon := range b.Cluster.Spec.Addons {
+		spec := clusterAddon.Helm
+		if spec == nil {
+			continue
+		}
+
+		if renderer == nil {
+			r, err := helm.NewRenderer()
+			if err != nil {
+				return err
+			}
+			renderer = r
+
+			configBase, err = registry.ConfigBase(vfs.Context, b.Cluster)
+			if err != nil {
+				return err
+			}
+		}
+
+		values, err := helm.BuildValues(c.Context(), vfs.Context, spec, configBase)
+		if err != nil {
+			return err
+		}
+
+		rendered, err := renderer.Render(c.Context(), spec, values, b.Cluster.Spec.KubernetesVersion)
+		if err != nil {
+			return fmt.Errorf("error rendering addon %q: %w", spec.Name, err)
+		}
+
+		a := &channelsapi.AddonSpec{
+			Name:        fi.PtrTo(spec.Name),
+			Selector:    map[string]string{"k8s-addon": spec.Name},
+			Manifest:    fi.PtrTo("helm/" + spec.Name + ".yaml"),
+			ChartDigest: rendered.ChartDigest,
+		}
+		if err := b.addGeneratedAddon(c, addons, a, "helm-"+spec.Name, rendered.Manifest, serviceAccounts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addGeneratedAddon adds an addon whose manifest is generated when the cluster is updated to the bootstrap channel.
+// The manifest is remapped like the manifests of the addons managed by kOps.
+func (b *BootstrapChannelBuilder) addGeneratedAddon(c *fi.CloudupModelBuilderContext, addons *AddonList, a *channelsapi.AddonSpec, key string, manifest []byte, serviceAccounts map[types.NamespacedName]iam.Subject) error {
+	name := b.Cluster.ObjectMeta.Name + "-addons-" + key
+	manifestPath := "addons/" + *a.Manifest
+
+	// Go through any transforms that are best expressed as code
+	manifestBytes, err := addonmanifests.RemapAddonManifest(a, b.KopsModelContext, b.assetBuilder, manifest, serviceAccounts)
+	if err != nil {
+		return fmt.Errorf("error remapping manifest %s: %v", manifestPath, err)
+	}
+
+	// Trim whitespace
+	manifestBytes = []byte(strings.TrimSpace(string(manifestBytes)))
+
+	rawManifest := string(manifestBytes)
+	klog.V(4).Infof("Manifest %v", rawManifest)
+
+	// The hash covers the generated output and the chart it was rendered from,
+	// so the addon is only reapplied when either changes.
+	manifestHash, err := utils.HashString(a.ChartDigest + rawManifest)
+	if err != nil {
+		return fmt.Errorf("error hashing manifest: %v", err)
+	}
+	a.ManifestHash = manifestHash
+
+	c.AddTask(&fitasks.ManagedFile{
+		Contents:  fi.NewBytesResource(manifestBytes),
+		Lifecycle: b.Lifecycle,
+		Location:  fi.PtrTo(manifestPath),
+		Name:      fi.PtrTo(name),
+	})
+
+	addon := addons.Add(a)
+	addon.ManifestData = manifestBytes
+	addon.BuildPrune = true
+
+	return nil
+}
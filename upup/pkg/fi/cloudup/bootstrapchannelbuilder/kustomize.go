@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapchannelbuilder
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/kustomize"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// buildKustomizeAddons builds the cluster's kustomize addons and adds them to the bootstrap channel.
+func (b *BootstrapChannelBuilder) buildKustomizeAddons(c *fi.CloudupModelBuilderContext, addons *AddonList, serviceAccounts map[types.NamespacedName]iam.Subject) error {
+	var builder *kustomize.Builder
+	var configBase vfs.Path
+
+	for _, clusterAddon := range b.Cluster.Spec.Addons {
+		spec := clusterAddon.Kustomize
+		if spec == nil {
+			continue
+		}
+
+		if builder == nil {
+			kb, err := kustomize.NewBuilder()
+			if err != nil {
+				return err
+			}
+			builder = kb
+
+			configBase, err = registry.ConfigBase(vfs.Context, b.Cluster)
+			if err != nil {
+				return err
+			}
+		}
+
+		source, err := b.readKustomizeSource(c, spec, configBase)
+		if err != nil {
+			return err
+		}
+
+		manifest, err := builder.Build(source, spec.Overlay)
+		if err != nil {
+			return fmt.Errorf("error building addon %q: %w", spec.Name, err)
+		}
+
+		a := &channelsapi.AddonSpec{
+			Name:     fi.PtrTo(spec.Name),
+			Selector: map[string]string{"k8s-addon": spec.Name},
+			Manifest: fi.PtrTo("kustomize/" + spec.Name + ".yaml"),
+		}
+		if err := b.addGeneratedAddon(c, addons, a, "kustomize-"+spec.Name, manifest, serviceAccounts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readKustomizeSource reads the files of a kustomize addon.
+// A local directory is uploaded to the state store, under kustomize/<name>/ in the cluster's configuration base,
+// so that the addon can still be built when the cluster is updated from a machine that does not have the directory.
+func (b *BootstrapChannelBuilder) readKustomizeSource(c *fi.CloudupModelBuilderContext, spec *kops.KustomizeAddonSpec, configBase vfs.Path) (kustomize.Source, error) {
+	ctx := c.Context()
+
+	if strings.Contains(spec.Path, "://") {
+		p, err := vfs.Context.BuildVfsPath(spec.Path)
+		if err != nil {
+			return nil, fmt.Errorf("parsing path %q of addon %q: %w", spec.Path, spec.Name, err)
+		}
+		return kustomize.ReadSource(ctx, p)
+	}
+
+	uploadLocation := "kustomize/" + spec.Name
+	if _, err := os.Stat(spec.Path); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("reading path %q of addon %q: %w", spec.Path, spec.Name, err)
+		}
+		klog.Warningf("directory %q of addon %q not found; using the copy in the state store", spec.Path, spec.Name)
+		source, err := kustomize.ReadSource(ctx, configBase.Join(uploadLocation))
+		if err != nil {
+			return nil, fmt.Errorf("directory %q of addon %q not found, and no copy has been uploaded to the state store: %w", spec.Path, spec.Name, err)
+		}
+		return source, nil
+	}
+
+	source, err := kustomize.ReadSource(ctx, vfs.NewFSPath(spec.Path))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range source.Names() {
+		c.AddTask(&fitasks.ManagedFile{
+			Contents:  fi.NewBytesResource(source[name]),
+			Lifecycle: b.Lifecycle,
+			Location:  fi.PtrTo(uploadLocation + "/" + name),
+			Name:      fi.PtrTo(b.Cluster.ObjectMeta.Name + "-kustomize-" + spec.Name + "-" + name),
+		})
+	}
+	return source, nil
+}
@@ -17,16 +17,24 @@ limitations under the License.
 package cloudup
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"os"
 	"path"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/client/simple/vfsclientset"
 	"k8s.io/kops/pkg/featureflag"
+	"k8s.io/kops/pkg/helm"
 	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/kustomize"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/pkg/templates"
@@ -55,6 +63,28 @@ func TestBootstrapChannelBuilder_BuildTasks(t *testing.T) {
 	runChannelBuilderTest(t, "metrics-server/insecure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "metrics-server/secure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "coredns", []string{"coredns.addons.k8s.io-k8s-1.12"})
+	runChannelBuilderTest(t, "addonpatches", []string{"coredns.addons.k8s.io-k8s-1.12"})
+}
+
+func TestBootstrapChannelBuilder_UnknownAddonPatch(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.SetupMockAWS()
+
+	bcb, cluster := newChannelBuilderTest(t, "addonpatches")
+	cluster.Spec.AddonPatches[0].Addon = "core-dns.addons.k8s.io"
+
+	context := &fi.CloudupModelBuilderContext{
+		Tasks: make(map[string]fi.CloudupTask),
+	}
+	err := bcb.Build(context)
+	if err == nil {
+		t.Fatalf("expected error from BootstrapChannelBuilder Build")
+	}
+	if expected := `addonPatches[0] names addon "core-dns.addons.k8s.io", which is not in the bootstrap channel`; !strings.Contains(err.Error(), expected) {
+		t.Errorf("unexpected error %q, expected %q", err, expected)
+	}
 }
 
 func TestBootstrapChannelBuilder_ServiceAccountIAM(t *testing.T) {
@@ -80,7 +110,148 @@ func TestBootstrapChannelBuilder_AWSCloudController(t *testing.T) {
 	runChannelBuilderTest(t, "awscloudcontroller", []string{"aws-cloud-controller.addons.k8s.io-k8s-1.18"})
 }
 
+func TestBootstrapChannelBuilder_Helm(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.SetupMockAWS()
+
+	// Charts are rendered from the local cache, so the test does not need network access.
+	cacheDir := t.TempDir()
+	t.Setenv(helm.CacheDirEnvVar, cacheDir)
+	writeChartArchive(t, path.Join(cacheDir, "charts.example.com", "stable", "widgets-1.2.3.tgz"), map[string]string{
+		"widgets/Chart.yaml": `apiVersion: v2
+name: widgets
+version: 1.2.3
+`,
+		"widgets/values.yaml": `replicaCount: 1
+`,
+		"widgets/templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+  selector:
+    matchLabels:
+      app: {{ .Release.Name }}
+  template:
+    metadata:
+      labels:
+        app: {{ .Release.Name }}
+    spec:
+      containers:
+      - name: widgets
+        image: registry.k8s.io/pause:3.9
+`,
+	})
+
+	runChannelBuilderTest(t, "helm", []string{"helm-widgets"})
+}
+
+func TestBootstrapChannelBuilder_Kustomize(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.SetupMockAWS()
+
+	t.Setenv(kustomize.CacheDirEnvVar, t.TempDir())
+
+	runChannelBuilderTest(t, "kustomize", []string{"kustomize-widgets"})
+}
+
+// writeChartArchive writes a packaged chart with a fixed modification time, so that its digest is stable.
+func writeChartArchive(t *testing.T, p string, files map[string]string) {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(files[name])),
+			ModTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("error writing chart: %v", err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			t.Fatalf("error writing chart: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("error writing chart: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("error writing chart: %v", err)
+	}
+
+	if err := os.MkdirAll(path.Dir(p), 0o755); err != nil {
+		t.Fatalf("error creating chart cache: %v", err)
+	}
+	if err := os.WriteFile(p, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("error writing chart: %v", err)
+	}
+}
+
 func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
+	bcb, cluster := newChannelBuilderTest(t, key)
+	basedir := path.Join("tests/bootstrapchannelbuilder/", key)
+
+	context := &fi.CloudupModelBuilderContext{
+		Tasks: make(map[string]fi.CloudupTask),
+	}
+	err := bcb.Build(context)
+	if err != nil {
+		t.Fatalf("error from BootstrapChannelBuilder Build: %v", err)
+	}
+
+	{
+		name := cluster.ObjectMeta.Name + "-addons-bootstrap"
+		manifestTask := context.Tasks["ManagedFile/"+name]
+		if manifestTask == nil {
+			t.Fatalf("manifest task not found (%q)", name)
+		}
+
+		manifestFileTask := manifestTask.(*fitasks.ManagedFile)
+		actualManifest, err := fi.ResourceAsString(manifestFileTask.Contents)
+		if err != nil {
+			t.Fatalf("error getting manifest as string: %v", err)
+		}
+
+		expectedManifestPath := path.Join(basedir, "manifest.yaml")
+		golden.AssertMatchesFile(t, actualManifest, expectedManifestPath)
+	}
+
+	for _, k := range addonManifests {
+		name := cluster.ObjectMeta.Name + "-addons-" + k
+		manifestTask := context.Tasks["ManagedFile/"+name]
+		if manifestTask == nil {
+			for k := range context.Tasks {
+				t.Logf("found task %s", k)
+			}
+			t.Fatalf("manifest task not found (%q)", name)
+		}
+
+		manifestFileTask := manifestTask.(*fitasks.ManagedFile)
+		actualManifest, err := fi.ResourceAsString(manifestFileTask.Contents)
+		if err != nil {
+			t.Fatalf("error getting manifest as string: %v", err)
+		}
+
+		expectedManifestPath := path.Join(basedir, k+".yaml")
+		golden.AssertMatchesFile(t, actualManifest, expectedManifestPath)
+	}
+}
+
+// newChannelBuilderTest builds a BootstrapChannelBuilder for the cluster of the named test
+func newChannelBuilderTest(t *testing.T, key string) (*bootstrapchannelbuilder.BootstrapChannelBuilder, *kopsapi.Cluster) {
 	ctx := context.TODO()
 
 	basedir := path.Join("tests/bootstrapchannelbuilder/", key)
@@ -167,48 +338,5 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 		nil,
 	)
 
-	context := &fi.CloudupModelBuilderContext{
-		Tasks: make(map[string]fi.CloudupTask),
-	}
-	err = bcb.Build(context)
-	if err != nil {
-		t.Fatalf("error from BootstrapChannelBuilder Build: %v", err)
-	}
-
-	{
-		name := cluster.ObjectMeta.Name + "-addons-bootstrap"
-		manifestTask := context.Tasks["ManagedFile/"+name]
-		if manifestTask == nil {
-			t.Fatalf("manifest task not found (%q)", name)
-		}
-
-		manifestFileTask := manifestTask.(*fitasks.ManagedFile)
-		actualManifest, err := fi.ResourceAsString(manifestFileTask.Contents)
-		if err != nil {
-			t.Fatalf("error getting manifest as string: %v", err)
-		}
-
-		expectedManifestPath := path.Join(basedir, "manifest.yaml")
-		golden.AssertMatchesFile(t, actualManifest, expectedManifestPath)
-	}
-
-	for _, k := range addonManifests {
-		name := cluster.ObjectMeta.Name + "-addons-" + k
-		manifestTask := context.Tasks["ManagedFile/"+name]
-		if manifestTask == nil {
-			for k := range context.Tasks {
-				t.Logf("found task %s", k)
-			}
-			t.Fatalf("manifest task not found (%q)", name)
-		}
-
-		manifestFileTask := manifestTask.(*fitasks.ManagedFile)
-		actualManifest, err := fi.ResourceAsString(manifestFileTask.Contents)
-		if err != nil {
-			t.Fatalf("error getting manifest as string: %v", err)
-		}
-
-		expectedManifestPath := path.Join(basedir, k+".yaml")
-		golden.AssertMatchesFile(t, actualManifest, expectedManifestPath)
-	}
+	return bcb, cluster
 }
@@ -26,6 +26,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/dns-controller/pkg/dns"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/powerdns"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/rfc2136"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
@@ -46,8 +48,42 @@ type recordKey struct {
 	rrsType  rrstype.RrsType
 }
 
-func findZone(cluster *kops.Cluster, cloud fi.Cloud) (dnsprovider.Zone, error) {
-	dns, err := cloud.DNS()
+// clusterDNS returns the DNS provider that manages the DNS zone of the cluster,
+// which is the DNS service of the cloud provider unless spec.dnsProvider is set.
+// spec.dnsProvider is configured from the dnsprovider secret, as it is for dns-controller.
+func clusterDNS(cluster *kops.Cluster, cloud fi.Cloud, secretStore fi.SecretStoreReader) (dnsprovider.Interface, error) {
+	if cluster.Spec.DNSProvider == "" {
+		return cloud.DNS()
+	}
+
+	envs, err := dnsProviderEnvs(cluster, secretStore)
+	if err != nil {
+		return nil, err
+	}
+	getenv := func(key string) string {
+		return envs[key]
+	}
+
+	switch cluster.Spec.DNSProvider {
+	case rfc2136.ProviderName:
+		dns, err := rfc2136.NewProvider(rfc2136.ConfigFromEnv(getenv))
+		if err != nil {
+			return nil, fmt.Errorf("error configuring DNS provider %q from the %s secret: %w", cluster.Spec.DNSProvider, DNSProviderSecretName, err)
+		}
+		return dns, nil
+	case powerdns.ProviderName:
+		dns, err := powerdns.NewProvider(powerdns.ConfigFromEnv(getenv))
+		if err != nil {
+			return nil, fmt.Errorf("error configuring DNS provider %q from the %s secret: %w", cluster.Spec.DNSProvider, DNSProviderSecretName, err)
+		}
+		return dns, nil
+	default:
+		return nil, fmt.Errorf("unknown DNS provider %q", cluster.Spec.DNSProvider)
+	}
+}
+
+func findZone(cluster *kops.Cluster, cloud fi.Cloud, secretStore fi.SecretStoreReader) (dnsprovider.Zone, error) {
+	dns, err := clusterDNS(cluster, cloud, secretStore)
 	if err != nil {
 		return nil, fmt.Errorf("error building DNS provider: %v", err)
 	}
@@ -91,13 +127,13 @@ func findZone(cluster *kops.Cluster, cloud fi.Cloud) (dnsprovider.Zone, error) {
 	return zone, nil
 }
 
-func validateDNS(cluster *kops.Cluster, cloud fi.Cloud) error {
+func validateDNS(cluster *kops.Cluster, cloud fi.Cloud, secretStore fi.SecretStoreReader) error {
 	if !cluster.PublishesDNSRecords() || cluster.UsesPrivateDNS() {
 		klog.V(2).Infof("Skipping DNS validation for non-public DNS")
 		return nil
 	}
 
-	zone, err := findZone(cluster, cloud)
+	zone, err := findZone(cluster, cloud, secretStore)
 	if err != nil {
 		return err
 	}
@@ -128,7 +164,7 @@ func validateDNS(cluster *kops.Cluster, cloud fi.Cloud) error {
 	return nil
 }
 
-func precreateDNS(ctx context.Context, cluster *kops.Cluster, cloud fi.Cloud) error {
+func precreateDNS(ctx context.Context, cluster *kops.Cluster, cloud fi.Cloud, secretStore fi.SecretStoreReader) error {
 	// TODO: Move to update
 
 	// We precreate some DNS names (where they don't exist), with a dummy IP address
@@ -147,7 +183,7 @@ func precreateDNS(ctx context.Context, cluster *kops.Cluster, cloud fi.Cloud) er
 	}
 
 	klog.V(2).Infof("Checking DNS records")
-	zone, err := findZone(cluster, cloud)
+	zone, err := findZone(cluster, cloud, secretStore)
 	if err != nil {
 		return err
 	}
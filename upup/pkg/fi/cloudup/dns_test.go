@@ -23,6 +23,7 @@ import (
 
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
 )
 
 func TestPrecreateDNSNames(t *testing.T) {
@@ -158,3 +159,31 @@ func TestPrecreateDNSNames(t *testing.T) {
 		}
 	}
 }
+
+func TestClusterDNSFromSecret(t *testing.T) {
+	t.Setenv("RFC2136_NAMESERVER", "")
+	t.Setenv("RFC2136_ZONES", "")
+
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			DNSProvider: "rfc2136",
+			DNSZone:     "example.com",
+		},
+	}
+	cluster.Name = "cluster1.example.com"
+
+	secretStore := mapSecretStore{
+		DNSProviderSecretName: &fi.Secret{Data: []byte("RFC2136_NAMESERVER: 192.0.2.53\nRFC2136_ZONES: example.com,example.org\n")},
+	}
+	zone, err := findZone(cluster, nil, secretStore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if zone.Name() != "example.com." {
+		t.Errorf("unexpected zone %q, expected %q", zone.Name(), "example.com.")
+	}
+
+	if _, err := findZone(cluster, nil, mapSecretStore{}); err == nil {
+		t.Errorf("expected error finding the zone without the %s secret", DNSProviderSecretName)
+	}
+}
@@ -112,6 +112,8 @@ func (o digitalOceanVerifier) VerifyToken(ctx context.Context, rawRequest *http.
 
 	result := &bootstrap.VerifyResult{
 		NodeName:          nodeName,
+		InstanceID:        strconv.Itoa(droplet.ID),
+		InstanceType:      droplet.SizeSlug,
 		CertificateNames:  addresses,
 		ChallengeEndpoint: challengeEndpoints[0],
 	}
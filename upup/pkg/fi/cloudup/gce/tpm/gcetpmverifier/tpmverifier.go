@@ -178,6 +178,8 @@ func (v *tpmVerifier) VerifyToken(ctx context.Context, rawRequest *http.Request,
 	result := &bootstrap.VerifyResult{
 		NodeName:          instance.Name,
 		InstanceGroupName: instanceGroupName,
+		InstanceID:        strconv.FormatUint(instance.Id, 10),
+		InstanceType:      lastComponent(instance.MachineType),
 		CertificateNames:  sans,
 		ChallengeEndpoint: challengeEndpoint,
 	}
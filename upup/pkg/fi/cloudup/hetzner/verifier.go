@@ -91,10 +91,15 @@ func (h hetznerVerifier) VerifyToken(ctx context.Context, rawRequest *http.Reque
 
 	result := &bootstrap.VerifyResult{
 		NodeName:          server.Name,
+		InstanceID:        strconv.Itoa(server.ID),
 		CertificateNames:  addrs,
 		ChallengeEndpoint: challengeEndpoints[0],
 	}
 
+	if server.ServerType != nil {
+		result.InstanceType = server.ServerType.Name
+	}
+
 	for key, value := range server.Labels {
 		if key == TagKubernetesInstanceGroup {
 			result.InstanceGroupName = value
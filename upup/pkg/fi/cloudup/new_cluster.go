@@ -148,6 +148,8 @@ type NewClusterOptions struct {
 	DNSType string
 	// DNSZone is the DNS zone to use.
 	DNSZone string
+	// DNSProvider is the DNS provider that manages DNSZone, if not the DNS service of the cloud provider.
+	DNSProvider string
 
 	// APILoadBalancerClass determines whether to use classic or network load balancers for the API
 	APILoadBalancerClass string
@@ -84,6 +84,8 @@ func NewOpenstackVerifier(opt *OpenStackVerifierOptions) (bootstrap.Verifier, er
 	if err != nil {
 		return nil, fmt.Errorf("error building nova client: %v", err)
 	}
+	// 2.47 is the minimum version where the compute API returns flavor names
+	novaClient.Microversion = "2.47"
 
 	kubeClient, err := newClientSet()
 	if err != nil {
@@ -167,6 +169,7 @@ func (o openstackVerifier) VerifyToken(ctx context.Context, rawRequest *http.Req
 
 	result := &bootstrap.VerifyResult{
 		NodeName:          instance.Name,
+		InstanceID:        instance.ID,
 		CertificateNames:  addrs,
 		ChallengeEndpoint: challengeEndpoint,
 	}
@@ -174,5 +177,8 @@ func (o openstackVerifier) VerifyToken(ctx context.Context, rawRequest *http.Req
 	if ok {
 		result.InstanceGroupName = value
 	}
+	if flavor, ok := instance.Flavor["original_name"].(string); ok {
+		result.InstanceType = flavor
+	}
 	return result, nil
 }
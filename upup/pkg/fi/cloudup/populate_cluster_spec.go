@@ -261,7 +261,7 @@ func (c *populateClusterSpec) run(ctx context.Context, clientset simple.Clientse
 	}
 
 	if cluster.Spec.DNSZone == "" && cluster.PublishesDNSRecords() {
-		dns, err := cloud.DNS()
+		dns, err := clusterDNS(cluster, cloud, secretStore)
 		if err != nil {
 			return err
 		}
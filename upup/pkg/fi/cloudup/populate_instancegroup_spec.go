@@ -299,9 +299,54 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 
 	ig.Spec.Kubelet = igKubeletConfig
 
+	if tuning := ig.Spec.NodeTuning; tuning != nil && tuning.CPUManager != nil && tuning.CPUManager.ReservedCPUs == nil && igKubeletConfig.ReservedSystemCPUs == "" {
+		reservedCPUs, err := defaultReservedCPUs(cloud, cluster, ig)
+		if err != nil {
+			return nil, err
+		}
+		tuning.CPUManager.ReservedCPUs = &reservedCPUs
+	}
+
 	return ig, nil
 }
 
+// defaultReservedCPUs returns the number of CPUs the CPU manager reserves for the system and Kubernetes daemons,
+// sized from the machine types of the instance group
+func defaultReservedCPUs(cloud fi.Cloud, cluster *kops.Cluster, ig *kops.InstanceGroup) (int32, error) {
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS || ig.Spec.MachineType == "" {
+		return 0, fmt.Errorf("spec.nodeTuning.cpuManager.reservedCPUs must be set for InstanceGroup %q, as the CPUs of its machine type are not known", ig.ObjectMeta.Name)
+	}
+
+	machineTypes := []string{ig.Spec.MachineType}
+	if ig.Spec.MixedInstancesPolicy != nil {
+		machineTypes = append(machineTypes, ig.Spec.MixedInstancesPolicy.Instances...)
+	}
+
+	// Size for the smallest machine type, so every node keeps CPUs for the workloads
+	var numCPUs int32
+	for _, machineType := range machineTypes {
+		mt, err := awsup.GetMachineTypeInfo(cloud.(awsup.AWSCloud), ec2types.InstanceType(machineType))
+		if err != nil {
+			return 0, fmt.Errorf("error looking up machine type info: %v", err)
+		}
+		if numCPUs == 0 || mt.Cores < numCPUs {
+			numCPUs = mt.Cores
+		}
+	}
+
+	reserved := int32(4)
+	switch {
+	case numCPUs <= 16:
+		reserved = 1
+	case numCPUs <= 64:
+		reserved = 2
+	}
+	if reserved >= numCPUs {
+		return 0, fmt.Errorf("cannot reserve %d of the %d CPUs of the machine types of InstanceGroup %q", reserved, numCPUs, ig.ObjectMeta.Name)
+	}
+	return reserved, nil
+}
+
 // defaultMachineType returns the default MachineType for the instance group, based on the cloudprovider
 func defaultMachineType(cloud fi.Cloud, cluster *kops.Cluster, ig *kops.InstanceGroup) (string, error) {
 	switch cluster.Spec.GetCloudProvider() {
@@ -294,3 +294,44 @@ func TestMachineArchitecture(t *testing.T) {
 		})
 	}
 }
+
+func TestPopulateInstanceGroup_ReservedCPUs(t *testing.T) {
+	_, cluster := buildMinimalCluster()
+	input := buildMinimalNodeInstanceGroup()
+	input.Spec.MachineType = "t2.medium"
+	input.Spec.NodeTuning = &kopsapi.NodeTuningSpec{
+		CPUManager: &kopsapi.CPUManagerSpec{},
+	}
+
+	channel := &kopsapi.Channel{}
+
+	cloud, err := BuildCloud(cluster)
+	if err != nil {
+		t.Fatalf("error from BuildCloud: %v", err)
+	}
+	output, err := PopulateInstanceGroupSpec(cluster, input, cloud, channel)
+	if err != nil {
+		t.Fatalf("error from PopulateInstanceGroupSpec: %v", err)
+	}
+	if fi.ValueOf(output.Spec.NodeTuning.CPUManager.ReservedCPUs) != 1 {
+		t.Errorf("Unexpected value %v", fi.ValueOf(output.Spec.NodeTuning.CPUManager.ReservedCPUs))
+	}
+	if input.Spec.NodeTuning.CPUManager.ReservedCPUs != nil {
+		t.Errorf("Input was modified")
+	}
+}
+
+func TestPopulateInstanceGroup_ReservedCPUs_UnknownMachineType(t *testing.T) {
+	_, cluster := buildMinimalCluster()
+	cluster.Spec.CloudProvider = kopsapi.CloudProviderSpec{GCE: &kopsapi.GCESpec{}}
+	input := buildMinimalNodeInstanceGroup()
+	input.Spec.MachineType = "n1-standard-2"
+	input.Spec.NodeTuning = &kopsapi.NodeTuningSpec{
+		CPUManager: &kopsapi.CPUManagerSpec{},
+	}
+
+	_, err := defaultReservedCPUs(nil, cluster, input)
+	if err == nil || !strings.Contains(err.Error(), "reservedCPUs must be set") {
+		t.Errorf("expected reservedCPUs error, got %v", err)
+	}
+}
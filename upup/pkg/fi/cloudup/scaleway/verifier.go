@@ -122,6 +122,8 @@ func (v scalewayVerifier) VerifyToken(ctx context.Context, rawRequest *http.Requ
 	result := &bootstrap.VerifyResult{
 		NodeName:          server.Name,
 		InstanceGroupName: InstanceGroupNameFromTags(server.Tags),
+		InstanceID:        server.ID,
+		InstanceType:      server.CommercialType,
 		CertificateNames:  addresses,
 		ChallengeEndpoint: challengeEndPoints[0],
 	}
@@ -49,10 +49,13 @@ import (
 	"k8s.io/klog/v2"
 	kopsroot "k8s.io/kops"
 	kopscontrollerconfig "k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/powerdns"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/rfc2136"
 	"k8s.io/kops/pkg/apis/kops"
 	apiModel "k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
+	"k8s.io/kops/pkg/bootstrap/tpmbootstrap"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/kubemanifest"
@@ -172,6 +175,9 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["OpenStackCCMTag"] = tf.OpenStackCCMTag
 	dest["OpenStackCSITag"] = tf.OpenStackCSITag
 	dest["DNSControllerEnvs"] = tf.DNSControllerEnvs
+	dest["DNSProviderEnvs"] = func() (map[string]string, error) {
+		return tf.DNSProviderEnvs(secretStore)
+	}
 	dest["ProxyEnv"] = tf.ProxyEnv
 
 	dest["KopsSystemEnv"] = tf.KopsSystemEnv
@@ -562,9 +568,15 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 			klog.Warningln("this may cause problems with previously defined services: https://github.com/kubernetes/kops/issues/2496")
 		}
 		argv = append(argv, fmt.Sprintf("--watch-ingress=%t", watchIngress))
+		if fi.ValueOf(cluster.Spec.ExternalDNS.WatchGateway) {
+			argv = append(argv, "--watch-gateway=true")
+		}
 		if cluster.Spec.ExternalDNS.WatchNamespace != "" {
 			argv = append(argv, fmt.Sprintf("--watch-namespace=%s", cluster.Spec.ExternalDNS.WatchNamespace))
 		}
+		if cluster.Spec.ExternalDNS.OwnerID != "" {
+			argv = append(argv, fmt.Sprintf("--txt-owner-id=%s", cluster.Spec.ExternalDNS.OwnerID))
+		}
 	}
 
 	if cluster.UsesLegacyGossip() {
@@ -581,6 +593,12 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 			if cluster.Spec.DNSControllerGossipConfig.Secret != nil {
 				argv = append(argv, "--gossip-secret="+*cluster.Spec.DNSControllerGossipConfig.Secret)
 			}
+			for _, secret := range cluster.Spec.DNSControllerGossipConfig.AdditionalSecrets {
+				argv = append(argv, "--gossip-additional-secrets="+secret)
+			}
+			if cluster.Spec.DNSControllerGossipConfig.Sealing != "" {
+				argv = append(argv, "--gossip-sealing="+cluster.Spec.DNSControllerGossipConfig.Sealing)
+			}
 
 			if cluster.Spec.DNSControllerGossipConfig.Seed != nil {
 				argv = append(argv, "--gossip-seed="+*cluster.Spec.DNSControllerGossipConfig.Seed)
@@ -598,6 +616,12 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 				if cluster.Spec.DNSControllerGossipConfig.Secondary.Secret != nil {
 					argv = append(argv, "--gossip-secret-secondary="+*cluster.Spec.DNSControllerGossipConfig.Secondary.Secret)
 				}
+				for _, secret := range cluster.Spec.DNSControllerGossipConfig.Secondary.AdditionalSecrets {
+					argv = append(argv, "--gossip-additional-secrets-secondary="+secret)
+				}
+				if cluster.Spec.DNSControllerGossipConfig.Secondary.Sealing != "" {
+					argv = append(argv, "--gossip-sealing-secondary="+cluster.Spec.DNSControllerGossipConfig.Secondary.Sealing)
+				}
 
 				if cluster.Spec.DNSControllerGossipConfig.Secondary.Seed != nil {
 					argv = append(argv, "--gossip-seed-secondary="+*cluster.Spec.DNSControllerGossipConfig.Secondary.Seed)
@@ -613,6 +637,8 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 			argv = append(argv, fmt.Sprintf("--gossip-listen-secondary=0.0.0.0:%d", wellknownports.DNSControllerGossipMemberlist))
 			argv = append(argv, fmt.Sprintf("--gossip-seed-secondary=127.0.0.1:%d", wellknownports.ProtokubeGossipMemberlist))
 		}
+	} else if cluster.Spec.DNSProvider != "" {
+		argv = append(argv, "--dns="+cluster.Spec.DNSProvider)
 	} else {
 		switch cluster.Spec.GetCloudProvider() {
 		case kops.CloudProviderAWS:
@@ -654,6 +680,8 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 
 	// permit wildcard updates
 	argv = append(argv, "--zone=*/*")
+	// serve metrics and health checks for the probes
+	argv = append(argv, fmt.Sprintf("--metrics-listen=127.0.0.1:%d", wellknownports.DNSControllerHealthCheck))
 	// Verbose, but not crazy logging
 	argv = append(argv, "-v=2")
 
@@ -697,10 +725,26 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 			CABasePath:            pkiDir,
 			SigningCAs:            signingCAs,
 			CertNames:             certNames,
+			AuditLogPath:          "/var/log/kops-controller/bootstrap-audit.log",
+		}
+
+		if cluster.Spec.KopsController != nil && cluster.Spec.KopsController.BootstrapAdmission != nil {
+			admission := cluster.Spec.KopsController.BootstrapAdmission
+			config.Server.Admission = &kopscontrollerconfig.AdmissionOptions{
+				AllowedInstanceTypes: admission.AllowedInstanceTypes,
+				DeniedInstanceIDs:    admission.DeniedInstanceIDs,
+			}
+			if len(admission.MaxNodesPerInstanceGroup) != 0 {
+				config.Server.Admission.MaxNodesPerInstanceGroup = make(map[string]int)
+				for name, max := range admission.MaxNodesPerInstanceGroup {
+					config.Server.Admission.MaxNodesPerInstanceGroup[name] = int(max)
+				}
+			}
 		}
 
 		if featureflag.Metal.Enabled() {
 			config.Server.PKI = &pkibootstrap.Options{}
+			config.Server.TPM = &tpmbootstrap.Options{}
 		}
 
 		switch cluster.Spec.GetCloudProvider() {
@@ -821,6 +865,10 @@ func (tf *TemplateFunctions) ExternalDNSArgv() ([]string, error) {
 	if externalDNS.WatchIngress == nil || *externalDNS.WatchIngress {
 		argv = append(argv, "--source=ingress")
 	}
+	if fi.ValueOf(externalDNS.WatchGateway) {
+		argv = append(argv, "--source=gateway-httproute")
+		argv = append(argv, "--source=gateway-grpcroute")
+	}
 	argv = append(argv, "--source=pod")
 	argv = append(argv, "--source=service")
 	argv = append(argv, "--compatibility=kops-dns-controller")
@@ -834,6 +882,49 @@ func (tf *TemplateFunctions) ExternalDNSArgv() ([]string, error) {
 	return argv, nil
 }
 
+// DNSProviderSecretName is the name of the secret in the secret store with the credentials of spec.dnsProvider
+const DNSProviderSecretName = "dnsprovider"
+
+// dnsProviderEnvPrefixes are the prefixes of the environment variables configuring each spec.dnsProvider
+var dnsProviderEnvPrefixes = map[string]string{
+	powerdns.ProviderName: "PDNS_",
+	rfc2136.ProviderName:  "RFC2136_",
+}
+
+// DNSProviderEnvs returns the environment variables configuring spec.dnsProvider,
+// which are read from the dnsprovider secret into the credentials secret of dns-controller
+func (tf *TemplateFunctions) DNSProviderEnvs(secretStore fi.SecretStoreReader) (map[string]string, error) {
+	return dnsProviderEnvs(tf.Cluster, secretStore)
+}
+
+// dnsProviderEnvs returns the environment variables configuring spec.dnsProvider from the dnsprovider secret
+func dnsProviderEnvs(cluster *kops.Cluster, secretStore fi.SecretStoreReader) (map[string]string, error) {
+	prefix := dnsProviderEnvPrefixes[cluster.Spec.DNSProvider]
+	if prefix == "" {
+		return nil, nil
+	}
+
+	secret, err := secretStore.FindSecret(DNSProviderSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the %s secret: %w", DNSProviderSecretName, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("could not find %s secret", DNSProviderSecretName)
+	}
+
+	var envs map[string]string
+	if err := kops.ParseRawYaml(secret.Data, &envs); err != nil {
+		return nil, fmt.Errorf("unable to parse the %s secret: %w", DNSProviderSecretName, err)
+	}
+	out := make(map[string]string)
+	for k, v := range envs {
+		if strings.HasPrefix(k, prefix) {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
 func (tf *TemplateFunctions) DNSControllerEnvs() map[string]string {
 	if tf.Cluster.Spec.GetCloudProvider() != kops.CloudProviderOpenstack {
 		return nil
@@ -360,3 +360,77 @@ func TestKopsFeatureEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestDNSProviderEnvs(t *testing.T) {
+	secretStore := mapSecretStore{
+		DNSProviderSecretName: &fi.Secret{Data: []byte("RFC2136_NAMESERVER: 192.0.2.53\nRFC2136_ZONES: example.com\nPDNS_API_KEY: secret\n")},
+	}
+
+	tests := []struct {
+		dnsProvider string
+		secretStore mapSecretStore
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			dnsProvider: "",
+		},
+		{
+			dnsProvider: "rfc2136",
+			secretStore: secretStore,
+			expected: map[string]string{
+				"RFC2136_NAMESERVER": "192.0.2.53",
+				"RFC2136_ZONES":      "example.com",
+			},
+		},
+		{
+			dnsProvider: "powerdns",
+			secretStore: secretStore,
+			expected: map[string]string{
+				"PDNS_API_KEY": "secret",
+			},
+		},
+		{
+			dnsProvider: "powerdns",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.dnsProvider, func(t *testing.T) {
+			tf := &TemplateFunctions{}
+			tf.Cluster = &kops.Cluster{Spec: kops.ClusterSpec{DNSProvider: tc.dnsProvider}}
+
+			actual, err := tf.DNSProviderEnvs(tc.secretStore)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error, got %v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(actual) != 0 || len(tc.expected) != 0 {
+				if !reflect.DeepEqual(actual, tc.expected) {
+					t.Errorf("expected %v, got %v", tc.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+// mapSecretStore is a fi.SecretStoreReader with the secrets in a map
+type mapSecretStore map[string]*fi.Secret
+
+func (s mapSecretStore) Secret(id string) (*fi.Secret, error) {
+	secret := s[id]
+	if secret == nil {
+		return nil, fmt.Errorf("secret %q not found", id)
+	}
+	return secret, nil
+}
+
+func (s mapSecretStore) FindSecret(id string) (*fi.Secret, error) {
+	return s[id], nil
+}
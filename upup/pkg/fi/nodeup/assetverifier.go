@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/assets/signatures"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// assetVerifier verifies the signatures of downloaded assets and images against the verification policy of the cluster.
+type assetVerifier struct {
+	verifier    *signatures.Verifier
+	signedFiles map[string]string
+}
+
+var _ fi.AssetVerifier = &assetVerifier{}
+
+func newAssetVerifier(config *nodeup.AssetVerification) (*assetVerifier, error) {
+	if config.Policy == nil {
+		return nil, fmt.Errorf("asset verification policy is not set")
+	}
+	verifier, err := signatures.NewVerifier(config.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("building asset verifier: %w", err)
+	}
+	return &assetVerifier{
+		verifier:    verifier,
+		signedFiles: config.SignedFiles,
+	}, nil
+}
+
+// VerifyAsset implements fi.AssetVerifier.
+func (v *assetVerifier) VerifyAsset(hash *hashing.Hash, urls []string, localFile string) error {
+	canonicalURL, found := v.signedFiles[hash.Hex()]
+	if !found {
+		return nil
+	}
+
+	f, err := os.Open(localFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("hashing %s: %w", localFile, err)
+	}
+
+	klog.Infof("verifying signature of %s", canonicalURL)
+	if err := v.verifier.VerifyFile(context.TODO(), canonicalURL, h.Sum(nil), urls, fetchSignature); err != nil {
+		return fmt.Errorf("refusing to use %s: %w", urls[0], err)
+	}
+	return nil
+}
+
+func fetchSignature(ctx context.Context, u string) ([]byte, error) {
+	// Signatures are usually stored next to only some of the locations, so don't wait long for missing ones.
+	return vfs.Context.ReadFile(u, vfs.WithBackoff(wait.Backoff{
+		Duration: 500 * time.Millisecond,
+		Factor:   2,
+		Steps:    2,
+	}))
+}
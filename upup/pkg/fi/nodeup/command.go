@@ -49,6 +49,7 @@ import (
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/bootstrap"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
+	"k8s.io/kops/pkg/bootstrap/tpmbootstrap"
 	"k8s.io/kops/pkg/configserver"
 	"k8s.io/kops/pkg/kopscontrollerclient"
 	"k8s.io/kops/pkg/wellknownports"
@@ -176,8 +177,19 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		return fmt.Errorf("error determining OS distribution: %v", err)
 	}
 
+	var verifier *assetVerifier
+	if nodeupConfig.AssetVerification != nil {
+		verifier, err = newAssetVerifier(nodeupConfig.AssetVerification)
+		if err != nil {
+			return err
+		}
+	}
+
 	configAssets := nodeupConfig.Assets[architecture]
 	assetStore := fi.NewAssetStore(c.CacheDir)
+	if verifier != nil {
+		assetStore.SetVerifier(verifier)
+	}
 	for _, asset := range configAssets {
 		err := assetStore.Add(asset)
 		if err != nil {
@@ -249,7 +261,7 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		modelContext.InstanceID = string(instanceIDBytes)
 
 		// Check if WarmPool is enabled first, to avoid additional API calls
-		if len(modelContext.NodeupConfig.WarmPoolImages) > 0 {
+		if len(modelContext.NodeupConfig.WarmPoolImages) > 0 || len(modelContext.NodeupConfig.PrePullImages) > 0 {
 			modelContext.ConfigurationMode, err = getAWSConfigurationMode(ctx, modelContext)
 			if err != nil {
 				return err
@@ -299,6 +311,7 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	loader.Builders = append(loader.Builders, &model.CloudConfigBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.FileAssetsBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.HookBuilder{NodeupModelContext: modelContext})
+	loader.Builders = append(loader.Builders, &model.SystemdOverridesBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.KubeletBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.KubectlBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.LogrotateBuilder{NodeupModelContext: modelContext})
@@ -308,6 +321,7 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	loader.Builders = append(loader.Builders, &model.SecretBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.FirewallBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.SysctlBuilder{NodeupModelContext: modelContext})
+	loader.Builders = append(loader.Builders, &model.HardeningBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.KubeAPIServerBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.KubeControllerManagerBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.KubeSchedulerBuilder{NodeupModelContext: modelContext})
@@ -315,6 +329,7 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	loader.Builders = append(loader.Builders, &model.KubeProxyBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.KopsControllerBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.WarmPoolBuilder{NodeupModelContext: modelContext})
+	loader.Builders = append(loader.Builders, &model.ImagePrePullBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.PrefixBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.NerdctlBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.CrictlBuilder{NodeupModelContext: modelContext})
@@ -332,10 +347,14 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	}
 
 	for i, image := range nodeupConfig.Images[architecture] {
-		taskMap["LoadImage."+strconv.Itoa(i)] = &nodetasks.LoadImageTask{
+		loadImageTask := &nodetasks.LoadImageTask{
 			Sources: image.Sources,
 			Hash:    image.Hash,
 		}
+		if verifier != nil {
+			loadImageTask.Verifier = verifier
+		}
+		taskMap["LoadImage."+strconv.Itoa(i)] = loadImageTask
 	}
 	// Protokube load image task is in ProtokubeBuilder
 
@@ -668,11 +687,19 @@ func getNodeConfigFromServers(ctx context.Context, bootConfig *nodeup.BootConfig
 		authenticator = a
 
 	case "metal":
-		a, err := pkibootstrap.NewAuthenticatorFromFile("/etc/kubernetes/kops/pki/machine/private.pem")
-		if err != nil {
-			return nil, err
+		if tpmbootstrap.IsEnrolled() {
+			a, err := tpmbootstrap.NewAuthenticator()
+			if err != nil {
+				return nil, err
+			}
+			authenticator = a
+		} else {
+			a, err := pkibootstrap.NewAuthenticatorFromFile("/etc/kubernetes/kops/pki/machine/private.pem")
+			if err != nil {
+				return nil, err
+			}
+			authenticator = a
 		}
-		authenticator = a
 
 	default:
 		return nil, fmt.Errorf("unsupported cloud provider for node configuration %s", bootConfig.CloudProvider)
@@ -731,7 +758,7 @@ func getNodeConfigFromServers(ctx context.Context, bootConfig *nodeup.BootConfig
 
 func getAWSConfigurationMode(ctx context.Context, c *model.NodeupModelContext) (string, error) {
 	// Check if WarmPool is enabled first, to avoid additional API calls
-	if len(c.NodeupConfig.WarmPoolImages) == 0 {
+	if len(c.NodeupConfig.WarmPoolImages) == 0 && len(c.NodeupConfig.PrePullImages) == 0 {
 		return "", nil
 	}
 
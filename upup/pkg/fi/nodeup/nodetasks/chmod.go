@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/local"
+)
+
+// Chmod restricts the permissions of existing files, removing any permission bits that are not in Mode.
+// It never makes a file more permissive. Path may be a glob pattern; missing files are ignored.
+type Chmod struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+}
+
+var (
+	_ fi.NodeupTask            = &Chmod{}
+	_ fi.HasName               = &Chmod{}
+	_ fi.NodeupHasDependencies = &Chmod{}
+)
+
+func (e *Chmod) String() string {
+	return fmt.Sprintf("Chmod: %s %s", e.Mode, e.Path)
+}
+
+func (e *Chmod) GetName() *string {
+	return fi.PtrTo("Chmod-" + e.Path)
+}
+
+// GetDependencies implements HasDependencies::GetDependencies
+func (e *Chmod) GetDependencies(tasks map[string]fi.NodeupTask) []fi.NodeupTask {
+	// We restrict permissions after files and units have been written, so that we see the final files.
+	var deps []fi.NodeupTask
+	for _, v := range tasks {
+		switch v.(type) {
+		case *File, *Service:
+			deps = append(deps, v)
+		}
+	}
+	return deps
+}
+
+func (e *Chmod) Find(c *fi.NodeupContext) (*Chmod, error) {
+	mask, err := e.mask()
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(e.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error matching %q: %w", e.Path, err)
+	}
+	for _, p := range paths {
+		stat, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("error getting file info for %q: %w", p, err)
+		}
+		if stat.Mode().Perm()&^mask != 0 {
+			return nil, nil
+		}
+	}
+
+	// All files are already at least as restrictive as Mode
+	actual := *e
+	return &actual, nil
+}
+
+func (e *Chmod) Run(c *fi.NodeupContext) error {
+	return fi.NodeupDefaultDeltaRunMethod(e, c)
+}
+
+func (_ *Chmod) CheckChanges(a, e, changes *Chmod) error {
+	if _, err := e.mask(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (_ *Chmod) RenderLocal(t *local.LocalTarget, a, e, changes *Chmod) error {
+	mask, err := e.mask()
+	if err != nil {
+		return err
+	}
+
+	paths, err := filepath.Glob(e.Path)
+	if err != nil {
+		return fmt.Errorf("error matching %q: %w", e.Path, err)
+	}
+	for _, p := range paths {
+		stat, err := os.Stat(p)
+		if err != nil {
+			return fmt.Errorf("error getting file info for %q: %w", p, err)
+		}
+		mode := stat.Mode().Perm()
+		if mode&^mask == 0 {
+			continue
+		}
+		klog.Infof("Restricting permissions of %q from %s to %s", p, fi.FileModeToString(mode), fi.FileModeToString(mode&mask))
+		if err := os.Chmod(p, mode&mask); err != nil {
+			return fmt.Errorf("error changing mode of %q: %w", p, err)
+		}
+	}
+
+	return nil
+}
+
+// mask returns the permission bits that are allowed by Mode.
+func (e *Chmod) mask() (os.FileMode, error) {
+	v, err := strconv.ParseUint(e.Mode, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid mode %q for %q: %w", e.Mode, e.Path, err)
+	}
+	return os.FileMode(v) & os.ModePerm, nil
+}
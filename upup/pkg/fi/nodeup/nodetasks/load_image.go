@@ -38,6 +38,8 @@ type LoadImageTask struct {
 	Sources []string
 	Hash    string
 	Runtime string
+	// Verifier verifies the image after it is downloaded, before it is loaded.
+	Verifier fi.AssetVerifier `json:"-"`
 }
 
 var (
@@ -118,6 +120,12 @@ func (_ *LoadImageTask) RenderLocal(t *local.LocalTarget, a, e, changes *LoadIma
 		return err
 	}
 
+	if e.Verifier != nil {
+		if err := e.Verifier.VerifyAsset(hash, urls, localFile); err != nil {
+			return err
+		}
+	}
+
 	// containerd can't import gzipped container images, if the image is gzipped extract it to tmp dir
 	// TODO: Improve the naive gzip format detection by checking the content type bytes "\x1F\x8B\x08"
 	var tarFile string
@@ -18,13 +18,18 @@ package nodetasks
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 )
 
+// containerdRegistryHostsDir is the directory containerd reads the registry host configuration from
+const containerdRegistryHostsDir = "/etc/containerd/certs.d"
+
 // PullImageTask is responsible for pulling a docker image
 type PullImageTask struct {
 	Name string
@@ -57,15 +62,22 @@ func (t *PullImageTask) GetName() *string {
 
 func (e *PullImageTask) Run(c *fi.NodeupContext) error {
 	// Pull the container image
-	args := []string{"ctr", "--namespace", "k8s.io", "images", "pull", e.Name}
+	args := []string{"ctr", "--namespace", "k8s.io", "images", "pull"}
+	// Use the same registry configuration as containerd, if there is one
+	if _, err := os.Stat(containerdRegistryHostsDir); err == nil {
+		args = append(args, "--hosts-dir", containerdRegistryHostsDir)
+	}
+	args = append(args, e.Name)
 	human := strings.Join(args, " ")
 
 	klog.Infof("running command %s", human)
+	start := time.Now()
 	cmd := exec.Command(args[0], args[1:]...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("error pulling docker image with '%s': %v: %s", human, err, string(output))
 	}
+	klog.Infof("pulled image %q in %v", e.Name, time.Since(start).Round(time.Millisecond))
 
 	return nil
 }
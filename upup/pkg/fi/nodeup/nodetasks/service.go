@@ -21,6 +21,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -41,6 +42,9 @@ const (
 	flatcarSystemdSystemPath     = "/etc/systemd/system"
 	containerosSystemdSystemPath = "/etc/systemd/system"
 
+	// systemdDropInPath is where we write drop-ins that override settings of units.
+	systemdDropInPath = "/etc/systemd/system"
+
 	containerdService = "containerd.service"
 	dockerService     = "docker.service"
 	kubeletService    = "kubelet.service"
@@ -90,7 +94,7 @@ func (s *Service) GetDependencies(tasks map[string]fi.NodeupTask) []fi.NodeupTas
 		switch v := v.(type) {
 		case *Package, *UpdatePackages, *UserTask, *GroupTask, *Chattr, *BindMount, *Archive, *Prefix, *UpdateEtcHostsTask:
 			deps = append(deps, v)
-		case *Service, *PullImageTask, *IssueCert, *BootstrapClientTask, *KubeConfig:
+		case *Service, *PullImageTask, *IssueCert, *BootstrapClientTask, *KubeConfig, *Chmod:
 			// ignore
 		case *LoadImageTask:
 			if s.Name == kubeletService {
@@ -170,6 +174,11 @@ func (_ *Service) systemdSystemPath() (string, error) {
 		return "", fmt.Errorf("unknown or unsupported distro: %v", err)
 	}
 
+	return SystemdSystemPath(d)
+}
+
+// SystemdSystemPath returns the directory where we install systemd units on the distribution.
+func SystemdSystemPath(d distributions.Distribution) (string, error) {
 	if d.IsDebianFamily() {
 		return debianSystemdSystemPath, nil
 	} else if d.IsRHELFamily() {
@@ -183,6 +192,11 @@ func (_ *Service) systemdSystemPath() (string, error) {
 	}
 }
 
+// SystemdDropInDirectory returns the directory for drop-ins of the named unit.
+func SystemdDropInDirectory(name string) string {
+	return path.Join(systemdDropInPath, name+".d")
+}
+
 func (e *InstallService) Find(_ *fi.InstallContext) (*InstallService, error) {
 	actual, err := e.Service.Find(nil)
 	if actual == nil || err != nil {
@@ -353,6 +367,13 @@ func (s *Service) RenderLocal(_ *local.LocalTarget, a, e, changes *Service) erro
 			// Include the systemd unit file itself
 			dependencies = append(dependencies, path.Join(systemdSystemPath, serviceName))
 
+			// Include any drop-ins for the unit
+			dropIns, err := filepath.Glob(path.Join(SystemdDropInDirectory(serviceName), "*.conf"))
+			if err != nil {
+				return fmt.Errorf("error listing drop-ins for %q: %w", serviceName, err)
+			}
+			dependencies = append(dependencies, dropIns...)
+
 			var newest time.Time
 			for _, dependency := range dependencies {
 				stat, err := os.Stat(dependency)